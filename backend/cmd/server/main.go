@@ -4,19 +4,34 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	"minibankingplatform/internal/api"
+	"minibankingplatform/internal/domain"
 	"minibankingplatform/internal/infrastructure"
 	"minibankingplatform/internal/service"
 	"minibankingplatform/pkg/jwt"
@@ -33,18 +48,77 @@ type Config struct {
 	PostgresPassword string
 	PostgresDB       string
 
+	// Transactions aborted by serialization failures or deadlocks are retried
+	TxMaxAttempts  int
+	TxRetryBackoff time.Duration
+
 	// Server
-	ServerPort string
+	ServerPort          string
+	ResponseCompression int
+	ResponseEnvelope    bool
+	DefaultPageSize     int
+	CORSAllowedOrigins  []string
+
+	// Environment the server runs in; insecure settings are only tolerated in development
+	AppEnv string
 
 	// JWT
-	JWTSecret   string
-	JWTDuration time.Duration
+	JWTSecret             string
+	JWTDuration           time.Duration
+	JWTRefreshGrace       time.Duration
+	TokenRotationInterval time.Duration
+
+	// Exchange
+	ExchangeRoundingBias      string
+	ExchangeSpread            string
+	AllowedExchangeDirections string
+	MinimumExchangeAmount     string
+	ExchangeMinimums          string
+	ExchangeQuoteTTL          time.Duration
+	ExchangeRateProvider      string
+	ExchangeRateCacheTTL      time.Duration
+	FrankfurterURL            string
+
+	// Transfers
+	TransferDedupWindow time.Duration
+	SubUnitPolicy       string
+
+	// Stored idempotency keys are replayed for this long, then purged
+	IdempotencyKeyTTL time.Duration
+
+	// Money operations allowed to run at once; 0 means unlimited
+	MaxMoneyOperations int
+
+	// Login attempts allowed per client IP within the window
+	RateLimitRequests int
+	RateLimitWindow   time.Duration
+
+	// Registration
+	FundingAccounts string
+	// Email domains rejected at registration, listed inline and/or in a file
+	// with one domain per line
+	BlockedEmailDomains     []string
+	BlockedEmailDomainsFile string
+
+	// Administration
+	AdminUserIDs          string
+	MaintenanceMode       bool
+	MaintenanceRetryAfter time.Duration
+
+	// Monitoring
+	CashbookAlertThresholds string
+	// Traces are exported over OTLP gRPC when set, e.g. http://localhost:4317
+	OTLPEndpoint string
 }
 
 func main() {
 	// Load configuration from environment
 	cfg := loadConfig()
 
+	// Structured JSON logs; the standard logger is routed through it as well
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	slog.SetDefault(logger)
+
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -62,12 +136,28 @@ func main() {
 		log.Fatalf("Failed to create transaction factory: %v", err)
 	}
 
+	if cfg.TxMaxAttempts < 1 {
+		log.Fatalf("Invalid TX_MAX_ATTEMPTS: %d must be at least 1", cfg.TxMaxAttempts)
+	}
+
+	tracerProvider, shutdownTracing, err := newTracerProvider(ctx, cfg.OTLPEndpoint)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+
 	// Create transaction manager
-	txManager := trm.NewTransactionManager(txFactory)
+	txManager := trm.NewTransactionManager(txFactory,
+		trm.WithRetry(cfg.TxMaxAttempts, cfg.TxRetryBackoff, pgxfactory.IsRetryable),
+		trm.WithTracer(tracerProvider.Tracer("minibankingplatform/pkg/trm")))
 
 	// Create injector for repositories
 	injector := trm.NewInjector[infrastructure.DBTX](pool)
 
+	// Fail fast when money columns can't hold the currencies' precision
+	if err := service.CheckMoneyColumnScales(ctx, infrastructure.NewSchemaRepository(injector)); err != nil {
+		log.Fatalf("Database schema check failed: %v", err)
+	}
+
 	// Create repositories
 	usersRepo := infrastructure.NewUsersRepository(injector)
 	accountsRepo := infrastructure.NewAccountsRepository(injector)
@@ -75,26 +165,187 @@ func main() {
 	exchangesRepo := infrastructure.NewExchangesRepository(injector)
 	transactionsRepo := infrastructure.NewTransactionsRepository(injector)
 	ledgerRepo := infrastructure.NewLedgerRepository(injector)
+	healthRepo := infrastructure.NewHealthRepository(injector)
+	idempotencyKeysRepo := infrastructure.NewIdempotencyKeysRepository(injector)
+	exchangeRateHistoryRepo := infrastructure.NewExchangeRateHistoryRepository(injector)
+
+	// Create exchange rate provider
+	var exchangeRateProvider domain.ExchangeRateProvider
+	switch cfg.ExchangeRateProvider {
+	case "fixed":
+		// 1 USD = 0.92 EUR, GBP rates are fixed
+		exchangeRateProvider = infrastructure.NewRecordingExchangeRateProvider(
+			infrastructure.NewFixedExchangeRateProvider(decimal.NewFromFloat(0.92)),
+			exchangeRateHistoryRepo,
+			cfg.ExchangeRateProvider,
+			logger,
+		)
+	case "frankfurter":
+		if cfg.ExchangeRateCacheTTL <= 0 {
+			log.Fatalf("Invalid EXCHANGE_RATE_CACHE_TTL: %s must be positive", cfg.ExchangeRateCacheTTL)
+		}
+		// Recording below the cache keeps cache hits out of the history.
+		exchangeRateProvider = infrastructure.NewCachingExchangeRateProvider(
+			infrastructure.NewRecordingExchangeRateProvider(
+				infrastructure.NewFrankfurterExchangeRateProvider(&http.Client{Timeout: 5 * time.Second}, cfg.FrankfurterURL),
+				exchangeRateHistoryRepo,
+				cfg.ExchangeRateProvider,
+				logger,
+			),
+			cfg.ExchangeRateCacheTTL,
+		)
+	default:
+		log.Fatalf("Invalid EXCHANGE_RATE_PROVIDER: %q is neither fixed nor frankfurter", cfg.ExchangeRateProvider)
+	}
+
+	if cfg.JWTRefreshGrace < 0 {
+		log.Fatalf("Invalid JWT_REFRESH_GRACE: %s must not be negative", cfg.JWTRefreshGrace)
+	}
 
-	// Create exchange rate provider (1 USD = 0.92 EUR)
-	exchangeRateProvider := infrastructure.NewFixedExchangeRateProvider(decimal.NewFromFloat(0.92))
+	// Refuse to sign tokens with a guessable secret outside development
+	if err := checkJWTSecret(cfg.JWTSecret, cfg.AppEnv); err != nil {
+		log.Fatalf("Invalid JWT_SECRET: %v", err)
+	}
+	if err := insecureJWTSecret(cfg.JWTSecret); err != nil {
+		logger.Warn("JWT_SECRET is insecure, tokens can be forged; only acceptable in development", slog.String("reason", err.Error()))
+	}
 
 	// Create JWT token manager
-	tokenManager := jwt.NewTokenManager(cfg.JWTSecret, cfg.JWTDuration)
+	tokenManager := jwt.NewTokenManager(cfg.JWTSecret, cfg.JWTDuration, jwt.WithRefreshGrace(cfg.JWTRefreshGrace))
+
+	exchangeSpread, err := domain.ParseExchangeSpread(cfg.ExchangeSpread)
+	if err != nil {
+		log.Fatalf("Invalid EXCHANGE_SPREAD: %v", err)
+	}
+
+	roundingBias, err := domain.ParseRoundingBias(cfg.ExchangeRoundingBias)
+	if err != nil {
+		log.Fatalf("Invalid EXCHANGE_ROUNDING_BIAS: %v", err)
+	}
+
+	allowedExchangeDirections, err := domain.ParseExchangeDirections(cfg.AllowedExchangeDirections)
+	if err != nil {
+		log.Fatalf("Invalid EXCHANGE_ALLOWED_DIRECTIONS: %v", err)
+	}
+
+	minimumExchangeAmount, err := decimal.NewFromString(cfg.MinimumExchangeAmount)
+	if err != nil || minimumExchangeAmount.IsNegative() {
+		log.Fatalf("Invalid EXCHANGE_MIN_AMOUNT: %q must be a non-negative amount", cfg.MinimumExchangeAmount)
+	}
+
+	exchangeMinimums, err := domain.ParseExchangeMinimums(cfg.ExchangeMinimums)
+	if err != nil {
+		log.Fatalf("Invalid EXCHANGE_PAIR_MIN_AMOUNTS: %v", err)
+	}
+
+	subUnitPolicy, err := domain.ParseSubUnitPolicy(cfg.SubUnitPolicy)
+	if err != nil {
+		log.Fatalf("Invalid SUB_UNIT_POLICY: %v", err)
+	}
+
+	if cfg.MaxMoneyOperations < 0 {
+		log.Fatalf("Invalid MAX_CONCURRENT_MONEY_OPERATIONS: %d must not be negative", cfg.MaxMoneyOperations)
+	}
+
+	adminUserIDs, err := parseUserIDs(cfg.AdminUserIDs)
+	if err != nil {
+		log.Fatalf("Invalid ADMIN_USER_IDS: %v", err)
+	}
+
+	cashbookAlertThresholds, err := parseMoneyList(cfg.CashbookAlertThresholds)
+	if err != nil {
+		log.Fatalf("Invalid CASHBOOK_ALERT_THRESHOLDS: %v", err)
+	}
+
+	fundingAccounts, err := parseFundingAccounts(cfg.FundingAccounts)
+	if err != nil {
+		log.Fatalf("Invalid FUNDING_ACCOUNTS: %v", err)
+	}
+
+	blockedEmailDomains := cfg.BlockedEmailDomains
+	if cfg.BlockedEmailDomainsFile != "" {
+		fileDomains, err := os.ReadFile(cfg.BlockedEmailDomainsFile)
+		if err != nil {
+			log.Fatalf("Invalid BLOCKED_EMAIL_DOMAINS_FILE: %v", err)
+		}
+		blockedEmailDomains = append(blockedEmailDomains, parseDomainList(string(fileDomains))...)
+	}
+
+	if cfg.ResponseCompression < 0 || cfg.ResponseCompression > 9 {
+		log.Fatalf("Invalid RESPONSE_COMPRESSION_LEVEL: %d is not between 0 and 9", cfg.ResponseCompression)
+	}
+
+	if cfg.DefaultPageSize < 1 || cfg.DefaultPageSize > 100 {
+		log.Fatalf("Invalid DEFAULT_PAGE_SIZE: %d is not between 1 and 100", cfg.DefaultPageSize)
+	}
+
+	if cfg.ExchangeQuoteTTL <= 0 {
+		log.Fatalf("Invalid EXCHANGE_QUOTE_TTL: %s must be positive", cfg.ExchangeQuoteTTL)
+	}
+
+	if cfg.IdempotencyKeyTTL <= 0 {
+		log.Fatalf("Invalid IDEMPOTENCY_KEY_TTL: %s must be positive", cfg.IdempotencyKeyTTL)
+	}
+
+	if cfg.RateLimitRequests < 1 {
+		log.Fatalf("Invalid RATE_LIMIT_REQUESTS: %d must be at least 1", cfg.RateLimitRequests)
+	}
+
+	if cfg.RateLimitWindow <= 0 {
+		log.Fatalf("Invalid RATE_LIMIT_WINDOW: %s must be positive", cfg.RateLimitWindow)
+	}
 
 	// Create application service
 	svc := service.NewService(
 		txManager,
-		usersRepo,
-		accountsRepo,
-		transfersRepo,
-		exchangesRepo,
-		transactionsRepo,
-		ledgerRepo,
+		service.Repositories{
+			Users:               usersRepo,
+			Accounts:            accountsRepo,
+			Transfers:           transfersRepo,
+			Exchanges:           exchangesRepo,
+			Transactions:        transactionsRepo,
+			Ledger:              ledgerRepo,
+			Health:              healthRepo,
+			IdempotencyKeys:     idempotencyKeysRepo,
+			ExchangeRateHistory: exchangeRateHistoryRepo,
+		},
 		exchangeRateProvider,
 		tokenManager,
+		service.WithConfig(service.Config{
+			ExchangeRoundingBias:      roundingBias,
+			ExchangeSpread:            exchangeSpread,
+			AllowedExchangeDirections: allowedExchangeDirections,
+			MinimumExchangeAmount:     minimumExchangeAmount,
+			ExchangeMinimums:          exchangeMinimums,
+			SubUnitPolicy:             subUnitPolicy,
+			InFlightTransfers:         infrastructure.NewInMemoryInFlightRegistry(cfg.TransferDedupWindow),
+			MaxMoneyOperations:        cfg.MaxMoneyOperations,
+			AdminUserIDs:              adminUserIDs,
+			CashbookAlertThresholds:   cashbookAlertThresholds,
+			FundingAccounts:           fundingAccounts,
+			BlockedEmailDomains:       blockedEmailDomains,
+			CashbookAlerter:           infrastructure.NewLogCashbookAlerter(logger),
+			DefaultPageSize:           cfg.DefaultPageSize,
+			ExchangeQuoteTTL:          cfg.ExchangeQuoteTTL,
+			ExecutedExchangeQuotes:    infrastructure.NewInMemoryInFlightRegistry(cfg.ExchangeQuoteTTL),
+			TokenRotations:            infrastructure.NewInMemoryInFlightRegistry(cfg.TokenRotationInterval),
+			IdempotencyKeyTTL:         cfg.IdempotencyKeyTTL,
+			MaintenanceRetryAfter:     cfg.MaintenanceRetryAfter,
+		}),
+		service.WithLogger(logger),
+		service.WithTracerProvider(tracerProvider),
 	)
 
+	if err := svc.CheckFundingAccounts(ctx); err != nil {
+		log.Fatalf("Invalid FUNDING_ACCOUNTS: %v", err)
+	}
+
+	// Maintenance mode can be switched on at startup and toggled later by admins
+	svc.SetMaintenanceMode(cfg.MaintenanceMode)
+
+	// Expired idempotency keys are no longer replayed, drop them now and then
+	go purgeIdempotencyKeys(ctx, svc, logger, idempotencyKeyPurgeInterval)
+
 	// Create API handler
 	handler := api.NewAPIHandler(svc)
 
@@ -108,12 +359,35 @@ func main() {
 	router.Use(middleware.RealIP)
 	router.Use(middleware.Timeout(60 * time.Second))
 
-	// Add CORS middleware for development
-	router.Use(corsMiddleware)
+	// Count requests and their latency per route for /metrics
+	router.Use(api.PrometheusMiddleware(prometheus.DefaultRegisterer))
+
+	// Add CORS middleware
+	router.Use(corsMiddleware(cfg.CORSAllowedOrigins, router))
+
+	// Compress responses, including problem details written by the middleware below
+	router.Use(api.Compress(cfg.ResponseCompression))
+
+	// Wrap success bodies in {"data", "meta"} when configured or asked for
+	router.Use(api.Envelope(cfg.ResponseEnvelope))
+
+	// Slow down password guessing, per client IP
+	router.Use(api.LoginRateLimit(infrastructure.NewInMemoryRateLimiter(cfg.RateLimitRequests, cfg.RateLimitWindow)))
 
 	// Add JWT authentication middleware
 	router.Use(api.AuthMiddleware(tokenManager))
 
+	// Reject write requests with non-JSON bodies
+	router.Use(api.RequireJSONContentType)
+
+	// Liveness and readiness probes; readiness runs a database transaction
+	// end-to-end rather than only pinging
+	router.Get("/health", api.LivenessHandler())
+	router.Get("/ready", api.ReadinessHandler(svc))
+
+	// Prometheus scrape endpoint
+	router.Handle("/metrics", promhttp.Handler())
+
 	// Register OpenAPI handlers
 	strictHandler := api.NewStrictHandler(handler, nil)
 	api.HandlerFromMux(strictHandler, router)
@@ -150,6 +424,10 @@ func main() {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Printf("Flushing traces failed: %v", err)
+	}
+
 	log.Println("Server exited properly")
 }
 
@@ -160,12 +438,119 @@ func loadConfig() Config {
 		PostgresUser:     getEnv("POSTGRES_USER", "bankuser"),
 		PostgresPassword: getEnv("POSTGRES_PASSWORD", "bankpass123"),
 		PostgresDB:       getEnv("POSTGRES_DB", "minibankingdb"),
+		TxMaxAttempts:    getIntEnv("TX_MAX_ATTEMPTS", 3),
+		TxRetryBackoff:   getDurationEnv("TX_RETRY_BACKOFF", 20*time.Millisecond),
 		ServerPort:       getEnv("SERVER_PORT", "8080"),
-		JWTSecret:        getEnv("JWT_SECRET", "your-super-secret-key-change-in-production"),
+		AppEnv:           getEnv("APP_ENV", appEnvProduction),
+		JWTSecret:        getEnv("JWT_SECRET", defaultJWTSecret),
 		JWTDuration:      24 * time.Hour,
+
+		TokenRotationInterval: getDurationEnv("TOKEN_ROTATION_INTERVAL", time.Minute),
+		JWTRefreshGrace:       getDurationEnv("JWT_REFRESH_GRACE", 30*time.Minute),
+
+		ResponseCompression: getIntEnv("RESPONSE_COMPRESSION_LEVEL", 5),
+		ResponseEnvelope:    getBoolEnv("RESPONSE_ENVELOPE", false),
+		DefaultPageSize:     getIntEnv("DEFAULT_PAGE_SIZE", service.FallbackPageSize),
+		CORSAllowedOrigins:  getListEnv("CORS_ALLOWED_ORIGINS", []string{corsAnyOrigin}),
+
+		OTLPEndpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+
+		ExchangeRoundingBias:      getEnv("EXCHANGE_ROUNDING_BIAS", "none"),
+		ExchangeSpread:            getEnv("EXCHANGE_SPREAD", ""),
+		AllowedExchangeDirections: getEnv("EXCHANGE_ALLOWED_DIRECTIONS", ""),
+		MinimumExchangeAmount:     getEnv("EXCHANGE_MIN_AMOUNT", "0.01"),
+		ExchangeMinimums:          getEnv("EXCHANGE_PAIR_MIN_AMOUNTS", ""),
+		ExchangeQuoteTTL:          getDurationEnv("EXCHANGE_QUOTE_TTL", service.DefaultExchangeQuoteTTL),
+		ExchangeRateProvider:      getEnv("EXCHANGE_RATE_PROVIDER", "fixed"),
+		ExchangeRateCacheTTL:      getDurationEnv("EXCHANGE_RATE_CACHE_TTL", time.Minute),
+		FrankfurterURL:            getEnv("FRANKFURTER_URL", infrastructure.FrankfurterBaseURL),
+
+		TransferDedupWindow: getDurationEnv("TRANSFER_DEDUP_WINDOW", 2*time.Second),
+		SubUnitPolicy:       getEnv("SUB_UNIT_POLICY", "reject"),
+
+		IdempotencyKeyTTL: getDurationEnv("IDEMPOTENCY_KEY_TTL", service.DefaultIdempotencyKeyTTL),
+
+		MaxMoneyOperations: getIntEnv("MAX_CONCURRENT_MONEY_OPERATIONS", 0),
+
+		RateLimitRequests: getIntEnv("RATE_LIMIT_REQUESTS", 10),
+		RateLimitWindow:   getDurationEnv("RATE_LIMIT_WINDOW", time.Minute),
+
+		FundingAccounts:         getEnv("FUNDING_ACCOUNTS", ""),
+		BlockedEmailDomains:     getListEnv("BLOCKED_EMAIL_DOMAINS", nil),
+		BlockedEmailDomainsFile: getEnv("BLOCKED_EMAIL_DOMAINS_FILE", ""),
+
+		AdminUserIDs:          getEnv("ADMIN_USER_IDS", ""),
+		MaintenanceMode:       getBoolEnv("MAINTENANCE_MODE", false),
+		MaintenanceRetryAfter: getDurationEnv("MAINTENANCE_RETRY_AFTER", service.DefaultMaintenanceRetryAfter),
+
+		CashbookAlertThresholds: getEnv("CASHBOOK_ALERT_THRESHOLDS", ""),
 	}
 }
 
+const (
+	appEnvDevelopment = "development"
+	appEnvProduction  = "production"
+)
+
+// idempotencyKeyPurgeInterval is how often expired idempotency keys are deleted.
+const idempotencyKeyPurgeInterval = time.Hour
+
+// purgeIdempotencyKeys deletes expired idempotency keys every interval until
+// ctx is done.
+func purgeIdempotencyKeys(ctx context.Context, svc *service.Service, logger *slog.Logger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := svc.PurgeExpiredIdempotencyKeys(ctx)
+			if err != nil {
+				logger.Error("purging expired idempotency keys failed", slog.Any("error", err))
+				continue
+			}
+			logger.Info("purged expired idempotency keys", slog.Int64("count", purged))
+		}
+	}
+}
+
+// defaultJWTSecret is the placeholder used when JWT_SECRET is unset.
+const defaultJWTSecret = "your-super-secret-key-change-in-production"
+
+// minJWTSecretLength is the shortest JWT secret, in bytes, accepted outside
+// development: 32 bytes match the output size of HS256.
+const minJWTSecretLength = 32
+
+// placeholderJWTSecrets are the JWT secrets shipped with the code and docs.
+var placeholderJWTSecrets = []string{
+	defaultJWTSecret,
+	"your_jwt_secret_key_change_this_in_production",
+}
+
+// insecureJWTSecret reports a JWT secret that is a known placeholder or too short.
+func insecureJWTSecret(secret string) error {
+	if slices.Contains(placeholderJWTSecrets, secret) {
+		return fmt.Errorf("the placeholder secret is used")
+	}
+	if len(secret) < minJWTSecretLength {
+		return fmt.Errorf("%d bytes is shorter than the minimum of %d", len(secret), minJWTSecretLength)
+	}
+	return nil
+}
+
+// checkJWTSecret rejects insecure JWT secrets unless appEnv is development.
+func checkJWTSecret(secret, appEnv string) error {
+	if appEnv == appEnvDevelopment {
+		return nil
+	}
+	if err := insecureJWTSecret(secret); err != nil {
+		return fmt.Errorf("%w; set a random secret or APP_ENV=%s", err, appEnvDevelopment)
+	}
+	return nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
@@ -173,6 +558,159 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getIntEnv(key string, defaultValue int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", key, err)
+	}
+	return number
+}
+
+func getBoolEnv(key string, defaultValue bool) bool {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", key, err)
+	}
+	return flag
+}
+
+// getListEnv reads a comma separated list, falling back to defaultValue when
+// the variable is unset or lists nothing.
+func getListEnv(key string, defaultValue []string) []string {
+	var list []string
+	for _, part := range strings.Split(os.Getenv(key), ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			list = append(list, part)
+		}
+	}
+
+	if len(list) == 0 {
+		return defaultValue
+	}
+	return list
+}
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", key, err)
+	}
+	return duration
+}
+
+// parseUserIDs parses a comma separated list of user UUIDs.
+func parseUserIDs(raw string) ([]domain.UserID, error) {
+	var ids []domain.UserID
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		id, err := uuid.Parse(part)
+		if err != nil {
+			return nil, fmt.Errorf("parsing user id %q: %w", part, err)
+		}
+		ids = append(ids, domain.UserID(id))
+	}
+	return ids, nil
+}
+
+// parseDomainList parses a list of domains, one per line. Blank lines and
+// lines starting with # are skipped.
+func parseDomainList(raw string) []string {
+	var domains []string
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, strings.ToLower(line))
+	}
+	return domains
+}
+
+// parseFundingAccounts parses a comma separated list of CURRENCY:ACCOUNT_UUID
+// pairs.
+func parseFundingAccounts(raw string) (map[domain.Currency]domain.AccountID, error) {
+	accounts := make(map[domain.Currency]domain.AccountID)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		rawCurrency, rawID, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("%q is not a CURRENCY:ACCOUNT_UUID pair", part)
+		}
+
+		currency, err := domain.ParseSupportedCurrency(strings.TrimSpace(rawCurrency))
+		if err != nil {
+			return nil, err
+		}
+		if _, duplicate := accounts[currency]; duplicate {
+			return nil, fmt.Errorf("%s is listed more than once", currency)
+		}
+
+		id, err := uuid.Parse(strings.TrimSpace(rawID))
+		if err != nil {
+			return nil, fmt.Errorf("parsing account id %q: %w", rawID, err)
+		}
+		accounts[currency] = domain.AccountID(id)
+	}
+	return accounts, nil
+}
+
+// parseMoneyList parses a comma separated list of CURRENCY:AMOUNT pairs.
+func parseMoneyList(raw string) ([]domain.Money, error) {
+	var list []domain.Money
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		rawCurrency, rawAmount, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("%q is not a CURRENCY:AMOUNT pair", part)
+		}
+
+		currency, err := domain.ParseSupportedCurrency(strings.TrimSpace(rawCurrency))
+		if err != nil {
+			return nil, err
+		}
+
+		amount, err := decimal.NewFromString(strings.TrimSpace(rawAmount))
+		if err != nil {
+			return nil, fmt.Errorf("parsing amount %q: %w", rawAmount, err)
+		}
+
+		money, err := domain.NewMoney(amount, currency)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, money)
+	}
+	return list, nil
+}
+
 func connectDB(ctx context.Context, cfg Config) (*pgxpool.Pool, error) {
 	connStr := fmt.Sprintf(
 		"postgres://%s:%s@%s:%s/%s?sslmode=disable",
@@ -211,18 +749,80 @@ func connectDB(ctx context.Context, cfg Config) (*pgxpool.Pool, error) {
 	return pool, nil
 }
 
-// corsMiddleware adds CORS headers for development.
-func corsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-CSRF-Token")
+// newTracerProvider exports spans to the OTLP gRPC collector at endpoint and
+// registers the provider globally. Without an endpoint spans are dropped. The
+// returned function flushes pending spans on shutdown.
+func newTracerProvider(ctx context.Context, endpoint string) (trace.TracerProvider, func(context.Context) error, error) {
+	if endpoint == "" {
+		return noop.NewTracerProvider(), func(context.Context) error { return nil }, nil
+	}
 
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating OTLP exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "minibankingplatform"))),
+	)
+	otel.SetTracerProvider(provider)
+
+	log.Printf("Exporting traces to %s", endpoint)
+	return provider, provider.Shutdown, nil
+}
 
-		next.ServeHTTP(w, r)
-	})
+// corsAnyOrigin in the allowed origins lets every origin through.
+const corsAnyOrigin = "*"
+
+// corsMethods are the methods a preflight can advertise, in the order they are
+// listed in Access-Control-Allow-Methods.
+var corsMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// corsMiddleware adds CORS headers. The request origin is echoed back only
+// when it is one of allowedOrigins, unless they include corsAnyOrigin.
+// Preflight requests are answered with the methods routes serve for the
+// requested path, and with 404 when it serves none.
+func corsMiddleware(allowedOrigins []string, routes chi.Routes) func(http.Handler) http.Handler {
+	anyOrigin := slices.Contains(allowedOrigins, corsAnyOrigin)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if anyOrigin {
+				w.Header().Set("Access-Control-Allow-Origin", corsAnyOrigin)
+			} else {
+				// The header depends on the origin, so caches must not share responses
+				w.Header().Add("Vary", "Origin")
+				if origin := r.Header.Get("Origin"); origin != "" && slices.Contains(allowedOrigins, origin) {
+					w.Header().Set("Access-Control-Allow-Origin", origin)
+				}
+			}
+			w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, Idempotency-Key, X-CSRF-Token, X-Dry-Run")
+
+			if r.Method == http.MethodOptions {
+				methods := routeMethods(routes, r.URL.Path)
+				if len(methods) == 0 {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				w.Header().Set("Access-Control-Allow-Methods", strings.Join(append(methods, http.MethodOptions), ", "))
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// routeMethods lists the corsMethods routes serves for path.
+func routeMethods(routes chi.Routes, path string) []string {
+	var methods []string
+	for _, method := range corsMethods {
+		if routes.Match(chi.NewRouteContext(), method, path) {
+			methods = append(methods, method)
+		}
+	}
+	return methods
 }
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"minibankingplatform/internal/api"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	t.Parallel()
+
+	allowed := []string{"https://bank.example.com", "http://localhost:3000"}
+
+	tests := []struct {
+		name           string
+		allowedOrigins []string
+		origin         string
+		expectedOrigin string
+		expectVary     bool
+	}{
+		{name: "allowed origin is echoed", allowedOrigins: allowed, origin: "http://localhost:3000", expectedOrigin: "http://localhost:3000", expectVary: true},
+		{name: "disallowed origin is omitted", allowedOrigins: allowed, origin: "https://evil.example.com", expectVary: true},
+		{name: "missing origin is omitted", allowedOrigins: allowed, expectVary: true},
+		{name: "wildcard allows any origin", allowedOrigins: []string{corsAnyOrigin}, origin: "https://evil.example.com", expectedOrigin: "*"},
+		{name: "wildcard among origins allows any origin", allowedOrigins: append([]string{corsAnyOrigin}, allowed...), origin: "https://other.example.com", expectedOrigin: "*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			handler := corsMiddleware(tt.allowedOrigins, chi.NewRouter())(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			assert.Equal(t, tt.expectedOrigin, rec.Header().Get("Access-Control-Allow-Origin"))
+			if tt.expectVary {
+				assert.Equal(t, "Origin", rec.Header().Get("Vary"))
+			} else {
+				assert.Empty(t, rec.Header().Get("Vary"))
+			}
+		})
+	}
+}
+
+func TestCORSMiddleware_Preflight(t *testing.T) {
+	t.Parallel()
+
+	// The API's own routes; preflights never reach the handlers
+	router := chi.NewRouter()
+	router.Use(corsMiddleware([]string{corsAnyOrigin}, router))
+	api.HandlerFromMux(api.NewStrictHandler(nil, nil), router)
+
+	tests := []struct {
+		name            string
+		path            string
+		expectedStatus  int
+		expectedMethods string
+	}{
+		{name: "list route advertises only GET", path: "/accounts", expectedStatus: http.StatusOK, expectedMethods: "GET, OPTIONS"},
+		{name: "write route advertises only POST", path: "/auth/register", expectedStatus: http.StatusOK, expectedMethods: "POST, OPTIONS"},
+		{name: "parameterized route is matched", path: "/accounts/6a1f1e4e-0d55-4c4f-9d58-1b1f7f3e2a11/balance", expectedStatus: http.StatusOK, expectedMethods: "GET, OPTIONS"},
+		{name: "unknown route is not found", path: "/wp-admin", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			req := httptest.NewRequest(http.MethodOptions, tt.path, nil)
+			req.Header.Set("Origin", "https://bank.example.com")
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			rec := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedMethods, rec.Header().Get("Access-Control-Allow-Methods"))
+		})
+	}
+}
+
+func TestCORSMiddleware_PreflightAllowsRequestHeaders(t *testing.T) {
+	t.Parallel()
+
+	router := chi.NewRouter()
+	router.Use(corsMiddleware([]string{"https://bank.example.com"}, router))
+	api.HandlerFromMux(api.NewStrictHandler(nil, nil), router)
+
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{name: "idempotency key", header: "Idempotency-Key"},
+		{name: "dry run", header: "X-Dry-Run"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			req := httptest.NewRequest(http.MethodOptions, "/transactions/transfer", nil)
+			req.Header.Set("Origin", "https://bank.example.com")
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			req.Header.Set("Access-Control-Request-Headers", strings.ToLower(tt.header))
+			rec := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, rec.Code)
+			allowed := strings.Split(rec.Header().Get("Access-Control-Allow-Headers"), ", ")
+			assert.Contains(t, allowed, tt.header)
+		})
+	}
+}
+
+func TestGetListEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []string
+	}{
+		{name: "empty falls back to default", value: "", expected: []string{corsAnyOrigin}},
+		{name: "blank entries fall back to default", value: " , ", expected: []string{corsAnyOrigin}},
+		{name: "entries are trimmed", value: "https://a.example.com, https://b.example.com ,", expected: []string{"https://a.example.com", "https://b.example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("CORS_ALLOWED_ORIGINS", tt.value)
+
+			// Act
+			origins := getListEnv("CORS_ALLOWED_ORIGINS", []string{corsAnyOrigin})
+
+			// Assert
+			assert.Equal(t, tt.expected, origins)
+		})
+	}
+}
+
+func TestParseDomainList(t *testing.T) {
+	// Arrange
+	raw := "# disposable providers\nMailinator.com\n\n  tempmail.dev  \n#guerrillamail.com\n"
+
+	// Act
+	domains := parseDomainList(raw)
+
+	// Assert
+	assert.Equal(t, []string{"mailinator.com", "tempmail.dev"}, domains)
+}
+
+func TestCheckJWTSecret(t *testing.T) {
+	t.Parallel()
+
+	randomSecret := strings.Repeat("k", minJWTSecretLength)
+
+	tests := []struct {
+		name    string
+		secret  string
+		appEnv  string
+		wantErr bool
+	}{
+		{name: "default secret is rejected in production", secret: defaultJWTSecret, appEnv: appEnvProduction, wantErr: true},
+		{name: "example secret is rejected in production", secret: "your_jwt_secret_key_change_this_in_production", appEnv: appEnvProduction, wantErr: true},
+		{name: "short secret is rejected in production", secret: randomSecret[1:], appEnv: appEnvProduction, wantErr: true},
+		{name: "unknown environment is treated like production", secret: defaultJWTSecret, appEnv: "staging", wantErr: true},
+		{name: "long random secret is accepted in production", secret: randomSecret, appEnv: appEnvProduction},
+		{name: "default secret is tolerated in development", secret: defaultJWTSecret, appEnv: appEnvDevelopment},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			err := checkJWTSecret(tt.secret, tt.appEnv)
+
+			// Assert
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -20,9 +20,25 @@ const (
 	BearerAuthScopes = "BearerAuth.Scopes"
 )
 
+// Defines values for AccountStatus.
+const (
+	AccountStatusActive AccountStatus = "active"
+	AccountStatusClosed AccountStatus = "closed"
+	AccountStatusFrozen AccountStatus = "frozen"
+)
+
+// Defines values for BatchTransferOutcomeStatus.
+const (
+	Failed     BatchTransferOutcomeStatus = "failed"
+	RolledBack BatchTransferOutcomeStatus = "rolledBack"
+	Skipped    BatchTransferOutcomeStatus = "skipped"
+	Succeeded  BatchTransferOutcomeStatus = "succeeded"
+)
+
 // Defines values for Currency.
 const (
 	EUR Currency = "EUR"
+	GBP Currency = "GBP"
 	USD Currency = "USD"
 )
 
@@ -34,11 +50,48 @@ const (
 	Withdrawal TransactionType = "withdrawal"
 )
 
+// Defines values for UpdateAccountStatusRequestStatus.
+const (
+	UpdateAccountStatusRequestStatusActive UpdateAccountStatusRequestStatus = "active"
+	UpdateAccountStatusRequestStatusClosed UpdateAccountStatusRequestStatus = "closed"
+	UpdateAccountStatusRequestStatusFrozen UpdateAccountStatusRequestStatus = "frozen"
+)
+
+// Defines values for ListAccountsParamsSource.
+const (
+	Ledger ListAccountsParamsSource = "ledger"
+	Stored ListAccountsParamsSource = "stored"
+)
+
 // Account defines model for Account.
 type Account struct {
 	Balance *Money              `json:"balance,omitempty"`
 	Id      *openapi_types.UUID `json:"id,omitempty"`
-	UserId  *openapi_types.UUID `json:"userId,omitempty"`
+
+	// IsClosed Whether the account has been closed
+	IsClosed *bool `json:"isClosed,omitempty"`
+
+	// Label Display name given by the user, omitted when the account has none
+	Label *string `json:"label,omitempty"`
+
+	// Status Frozen and closed accounts cannot be debited, closed accounts cannot be credited either
+	Status *AccountStatus      `json:"status,omitempty"`
+	UserId *openapi_types.UUID `json:"userId,omitempty"`
+}
+
+// AccountLedger defines model for AccountLedger.
+type AccountLedger struct {
+	AccountId *openapi_types.UUID   `json:"accountId,omitempty"`
+	Entries   *[]AccountLedgerEntry `json:"entries,omitempty"`
+}
+
+// AccountLedgerEntry defines model for AccountLedgerEntry.
+type AccountLedgerEntry struct {
+	Amount         *Money              `json:"amount,omitempty"`
+	Id             *openapi_types.UUID `json:"id,omitempty"`
+	RunningBalance *Money              `json:"runningBalance,omitempty"`
+	Timestamp      *time.Time          `json:"timestamp,omitempty"`
+	TransactionId  *openapi_types.UUID `json:"transactionId,omitempty"`
 }
 
 // AccountMismatch defines model for AccountMismatch.
@@ -52,6 +105,15 @@ type AccountMismatch struct {
 	LedgerBalance *string   `json:"ledgerBalance,omitempty"`
 }
 
+// AccountStatus Frozen and closed accounts cannot be debited, closed accounts cannot be credited either
+type AccountStatus string
+
+// AccountsSummary defines model for AccountsSummary.
+type AccountsSummary struct {
+	// Balances One total per currency, in the order of the Currency enum
+	Balances *[]Money `json:"balances,omitempty"`
+}
+
 // AuthResponse defines model for AuthResponse.
 type AuthResponse struct {
 	Email *openapi_types.Email `json:"email,omitempty"`
@@ -67,6 +129,64 @@ type Balance struct {
 	Balance   *Money              `json:"balance,omitempty"`
 }
 
+// BatchTransferOutcome defines model for BatchTransferOutcome.
+type BatchTransferOutcome struct {
+	// Error RFC 7807 Problem Details for HTTP APIs
+	Error *ProblemDetails `json:"error,omitempty"`
+
+	// Index Position of the transfer in the request
+	Index int `json:"index"`
+
+	// Status succeeded: committed. rolledBack: went through but was undone because a later
+	// transfer failed. failed: the transfer that failed the batch. skipped: not attempted
+	// because an earlier transfer failed.
+	Status BatchTransferOutcomeStatus `json:"status"`
+
+	// TransactionId Transaction of a succeeded transfer
+	TransactionId *openapi_types.UUID `json:"transactionId,omitempty"`
+}
+
+// BatchTransferOutcomeStatus succeeded: committed. rolledBack: went through but was undone because a later
+// transfer failed. failed: the transfer that failed the batch. skipped: not attempted
+// because an earlier transfer failed.
+type BatchTransferOutcomeStatus string
+
+// BatchTransferRequest defines model for BatchTransferRequest.
+type BatchTransferRequest struct {
+	Transfers []TransferRequest `json:"transfers" validate:"required,min=1,max=100,dive"`
+}
+
+// BatchTransferResponse defines model for BatchTransferResponse.
+type BatchTransferResponse struct {
+	// Committed Whether the transfers were committed; false when the batch was rolled back
+	Committed bool `json:"committed"`
+
+	// Transfers Outcome of every transfer, in request order
+	Transfers []BatchTransferOutcome `json:"transfers"`
+}
+
+// CashRequest defines model for CashRequest.
+type CashRequest struct {
+	// Amount Amount in the account's currency
+	Amount string `json:"amount" validate:"required,max=32"`
+}
+
+// CashResponse defines model for CashResponse.
+type CashResponse struct {
+	AccountId     *openapi_types.UUID `json:"accountId,omitempty"`
+	Amount        *Money              `json:"amount,omitempty"`
+	Balance       *Money              `json:"balance,omitempty"`
+	Timestamp     *time.Time          `json:"timestamp,omitempty"`
+	TransactionId *openapi_types.UUID `json:"transactionId,omitempty"`
+}
+
+// ChangePasswordRequest defines model for ChangePasswordRequest.
+type ChangePasswordRequest struct {
+	// NewPassword At least 8 characters and at most 72 bytes, the limit of bcrypt
+	NewPassword string `json:"newPassword" validate:"required"`
+	OldPassword string `json:"oldPassword" validate:"required,max=72"`
+}
+
 // Currency Supported currencies
 type Currency string
 
@@ -96,10 +216,33 @@ type ExchangeDetails struct {
 	TargetAmount    *Money              `json:"targetAmount,omitempty"`
 }
 
+// ExchangeRateHistory defines model for ExchangeRateHistory.
+type ExchangeRateHistory struct {
+	Entries []ExchangeRateHistoryEntry `json:"entries"`
+
+	// From Supported currencies
+	From Currency `json:"from"`
+
+	// To Supported currencies
+	To Currency `json:"to"`
+}
+
+// ExchangeRateHistoryEntry defines model for ExchangeRateHistoryEntry.
+type ExchangeRateHistoryEntry struct {
+	// EffectiveAt When the provider first reported this rate
+	EffectiveAt time.Time `json:"effectiveAt"`
+
+	// Rate Exchange rate value
+	Rate string `json:"rate"`
+
+	// Source Exchange rate provider that reported the rate
+	Source string `json:"source"`
+}
+
 // ExchangeRequest defines model for ExchangeRequest.
 type ExchangeRequest struct {
 	// Amount Amount to exchange from source currency
-	Amount string `json:"amount" validate:"required"`
+	Amount string `json:"amount" validate:"required,max=32"`
 
 	// SourceAccountId Source account UUID (currency to exchange from)
 	SourceAccountId openapi_types.UUID `json:"sourceAccountId" validate:"required,uuid"`
@@ -110,6 +253,15 @@ type ExchangeRequest struct {
 
 // ExchangeResponse defines model for ExchangeResponse.
 type ExchangeResponse struct {
+	// Balances Balances the caller's accounts involved in the operation would have after it, only returned by dry runs
+	Balances *[]Balance `json:"balances,omitempty"`
+
+	// DryRun True when the request was a dry run and nothing was persisted; the IDs then refer to records that were rolled back
+	DryRun *bool `json:"dryRun,omitempty"`
+
+	// ExchangeId Exchange ID, usable with GET /transactions/exchanges/{exchangeId}
+	ExchangeId *openapi_types.UUID `json:"exchangeId,omitempty"`
+
 	// ExchangeRate Exchange rate used (e.g., "0.92" for USD to EUR)
 	ExchangeRate    *string             `json:"exchangeRate,omitempty"`
 	SourceAccountId *openapi_types.UUID `json:"sourceAccountId,omitempty"`
@@ -120,6 +272,27 @@ type ExchangeResponse struct {
 	TransactionId   *openapi_types.UUID `json:"transactionId,omitempty"`
 }
 
+// HistoricalBalance defines model for HistoricalBalance.
+type HistoricalBalance struct {
+	AccountId openapi_types.UUID `json:"accountId"`
+
+	// At The time the balance refers to
+	At      time.Time `json:"at"`
+	Balance Money     `json:"balance"`
+}
+
+// LedgerCurrencyMismatch defines model for LedgerCurrencyMismatch.
+type LedgerCurrencyMismatch struct {
+	// AccountCurrency Supported currencies
+	AccountCurrency *Currency           `json:"accountCurrency,omitempty"`
+	AccountId       *openapi_types.UUID `json:"accountId,omitempty"`
+
+	// LedgerCurrency Supported currencies
+	LedgerCurrency *Currency           `json:"ledgerCurrency,omitempty"`
+	RecordId       *openapi_types.UUID `json:"recordId,omitempty"`
+	TransactionId  *openapi_types.UUID `json:"transactionId,omitempty"`
+}
+
 // LedgerCurrencyStatus defines model for LedgerCurrencyStatus.
 type LedgerCurrencyStatus struct {
 	// Currency Supported currencies
@@ -134,8 +307,15 @@ type LedgerCurrencyStatus struct {
 
 // LoginRequest defines model for LoginRequest.
 type LoginRequest struct {
-	Email    openapi_types.Email `json:"email" validate:"required,email"`
-	Password string              `json:"password" validate:"required"`
+	// Email Surrounding whitespace is ignored
+	Email    string `json:"email" validate:"required,max=254,email"`
+	Password string `json:"password" validate:"required,max=72"`
+}
+
+// MaintenanceMode defines model for MaintenanceMode.
+type MaintenanceMode struct {
+	// Enabled Whether money-moving endpoints are blocked
+	Enabled bool `json:"enabled"`
 }
 
 // Money defines model for Money.
@@ -145,6 +325,9 @@ type Money struct {
 
 	// Currency Supported currencies
 	Currency *Currency `json:"currency,omitempty"`
+
+	// Formatted Amount formatted for display, present only when a `locale` was requested
+	Formatted *string `json:"formatted,omitempty"`
 }
 
 // Pagination defines model for Pagination.
@@ -152,13 +335,13 @@ type Pagination struct {
 	// Limit Items per page
 	Limit *int `json:"limit,omitempty"`
 
-	// Page Current page number
+	// Page Current page number, absent when paging with a cursor
 	Page *int `json:"page,omitempty"`
 
-	// Total Total number of items
+	// Total Total number of items, absent when paging with a cursor
 	Total *int `json:"total,omitempty"`
 
-	// TotalPages Total number of pages
+	// TotalPages Total number of pages, absent when paging with a cursor
 	TotalPages *int `json:"totalPages,omitempty"`
 }
 
@@ -193,37 +376,110 @@ type ReconciliationReport struct {
 	AccountMismatches *[]AccountMismatch `json:"accountMismatches,omitempty"`
 
 	// IsConsistent True if all checks passed
-	IsConsistent         *bool                   `json:"isConsistent,omitempty"`
-	LedgerBalances       *[]LedgerCurrencyStatus `json:"ledgerBalances,omitempty"`
-	Timestamp            *time.Time              `json:"timestamp,omitempty"`
-	TotalAccountsChecked *int                    `json:"totalAccountsChecked,omitempty"`
+	IsConsistent   *bool                   `json:"isConsistent,omitempty"`
+	LedgerBalances *[]LedgerCurrencyStatus `json:"ledgerBalances,omitempty"`
+
+	// LedgerCurrencyMismatches Ledger records booked in a currency other than their account's
+	LedgerCurrencyMismatches *[]LedgerCurrencyMismatch `json:"ledgerCurrencyMismatches,omitempty"`
+	Timestamp                *time.Time                `json:"timestamp,omitempty"`
+	TotalAccountsChecked     *int                      `json:"totalAccountsChecked,omitempty"`
+
+	// TransactionDetailMismatches Transactions whose transfer or exchange details don't match their type
+	TransactionDetailMismatches *[]TransactionDetailMismatch `json:"transactionDetailMismatches,omitempty"`
 }
 
 // RegisterRequest defines model for RegisterRequest.
 type RegisterRequest struct {
-	Email    openapi_types.Email `json:"email" validate:"required,email"`
-	Password string              `json:"password" validate:"required,min=8"`
+	// Email Surrounding whitespace is ignored
+	Email string `json:"email" validate:"required,max=254,email"`
+
+	// Password At least 8 characters and at most 72 bytes, the limit of bcrypt
+	Password string `json:"password" validate:"required"`
+}
+
+// SweepAccountRequest defines model for SweepAccountRequest.
+type SweepAccountRequest struct {
+	// FromAccountId Account whose entire balance is moved
+	FromAccountId openapi_types.UUID `json:"fromAccountId" validate:"required,uuid"`
+
+	// ToAccountId Account receiving the balance (same currency)
+	ToAccountId openapi_types.UUID `json:"toAccountId" validate:"required,uuid"`
+}
+
+// SweepAccountResponse defines model for SweepAccountResponse.
+type SweepAccountResponse struct {
+	Amount        *Money              `json:"amount,omitempty"`
+	FromAccountId *openapi_types.UUID `json:"fromAccountId,omitempty"`
+	Timestamp     *time.Time          `json:"timestamp,omitempty"`
+	ToAccountId   *openapi_types.UUID `json:"toAccountId,omitempty"`
+
+	// TransactionId Absent when the source account was already empty
+	TransactionId *openapi_types.UUID `json:"transactionId,omitempty"`
 }
 
 // Transaction defines model for Transaction.
 type Transaction struct {
-	AccountId       *openapi_types.UUID `json:"accountId,omitempty"`
-	ExchangeDetails *ExchangeDetails    `json:"exchangeDetails"`
-	Id              *openapi_types.UUID `json:"id,omitempty"`
-	Timestamp       *time.Time          `json:"timestamp,omitempty"`
-	TransferDetails *TransferDetails    `json:"transferDetails"`
+	AccountId *openapi_types.UUID `json:"accountId,omitempty"`
+
+	// DetailsUnavailable Set when the stored details of the transaction don't match its type, so neither
+	// `transferDetails` nor `exchangeDetails` can be shown. Absent otherwise.
+	DetailsUnavailable *bool               `json:"detailsUnavailable,omitempty"`
+	ExchangeDetails    *ExchangeDetails    `json:"exchangeDetails"`
+	Id                 *openapi_types.UUID `json:"id,omitempty"`
+	Timestamp          *time.Time          `json:"timestamp,omitempty"`
+	TransferDetails    *TransferDetails    `json:"transferDetails"`
 
 	// Type Type of transaction
 	Type *TransactionType `json:"type,omitempty"`
 }
 
+// TransactionDetailMismatch defines model for TransactionDetailMismatch.
+type TransactionDetailMismatch struct {
+	// ExchangeDetails Number of exchange details rows of the transaction
+	ExchangeDetails *int                `json:"exchangeDetails,omitempty"`
+	TransactionId   *openapi_types.UUID `json:"transactionId,omitempty"`
+
+	// TransferDetails Number of transfer details rows of the transaction
+	TransferDetails *int `json:"transferDetails,omitempty"`
+
+	// Type Type of transaction
+	Type *TransactionType `json:"type,omitempty"`
+}
+
+// TransactionLedger defines model for TransactionLedger.
+type TransactionLedger struct {
+	Records       *[]TransactionLedgerRecord `json:"records,omitempty"`
+	TransactionId *openapi_types.UUID        `json:"transactionId,omitempty"`
+}
+
+// TransactionLedgerRecord defines model for TransactionLedgerRecord.
+type TransactionLedgerRecord struct {
+	AccountId *openapi_types.UUID `json:"accountId,omitempty"`
+	Amount    *Money              `json:"amount,omitempty"`
+	Id        *openapi_types.UUID `json:"id,omitempty"`
+	Timestamp *time.Time          `json:"timestamp,omitempty"`
+}
+
 // TransactionType Type of transaction
 type TransactionType string
 
+// TransactionTypeCounts Number of transactions of each type in the filtered set, across all pages
+type TransactionTypeCounts struct {
+	Deposit    *int `json:"deposit,omitempty"`
+	Exchange   *int `json:"exchange,omitempty"`
+	Transfer   *int `json:"transfer,omitempty"`
+	Withdrawal *int `json:"withdrawal,omitempty"`
+}
+
 // TransactionsResponse defines model for TransactionsResponse.
 type TransactionsResponse struct {
+	// NextCursor Pass as `cursor` to get the next page. Absent on the last page.
+	NextCursor   *string        `json:"nextCursor,omitempty"`
 	Pagination   *Pagination    `json:"pagination,omitempty"`
 	Transactions *[]Transaction `json:"transactions,omitempty"`
+
+	// TypeCounts Number of transactions of each type in the filtered set, across all pages
+	TypeCounts *TransactionTypeCounts `json:"typeCounts,omitempty"`
 }
 
 // TransferDetails defines model for TransferDetails.
@@ -236,10 +492,10 @@ type TransferDetails struct {
 // TransferRequest defines model for TransferRequest.
 type TransferRequest struct {
 	// Amount Amount to transfer (positive, 2 decimal places)
-	Amount string `json:"amount" validate:"required"`
+	Amount string `json:"amount" validate:"required,max=32"`
 
 	// Currency Supported currencies
-	Currency Currency `json:"currency"`
+	Currency *Currency `json:"currency,omitempty"`
 
 	// FromAccountId Source account UUID (must belong to authenticated user)
 	FromAccountId openapi_types.UUID `json:"fromAccountId" validate:"required,uuid"`
@@ -250,29 +506,136 @@ type TransferRequest struct {
 
 // TransferResponse defines model for TransferResponse.
 type TransferResponse struct {
-	Amount        *Money              `json:"amount,omitempty"`
+	Amount *Money `json:"amount,omitempty"`
+
+	// Balances Balances the caller's accounts involved in the operation would have after it, only returned by dry runs
+	Balances *[]Balance `json:"balances,omitempty"`
+
+	// DryRun True when the request was a dry run and nothing was persisted; the IDs then refer to records that were rolled back
+	DryRun        *bool               `json:"dryRun,omitempty"`
 	FromAccountId *openapi_types.UUID `json:"fromAccountId,omitempty"`
 	Timestamp     *time.Time          `json:"timestamp,omitempty"`
 	ToAccountId   *openapi_types.UUID `json:"toAccountId,omitempty"`
 	TransactionId *openapi_types.UUID `json:"transactionId,omitempty"`
 }
 
+// UpdateAccountRequest defines model for UpdateAccountRequest.
+type UpdateAccountRequest struct {
+	// Label New display name; a blank label removes it
+	Label string `json:"label"`
+}
+
+// UpdateAccountStatusRequest defines model for UpdateAccountStatusRequest.
+type UpdateAccountStatusRequest struct {
+	Status UpdateAccountStatusRequestStatus `json:"status" validate:"required,oneof=active frozen closed"`
+}
+
+// UpdateAccountStatusRequestStatus defines model for UpdateAccountStatusRequest.Status.
+type UpdateAccountStatusRequestStatus string
+
 // UserInfo defines model for UserInfo.
 type UserInfo struct {
 	Email  *openapi_types.Email `json:"email,omitempty"`
 	UserId *openapi_types.UUID  `json:"userId,omitempty"`
 }
 
+// ListAccountsParams defines parameters for ListAccounts.
+type ListAccountsParams struct {
+	// IncludeClosed Include closed accounts in the list
+	IncludeClosed *bool `form:"includeClosed,omitempty" json:"includeClosed,omitempty"`
+
+	// Source Where balances are read from: `stored` returns the balance kept on each account,
+	// `ledger` computes it from the account's ledger records. The two only differ if the
+	// stored balance has drifted from the ledger.
+	Source *ListAccountsParamsSource `form:"source,omitempty" json:"source,omitempty"`
+}
+
+// ListAccountsParamsSource defines parameters for ListAccounts.
+type ListAccountsParamsSource string
+
+// GetAccountsSummaryParams defines parameters for GetAccountsSummary.
+type GetAccountsSummaryParams struct {
+	// Locale BCP 47 locale (e.g. `en-US`, `de-DE`). When set, money amounts also carry a
+	// `formatted` display string for that locale. Unparseable locales are ignored.
+	Locale *string `form:"locale,omitempty" json:"locale,omitempty"`
+}
+
+// GetAccountBalanceParams defines parameters for GetAccountBalance.
+type GetAccountBalanceParams struct {
+	// Locale BCP 47 locale (e.g. `en-US`, `de-DE`). When set, money amounts also carry a
+	// `formatted` display string for that locale. Unparseable locales are ignored.
+	Locale *string `form:"locale,omitempty" json:"locale,omitempty"`
+}
+
+// GetAccountBalanceAtDateParams defines parameters for GetAccountBalanceAtDate.
+type GetAccountBalanceAtDateParams struct {
+	// At Point in time (RFC 3339)
+	At time.Time `form:"at" json:"at"`
+}
+
+// RefreshTokenParams defines parameters for RefreshToken.
+type RefreshTokenParams struct {
+	// Authorization Bearer token to refresh
+	Authorization *string `json:"Authorization,omitempty"`
+}
+
+// GetExchangeRateHistoryParams defines parameters for GetExchangeRateHistory.
+type GetExchangeRateHistoryParams struct {
+	// From Source currency
+	From Currency `form:"from" json:"from"`
+
+	// To Target currency
+	To Currency `form:"to" json:"to"`
+
+	// Since Only include rates that took effect at or after this time (RFC 3339); defaults to the beginning of the history
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+}
+
 // ListTransactionsParams defines parameters for ListTransactions.
 type ListTransactionsParams struct {
 	// Type Filter by transaction type
 	Type *TransactionType `form:"type,omitempty" json:"type,omitempty"`
 
-	// Page Page number (1-based)
+	// AccountId Only include transactions that debit or credit this account, which must belong to the user
+	AccountId *openapi_types.UUID `form:"accountId,omitempty" json:"accountId,omitempty"`
+
+	// From Only include transactions booked at or after this time (RFC 3339)
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only include transactions booked at or before this time (RFC 3339)
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Page Page number (1-based), ignored when a cursor is given
 	Page *int `form:"page,omitempty" json:"page,omitempty"`
 
-	// Limit Number of items per page
+	// Cursor Continue the list after the page that returned this `nextCursor`. Cursor pages
+	// stay stable while new transactions are booked, unlike numbered pages.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Number of items per page, defaults to the deployment's configured page size (20 unless changed)
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// IncludeTypeCounts Include the number of transactions of each type across all pages. Ignored with
+	// `cursor`, so ask for them on the first page.
+	IncludeTypeCounts *bool `form:"includeTypeCounts,omitempty" json:"includeTypeCounts,omitempty"`
+
+	// Locale BCP 47 locale (e.g. `en-US`, `de-DE`). When set, money amounts also carry a
+	// `formatted` display string for that locale. Unparseable locales are ignored.
+	Locale *string `form:"locale,omitempty" json:"locale,omitempty"`
+}
+
+// ExchangeParams defines parameters for Exchange.
+type ExchangeParams struct {
+	// IdempotencyKey Client chosen key that makes retries safe. The first successful exchange with a key
+	// is executed once; later requests of the same user with that key get the stored
+	// response of the first one and move no money. A key reused with a different request,
+	// or on another endpoint, is rejected with 422. Keys are remembered for 24 hours by default.
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+
+	// XDryRun When true, the exchange runs with all its checks in a database transaction that is
+	// rolled back at the end. The response shows its outcome, including the resulting
+	// balances, but no money moves. Dry runs ignore the Idempotency-Key header.
+	XDryRun *bool `json:"X-Dry-Run,omitempty"`
 }
 
 // CalculateExchangeParams defines parameters for CalculateExchange.
@@ -287,18 +650,63 @@ type CalculateExchangeParams struct {
 	TargetCurrency Currency `form:"targetCurrency" json:"targetCurrency"`
 }
 
+// TransferParams defines parameters for Transfer.
+type TransferParams struct {
+	// IdempotencyKey Client chosen key that makes retries safe. The first successful transfer with a key
+	// is executed once; later requests of the same user with that key get the stored
+	// response of the first one and move no money. A key reused with a different request,
+	// or on another endpoint, is rejected with 422. Keys are remembered for 24 hours by default.
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+
+	// XDryRun When true, the transfer runs with all its checks in a database transaction that is
+	// rolled back at the end. The response shows its outcome, including the resulting
+	// balances, but no money moves. Dry runs ignore the Idempotency-Key header.
+	XDryRun *bool `json:"X-Dry-Run,omitempty"`
+}
+
+// GetTransactionParams defines parameters for GetTransaction.
+type GetTransactionParams struct {
+	// Locale BCP 47 locale (e.g. `en-US`, `de-DE`). When set, money amounts also carry a
+	// `formatted` display string for that locale. Unparseable locales are ignored.
+	Locale *string `form:"locale,omitempty" json:"locale,omitempty"`
+}
+
+// UpdateAccountJSONRequestBody defines body for UpdateAccount for application/json ContentType.
+type UpdateAccountJSONRequestBody = UpdateAccountRequest
+
+// DepositJSONRequestBody defines body for Deposit for application/json ContentType.
+type DepositJSONRequestBody = CashRequest
+
+// UpdateAccountStatusJSONRequestBody defines body for UpdateAccountStatus for application/json ContentType.
+type UpdateAccountStatusJSONRequestBody = UpdateAccountStatusRequest
+
+// WithdrawJSONRequestBody defines body for Withdraw for application/json ContentType.
+type WithdrawJSONRequestBody = CashRequest
+
 // LoginJSONRequestBody defines body for Login for application/json ContentType.
 type LoginJSONRequestBody = LoginRequest
 
 // RegisterJSONRequestBody defines body for Register for application/json ContentType.
 type RegisterJSONRequestBody = RegisterRequest
 
+// SweepAccountJSONRequestBody defines body for SweepAccount for application/json ContentType.
+type SweepAccountJSONRequestBody = SweepAccountRequest
+
+// SetMaintenanceModeJSONRequestBody defines body for SetMaintenanceMode for application/json ContentType.
+type SetMaintenanceModeJSONRequestBody = MaintenanceMode
+
 // ExchangeJSONRequestBody defines body for Exchange for application/json ContentType.
 type ExchangeJSONRequestBody = ExchangeRequest
 
 // TransferJSONRequestBody defines body for Transfer for application/json ContentType.
 type TransferJSONRequestBody = TransferRequest
 
+// BatchTransferJSONRequestBody defines body for BatchTransfer for application/json ContentType.
+type BatchTransferJSONRequestBody = BatchTransferRequest
+
+// ChangePasswordJSONRequestBody defines body for ChangePassword for application/json ContentType.
+type ChangePasswordJSONRequestBody = ChangePasswordRequest
+
 // Getter for additional properties for ProblemDetails. Returns the specified
 // element and whether it was found
 func (a ProblemDetails) Get(fieldName string) (value interface{}, found bool) {
@@ -425,19 +833,55 @@ func (a ProblemDetails) MarshalJSON() ([]byte, error) {
 type ServerInterface interface {
 	// List user's accounts
 	// (GET /accounts)
-	ListAccounts(w http.ResponseWriter, r *http.Request)
+	ListAccounts(w http.ResponseWriter, r *http.Request, params ListAccountsParams)
+	// Get total balances per currency
+	// (GET /accounts/summary)
+	GetAccountsSummary(w http.ResponseWriter, r *http.Request, params GetAccountsSummaryParams)
+	// Update an account
+	// (PATCH /accounts/{accountId})
+	UpdateAccount(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID)
 	// Get account balance
 	// (GET /accounts/{accountId}/balance)
-	GetAccountBalance(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID)
+	GetAccountBalance(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID, params GetAccountBalanceParams)
+	// Get account balance at a past time
+	// (GET /accounts/{accountId}/balance/history)
+	GetAccountBalanceAtDate(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID, params GetAccountBalanceAtDateParams)
+	// Deposit money into an account
+	// (POST /accounts/{accountId}/deposit)
+	Deposit(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID)
+	// Get account ledger
+	// (GET /accounts/{accountId}/ledger)
+	GetAccountLedger(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID)
+	// Freeze, unfreeze or close an account
+	// (PATCH /accounts/{accountId}/status)
+	UpdateAccountStatus(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID)
+	// Withdraw money from an account
+	// (POST /accounts/{accountId}/withdraw)
+	Withdraw(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID)
 	// Authenticate user
 	// (POST /auth/login)
 	Login(w http.ResponseWriter, r *http.Request)
 	// Get current user info
 	// (GET /auth/me)
 	GetCurrentUser(w http.ResponseWriter, r *http.Request)
+	// Refresh a token
+	// (POST /auth/refresh)
+	RefreshToken(w http.ResponseWriter, r *http.Request, params RefreshTokenParams)
 	// Register a new user
 	// (POST /auth/register)
 	Register(w http.ResponseWriter, r *http.Request)
+	// Rotate the current token
+	// (POST /auth/rotate)
+	RotateToken(w http.ResponseWriter, r *http.Request)
+	// Get exchange rate history
+	// (GET /exchange-rates/history)
+	GetExchangeRateHistory(w http.ResponseWriter, r *http.Request, params GetExchangeRateHistoryParams)
+	// Sweep an account's entire balance
+	// (POST /system/accounts/sweep)
+	SweepAccount(w http.ResponseWriter, r *http.Request)
+	// Switch maintenance mode
+	// (PUT /system/maintenance)
+	SetMaintenanceMode(w http.ResponseWriter, r *http.Request)
 	// Reconciliation report
 	// (GET /system/reconcile)
 	Reconcile(w http.ResponseWriter, r *http.Request)
@@ -446,13 +890,28 @@ type ServerInterface interface {
 	ListTransactions(w http.ResponseWriter, r *http.Request, params ListTransactionsParams)
 	// Exchange currency within user's accounts
 	// (POST /transactions/exchange)
-	Exchange(w http.ResponseWriter, r *http.Request)
+	Exchange(w http.ResponseWriter, r *http.Request, params ExchangeParams)
 	// Calculate exchange amount
 	// (GET /transactions/exchange/calculate)
 	CalculateExchange(w http.ResponseWriter, r *http.Request, params CalculateExchangeParams)
+	// Get exchange details
+	// (GET /transactions/exchanges/{exchangeId})
+	GetExchange(w http.ResponseWriter, r *http.Request, exchangeId openapi_types.UUID)
 	// Transfer money between users
 	// (POST /transactions/transfer)
-	Transfer(w http.ResponseWriter, r *http.Request)
+	Transfer(w http.ResponseWriter, r *http.Request, params TransferParams)
+	// Run several transfers atomically
+	// (POST /transactions/transfers/batch)
+	BatchTransfer(w http.ResponseWriter, r *http.Request)
+	// Get transaction
+	// (GET /transactions/{transactionId})
+	GetTransaction(w http.ResponseWriter, r *http.Request, transactionId openapi_types.UUID, params GetTransactionParams)
+	// Get transaction ledger records
+	// (GET /transactions/{transactionId}/ledger)
+	GetTransactionLedger(w http.ResponseWriter, r *http.Request, transactionId openapi_types.UUID)
+	// Change password
+	// (PATCH /users/me/password)
+	ChangePassword(w http.ResponseWriter, r *http.Request)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
@@ -461,13 +920,55 @@ type Unimplemented struct{}
 
 // List user's accounts
 // (GET /accounts)
-func (_ Unimplemented) ListAccounts(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) ListAccounts(w http.ResponseWriter, r *http.Request, params ListAccountsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get total balances per currency
+// (GET /accounts/summary)
+func (_ Unimplemented) GetAccountsSummary(w http.ResponseWriter, r *http.Request, params GetAccountsSummaryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update an account
+// (PATCH /accounts/{accountId})
+func (_ Unimplemented) UpdateAccount(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
 // Get account balance
 // (GET /accounts/{accountId}/balance)
-func (_ Unimplemented) GetAccountBalance(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID) {
+func (_ Unimplemented) GetAccountBalance(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID, params GetAccountBalanceParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get account balance at a past time
+// (GET /accounts/{accountId}/balance/history)
+func (_ Unimplemented) GetAccountBalanceAtDate(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID, params GetAccountBalanceAtDateParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Deposit money into an account
+// (POST /accounts/{accountId}/deposit)
+func (_ Unimplemented) Deposit(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get account ledger
+// (GET /accounts/{accountId}/ledger)
+func (_ Unimplemented) GetAccountLedger(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Freeze, unfreeze or close an account
+// (PATCH /accounts/{accountId}/status)
+func (_ Unimplemented) UpdateAccountStatus(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Withdraw money from an account
+// (POST /accounts/{accountId}/withdraw)
+func (_ Unimplemented) Withdraw(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -483,12 +984,42 @@ func (_ Unimplemented) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Refresh a token
+// (POST /auth/refresh)
+func (_ Unimplemented) RefreshToken(w http.ResponseWriter, r *http.Request, params RefreshTokenParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Register a new user
 // (POST /auth/register)
 func (_ Unimplemented) Register(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Rotate the current token
+// (POST /auth/rotate)
+func (_ Unimplemented) RotateToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get exchange rate history
+// (GET /exchange-rates/history)
+func (_ Unimplemented) GetExchangeRateHistory(w http.ResponseWriter, r *http.Request, params GetExchangeRateHistoryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Sweep an account's entire balance
+// (POST /system/accounts/sweep)
+func (_ Unimplemented) SweepAccount(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Switch maintenance mode
+// (PUT /system/maintenance)
+func (_ Unimplemented) SetMaintenanceMode(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Reconciliation report
 // (GET /system/reconcile)
 func (_ Unimplemented) Reconcile(w http.ResponseWriter, r *http.Request) {
@@ -503,7 +1034,7 @@ func (_ Unimplemented) ListTransactions(w http.ResponseWriter, r *http.Request,
 
 // Exchange currency within user's accounts
 // (POST /transactions/exchange)
-func (_ Unimplemented) Exchange(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) Exchange(w http.ResponseWriter, r *http.Request, params ExchangeParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -513,9 +1044,39 @@ func (_ Unimplemented) CalculateExchange(w http.ResponseWriter, r *http.Request,
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get exchange details
+// (GET /transactions/exchanges/{exchangeId})
+func (_ Unimplemented) GetExchange(w http.ResponseWriter, r *http.Request, exchangeId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Transfer money between users
 // (POST /transactions/transfer)
-func (_ Unimplemented) Transfer(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) Transfer(w http.ResponseWriter, r *http.Request, params TransferParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Run several transfers atomically
+// (POST /transactions/transfers/batch)
+func (_ Unimplemented) BatchTransfer(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get transaction
+// (GET /transactions/{transactionId})
+func (_ Unimplemented) GetTransaction(w http.ResponseWriter, r *http.Request, transactionId openapi_types.UUID, params GetTransactionParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get transaction ledger records
+// (GET /transactions/{transactionId}/ledger)
+func (_ Unimplemented) GetTransactionLedger(w http.ResponseWriter, r *http.Request, transactionId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change password
+// (PATCH /users/me/password)
+func (_ Unimplemented) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -531,14 +1092,35 @@ type MiddlewareFunc func(http.Handler) http.Handler
 // ListAccounts operation middleware
 func (siw *ServerInterfaceWrapper) ListAccounts(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAccountsParams
+
+	// ------------- Optional query parameter "includeClosed" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeClosed", r.URL.Query(), &params.IncludeClosed)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "includeClosed", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "source" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "source", r.URL.Query(), &params.Source)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "source", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListAccounts(w, r)
+		siw.Handler.ListAccounts(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -548,42 +1130,30 @@ func (siw *ServerInterfaceWrapper) ListAccounts(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// GetAccountBalance operation middleware
-func (siw *ServerInterfaceWrapper) GetAccountBalance(w http.ResponseWriter, r *http.Request) {
+// GetAccountsSummary operation middleware
+func (siw *ServerInterfaceWrapper) GetAccountsSummary(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "accountId" -------------
-	var accountId openapi_types.UUID
-
-	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
-		return
-	}
-
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	r = r.WithContext(ctx)
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAccountBalance(w, r, accountId)
-	}))
-
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAccountsSummaryParams
 
-	handler.ServeHTTP(w, r)
-}
+	// ------------- Optional query parameter "locale" -------------
 
-// Login operation middleware
-func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request) {
+	err = runtime.BindQueryParameter("form", true, false, "locale", r.URL.Query(), &params.Locale)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "locale", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Login(w, r)
+		siw.Handler.GetAccountsSummary(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -593,8 +1163,19 @@ func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request)
 	handler.ServeHTTP(w, r)
 }
 
-// GetCurrentUser operation middleware
-func (siw *ServerInterfaceWrapper) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
+// UpdateAccount operation middleware
+func (siw *ServerInterfaceWrapper) UpdateAccount(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "accountId" -------------
+	var accountId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -603,7 +1184,7 @@ func (siw *ServerInterfaceWrapper) GetCurrentUser(w http.ResponseWriter, r *http
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetCurrentUser(w, r)
+		siw.Handler.UpdateAccount(w, r, accountId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -613,22 +1194,19 @@ func (siw *ServerInterfaceWrapper) GetCurrentUser(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// Register operation middleware
-func (siw *ServerInterfaceWrapper) Register(w http.ResponseWriter, r *http.Request) {
-
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Register(w, r)
-	}))
+// GetAccountBalance operation middleware
+func (siw *ServerInterfaceWrapper) GetAccountBalance(w http.ResponseWriter, r *http.Request) {
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	var err error
 
-	handler.ServeHTTP(w, r)
-}
+	// ------------- Path parameter "accountId" -------------
+	var accountId openapi_types.UUID
 
-// Reconcile operation middleware
-func (siw *ServerInterfaceWrapper) Reconcile(w http.ResponseWriter, r *http.Request) {
+	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -636,8 +1214,19 @@ func (siw *ServerInterfaceWrapper) Reconcile(w http.ResponseWriter, r *http.Requ
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAccountBalanceParams
+
+	// ------------- Optional query parameter "locale" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "locale", r.URL.Query(), &params.Locale)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "locale", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Reconcile(w, r)
+		siw.Handler.GetAccountBalance(w, r, accountId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -647,11 +1236,20 @@ func (siw *ServerInterfaceWrapper) Reconcile(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// ListTransactions operation middleware
-func (siw *ServerInterfaceWrapper) ListTransactions(w http.ResponseWriter, r *http.Request) {
+// GetAccountBalanceAtDate operation middleware
+func (siw *ServerInterfaceWrapper) GetAccountBalanceAtDate(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	// ------------- Path parameter "accountId" -------------
+	var accountId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
+		return
+	}
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -659,34 +1257,25 @@ func (siw *ServerInterfaceWrapper) ListTransactions(w http.ResponseWriter, r *ht
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListTransactionsParams
-
-	// ------------- Optional query parameter "type" -------------
+	var params GetAccountBalanceAtDateParams
 
-	err = runtime.BindQueryParameter("form", true, false, "type", r.URL.Query(), &params.Type)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
-		return
-	}
+	// ------------- Required query parameter "at" -------------
 
-	// ------------- Optional query parameter "page" -------------
+	if paramValue := r.URL.Query().Get("at"); paramValue != "" {
 
-	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "at"})
 		return
 	}
 
-	// ------------- Optional query parameter "limit" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindQueryParameter("form", true, true, "at", r.URL.Query(), &params.At)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "at", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListTransactions(w, r, params)
+		siw.Handler.GetAccountBalanceAtDate(w, r, accountId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -696,8 +1285,19 @@ func (siw *ServerInterfaceWrapper) ListTransactions(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// Exchange operation middleware
-func (siw *ServerInterfaceWrapper) Exchange(w http.ResponseWriter, r *http.Request) {
+// Deposit operation middleware
+func (siw *ServerInterfaceWrapper) Deposit(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "accountId" -------------
+	var accountId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -706,7 +1306,7 @@ func (siw *ServerInterfaceWrapper) Exchange(w http.ResponseWriter, r *http.Reque
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Exchange(w, r)
+		siw.Handler.Deposit(w, r, accountId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -716,67 +1316,104 @@ func (siw *ServerInterfaceWrapper) Exchange(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r)
 }
 
-// CalculateExchange operation middleware
-func (siw *ServerInterfaceWrapper) CalculateExchange(w http.ResponseWriter, r *http.Request) {
+// GetAccountLedger operation middleware
+func (siw *ServerInterfaceWrapper) GetAccountLedger(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	// ------------- Path parameter "accountId" -------------
+	var accountId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
+		return
+	}
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	r = r.WithContext(ctx)
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params CalculateExchangeParams
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAccountLedger(w, r, accountId)
+	}))
 
-	// ------------- Required query parameter "amount" -------------
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	if paramValue := r.URL.Query().Get("amount"); paramValue != "" {
+	handler.ServeHTTP(w, r)
+}
 
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "amount"})
-		return
-	}
+// UpdateAccountStatus operation middleware
+func (siw *ServerInterfaceWrapper) UpdateAccountStatus(w http.ResponseWriter, r *http.Request) {
 
-	err = runtime.BindQueryParameter("form", true, true, "amount", r.URL.Query(), &params.Amount)
+	var err error
+
+	// ------------- Path parameter "accountId" -------------
+	var accountId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "amount", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
 		return
 	}
 
-	// ------------- Required query parameter "sourceCurrency" -------------
+	ctx := r.Context()
 
-	if paramValue := r.URL.Query().Get("sourceCurrency"); paramValue != "" {
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "sourceCurrency"})
-		return
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateAccountStatus(w, r, accountId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "sourceCurrency", r.URL.Query(), &params.SourceCurrency)
+	handler.ServeHTTP(w, r)
+}
+
+// Withdraw operation middleware
+func (siw *ServerInterfaceWrapper) Withdraw(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "accountId" -------------
+	var accountId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountId", chi.URLParam(r, "accountId"), &accountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sourceCurrency", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
 		return
 	}
 
-	// ------------- Required query parameter "targetCurrency" -------------
+	ctx := r.Context()
 
-	if paramValue := r.URL.Query().Get("targetCurrency"); paramValue != "" {
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "targetCurrency"})
-		return
-	}
+	r = r.WithContext(ctx)
 
-	err = runtime.BindQueryParameter("form", true, true, "targetCurrency", r.URL.Query(), &params.TargetCurrency)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "targetCurrency", Err: err})
-		return
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Withdraw(w, r, accountId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// Login operation middleware
+func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request) {
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CalculateExchange(w, r, params)
+		siw.Handler.Login(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -786,8 +1423,8 @@ func (siw *ServerInterfaceWrapper) CalculateExchange(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// Transfer operation middleware
-func (siw *ServerInterfaceWrapper) Transfer(w http.ResponseWriter, r *http.Request) {
+// GetCurrentUser operation middleware
+func (siw *ServerInterfaceWrapper) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -796,7 +1433,7 @@ func (siw *ServerInterfaceWrapper) Transfer(w http.ResponseWriter, r *http.Reque
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Transfer(w, r)
+		siw.Handler.GetCurrentUser(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -806,93 +1443,731 @@ func (siw *ServerInterfaceWrapper) Transfer(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// RefreshToken operation middleware
+func (siw *ServerInterfaceWrapper) RefreshToken(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RefreshTokenParams
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	headers := r.Header
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	// ------------- Optional header parameter "Authorization" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Authorization")]; found {
+		var Authorization string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Authorization", Count: n})
+			return
+		}
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+		err = runtime.BindStyledParameterWithOptions("simple", "Authorization", valueList[0], &Authorization, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Authorization", Err: err})
+			return
+		}
 
-type RequiredParamError struct {
-	ParamName string
-}
+		params.Authorization = &Authorization
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	}
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RefreshToken(w, r, params)
+	}))
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
+	handler.ServeHTTP(w, r)
 }
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+// Register operation middleware
+func (siw *ServerInterfaceWrapper) Register(w http.ResponseWriter, r *http.Request) {
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Register(w, r)
+	}))
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
+	handler.ServeHTTP(w, r)
 }
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+// RotateToken operation middleware
+func (siw *ServerInterfaceWrapper) RotateToken(w http.ResponseWriter, r *http.Request) {
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+	ctx := r.Context()
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
-}
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RotateToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetExchangeRateHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetExchangeRateHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetExchangeRateHistoryParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "to"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetExchangeRateHistory(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SweepAccount operation middleware
+func (siw *ServerInterfaceWrapper) SweepAccount(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SweepAccount(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetMaintenanceMode operation middleware
+func (siw *ServerInterfaceWrapper) SetMaintenanceMode(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetMaintenanceMode(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Reconcile operation middleware
+func (siw *ServerInterfaceWrapper) Reconcile(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Reconcile(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListTransactions operation middleware
+func (siw *ServerInterfaceWrapper) ListTransactions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTransactionsParams
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", r.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "accountId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "accountId", r.URL.Query(), &params.AccountId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "includeTypeCounts" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeTypeCounts", r.URL.Query(), &params.IncludeTypeCounts)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "includeTypeCounts", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "locale" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "locale", r.URL.Query(), &params.Locale)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "locale", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTransactions(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Exchange operation middleware
+func (siw *ServerInterfaceWrapper) Exchange(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExchangeParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	// ------------- Optional header parameter "X-Dry-Run" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Dry-Run")]; found {
+		var XDryRun bool
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Dry-Run", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Dry-Run", valueList[0], &XDryRun, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Dry-Run", Err: err})
+			return
+		}
+
+		params.XDryRun = &XDryRun
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Exchange(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CalculateExchange operation middleware
+func (siw *ServerInterfaceWrapper) CalculateExchange(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CalculateExchangeParams
+
+	// ------------- Required query parameter "amount" -------------
+
+	if paramValue := r.URL.Query().Get("amount"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "amount"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "amount", r.URL.Query(), &params.Amount)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "amount", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "sourceCurrency" -------------
+
+	if paramValue := r.URL.Query().Get("sourceCurrency"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "sourceCurrency"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "sourceCurrency", r.URL.Query(), &params.SourceCurrency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sourceCurrency", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "targetCurrency" -------------
+
+	if paramValue := r.URL.Query().Get("targetCurrency"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "targetCurrency"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "targetCurrency", r.URL.Query(), &params.TargetCurrency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "targetCurrency", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CalculateExchange(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetExchange operation middleware
+func (siw *ServerInterfaceWrapper) GetExchange(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "exchangeId" -------------
+	var exchangeId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "exchangeId", chi.URLParam(r, "exchangeId"), &exchangeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "exchangeId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetExchange(w, r, exchangeId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Transfer operation middleware
+func (siw *ServerInterfaceWrapper) Transfer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TransferParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	// ------------- Optional header parameter "X-Dry-Run" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Dry-Run")]; found {
+		var XDryRun bool
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Dry-Run", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Dry-Run", valueList[0], &XDryRun, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Dry-Run", Err: err})
+			return
+		}
+
+		params.XDryRun = &XDryRun
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Transfer(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BatchTransfer operation middleware
+func (siw *ServerInterfaceWrapper) BatchTransfer(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BatchTransfer(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTransaction operation middleware
+func (siw *ServerInterfaceWrapper) GetTransaction(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "transactionId" -------------
+	var transactionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "transactionId", chi.URLParam(r, "transactionId"), &transactionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "transactionId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTransactionParams
+
+	// ------------- Optional query parameter "locale" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "locale", r.URL.Query(), &params.Locale)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "locale", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTransaction(w, r, transactionId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTransactionLedger operation middleware
+func (siw *ServerInterfaceWrapper) GetTransactionLedger(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "transactionId" -------------
+	var transactionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "transactionId", chi.URLParam(r, "transactionId"), &transactionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "transactionId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTransactionLedger(w, r, transactionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ChangePassword operation middleware
+func (siw *ServerInterfaceWrapper) ChangePassword(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ChangePassword(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
 
 func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
 	return HandlerWithOptions(si, ChiServerOptions{
@@ -901,392 +2176,1689 @@ func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string)
 	})
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/accounts", wrapper.ListAccounts)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/accounts/summary", wrapper.GetAccountsSummary)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/accounts/{accountId}", wrapper.UpdateAccount)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/accounts/{accountId}/balance", wrapper.GetAccountBalance)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/accounts/{accountId}/balance/history", wrapper.GetAccountBalanceAtDate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/accounts/{accountId}/deposit", wrapper.Deposit)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/accounts/{accountId}/ledger", wrapper.GetAccountLedger)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/accounts/{accountId}/status", wrapper.UpdateAccountStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/accounts/{accountId}/withdraw", wrapper.Withdraw)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/login", wrapper.Login)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/me", wrapper.GetCurrentUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/refresh", wrapper.RefreshToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/register", wrapper.Register)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/rotate", wrapper.RotateToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/exchange-rates/history", wrapper.GetExchangeRateHistory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/system/accounts/sweep", wrapper.SweepAccount)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/system/maintenance", wrapper.SetMaintenanceMode)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/system/reconcile", wrapper.Reconcile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/transactions", wrapper.ListTransactions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/transactions/exchange", wrapper.Exchange)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/transactions/exchange/calculate", wrapper.CalculateExchange)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/transactions/exchanges/{exchangeId}", wrapper.GetExchange)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/transactions/transfer", wrapper.Transfer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/transactions/transfers/batch", wrapper.BatchTransfer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/transactions/{transactionId}", wrapper.GetTransaction)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/transactions/{transactionId}/ledger", wrapper.GetTransactionLedger)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/users/me/password", wrapper.ChangePassword)
+	})
+
+	return r
+}
+
+type ListAccountsRequestObject struct {
+	Params ListAccountsParams
+}
+
+type ListAccountsResponseObject interface {
+	VisitListAccountsResponse(w http.ResponseWriter) error
+}
+
+type ListAccounts200JSONResponse []Account
+
+func (response ListAccounts200JSONResponse) VisitListAccountsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAccounts401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response ListAccounts401ApplicationProblemPlusJSONResponse) VisitListAccountsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountsSummaryRequestObject struct {
+	Params GetAccountsSummaryParams
+}
+
+type GetAccountsSummaryResponseObject interface {
+	VisitGetAccountsSummaryResponse(w http.ResponseWriter) error
+}
+
+type GetAccountsSummary200JSONResponse AccountsSummary
+
+func (response GetAccountsSummary200JSONResponse) VisitGetAccountsSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountsSummary401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAccountsSummary401ApplicationProblemPlusJSONResponse) VisitGetAccountsSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountsSummary500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAccountsSummary500ApplicationProblemPlusJSONResponse) VisitGetAccountsSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccountRequestObject struct {
+	AccountId openapi_types.UUID `json:"accountId"`
+	Body      *UpdateAccountJSONRequestBody
+}
+
+type UpdateAccountResponseObject interface {
+	VisitUpdateAccountResponse(w http.ResponseWriter) error
+}
+
+type UpdateAccount200JSONResponse Account
+
+func (response UpdateAccount200JSONResponse) VisitUpdateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccount400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response UpdateAccount400ApplicationProblemPlusJSONResponse) VisitUpdateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccount401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response UpdateAccount401ApplicationProblemPlusJSONResponse) VisitUpdateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccount403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response UpdateAccount403ApplicationProblemPlusJSONResponse) VisitUpdateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccount404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response UpdateAccount404ApplicationProblemPlusJSONResponse) VisitUpdateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccount500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response UpdateAccount500ApplicationProblemPlusJSONResponse) VisitUpdateAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountBalanceRequestObject struct {
+	AccountId openapi_types.UUID `json:"accountId"`
+	Params    GetAccountBalanceParams
+}
+
+type GetAccountBalanceResponseObject interface {
+	VisitGetAccountBalanceResponse(w http.ResponseWriter) error
+}
+
+type GetAccountBalance200JSONResponse Balance
+
+func (response GetAccountBalance200JSONResponse) VisitGetAccountBalanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountBalance401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAccountBalance401ApplicationProblemPlusJSONResponse) VisitGetAccountBalanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountBalance403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAccountBalance403ApplicationProblemPlusJSONResponse) VisitGetAccountBalanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountBalance404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAccountBalance404ApplicationProblemPlusJSONResponse) VisitGetAccountBalanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountBalance500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAccountBalance500ApplicationProblemPlusJSONResponse) VisitGetAccountBalanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountBalanceAtDateRequestObject struct {
+	AccountId openapi_types.UUID `json:"accountId"`
+	Params    GetAccountBalanceAtDateParams
+}
+
+type GetAccountBalanceAtDateResponseObject interface {
+	VisitGetAccountBalanceAtDateResponse(w http.ResponseWriter) error
+}
+
+type GetAccountBalanceAtDate200JSONResponse HistoricalBalance
+
+func (response GetAccountBalanceAtDate200JSONResponse) VisitGetAccountBalanceAtDateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountBalanceAtDate401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAccountBalanceAtDate401ApplicationProblemPlusJSONResponse) VisitGetAccountBalanceAtDateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountBalanceAtDate403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAccountBalanceAtDate403ApplicationProblemPlusJSONResponse) VisitGetAccountBalanceAtDateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountBalanceAtDate404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAccountBalanceAtDate404ApplicationProblemPlusJSONResponse) VisitGetAccountBalanceAtDateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountBalanceAtDate500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAccountBalanceAtDate500ApplicationProblemPlusJSONResponse) VisitGetAccountBalanceAtDateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DepositRequestObject struct {
+	AccountId openapi_types.UUID `json:"accountId"`
+	Body      *DepositJSONRequestBody
+}
+
+type DepositResponseObject interface {
+	VisitDepositResponse(w http.ResponseWriter) error
+}
+
+type Deposit200JSONResponse CashResponse
+
+func (response Deposit200JSONResponse) VisitDepositResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Deposit400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Deposit400ApplicationProblemPlusJSONResponse) VisitDepositResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Deposit401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Deposit401ApplicationProblemPlusJSONResponse) VisitDepositResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Deposit403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Deposit403ApplicationProblemPlusJSONResponse) VisitDepositResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Deposit404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Deposit404ApplicationProblemPlusJSONResponse) VisitDepositResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Deposit429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type Deposit429ApplicationProblemPlusJSONResponse struct {
+	Body    ProblemDetails
+	Headers Deposit429ResponseHeaders
+}
+
+func (response Deposit429ApplicationProblemPlusJSONResponse) VisitDepositResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type Deposit500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Deposit500ApplicationProblemPlusJSONResponse) VisitDepositResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Deposit503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type Deposit503ApplicationProblemPlusJSONResponse struct {
+	Body    ProblemDetails
+	Headers Deposit503ResponseHeaders
+}
+
+func (response Deposit503ApplicationProblemPlusJSONResponse) VisitDepositResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAccountLedgerRequestObject struct {
+	AccountId openapi_types.UUID `json:"accountId"`
+}
+
+type GetAccountLedgerResponseObject interface {
+	VisitGetAccountLedgerResponse(w http.ResponseWriter) error
+}
+
+type GetAccountLedger200JSONResponse AccountLedger
+
+func (response GetAccountLedger200JSONResponse) VisitGetAccountLedgerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountLedger401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAccountLedger401ApplicationProblemPlusJSONResponse) VisitGetAccountLedgerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountLedger403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAccountLedger403ApplicationProblemPlusJSONResponse) VisitGetAccountLedgerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountLedger404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAccountLedger404ApplicationProblemPlusJSONResponse) VisitGetAccountLedgerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAccountLedger500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetAccountLedger500ApplicationProblemPlusJSONResponse) VisitGetAccountLedgerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccountStatusRequestObject struct {
+	AccountId openapi_types.UUID `json:"accountId"`
+	Body      *UpdateAccountStatusJSONRequestBody
+}
+
+type UpdateAccountStatusResponseObject interface {
+	VisitUpdateAccountStatusResponse(w http.ResponseWriter) error
+}
+
+type UpdateAccountStatus200JSONResponse Account
+
+func (response UpdateAccountStatus200JSONResponse) VisitUpdateAccountStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccountStatus400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response UpdateAccountStatus400ApplicationProblemPlusJSONResponse) VisitUpdateAccountStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccountStatus401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response UpdateAccountStatus401ApplicationProblemPlusJSONResponse) VisitUpdateAccountStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccountStatus403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response UpdateAccountStatus403ApplicationProblemPlusJSONResponse) VisitUpdateAccountStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccountStatus404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response UpdateAccountStatus404ApplicationProblemPlusJSONResponse) VisitUpdateAccountStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccountStatus409ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response UpdateAccountStatus409ApplicationProblemPlusJSONResponse) VisitUpdateAccountStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAccountStatus500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response UpdateAccountStatus500ApplicationProblemPlusJSONResponse) VisitUpdateAccountStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type WithdrawRequestObject struct {
+	AccountId openapi_types.UUID `json:"accountId"`
+	Body      *WithdrawJSONRequestBody
+}
+
+type WithdrawResponseObject interface {
+	VisitWithdrawResponse(w http.ResponseWriter) error
+}
+
+type Withdraw200JSONResponse CashResponse
+
+func (response Withdraw200JSONResponse) VisitWithdrawResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Withdraw400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Withdraw400ApplicationProblemPlusJSONResponse) VisitWithdrawResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Withdraw401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Withdraw401ApplicationProblemPlusJSONResponse) VisitWithdrawResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Withdraw403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Withdraw403ApplicationProblemPlusJSONResponse) VisitWithdrawResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Withdraw404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Withdraw404ApplicationProblemPlusJSONResponse) VisitWithdrawResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Withdraw429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type Withdraw429ApplicationProblemPlusJSONResponse struct {
+	Body    ProblemDetails
+	Headers Withdraw429ResponseHeaders
+}
+
+func (response Withdraw429ApplicationProblemPlusJSONResponse) VisitWithdrawResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type Withdraw500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Withdraw500ApplicationProblemPlusJSONResponse) VisitWithdrawResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Withdraw503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type Withdraw503ApplicationProblemPlusJSONResponse struct {
+	Body    ProblemDetails
+	Headers Withdraw503ResponseHeaders
+}
+
+func (response Withdraw503ApplicationProblemPlusJSONResponse) VisitWithdrawResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type LoginRequestObject struct {
+	Body *LoginJSONRequestBody
+}
+
+type LoginResponseObject interface {
+	VisitLoginResponse(w http.ResponseWriter) error
+}
+
+type Login200JSONResponse AuthResponse
+
+func (response Login200JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Login400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Login400ApplicationProblemPlusJSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Login401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Login401ApplicationProblemPlusJSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Login429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type Login429ApplicationProblemPlusJSONResponse struct {
+	Body    ProblemDetails
+	Headers Login429ResponseHeaders
+}
+
+func (response Login429ApplicationProblemPlusJSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetCurrentUserRequestObject struct {
+}
+
+type GetCurrentUserResponseObject interface {
+	VisitGetCurrentUserResponse(w http.ResponseWriter) error
+}
+
+type GetCurrentUser200JSONResponse UserInfo
+
+func (response GetCurrentUser200JSONResponse) VisitGetCurrentUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCurrentUser401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetCurrentUser401ApplicationProblemPlusJSONResponse) VisitGetCurrentUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshTokenRequestObject struct {
+	Params RefreshTokenParams
+}
+
+type RefreshTokenResponseObject interface {
+	VisitRefreshTokenResponse(w http.ResponseWriter) error
+}
+
+type RefreshToken200JSONResponse AuthResponse
+
+func (response RefreshToken200JSONResponse) VisitRefreshTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshToken401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response RefreshToken401ApplicationProblemPlusJSONResponse) VisitRefreshTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshToken500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response RefreshToken500ApplicationProblemPlusJSONResponse) VisitRefreshTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterRequestObject struct {
+	Body *RegisterJSONRequestBody
+}
+
+type RegisterResponseObject interface {
+	VisitRegisterResponse(w http.ResponseWriter) error
+}
+
+type Register201JSONResponse AuthResponse
+
+func (response Register201JSONResponse) VisitRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Register400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Register400ApplicationProblemPlusJSONResponse) VisitRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Register403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Register403ApplicationProblemPlusJSONResponse) VisitRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Register409ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Register409ApplicationProblemPlusJSONResponse) VisitRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Register422ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Register422ApplicationProblemPlusJSONResponse) VisitRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Register503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type Register503ApplicationProblemPlusJSONResponse struct {
+	Body    ProblemDetails
+	Headers Register503ResponseHeaders
+}
+
+func (response Register503ApplicationProblemPlusJSONResponse) VisitRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RotateTokenRequestObject struct {
+}
+
+type RotateTokenResponseObject interface {
+	VisitRotateTokenResponse(w http.ResponseWriter) error
+}
+
+type RotateToken200JSONResponse AuthResponse
+
+func (response RotateToken200JSONResponse) VisitRotateTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateToken401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response RotateToken401ApplicationProblemPlusJSONResponse) VisitRotateTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateToken429ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response RotateToken429ApplicationProblemPlusJSONResponse) VisitRotateTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateToken500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response RotateToken500ApplicationProblemPlusJSONResponse) VisitRotateTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetExchangeRateHistoryRequestObject struct {
+	Params GetExchangeRateHistoryParams
+}
+
+type GetExchangeRateHistoryResponseObject interface {
+	VisitGetExchangeRateHistoryResponse(w http.ResponseWriter) error
+}
+
+type GetExchangeRateHistory200JSONResponse ExchangeRateHistory
+
+func (response GetExchangeRateHistory200JSONResponse) VisitGetExchangeRateHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetExchangeRateHistory400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetExchangeRateHistory400ApplicationProblemPlusJSONResponse) VisitGetExchangeRateHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetExchangeRateHistory401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetExchangeRateHistory401ApplicationProblemPlusJSONResponse) VisitGetExchangeRateHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetExchangeRateHistory500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetExchangeRateHistory500ApplicationProblemPlusJSONResponse) VisitGetExchangeRateHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SweepAccountRequestObject struct {
+	Body *SweepAccountJSONRequestBody
+}
+
+type SweepAccountResponseObject interface {
+	VisitSweepAccountResponse(w http.ResponseWriter) error
+}
+
+type SweepAccount200JSONResponse SweepAccountResponse
+
+func (response SweepAccount200JSONResponse) VisitSweepAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SweepAccount400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response SweepAccount400ApplicationProblemPlusJSONResponse) VisitSweepAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SweepAccount401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response SweepAccount401ApplicationProblemPlusJSONResponse) VisitSweepAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SweepAccount403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response SweepAccount403ApplicationProblemPlusJSONResponse) VisitSweepAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SweepAccount404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response SweepAccount404ApplicationProblemPlusJSONResponse) VisitSweepAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SweepAccount429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type SweepAccount429ApplicationProblemPlusJSONResponse struct {
+	Body    ProblemDetails
+	Headers SweepAccount429ResponseHeaders
+}
+
+func (response SweepAccount429ApplicationProblemPlusJSONResponse) VisitSweepAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SweepAccount500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response SweepAccount500ApplicationProblemPlusJSONResponse) VisitSweepAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SweepAccount503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type SweepAccount503ApplicationProblemPlusJSONResponse struct {
+	Body    ProblemDetails
+	Headers SweepAccount503ResponseHeaders
+}
+
+func (response SweepAccount503ApplicationProblemPlusJSONResponse) VisitSweepAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SetMaintenanceModeRequestObject struct {
+	Body *SetMaintenanceModeJSONRequestBody
+}
+
+type SetMaintenanceModeResponseObject interface {
+	VisitSetMaintenanceModeResponse(w http.ResponseWriter) error
+}
+
+type SetMaintenanceMode200JSONResponse MaintenanceMode
+
+func (response SetMaintenanceMode200JSONResponse) VisitSetMaintenanceModeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetMaintenanceMode401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response SetMaintenanceMode401ApplicationProblemPlusJSONResponse) VisitSetMaintenanceModeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetMaintenanceMode403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response SetMaintenanceMode403ApplicationProblemPlusJSONResponse) VisitSetMaintenanceModeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReconcileRequestObject struct {
+}
+
+type ReconcileResponseObject interface {
+	VisitReconcileResponse(w http.ResponseWriter) error
+}
+
+type Reconcile200JSONResponse ReconciliationReport
+
+func (response Reconcile200JSONResponse) VisitReconcileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Reconcile401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Reconcile401ApplicationProblemPlusJSONResponse) VisitReconcileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTransactionsRequestObject struct {
+	Params ListTransactionsParams
+}
+
+type ListTransactionsResponseObject interface {
+	VisitListTransactionsResponse(w http.ResponseWriter) error
+}
+
+type ListTransactions200JSONResponse TransactionsResponse
+
+func (response ListTransactions200JSONResponse) VisitListTransactionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTransactions400ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response ListTransactions400ApplicationProblemPlusJSONResponse) VisitListTransactionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTransactions401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response ListTransactions401ApplicationProblemPlusJSONResponse) VisitListTransactionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTransactions403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response ListTransactions403ApplicationProblemPlusJSONResponse) VisitListTransactionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTransactions404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response ListTransactions404ApplicationProblemPlusJSONResponse) VisitListTransactionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTransactions500ApplicationProblemPlusJSONResponse ProblemDetails
 
-	if r == nil {
-		r = chi.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+func (response ListTransactions500ApplicationProblemPlusJSONResponse) VisitListTransactionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/accounts", wrapper.ListAccounts)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/accounts/{accountId}/balance", wrapper.GetAccountBalance)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/auth/login", wrapper.Login)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/auth/me", wrapper.GetCurrentUser)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/auth/register", wrapper.Register)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/system/reconcile", wrapper.Reconcile)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/transactions", wrapper.ListTransactions)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/transactions/exchange", wrapper.Exchange)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/transactions/exchange/calculate", wrapper.CalculateExchange)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/transactions/transfer", wrapper.Transfer)
-	})
+	return json.NewEncoder(w).Encode(response)
+}
 
-	return r
+type ExchangeRequestObject struct {
+	Params ExchangeParams
+	Body   *ExchangeJSONRequestBody
 }
 
-type ListAccountsRequestObject struct {
+type ExchangeResponseObject interface {
+	VisitExchangeResponse(w http.ResponseWriter) error
 }
 
-type ListAccountsResponseObject interface {
-	VisitListAccountsResponse(w http.ResponseWriter) error
+type Exchange200JSONResponse ExchangeResponse
+
+func (response Exchange200JSONResponse) VisitExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type ListAccounts200JSONResponse []Account
+type Exchange400ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response ListAccounts200JSONResponse) VisitListAccountsResponse(w http.ResponseWriter) error {
+func (response Exchange400ApplicationProblemPlusJSONResponse) VisitExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Exchange401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Exchange401ApplicationProblemPlusJSONResponse) VisitExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Exchange403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Exchange403ApplicationProblemPlusJSONResponse) VisitExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Exchange404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Exchange404ApplicationProblemPlusJSONResponse) VisitExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Exchange422ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Exchange422ApplicationProblemPlusJSONResponse) VisitExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Exchange429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type Exchange429ApplicationProblemPlusJSONResponse struct {
+	Body    ProblemDetails
+	Headers Exchange429ResponseHeaders
+}
+
+func (response Exchange429ApplicationProblemPlusJSONResponse) VisitExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type Exchange503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type Exchange503ApplicationProblemPlusJSONResponse struct {
+	Body    ProblemDetails
+	Headers Exchange503ResponseHeaders
+}
+
+func (response Exchange503ApplicationProblemPlusJSONResponse) VisitExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CalculateExchangeRequestObject struct {
+	Params CalculateExchangeParams
+}
+
+type CalculateExchangeResponseObject interface {
+	VisitCalculateExchangeResponse(w http.ResponseWriter) error
+}
+
+type CalculateExchange200JSONResponse ExchangeCalculation
+
+func (response CalculateExchange200JSONResponse) VisitCalculateExchangeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListAccounts401ApplicationProblemPlusJSONResponse ProblemDetails
+type CalculateExchange400ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response ListAccounts401ApplicationProblemPlusJSONResponse) VisitListAccountsResponse(w http.ResponseWriter) error {
+func (response CalculateExchange400ApplicationProblemPlusJSONResponse) VisitCalculateExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CalculateExchange401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response CalculateExchange401ApplicationProblemPlusJSONResponse) VisitCalculateExchangeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAccountBalanceRequestObject struct {
-	AccountId openapi_types.UUID `json:"accountId"`
+type CalculateExchange403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response CalculateExchange403ApplicationProblemPlusJSONResponse) VisitCalculateExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAccountBalanceResponseObject interface {
-	VisitGetAccountBalanceResponse(w http.ResponseWriter) error
+type GetExchangeRequestObject struct {
+	ExchangeId openapi_types.UUID `json:"exchangeId"`
 }
 
-type GetAccountBalance200JSONResponse Balance
+type GetExchangeResponseObject interface {
+	VisitGetExchangeResponse(w http.ResponseWriter) error
+}
 
-func (response GetAccountBalance200JSONResponse) VisitGetAccountBalanceResponse(w http.ResponseWriter) error {
+type GetExchange200JSONResponse ExchangeResponse
+
+func (response GetExchange200JSONResponse) VisitGetExchangeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAccountBalance401ApplicationProblemPlusJSONResponse ProblemDetails
+type GetExchange401ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response GetAccountBalance401ApplicationProblemPlusJSONResponse) VisitGetAccountBalanceResponse(w http.ResponseWriter) error {
+func (response GetExchange401ApplicationProblemPlusJSONResponse) VisitGetExchangeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAccountBalance403ApplicationProblemPlusJSONResponse ProblemDetails
+type GetExchange403ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response GetAccountBalance403ApplicationProblemPlusJSONResponse) VisitGetAccountBalanceResponse(w http.ResponseWriter) error {
+func (response GetExchange403ApplicationProblemPlusJSONResponse) VisitGetExchangeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAccountBalance404ApplicationProblemPlusJSONResponse ProblemDetails
+type GetExchange404ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response GetAccountBalance404ApplicationProblemPlusJSONResponse) VisitGetAccountBalanceResponse(w http.ResponseWriter) error {
+func (response GetExchange404ApplicationProblemPlusJSONResponse) VisitGetExchangeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type LoginRequestObject struct {
-	Body *LoginJSONRequestBody
+type GetExchange500ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response GetExchange500ApplicationProblemPlusJSONResponse) VisitGetExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type LoginResponseObject interface {
-	VisitLoginResponse(w http.ResponseWriter) error
+type TransferRequestObject struct {
+	Params TransferParams
+	Body   *TransferJSONRequestBody
 }
 
-type Login200JSONResponse AuthResponse
+type TransferResponseObject interface {
+	VisitTransferResponse(w http.ResponseWriter) error
+}
 
-func (response Login200JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+type Transfer200JSONResponse TransferResponse
+
+func (response Transfer200JSONResponse) VisitTransferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Login400ApplicationProblemPlusJSONResponse ProblemDetails
+type Transfer400ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response Login400ApplicationProblemPlusJSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+func (response Transfer400ApplicationProblemPlusJSONResponse) VisitTransferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Login401ApplicationProblemPlusJSONResponse ProblemDetails
+type Transfer401ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response Login401ApplicationProblemPlusJSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+func (response Transfer401ApplicationProblemPlusJSONResponse) VisitTransferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCurrentUserRequestObject struct {
+type Transfer403ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Transfer403ApplicationProblemPlusJSONResponse) VisitTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCurrentUserResponseObject interface {
-	VisitGetCurrentUserResponse(w http.ResponseWriter) error
+type Transfer404ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Transfer404ApplicationProblemPlusJSONResponse) VisitTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCurrentUser200JSONResponse UserInfo
+type Transfer409ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response GetCurrentUser200JSONResponse) VisitGetCurrentUserResponse(w http.ResponseWriter) error {
+func (response Transfer409ApplicationProblemPlusJSONResponse) VisitTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Transfer422ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response Transfer422ApplicationProblemPlusJSONResponse) VisitTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Transfer429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type Transfer429ApplicationProblemPlusJSONResponse struct {
+	Body    ProblemDetails
+	Headers Transfer429ResponseHeaders
+}
+
+func (response Transfer429ApplicationProblemPlusJSONResponse) VisitTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type Transfer503ResponseHeaders struct {
+	RetryAfter int
+}
+
+type Transfer503ApplicationProblemPlusJSONResponse struct {
+	Body    ProblemDetails
+	Headers Transfer503ResponseHeaders
+}
+
+func (response Transfer503ApplicationProblemPlusJSONResponse) VisitTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type BatchTransferRequestObject struct {
+	Body *BatchTransferJSONRequestBody
+}
+
+type BatchTransferResponseObject interface {
+	VisitBatchTransferResponse(w http.ResponseWriter) error
+}
+
+type BatchTransfer200JSONResponse BatchTransferResponse
+
+func (response BatchTransfer200JSONResponse) VisitBatchTransferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCurrentUser401ApplicationProblemPlusJSONResponse ProblemDetails
+type BatchTransfer400ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response GetCurrentUser401ApplicationProblemPlusJSONResponse) VisitGetCurrentUserResponse(w http.ResponseWriter) error {
+func (response BatchTransfer400ApplicationProblemPlusJSONResponse) VisitBatchTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchTransfer401ApplicationProblemPlusJSONResponse ProblemDetails
+
+func (response BatchTransfer401ApplicationProblemPlusJSONResponse) VisitBatchTransferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RegisterRequestObject struct {
-	Body *RegisterJSONRequestBody
+type BatchTransfer422JSONResponse BatchTransferResponse
+
+func (response BatchTransfer422JSONResponse) VisitBatchTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type RegisterResponseObject interface {
-	VisitRegisterResponse(w http.ResponseWriter) error
+type BatchTransfer429ResponseHeaders struct {
+	RetryAfter int
 }
 
-type Register201JSONResponse AuthResponse
+type BatchTransfer429ApplicationProblemPlusJSONResponse struct {
+	Body    ProblemDetails
+	Headers BatchTransfer429ResponseHeaders
+}
 
-func (response Register201JSONResponse) VisitRegisterResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+func (response BatchTransfer429ApplicationProblemPlusJSONResponse) VisitBatchTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type Register400ApplicationProblemPlusJSONResponse ProblemDetails
+type BatchTransfer500ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response Register400ApplicationProblemPlusJSONResponse) VisitRegisterResponse(w http.ResponseWriter) error {
+func (response BatchTransfer500ApplicationProblemPlusJSONResponse) VisitBatchTransferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(400)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Register409ApplicationProblemPlusJSONResponse ProblemDetails
+type BatchTransfer503ResponseHeaders struct {
+	RetryAfter int
+}
 
-func (response Register409ApplicationProblemPlusJSONResponse) VisitRegisterResponse(w http.ResponseWriter) error {
+type BatchTransfer503ApplicationProblemPlusJSONResponse struct {
+	Body    ProblemDetails
+	Headers BatchTransfer503ResponseHeaders
+}
+
+func (response BatchTransfer503ApplicationProblemPlusJSONResponse) VisitBatchTransferResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(409)
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type ReconcileRequestObject struct {
+type GetTransactionRequestObject struct {
+	TransactionId openapi_types.UUID `json:"transactionId"`
+	Params        GetTransactionParams
 }
 
-type ReconcileResponseObject interface {
-	VisitReconcileResponse(w http.ResponseWriter) error
+type GetTransactionResponseObject interface {
+	VisitGetTransactionResponse(w http.ResponseWriter) error
 }
 
-type Reconcile200JSONResponse ReconciliationReport
+type GetTransaction200JSONResponse Transaction
 
-func (response Reconcile200JSONResponse) VisitReconcileResponse(w http.ResponseWriter) error {
+func (response GetTransaction200JSONResponse) VisitGetTransactionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Reconcile401ApplicationProblemPlusJSONResponse ProblemDetails
+type GetTransaction401ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response Reconcile401ApplicationProblemPlusJSONResponse) VisitReconcileResponse(w http.ResponseWriter) error {
+func (response GetTransaction401ApplicationProblemPlusJSONResponse) VisitGetTransactionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListTransactionsRequestObject struct {
-	Params ListTransactionsParams
-}
+type GetTransaction403ApplicationProblemPlusJSONResponse ProblemDetails
 
-type ListTransactionsResponseObject interface {
-	VisitListTransactionsResponse(w http.ResponseWriter) error
+func (response GetTransaction403ApplicationProblemPlusJSONResponse) VisitGetTransactionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type ListTransactions200JSONResponse TransactionsResponse
+type GetTransaction404ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response ListTransactions200JSONResponse) VisitListTransactionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+func (response GetTransaction404ApplicationProblemPlusJSONResponse) VisitGetTransactionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListTransactions401ApplicationProblemPlusJSONResponse ProblemDetails
+type GetTransaction500ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response ListTransactions401ApplicationProblemPlusJSONResponse) VisitListTransactionsResponse(w http.ResponseWriter) error {
+func (response GetTransaction500ApplicationProblemPlusJSONResponse) VisitGetTransactionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(401)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ExchangeRequestObject struct {
-	Body *ExchangeJSONRequestBody
+type GetTransactionLedgerRequestObject struct {
+	TransactionId openapi_types.UUID `json:"transactionId"`
 }
 
-type ExchangeResponseObject interface {
-	VisitExchangeResponse(w http.ResponseWriter) error
+type GetTransactionLedgerResponseObject interface {
+	VisitGetTransactionLedgerResponse(w http.ResponseWriter) error
 }
 
-type Exchange200JSONResponse ExchangeResponse
+type GetTransactionLedger200JSONResponse TransactionLedger
 
-func (response Exchange200JSONResponse) VisitExchangeResponse(w http.ResponseWriter) error {
+func (response GetTransactionLedger200JSONResponse) VisitGetTransactionLedgerResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Exchange400ApplicationProblemPlusJSONResponse ProblemDetails
+type GetTransactionLedger401ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response Exchange400ApplicationProblemPlusJSONResponse) VisitExchangeResponse(w http.ResponseWriter) error {
+func (response GetTransactionLedger401ApplicationProblemPlusJSONResponse) VisitGetTransactionLedgerResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(400)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Exchange401ApplicationProblemPlusJSONResponse ProblemDetails
+type GetTransactionLedger403ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response Exchange401ApplicationProblemPlusJSONResponse) VisitExchangeResponse(w http.ResponseWriter) error {
+func (response GetTransactionLedger403ApplicationProblemPlusJSONResponse) VisitGetTransactionLedgerResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(401)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Exchange404ApplicationProblemPlusJSONResponse ProblemDetails
+type GetTransactionLedger404ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response Exchange404ApplicationProblemPlusJSONResponse) VisitExchangeResponse(w http.ResponseWriter) error {
+func (response GetTransactionLedger404ApplicationProblemPlusJSONResponse) VisitGetTransactionLedgerResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CalculateExchangeRequestObject struct {
-	Params CalculateExchangeParams
-}
-
-type CalculateExchangeResponseObject interface {
-	VisitCalculateExchangeResponse(w http.ResponseWriter) error
-}
-
-type CalculateExchange200JSONResponse ExchangeCalculation
-
-func (response CalculateExchange200JSONResponse) VisitCalculateExchangeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type CalculateExchange400ApplicationProblemPlusJSONResponse ProblemDetails
+type GetTransactionLedger500ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response CalculateExchange400ApplicationProblemPlusJSONResponse) VisitCalculateExchangeResponse(w http.ResponseWriter) error {
+func (response GetTransactionLedger500ApplicationProblemPlusJSONResponse) VisitGetTransactionLedgerResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(400)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CalculateExchange401ApplicationProblemPlusJSONResponse ProblemDetails
-
-func (response CalculateExchange401ApplicationProblemPlusJSONResponse) VisitCalculateExchangeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(401)
+type ChangePasswordRequestObject struct {
+	Body *ChangePasswordJSONRequestBody
+}
 
-	return json.NewEncoder(w).Encode(response)
+type ChangePasswordResponseObject interface {
+	VisitChangePasswordResponse(w http.ResponseWriter) error
 }
 
-type TransferRequestObject struct {
-	Body *TransferJSONRequestBody
+type ChangePassword204Response struct {
 }
 
-type TransferResponseObject interface {
-	VisitTransferResponse(w http.ResponseWriter) error
+func (response ChangePassword204Response) VisitChangePasswordResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
 }
 
-type Transfer200JSONResponse TransferResponse
+type ChangePassword400ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response Transfer200JSONResponse) VisitTransferResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+func (response ChangePassword400ApplicationProblemPlusJSONResponse) VisitChangePasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Transfer400ApplicationProblemPlusJSONResponse ProblemDetails
+type ChangePassword401ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response Transfer400ApplicationProblemPlusJSONResponse) VisitTransferResponse(w http.ResponseWriter) error {
+func (response ChangePassword401ApplicationProblemPlusJSONResponse) VisitChangePasswordResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(400)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Transfer401ApplicationProblemPlusJSONResponse ProblemDetails
+type ChangePassword422ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response Transfer401ApplicationProblemPlusJSONResponse) VisitTransferResponse(w http.ResponseWriter) error {
+func (response ChangePassword422ApplicationProblemPlusJSONResponse) VisitChangePasswordResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(401)
+	w.WriteHeader(422)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Transfer404ApplicationProblemPlusJSONResponse ProblemDetails
+type ChangePassword500ApplicationProblemPlusJSONResponse ProblemDetails
 
-func (response Transfer404ApplicationProblemPlusJSONResponse) VisitTransferResponse(w http.ResponseWriter) error {
+func (response ChangePassword500ApplicationProblemPlusJSONResponse) VisitChangePasswordResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(404)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
@@ -1296,18 +3868,54 @@ type StrictServerInterface interface {
 	// List user's accounts
 	// (GET /accounts)
 	ListAccounts(ctx context.Context, request ListAccountsRequestObject) (ListAccountsResponseObject, error)
+	// Get total balances per currency
+	// (GET /accounts/summary)
+	GetAccountsSummary(ctx context.Context, request GetAccountsSummaryRequestObject) (GetAccountsSummaryResponseObject, error)
+	// Update an account
+	// (PATCH /accounts/{accountId})
+	UpdateAccount(ctx context.Context, request UpdateAccountRequestObject) (UpdateAccountResponseObject, error)
 	// Get account balance
 	// (GET /accounts/{accountId}/balance)
 	GetAccountBalance(ctx context.Context, request GetAccountBalanceRequestObject) (GetAccountBalanceResponseObject, error)
+	// Get account balance at a past time
+	// (GET /accounts/{accountId}/balance/history)
+	GetAccountBalanceAtDate(ctx context.Context, request GetAccountBalanceAtDateRequestObject) (GetAccountBalanceAtDateResponseObject, error)
+	// Deposit money into an account
+	// (POST /accounts/{accountId}/deposit)
+	Deposit(ctx context.Context, request DepositRequestObject) (DepositResponseObject, error)
+	// Get account ledger
+	// (GET /accounts/{accountId}/ledger)
+	GetAccountLedger(ctx context.Context, request GetAccountLedgerRequestObject) (GetAccountLedgerResponseObject, error)
+	// Freeze, unfreeze or close an account
+	// (PATCH /accounts/{accountId}/status)
+	UpdateAccountStatus(ctx context.Context, request UpdateAccountStatusRequestObject) (UpdateAccountStatusResponseObject, error)
+	// Withdraw money from an account
+	// (POST /accounts/{accountId}/withdraw)
+	Withdraw(ctx context.Context, request WithdrawRequestObject) (WithdrawResponseObject, error)
 	// Authenticate user
 	// (POST /auth/login)
 	Login(ctx context.Context, request LoginRequestObject) (LoginResponseObject, error)
 	// Get current user info
 	// (GET /auth/me)
 	GetCurrentUser(ctx context.Context, request GetCurrentUserRequestObject) (GetCurrentUserResponseObject, error)
+	// Refresh a token
+	// (POST /auth/refresh)
+	RefreshToken(ctx context.Context, request RefreshTokenRequestObject) (RefreshTokenResponseObject, error)
 	// Register a new user
 	// (POST /auth/register)
 	Register(ctx context.Context, request RegisterRequestObject) (RegisterResponseObject, error)
+	// Rotate the current token
+	// (POST /auth/rotate)
+	RotateToken(ctx context.Context, request RotateTokenRequestObject) (RotateTokenResponseObject, error)
+	// Get exchange rate history
+	// (GET /exchange-rates/history)
+	GetExchangeRateHistory(ctx context.Context, request GetExchangeRateHistoryRequestObject) (GetExchangeRateHistoryResponseObject, error)
+	// Sweep an account's entire balance
+	// (POST /system/accounts/sweep)
+	SweepAccount(ctx context.Context, request SweepAccountRequestObject) (SweepAccountResponseObject, error)
+	// Switch maintenance mode
+	// (PUT /system/maintenance)
+	SetMaintenanceMode(ctx context.Context, request SetMaintenanceModeRequestObject) (SetMaintenanceModeResponseObject, error)
 	// Reconciliation report
 	// (GET /system/reconcile)
 	Reconcile(ctx context.Context, request ReconcileRequestObject) (ReconcileResponseObject, error)
@@ -1320,9 +3928,24 @@ type StrictServerInterface interface {
 	// Calculate exchange amount
 	// (GET /transactions/exchange/calculate)
 	CalculateExchange(ctx context.Context, request CalculateExchangeRequestObject) (CalculateExchangeResponseObject, error)
+	// Get exchange details
+	// (GET /transactions/exchanges/{exchangeId})
+	GetExchange(ctx context.Context, request GetExchangeRequestObject) (GetExchangeResponseObject, error)
 	// Transfer money between users
 	// (POST /transactions/transfer)
 	Transfer(ctx context.Context, request TransferRequestObject) (TransferResponseObject, error)
+	// Run several transfers atomically
+	// (POST /transactions/transfers/batch)
+	BatchTransfer(ctx context.Context, request BatchTransferRequestObject) (BatchTransferResponseObject, error)
+	// Get transaction
+	// (GET /transactions/{transactionId})
+	GetTransaction(ctx context.Context, request GetTransactionRequestObject) (GetTransactionResponseObject, error)
+	// Get transaction ledger records
+	// (GET /transactions/{transactionId}/ledger)
+	GetTransactionLedger(ctx context.Context, request GetTransactionLedgerRequestObject) (GetTransactionLedgerResponseObject, error)
+	// Change password
+	// (PATCH /users/me/password)
+	ChangePassword(ctx context.Context, request ChangePasswordRequestObject) (ChangePasswordResponseObject, error)
 }
 
 type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
@@ -1344,33 +3967,240 @@ func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareF
 	}}
 }
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// ListAccounts operation middleware
+func (sh *strictHandler) ListAccounts(w http.ResponseWriter, r *http.Request, params ListAccountsParams) {
+	var request ListAccountsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAccounts(ctx, request.(ListAccountsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAccounts")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAccountsResponseObject); ok {
+		if err := validResponse.VisitListAccountsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAccountsSummary operation middleware
+func (sh *strictHandler) GetAccountsSummary(w http.ResponseWriter, r *http.Request, params GetAccountsSummaryParams) {
+	var request GetAccountsSummaryRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAccountsSummary(ctx, request.(GetAccountsSummaryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAccountsSummary")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAccountsSummaryResponseObject); ok {
+		if err := validResponse.VisitGetAccountsSummaryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateAccount operation middleware
+func (sh *strictHandler) UpdateAccount(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID) {
+	var request UpdateAccountRequestObject
+
+	request.AccountId = accountId
+
+	var body UpdateAccountJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateAccount(ctx, request.(UpdateAccountRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateAccount")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateAccountResponseObject); ok {
+		if err := validResponse.VisitUpdateAccountResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAccountBalance operation middleware
+func (sh *strictHandler) GetAccountBalance(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID, params GetAccountBalanceParams) {
+	var request GetAccountBalanceRequestObject
+
+	request.AccountId = accountId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAccountBalance(ctx, request.(GetAccountBalanceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAccountBalance")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAccountBalanceResponseObject); ok {
+		if err := validResponse.VisitGetAccountBalanceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAccountBalanceAtDate operation middleware
+func (sh *strictHandler) GetAccountBalanceAtDate(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID, params GetAccountBalanceAtDateParams) {
+	var request GetAccountBalanceAtDateRequestObject
+
+	request.AccountId = accountId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAccountBalanceAtDate(ctx, request.(GetAccountBalanceAtDateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAccountBalanceAtDate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAccountBalanceAtDateResponseObject); ok {
+		if err := validResponse.VisitGetAccountBalanceAtDateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Deposit operation middleware
+func (sh *strictHandler) Deposit(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID) {
+	var request DepositRequestObject
+
+	request.AccountId = accountId
+
+	var body DepositJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Deposit(ctx, request.(DepositRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Deposit")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DepositResponseObject); ok {
+		if err := validResponse.VisitDepositResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAccountLedger operation middleware
+func (sh *strictHandler) GetAccountLedger(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID) {
+	var request GetAccountLedgerRequestObject
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+	request.AccountId = accountId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAccountLedger(ctx, request.(GetAccountLedgerRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAccountLedger")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAccountLedgerResponseObject); ok {
+		if err := validResponse.VisitGetAccountLedgerResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// ListAccounts operation middleware
-func (sh *strictHandler) ListAccounts(w http.ResponseWriter, r *http.Request) {
-	var request ListAccountsRequestObject
+// UpdateAccountStatus operation middleware
+func (sh *strictHandler) UpdateAccountStatus(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID) {
+	var request UpdateAccountStatusRequestObject
+
+	request.AccountId = accountId
+
+	var body UpdateAccountStatusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListAccounts(ctx, request.(ListAccountsRequestObject))
+		return sh.ssi.UpdateAccountStatus(ctx, request.(UpdateAccountStatusRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListAccounts")
+		handler = middleware(handler, "UpdateAccountStatus")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListAccountsResponseObject); ok {
-		if err := validResponse.VisitListAccountsResponse(w); err != nil {
+	} else if validResponse, ok := response.(UpdateAccountStatusResponseObject); ok {
+		if err := validResponse.VisitUpdateAccountStatusResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1378,25 +4208,32 @@ func (sh *strictHandler) ListAccounts(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetAccountBalance operation middleware
-func (sh *strictHandler) GetAccountBalance(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID) {
-	var request GetAccountBalanceRequestObject
+// Withdraw operation middleware
+func (sh *strictHandler) Withdraw(w http.ResponseWriter, r *http.Request, accountId openapi_types.UUID) {
+	var request WithdrawRequestObject
 
 	request.AccountId = accountId
 
+	var body WithdrawJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetAccountBalance(ctx, request.(GetAccountBalanceRequestObject))
+		return sh.ssi.Withdraw(ctx, request.(WithdrawRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetAccountBalance")
+		handler = middleware(handler, "Withdraw")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetAccountBalanceResponseObject); ok {
-		if err := validResponse.VisitGetAccountBalanceResponse(w); err != nil {
+	} else if validResponse, ok := response.(WithdrawResponseObject); ok {
+		if err := validResponse.VisitWithdrawResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1459,6 +4296,32 @@ func (sh *strictHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// RefreshToken operation middleware
+func (sh *strictHandler) RefreshToken(w http.ResponseWriter, r *http.Request, params RefreshTokenParams) {
+	var request RefreshTokenRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RefreshToken(ctx, request.(RefreshTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RefreshToken")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RefreshTokenResponseObject); ok {
+		if err := validResponse.VisitRefreshTokenResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Register operation middleware
 func (sh *strictHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var request RegisterRequestObject
@@ -1490,6 +4353,118 @@ func (sh *strictHandler) Register(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// RotateToken operation middleware
+func (sh *strictHandler) RotateToken(w http.ResponseWriter, r *http.Request) {
+	var request RotateTokenRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RotateToken(ctx, request.(RotateTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RotateToken")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RotateTokenResponseObject); ok {
+		if err := validResponse.VisitRotateTokenResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetExchangeRateHistory operation middleware
+func (sh *strictHandler) GetExchangeRateHistory(w http.ResponseWriter, r *http.Request, params GetExchangeRateHistoryParams) {
+	var request GetExchangeRateHistoryRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetExchangeRateHistory(ctx, request.(GetExchangeRateHistoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetExchangeRateHistory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetExchangeRateHistoryResponseObject); ok {
+		if err := validResponse.VisitGetExchangeRateHistoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SweepAccount operation middleware
+func (sh *strictHandler) SweepAccount(w http.ResponseWriter, r *http.Request) {
+	var request SweepAccountRequestObject
+
+	var body SweepAccountJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SweepAccount(ctx, request.(SweepAccountRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SweepAccount")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SweepAccountResponseObject); ok {
+		if err := validResponse.VisitSweepAccountResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetMaintenanceMode operation middleware
+func (sh *strictHandler) SetMaintenanceMode(w http.ResponseWriter, r *http.Request) {
+	var request SetMaintenanceModeRequestObject
+
+	var body SetMaintenanceModeJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetMaintenanceMode(ctx, request.(SetMaintenanceModeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetMaintenanceMode")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetMaintenanceModeResponseObject); ok {
+		if err := validResponse.VisitSetMaintenanceModeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Reconcile operation middleware
 func (sh *strictHandler) Reconcile(w http.ResponseWriter, r *http.Request) {
 	var request ReconcileRequestObject
@@ -1541,9 +4516,11 @@ func (sh *strictHandler) ListTransactions(w http.ResponseWriter, r *http.Request
 }
 
 // Exchange operation middleware
-func (sh *strictHandler) Exchange(w http.ResponseWriter, r *http.Request) {
+func (sh *strictHandler) Exchange(w http.ResponseWriter, r *http.Request, params ExchangeParams) {
 	var request ExchangeRequestObject
 
+	request.Params = params
+
 	var body ExchangeJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
@@ -1597,10 +4574,38 @@ func (sh *strictHandler) CalculateExchange(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// GetExchange operation middleware
+func (sh *strictHandler) GetExchange(w http.ResponseWriter, r *http.Request, exchangeId openapi_types.UUID) {
+	var request GetExchangeRequestObject
+
+	request.ExchangeId = exchangeId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetExchange(ctx, request.(GetExchangeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetExchange")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetExchangeResponseObject); ok {
+		if err := validResponse.VisitGetExchangeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Transfer operation middleware
-func (sh *strictHandler) Transfer(w http.ResponseWriter, r *http.Request) {
+func (sh *strictHandler) Transfer(w http.ResponseWriter, r *http.Request, params TransferParams) {
 	var request TransferRequestObject
 
+	request.Params = params
+
 	var body TransferJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
@@ -1627,3 +4632,118 @@ func (sh *strictHandler) Transfer(w http.ResponseWriter, r *http.Request) {
 		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
 	}
 }
+
+// BatchTransfer operation middleware
+func (sh *strictHandler) BatchTransfer(w http.ResponseWriter, r *http.Request) {
+	var request BatchTransferRequestObject
+
+	var body BatchTransferJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.BatchTransfer(ctx, request.(BatchTransferRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BatchTransfer")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(BatchTransferResponseObject); ok {
+		if err := validResponse.VisitBatchTransferResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetTransaction operation middleware
+func (sh *strictHandler) GetTransaction(w http.ResponseWriter, r *http.Request, transactionId openapi_types.UUID, params GetTransactionParams) {
+	var request GetTransactionRequestObject
+
+	request.TransactionId = transactionId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTransaction(ctx, request.(GetTransactionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTransaction")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTransactionResponseObject); ok {
+		if err := validResponse.VisitGetTransactionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetTransactionLedger operation middleware
+func (sh *strictHandler) GetTransactionLedger(w http.ResponseWriter, r *http.Request, transactionId openapi_types.UUID) {
+	var request GetTransactionLedgerRequestObject
+
+	request.TransactionId = transactionId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTransactionLedger(ctx, request.(GetTransactionLedgerRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTransactionLedger")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTransactionLedgerResponseObject); ok {
+		if err := validResponse.VisitGetTransactionLedgerResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ChangePassword operation middleware
+func (sh *strictHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var request ChangePasswordRequestObject
+
+	var body ChangePasswordJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ChangePassword(ctx, request.(ChangePasswordRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ChangePassword")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ChangePasswordResponseObject); ok {
+		if err := validResponse.VisitChangePasswordResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
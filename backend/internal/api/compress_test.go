@@ -0,0 +1,156 @@
+package api_test
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/api"
+	"minibankingplatform/pkg/jwt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// largeListServer answers ListTransactions with a page big enough to be worth compressing.
+type largeListServer struct {
+	api.StrictServerInterface
+}
+
+func (largeListServer) ListTransactions(context.Context, api.ListTransactionsRequestObject) (api.ListTransactionsResponseObject, error) {
+	transactionType := api.Transfer
+	timestamp := time.Now()
+
+	transactions := make([]api.Transaction, 500)
+	for i := range transactions {
+		id := openapi_types.UUID(uuid.New())
+		accountID := openapi_types.UUID(uuid.New())
+		transactions[i] = api.Transaction{
+			Id:        &id,
+			AccountId: &accountID,
+			Type:      &transactionType,
+			Timestamp: &timestamp,
+		}
+	}
+
+	total := len(transactions)
+	return api.ListTransactions200JSONResponse{
+		Transactions: &transactions,
+		Pagination:   &api.Pagination{Total: &total},
+	}, nil
+}
+
+func newCompressedRouter(t *testing.T) (http.Handler, string) {
+	t.Helper()
+
+	tokenManager := jwt.NewTokenManager("test-secret-key", time.Hour)
+	token, err := tokenManager.GenerateToken(uuid.New(), "user@example.com")
+	require.NoError(t, err)
+
+	router := chi.NewRouter()
+	router.Use(api.Compress(5))
+	router.Use(api.AuthMiddleware(tokenManager))
+	api.HandlerFromMux(api.NewStrictHandler(largeListServer{}, nil), router)
+
+	return router, token
+}
+
+func TestCompress_LargeTransactionList(t *testing.T) {
+	t.Parallel()
+
+	router, token := newCompressedRouter(t)
+
+	t.Run("gzip encoded when accepted", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/transactions", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rec, req)
+
+		// Assert
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+
+		reader, err := gzip.NewReader(rec.Body)
+		require.NoError(t, err)
+		var response api.TransactionsResponse
+		require.NoError(t, json.NewDecoder(reader).Decode(&response))
+		assert.Len(t, *response.Transactions, 500)
+	})
+
+	t.Run("plain when not accepted", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/transactions", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rec, req)
+
+		// Assert
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+
+		var response api.TransactionsResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		assert.Len(t, *response.Transactions, 500)
+	})
+}
+
+func TestCompress_ProblemDetails(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	router, _ := newCompressedRouter(t)
+	req := httptest.NewRequest(http.MethodGet, "/transactions", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rec, req)
+
+	// Assert
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+
+	reader, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "unauthorized")
+}
+
+func TestCompress_DisabledAtLevelZero(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	handler := api.Compress(0)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/transactions", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, `{}`, rec.Body.String())
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// EnvelopeProfile is the Accept profile that asks for enveloped responses:
+// Accept: application/json; profile="envelope".
+const EnvelopeProfile = "envelope"
+
+// envelope wraps a successful JSON response body.
+type envelope struct {
+	Data json.RawMessage `json:"data"`
+	Meta envelopeMeta    `json:"meta"`
+}
+
+type envelopeMeta struct {
+	Status    int    `json:"status"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// Envelope wraps successful JSON responses in {"data": ..., "meta": ...} for
+// clients that ask for it with the envelope Accept profile, or for every
+// client when always is set. Problem details and non-JSON bodies such as CSV
+// exports pass through unchanged and unbuffered, so streamed exports keep
+// streaming.
+func Envelope(always bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !always {
+				w.Header().Add("Vary", "Accept")
+			}
+
+			if !always && !acceptsEnvelope(r.Header.Values("Accept")) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ew := &envelopeResponseWriter{ResponseWriter: w}
+			next.ServeHTTP(ew, r)
+
+			if !ew.buffering {
+				return
+			}
+
+			body := ew.body.Bytes()
+			if len(bytes.TrimSpace(body)) == 0 {
+				w.WriteHeader(ew.status)
+				_, _ = w.Write(body)
+				return
+			}
+
+			wrapped, err := json.Marshal(envelope{
+				Data: json.RawMessage(bytes.TrimSpace(body)),
+				Meta: envelopeMeta{
+					Status:    ew.status,
+					RequestID: middleware.GetReqID(r.Context()),
+				},
+			})
+			if err != nil {
+				w.WriteHeader(ew.status)
+				_, _ = w.Write(body)
+				return
+			}
+
+			w.Header().Del("Content-Length")
+			w.WriteHeader(ew.status)
+			_, _ = w.Write(append(wrapped, '\n'))
+		})
+	}
+}
+
+// acceptsEnvelope reports whether any Accept value names the envelope profile.
+func acceptsEnvelope(accept []string) bool {
+	for _, value := range accept {
+		for _, mediaRange := range strings.Split(value, ",") {
+			_, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+			if err == nil && params["profile"] == EnvelopeProfile {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isJSONSuccess(status int, header http.Header) bool {
+	if status < 200 || status >= 300 {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+// envelopeResponseWriter holds successful JSON responses back so Envelope can
+// wrap them. Whether to hold a response back is decided when its header is
+// written; every other response goes straight to the client.
+type envelopeResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	buffering   bool
+	body        bytes.Buffer
+}
+
+func (w *envelopeResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+
+	w.buffering = isJSONSuccess(status, w.Header())
+	if !w.buffering {
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (w *envelopeResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.buffering {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush passes flushes of responses that aren't held back to the client.
+func (w *envelopeResponseWriter) Flush() {
+	if w.buffering {
+		return
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *envelopeResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
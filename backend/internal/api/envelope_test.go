@@ -0,0 +1,172 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/api"
+	"minibankingplatform/pkg/jwt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accountsServer answers ListAccounts with two accounts.
+type accountsServer struct {
+	api.StrictServerInterface
+}
+
+func (accountsServer) ListAccounts(context.Context, api.ListAccountsRequestObject) (api.ListAccountsResponseObject, error) {
+	accounts := make(api.ListAccounts200JSONResponse, 2)
+	for i := range accounts {
+		id := openapi_types.UUID(uuid.New())
+		accounts[i] = api.Account{Id: &id}
+	}
+	return accounts, nil
+}
+
+func newEnvelopeRouter(t *testing.T, always bool) (http.Handler, string) {
+	t.Helper()
+
+	tokenManager := jwt.NewTokenManager("test-secret-key", time.Hour)
+	token, err := tokenManager.GenerateToken(uuid.New(), "user@example.com")
+	require.NoError(t, err)
+
+	router := chi.NewRouter()
+	router.Use(middleware.RequestID)
+	router.Use(api.Envelope(always))
+	router.Use(api.AuthMiddleware(tokenManager))
+	api.HandlerFromMux(api.NewStrictHandler(accountsServer{}, nil), router)
+
+	return router, token
+}
+
+func TestEnvelope_ListAccounts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("flat by default", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		router, token := newEnvelopeRouter(t, false)
+		req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Accept", "application/json")
+		rec := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rec, req)
+
+		// Assert
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "Accept", rec.Header().Get("Vary"))
+
+		var accounts []api.Account
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&accounts))
+		assert.Len(t, accounts, 2)
+	})
+
+	t.Run("enveloped when the profile is accepted", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		router, token := newEnvelopeRouter(t, false)
+		req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Accept", `application/json; profile="envelope"`)
+		rec := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rec, req)
+
+		// Assert
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var response struct {
+			Data []api.Account `json:"data"`
+			Meta struct {
+				Status    int    `json:"status"`
+				RequestID string `json:"requestId"`
+			} `json:"meta"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		assert.Len(t, response.Data, 2)
+		assert.Equal(t, http.StatusOK, response.Meta.Status)
+		assert.NotEmpty(t, response.Meta.RequestID)
+	})
+
+	t.Run("enveloped for every client when configured", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		router, token := newEnvelopeRouter(t, true)
+		req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rec, req)
+
+		// Assert
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var response struct {
+			Data []api.Account `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		assert.Len(t, response.Data, 2)
+	})
+
+	t.Run("problem details stay flat", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		router, _ := newEnvelopeRouter(t, true)
+		req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+		rec := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rec, req)
+
+		// Assert
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+		var problem api.ProblemDetails
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+		assert.Equal(t, http.StatusUnauthorized, problem.Status)
+	})
+}
+
+func TestEnvelope_StreamedResponsesAreNotBuffered(t *testing.T) {
+	t.Parallel()
+
+	// Arrange - a CSV export flushed row by row
+	rec := httptest.NewRecorder()
+	var sentBeforeEnd string
+	export := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("id,amount\n"))
+		w.(http.Flusher).Flush()
+		sentBeforeEnd = rec.Body.String()
+		_, _ = w.Write([]byte("1,10.00\n"))
+	})
+	req := httptest.NewRequest(http.MethodGet, "/export", nil)
+
+	// Act
+	api.Envelope(true)(export).ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, "id,amount\n", sentBeforeEnd)
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, "id,amount\n1,10.00\n", rec.Body.String())
+}
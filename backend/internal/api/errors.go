@@ -2,7 +2,9 @@ package api
 
 import (
 	"errors"
+	"math"
 	"net/http"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -28,6 +30,26 @@ func MapError(err error, instance string) (ProblemDetails, int) {
 		return problem, http.StatusBadRequest
 	}
 
+	// New password breaking the password policy
+	var invalidPasswordErr *domain.InvalidPasswordError
+	if errors.As(err, &invalidPasswordErr) {
+		problem.Type = problemBaseURL + "invalid-password"
+		problem.Title = "Invalid Password"
+		problem.Status = http.StatusUnprocessableEntity
+		problem.Detail = ptr(invalidPasswordErr.Error())
+		return problem, http.StatusUnprocessableEntity
+	}
+
+	// Account label too long
+	var invalidLabelErr *domain.InvalidAccountLabelError
+	if errors.As(err, &invalidLabelErr) {
+		problem.Type = problemBaseURL + "invalid-account-label"
+		problem.Title = "Invalid Account Label"
+		problem.Status = http.StatusBadRequest
+		problem.Detail = ptr(invalidLabelErr.Error())
+		return problem, http.StatusBadRequest
+	}
+
 	// User already exists
 	var userExistsErr *domain.UserAlreadyExistsError
 	if errors.As(err, &userExistsErr) {
@@ -39,6 +61,68 @@ func MapError(err error, instance string) (ProblemDetails, int) {
 		return problem, http.StatusConflict
 	}
 
+	// Email domain on the registration blocklist
+	var blockedDomainErr *domain.BlockedEmailDomainError
+	if errors.As(err, &blockedDomainErr) {
+		problem.Type = problemBaseURL + "blocked-email-domain"
+		problem.Title = "Blocked Email Domain"
+		problem.Status = http.StatusForbidden
+		problem.Detail = ptr(blockedDomainErr.Error())
+		problem.Set("domain", blockedDomainErr.Domain)
+		return problem, http.StatusForbidden
+	}
+
+	// Empty or oversized transfer batch
+	var batchSizeErr *domain.BatchTransferSizeError
+	if errors.As(err, &batchSizeErr) {
+		problem.Type = problemBaseURL + "invalid-batch-size"
+		problem.Title = "Invalid Batch Size"
+		problem.Status = http.StatusBadRequest
+		problem.Detail = ptr(batchSizeErr.Error())
+		problem.Set("maxTransfers", batchSizeErr.Max)
+		return problem, http.StatusBadRequest
+	}
+
+	// Duplicate transfer in progress
+	var duplicateTransferErr *domain.DuplicateTransferInProgressError
+	if errors.As(err, &duplicateTransferErr) {
+		problem.Type = problemBaseURL + "duplicate-transfer-in-progress"
+		problem.Title = "Duplicate Transfer In Progress"
+		problem.Status = http.StatusConflict
+		problem.Detail = ptr("An identical transfer is already being processed")
+		return problem, http.StatusConflict
+	}
+
+	// Idempotency key reused for a different request
+	var idempotencyMismatchErr *domain.IdempotencyKeyMismatchError
+	if errors.As(err, &idempotencyMismatchErr) {
+		problem.Type = problemBaseURL + "idempotency-key-mismatch"
+		problem.Title = "Idempotency Key Mismatch"
+		problem.Status = http.StatusUnprocessableEntity
+		problem.Detail = ptr(idempotencyMismatchErr.Error())
+		return problem, http.StatusUnprocessableEntity
+	}
+
+	// Too many money operations running at once
+	var tooManyRequestsErr *domain.TooManyRequestsError
+	if errors.As(err, &tooManyRequestsErr) {
+		problem.Type = problemBaseURL + "too-many-requests"
+		problem.Title = "Too Many Requests"
+		problem.Status = http.StatusTooManyRequests
+		problem.Detail = ptr("Too many money operations in progress, please retry later")
+		return problem, http.StatusTooManyRequests
+	}
+
+	// Maintenance mode
+	var maintenanceErr *domain.MaintenanceModeError
+	if errors.As(err, &maintenanceErr) {
+		problem.Type = problemBaseURL + "maintenance"
+		problem.Title = "Service Unavailable"
+		problem.Status = http.StatusServiceUnavailable
+		problem.Detail = ptr("The platform is under maintenance, please retry later")
+		return problem, http.StatusServiceUnavailable
+	}
+
 	// Invalid credentials
 	var invalidCredsErr *domain.InvalidCredentialsError
 	if errors.As(err, &invalidCredsErr) {
@@ -49,6 +133,16 @@ func MapError(err error, instance string) (ProblemDetails, int) {
 		return problem, http.StatusUnauthorized
 	}
 
+	// Token rotated too often
+	var tokenRotationLimitErr *domain.TokenRotationLimitError
+	if errors.As(err, &tokenRotationLimitErr) {
+		problem.Type = problemBaseURL + "too-many-requests"
+		problem.Title = "Too Many Requests"
+		problem.Status = http.StatusTooManyRequests
+		problem.Detail = ptr("The token was rotated too recently, please retry later")
+		return problem, http.StatusTooManyRequests
+	}
+
 	// Account not found
 	var accountNotFoundErr *domain.AccountNotFoundError
 	if errors.As(err, &accountNotFoundErr) {
@@ -60,6 +154,114 @@ func MapError(err error, instance string) (ProblemDetails, int) {
 		return problem, http.StatusNotFound
 	}
 
+	// Account belongs to another user
+	var accountAccessDeniedErr *domain.AccountAccessDeniedError
+	if errors.As(err, &accountAccessDeniedErr) {
+		problem.Type = problemBaseURL + "forbidden"
+		problem.Title = "Forbidden"
+		problem.Status = http.StatusForbidden
+		problem.Detail = ptr("You do not have access to this account")
+		return problem, http.StatusForbidden
+	}
+
+	// Debit from a frozen account
+	var frozenAccountErr *domain.FrozenAccountError
+	if errors.As(err, &frozenAccountErr) {
+		problem.Type = problemBaseURL + "account-frozen"
+		problem.Title = "Account Frozen"
+		problem.Status = http.StatusForbidden
+		problem.Detail = ptr(frozenAccountErr.Error())
+		problem.Set("accountId", uuid.UUID(frozenAccountErr.AccountID).String())
+		return problem, http.StatusForbidden
+	}
+
+	// Debit from or freezing of a closed account
+	var closedAccountErr *domain.ClosedAccountError
+	if errors.As(err, &closedAccountErr) {
+		problem.Type = problemBaseURL + "account-closed"
+		problem.Title = "Account Closed"
+		problem.Status = http.StatusForbidden
+		problem.Detail = ptr(closedAccountErr.Error())
+		problem.Set("accountId", uuid.UUID(closedAccountErr.AccountID).String())
+		return problem, http.StatusForbidden
+	}
+
+	// Closing an account that still holds money
+	var accountNotEmptyErr *domain.AccountNotEmptyError
+	if errors.As(err, &accountNotEmptyErr) {
+		problem.Type = problemBaseURL + "account-not-empty"
+		problem.Title = "Account Not Empty"
+		problem.Status = http.StatusConflict
+		problem.Detail = ptr(accountNotEmptyErr.Error())
+		problem.Set("accountId", uuid.UUID(accountNotEmptyErr.AccountID).String())
+		problem.Set("balance", accountNotEmptyErr.Balance.Amount().String())
+		problem.Set("currency", string(accountNotEmptyErr.Balance.Currency()))
+		return problem, http.StatusConflict
+	}
+
+	// Transaction not found
+	var transactionNotFoundErr *domain.TransactionNotFoundError
+	if errors.As(err, &transactionNotFoundErr) {
+		problem.Type = problemBaseURL + "transaction-not-found"
+		problem.Title = "Transaction Not Found"
+		problem.Status = http.StatusNotFound
+		problem.Detail = ptr(transactionNotFoundErr.Error())
+		problem.Set("transactionId", uuid.UUID(transactionNotFoundErr.TransactionID).String())
+		return problem, http.StatusNotFound
+	}
+
+	// Transaction belongs to another user
+	var transactionAccessDeniedErr *domain.TransactionAccessDeniedError
+	if errors.As(err, &transactionAccessDeniedErr) {
+		problem.Type = problemBaseURL + "forbidden"
+		problem.Title = "Forbidden"
+		problem.Status = http.StatusForbidden
+		problem.Detail = ptr("You do not have access to this transaction")
+		return problem, http.StatusForbidden
+	}
+
+	// Exchange not found
+	var exchangeNotFoundErr *domain.ExchangeNotFoundError
+	if errors.As(err, &exchangeNotFoundErr) {
+		problem.Type = problemBaseURL + "exchange-not-found"
+		problem.Title = "Exchange Not Found"
+		problem.Status = http.StatusNotFound
+		problem.Detail = ptr(exchangeNotFoundErr.Error())
+		problem.Set("exchangeId", uuid.UUID(exchangeNotFoundErr.ExchangeID).String())
+		return problem, http.StatusNotFound
+	}
+
+	// Exchange belongs to another user
+	var exchangeAccessDeniedErr *domain.ExchangeAccessDeniedError
+	if errors.As(err, &exchangeAccessDeniedErr) {
+		problem.Type = problemBaseURL + "forbidden"
+		problem.Title = "Forbidden"
+		problem.Status = http.StatusForbidden
+		problem.Detail = ptr("You do not have access to this exchange")
+		return problem, http.StatusForbidden
+	}
+
+	// Administrative operation requested by a regular user
+	var adminRequiredErr *domain.AdminRequiredError
+	if errors.As(err, &adminRequiredErr) {
+		problem.Type = problemBaseURL + "admin-required"
+		problem.Title = "Admin Required"
+		problem.Status = http.StatusForbidden
+		problem.Detail = ptr("This operation requires administrator privileges")
+		return problem, http.StatusForbidden
+	}
+
+	// User operation touching a cashbook account
+	var cashbookTransferErr *domain.CashbookTransferError
+	if errors.As(err, &cashbookTransferErr) {
+		problem.Type = problemBaseURL + "cashbook-transfer"
+		problem.Title = "Cashbook Transfer"
+		problem.Status = http.StatusForbidden
+		problem.Detail = ptr("Cashbook accounts cannot be used in transfers or exchanges")
+		problem.Set("accountId", uuid.UUID(cashbookTransferErr.AccountID).String())
+		return problem, http.StatusForbidden
+	}
+
 	// Insufficient funds
 	var insufficientFundsErr *domain.InsufficientFundsError
 	if errors.As(err, &insufficientFundsErr) {
@@ -67,8 +269,24 @@ func MapError(err error, instance string) (ProblemDetails, int) {
 		problem.Title = "Insufficient Funds"
 		problem.Status = http.StatusBadRequest
 		problem.Detail = ptr("Account has insufficient funds for this operation")
-		problem.Set("available", insufficientFundsErr.AvailableBalance.String())
-		problem.Set("required", insufficientFundsErr.RequestedAmount.String())
+		problem.Set("available", insufficientFundsErr.AvailableBalance.Amount().String())
+		problem.Set("required", insufficientFundsErr.RequestedAmount.Amount().String())
+		problem.Set("currency", insufficientFundsErr.RequestedAmount.Currency().String())
+		return problem, http.StatusBadRequest
+	}
+
+	// Daily limit exceeded
+	var dailyLimitErr *domain.DailyLimitExceededError
+	if errors.As(err, &dailyLimitErr) {
+		problem.Type = problemBaseURL + "daily-limit-exceeded"
+		problem.Title = "Daily Limit Exceeded"
+		problem.Status = http.StatusBadRequest
+		problem.Detail = ptr("Operation exceeds the daily outflow limit of the account")
+		problem.Set("accountId", uuid.UUID(dailyLimitErr.AccountID).String())
+		problem.Set("dailyLimit", dailyLimitErr.Limit.String())
+		problem.Set("transferredToday", dailyLimitErr.Outflow.String())
+		problem.Set("required", dailyLimitErr.Amount.Amount().String())
+		problem.Set("currency", string(dailyLimitErr.Amount.Currency()))
 		return problem, http.StatusBadRequest
 	}
 
@@ -92,6 +310,17 @@ func MapError(err error, instance string) (ProblemDetails, int) {
 		return problem, http.StatusBadRequest
 	}
 
+	// Zero amount
+	var zeroAmountErr *domain.ZeroAmountError
+	if errors.As(err, &zeroAmountErr) {
+		problem.Type = problemBaseURL + "zero-amount"
+		problem.Title = "Zero Amount"
+		problem.Status = http.StatusBadRequest
+		problem.Detail = ptr(zeroAmountErr.Error())
+		problem.Set("currency", string(zeroAmountErr.Currency))
+		return problem, http.StatusBadRequest
+	}
+
 	// Negative exchange amount
 	var negativeExchangeErr *domain.NegativeExchangeError
 	if errors.As(err, &negativeExchangeErr) {
@@ -103,6 +332,16 @@ func MapError(err error, instance string) (ProblemDetails, int) {
 	}
 
 	// Same currency exchange
+	var sameAccountExchangeErr *domain.SameAccountExchangeError
+	if errors.As(err, &sameAccountExchangeErr) {
+		problem.Type = problemBaseURL + "same-account-exchange"
+		problem.Title = "Same Account Exchange"
+		problem.Status = http.StatusBadRequest
+		problem.Detail = ptr(sameAccountExchangeErr.Error())
+		problem.Set("accountId", uuid.UUID(sameAccountExchangeErr.AccountID).String())
+		return problem, http.StatusBadRequest
+	}
+
 	var sameCurrencyExchangeErr *domain.SameCurrencyExchangeError
 	if errors.As(err, &sameCurrencyExchangeErr) {
 		problem.Type = problemBaseURL + "same-currency-exchange"
@@ -122,6 +361,65 @@ func MapError(err error, instance string) (ProblemDetails, int) {
 		return problem, http.StatusBadRequest
 	}
 
+	// Amount finer than the currency's minor unit
+	var subUnitErr *domain.SubUnitAmountError
+	if errors.As(err, &subUnitErr) {
+		problem.Type = problemBaseURL + "sub-unit-amount"
+		problem.Title = "Sub-Unit Amount"
+		problem.Status = http.StatusBadRequest
+		problem.Detail = ptr(subUnitErr.Error())
+		problem.Set("amount", subUnitErr.Amount.String())
+		problem.Set("currency", string(subUnitErr.Currency))
+		return problem, http.StatusBadRequest
+	}
+
+	// Exchange direction disabled by configuration
+	var directionNotAllowedErr *domain.ExchangeDirectionNotAllowedError
+	if errors.As(err, &directionNotAllowedErr) {
+		problem.Type = problemBaseURL + "exchange-direction-not-allowed"
+		problem.Title = "Exchange Direction Not Allowed"
+		problem.Status = http.StatusForbidden
+		problem.Detail = ptr(directionNotAllowedErr.Error())
+		problem.Set("sourceCurrency", string(directionNotAllowedErr.From))
+		problem.Set("targetCurrency", string(directionNotAllowedErr.To))
+		return problem, http.StatusForbidden
+	}
+
+	// Exchanged amount below the pair's minimum
+	var belowMinimumErr *domain.ExchangeBelowMinimumError
+	if errors.As(err, &belowMinimumErr) {
+		problem.Type = problemBaseURL + "exchange-below-minimum"
+		problem.Title = "Exchange Below Minimum"
+		problem.Status = http.StatusBadRequest
+		problem.Detail = ptr(belowMinimumErr.Error())
+		problem.Set("minimumAmount", belowMinimumErr.Minimum.Amount().String())
+		problem.Set("currency", string(belowMinimumErr.Minimum.Currency()))
+		problem.Set("targetCurrency", string(belowMinimumErr.To))
+		return problem, http.StatusBadRequest
+	}
+
+	// Exchanged amount too small to be worth anything in the target currency
+	var roundsToZeroErr *domain.ExchangeRoundsToZeroError
+	if errors.As(err, &roundsToZeroErr) {
+		problem.Type = problemBaseURL + "exchange-rounds-to-zero"
+		problem.Title = "Exchange Rounds To Zero"
+		problem.Status = http.StatusBadRequest
+		problem.Detail = ptr(roundsToZeroErr.Error())
+		problem.Set("currency", string(roundsToZeroErr.Amount.Currency()))
+		problem.Set("targetCurrency", string(roundsToZeroErr.To))
+		return problem, http.StatusBadRequest
+	}
+
+	// Pagination cursor not issued by the platform
+	var invalidCursorErr *domain.InvalidCursorError
+	if errors.As(err, &invalidCursorErr) {
+		problem.Type = problemBaseURL + "invalid-cursor"
+		problem.Title = "Invalid Cursor"
+		problem.Status = http.StatusBadRequest
+		problem.Detail = ptr("The cursor was not issued by this list or was altered")
+		return problem, http.StatusBadRequest
+	}
+
 	// Unsupported currency
 	var unsupportedCurrencyErr *domain.UnsupportedCurrencyError
 	if errors.As(err, &unsupportedCurrencyErr) {
@@ -162,6 +460,12 @@ func ForbiddenError(instance string, detail string) ProblemDetails {
 	}
 }
 
+// retryAfterSeconds rounds d up to the whole seconds of a Retry-After header,
+// asking clients to wait at least a second.
+func retryAfterSeconds(d time.Duration) int {
+	return max(1, int(math.Ceil(d.Seconds())))
+}
+
 // ptr is a helper to create pointers to values.
 func ptr[T any](v T) *T {
 	return &v
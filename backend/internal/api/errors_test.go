@@ -0,0 +1,239 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/api"
+	"minibankingplatform/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapError_TransactionErrors(t *testing.T) {
+	t.Parallel()
+
+	transactionID := domain.NewTransactionID()
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedType   string
+	}{
+		{
+			name:           "nonexistent transaction is not found",
+			err:            fmt.Errorf("getting transaction: %w", domain.NewTransactionNotFoundError(transactionID)),
+			expectedStatus: http.StatusNotFound,
+			expectedType:   "https://minibankingplatform.com/problems/transaction-not-found",
+		},
+		{
+			name:           "another user's transaction is forbidden",
+			err:            domain.NewTransactionAccessDeniedError(transactionID),
+			expectedStatus: http.StatusForbidden,
+			expectedType:   "https://minibankingplatform.com/problems/forbidden",
+		},
+		{
+			name:           "nonexistent exchange is not found",
+			err:            fmt.Errorf("getting exchange: %w", domain.NewExchangeNotFoundError(domain.NewExchangeDetailsID())),
+			expectedStatus: http.StatusNotFound,
+			expectedType:   "https://minibankingplatform.com/problems/exchange-not-found",
+		},
+		{
+			name:           "another user's exchange is forbidden",
+			err:            domain.NewExchangeAccessDeniedError(domain.NewExchangeDetailsID()),
+			expectedStatus: http.StatusForbidden,
+			expectedType:   "https://minibankingplatform.com/problems/forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			problem, status := api.MapError(tt.err, "/transactions")
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedStatus, problem.Status)
+			assert.Equal(t, tt.expectedType, problem.Type)
+		})
+	}
+}
+
+func TestMapError_TooManyRequests(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	problem, status := api.MapError(domain.NewTooManyRequestsError(time.Second), "/transactions/transfer")
+
+	// Assert
+	assert.Equal(t, http.StatusTooManyRequests, status)
+	assert.Equal(t, "https://minibankingplatform.com/problems/too-many-requests", problem.Type)
+}
+
+func TestMapError_MaintenanceMode(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	problem, status := api.MapError(domain.NewMaintenanceModeError(time.Minute), "/system/accounts/sweep")
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "https://minibankingplatform.com/problems/maintenance", problem.Type)
+}
+
+func TestMapError_IdempotencyKeyMismatch(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	problem, status := api.MapError(domain.NewIdempotencyKeyMismatchError("key-1"), "/transactions/transfer")
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "https://minibankingplatform.com/problems/idempotency-key-mismatch", problem.Type)
+}
+
+func TestMapError_AdminRequired(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	problem, status := api.MapError(domain.NewAdminRequiredError(domain.UserID{}), "/system/accounts/sweep")
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "https://minibankingplatform.com/problems/admin-required", problem.Type)
+}
+
+func TestMapError_CashbookTransfer(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	err := fmt.Errorf("executing transfer domain service: %w", domain.NewCashbookTransferError(domain.CashbookUSD))
+
+	// Act
+	problem, status := api.MapError(err, "/transactions/transfer")
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "https://minibankingplatform.com/problems/cashbook-transfer", problem.Type)
+	assert.Equal(t, uuid.UUID(domain.CashbookUSD).String(), problem.AdditionalProperties["accountId"])
+}
+
+func TestMapError_ExchangeBelowMinimum(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	amount, _ := domain.NewMoney(decimal.NewFromInt(5), domain.CurrencyUSD)
+	minimum, _ := domain.NewMoney(decimal.NewFromInt(10), domain.CurrencyUSD)
+	err := fmt.Errorf("doing atomic operation: %w", domain.NewExchangeBelowMinimumError(amount, minimum, domain.CurrencyEUR))
+
+	// Act
+	problem, status := api.MapError(err, "/transactions/exchange")
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "https://minibankingplatform.com/problems/exchange-below-minimum", problem.Type)
+	assert.Equal(t, "10", problem.AdditionalProperties["minimumAmount"])
+	assert.Equal(t, "USD", problem.AdditionalProperties["currency"])
+	assert.Equal(t, "EUR", problem.AdditionalProperties["targetCurrency"])
+}
+
+func TestMapError_ExchangeRoundsToZero(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	amount, _ := domain.NewMoney(decimal.RequireFromString("0.005"), domain.CurrencyUSD)
+	err := fmt.Errorf("doing atomic operation: %w", domain.NewExchangeRoundsToZeroError(amount, domain.CurrencyEUR))
+
+	// Act
+	problem, status := api.MapError(err, "/transactions/exchange")
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "https://minibankingplatform.com/problems/exchange-rounds-to-zero", problem.Type)
+	assert.Equal(t, "USD", problem.AdditionalProperties["currency"])
+	assert.Equal(t, "EUR", problem.AdditionalProperties["targetCurrency"])
+}
+
+func TestMapError_DailyLimitExceeded(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	amount, _ := domain.NewMoney(decimal.NewFromInt(300), domain.CurrencyUSD)
+	err := fmt.Errorf("checking daily limit: %w", domain.NewDailyLimitExceededError(
+		domain.AccountID{1}, decimal.NewFromInt(1000), decimal.NewFromInt(800), amount,
+	))
+
+	// Act
+	problem, status := api.MapError(err, "/transactions/transfer")
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "https://minibankingplatform.com/problems/daily-limit-exceeded", problem.Type)
+	assert.Equal(t, "1000", problem.AdditionalProperties["dailyLimit"])
+	assert.Equal(t, "800", problem.AdditionalProperties["transferredToday"])
+	assert.Equal(t, "300", problem.AdditionalProperties["required"])
+	assert.Equal(t, "USD", problem.AdditionalProperties["currency"])
+}
+
+func TestMapError_InvalidPassword(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	err := fmt.Errorf("changing password: %w", domain.NewInvalidPasswordError("must be at least 8 characters long"))
+
+	// Act
+	problem, status := api.MapError(err, "/users/me/password")
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "https://minibankingplatform.com/problems/invalid-password", problem.Type)
+	assert.Equal(t, "password must be at least 8 characters long", *problem.Detail)
+}
+
+func TestMapError_BlockedEmailDomain(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	err := fmt.Errorf("registering user: %w", domain.NewBlockedEmailDomainError("mailinator.com"))
+
+	// Act
+	problem, status := api.MapError(err, "/auth/register")
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "https://minibankingplatform.com/problems/blocked-email-domain", problem.Type)
+	assert.Equal(t, "mailinator.com", problem.AdditionalProperties["domain"])
+}
+
+func TestMapError_ZeroAmount(t *testing.T) {
+	t.Parallel()
+
+	// Arrange - as wrapped by the service when the amount CHECK constraint fails
+	err := fmt.Errorf("doing atomic operation: %w", domain.NewZeroAmountError(domain.CurrencyUSD))
+
+	// Act
+	problem, status := api.MapError(err, "/transactions/transfer")
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "https://minibankingplatform.com/problems/zero-amount", problem.Type)
+	assert.Equal(t, "USD", problem.AdditionalProperties["currency"])
+}
+
+func TestMapError_TokenRotationLimit(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	problem, status := api.MapError(domain.NewTokenRotationLimitError(domain.UserID{}), "/auth/rotate")
+
+	// Assert
+	assert.Equal(t, http.StatusTooManyRequests, status)
+	assert.Equal(t, "https://minibankingplatform.com/problems/too-many-requests", problem.Type)
+}
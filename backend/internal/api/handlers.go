@@ -2,16 +2,21 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 	"github.com/shopspring/decimal"
+	"golang.org/x/text/language"
 
 	"minibankingplatform/internal/domain"
 	"minibankingplatform/internal/service"
+	"minibankingplatform/pkg/jwt"
 )
 
 // APIHandler implements the StrictServerInterface.
@@ -29,17 +34,15 @@ var _ StrictServerInterface = (*APIHandler)(nil)
 
 // Register handles user registration.
 func (h *APIHandler) Register(ctx context.Context, request RegisterRequestObject) (RegisterResponseObject, error) {
+	cmd := service.NewRegisterCommand(request.Body.Email, request.Body.Password)
+	request.Body.Email = cmd.Email
+
 	// Validate request
 	if err := ValidateStruct(request.Body); err != nil {
 		problem, _ := MapError(err, "/auth/register")
 		return Register400ApplicationProblemPlusJSONResponse(problem), nil
 	}
 
-	cmd := &service.RegisterCommand{
-		Email:    string(request.Body.Email),
-		Password: request.Body.Password,
-	}
-
 	result, err := h.service.Register(ctx, cmd)
 	if err != nil {
 		return h.mapRegisterError(err)
@@ -59,23 +62,42 @@ func (h *APIHandler) mapRegisterError(err error) (RegisterResponseObject, error)
 		return Register409ApplicationProblemPlusJSONResponse(problem), nil
 	}
 
+	var blockedDomainErr *domain.BlockedEmailDomainError
+	if errors.As(err, &blockedDomainErr) {
+		problem, _ := MapError(err, "/auth/register")
+		return Register403ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	var invalidPasswordErr *domain.InvalidPasswordError
+	if errors.As(err, &invalidPasswordErr) {
+		problem, _ := MapError(err, "/auth/register")
+		return Register422ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	var maintenanceErr *domain.MaintenanceModeError
+	if errors.As(err, &maintenanceErr) {
+		problem, _ := MapError(err, "/auth/register")
+		return Register503ApplicationProblemPlusJSONResponse{
+			Body:    problem,
+			Headers: Register503ResponseHeaders{RetryAfter: retryAfterSeconds(maintenanceErr.RetryAfter)},
+		}, nil
+	}
+
 	problem, _ := MapError(err, "/auth/register")
 	return Register400ApplicationProblemPlusJSONResponse(problem), nil
 }
 
 // Login handles user authentication.
 func (h *APIHandler) Login(ctx context.Context, request LoginRequestObject) (LoginResponseObject, error) {
+	cmd := service.NewLoginCommand(request.Body.Email, request.Body.Password)
+	request.Body.Email = cmd.Email
+
 	// Validate request
 	if err := ValidateStruct(request.Body); err != nil {
 		problem, _ := MapError(err, "/auth/login")
 		return Login400ApplicationProblemPlusJSONResponse(problem), nil
 	}
 
-	cmd := &service.LoginCommand{
-		Email:    string(request.Body.Email),
-		Password: request.Body.Password,
-	}
-
 	result, err := h.service.Login(ctx, cmd)
 	if err != nil {
 		var invalidCredsErr *domain.InvalidCredentialsError
@@ -107,14 +129,109 @@ func (h *APIHandler) GetCurrentUser(ctx context.Context, _ GetCurrentUserRequest
 	}, nil
 }
 
-// ListAccounts returns all accounts for the authenticated user.
-func (h *APIHandler) ListAccounts(ctx context.Context, _ ListAccountsRequestObject) (ListAccountsResponseObject, error) {
+// ChangePassword replaces the authenticated user's password.
+func (h *APIHandler) ChangePassword(ctx context.Context, request ChangePasswordRequestObject) (ChangePasswordResponseObject, error) {
+	const instance = "/users/me/password"
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return ChangePassword401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	if err := ValidateStruct(request.Body); err != nil {
+		problem, _ := MapError(err, instance)
+		return ChangePassword400ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	err = h.service.ChangePassword(ctx, &service.ChangePasswordCommand{
+		UserID:      domain.UserID(userID),
+		OldPassword: request.Body.OldPassword,
+		NewPassword: request.Body.NewPassword,
+	})
+	if err != nil {
+		problem, status := MapError(err, instance)
+		switch status {
+		case http.StatusUnauthorized:
+			return ChangePassword401ApplicationProblemPlusJSONResponse(problem), nil
+		case http.StatusUnprocessableEntity:
+			return ChangePassword422ApplicationProblemPlusJSONResponse(problem), nil
+		default:
+			return ChangePassword500ApplicationProblemPlusJSONResponse(problem), nil
+		}
+	}
+
+	return ChangePassword204Response{}, nil
+}
+
+// RotateToken issues a fresh token to the authenticated user.
+func (h *APIHandler) RotateToken(ctx context.Context, _ RotateTokenRequestObject) (RotateTokenResponseObject, error) {
+	const instance = "/auth/rotate"
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return RotateToken401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	result, err := h.service.RotateToken(ctx, domain.UserID(userID))
+	if err != nil {
+		// The user behind a still valid token may have been removed
+		var userNotFoundErr *domain.UserNotFoundError
+		if errors.As(err, &userNotFoundErr) {
+			return RotateToken401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+		}
+
+		problem, status := MapError(err, instance)
+		if status == http.StatusTooManyRequests {
+			return RotateToken429ApplicationProblemPlusJSONResponse(problem), nil
+		}
+		return RotateToken500ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	return RotateToken200JSONResponse{
+		UserId: ptr(openapi_types.UUID(result.UserID)),
+		Email:  ptr(openapi_types.Email(result.Email)),
+		Token:  ptr(result.Token),
+	}, nil
+}
+
+// RefreshToken exchanges a valid or recently expired token for a fresh one.
+func (h *APIHandler) RefreshToken(ctx context.Context, request RefreshTokenRequestObject) (RefreshTokenResponseObject, error) {
+	const instance = "/auth/refresh"
+
+	const bearerPrefix = "Bearer "
+	if request.Params.Authorization == nil || !strings.HasPrefix(*request.Params.Authorization, bearerPrefix) {
+		return RefreshToken401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	result, err := h.service.RefreshToken(ctx, strings.TrimPrefix(*request.Params.Authorization, bearerPrefix))
+	if err != nil {
+		var userNotFoundErr *domain.UserNotFoundError
+		if errors.Is(err, jwt.ErrInvalidToken) || errors.As(err, &userNotFoundErr) {
+			return RefreshToken401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+		}
+
+		problem, _ := MapError(err, instance)
+		return RefreshToken500ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	return RefreshToken200JSONResponse{
+		UserId: ptr(openapi_types.UUID(result.UserID)),
+		Email:  ptr(openapi_types.Email(result.Email)),
+		Token:  ptr(result.Token),
+	}, nil
+}
+
+// ListAccounts returns the authenticated user's accounts, hiding closed ones unless requested.
+// With source=ledger, balances are computed from the ledger instead of read from the accounts.
+func (h *APIHandler) ListAccounts(ctx context.Context, request ListAccountsRequestObject) (ListAccountsResponseObject, error) {
 	userID, err := UserIDFromContext(ctx)
 	if err != nil {
 		return ListAccounts401ApplicationProblemPlusJSONResponse(UnauthorizedError("/accounts")), nil
 	}
 
-	accounts, err := h.service.GetUserAccounts(ctx, domain.UserID(userID))
+	includeClosed := request.Params.IncludeClosed != nil && *request.Params.IncludeClosed
+
+	accounts, err := h.service.GetUserAccounts(ctx, domain.UserID(userID), includeClosed)
 	if err != nil {
 		problem, _ := MapError(err, "/accounts")
 		return ListAccounts401ApplicationProblemPlusJSONResponse(problem), nil
@@ -125,16 +242,73 @@ func (h *APIHandler) ListAccounts(ctx context.Context, _ ListAccountsRequestObje
 		response[i] = domainAccountToAPI(acc)
 	}
 
+	if request.Params.Source != nil && *request.Params.Source == Ledger {
+		balances, err := h.service.GetUserAccountsFromLedger(ctx, domain.UserID(userID), includeClosed)
+		if err != nil {
+			problem, _ := MapError(err, "/accounts")
+			return ListAccounts401ApplicationProblemPlusJSONResponse(problem), nil
+		}
+
+		ledgerBalances := make(map[domain.AccountID]domain.Money, len(balances))
+		for _, balance := range balances {
+			ledgerBalances[balance.AccountID] = balance.Balance
+		}
+		for i := range response {
+			if balance, ok := ledgerBalances[domain.AccountID(*response[i].Id)]; ok {
+				response[i].Balance = domainMoneyToAPI(balance)
+			}
+		}
+	}
+
 	return ListAccounts200JSONResponse(response), nil
 }
 
+// GetAccountsSummary returns the user's total balance in each currency.
+func (h *APIHandler) GetAccountsSummary(ctx context.Context, request GetAccountsSummaryRequestObject) (GetAccountsSummaryResponseObject, error) {
+	const instance = "/accounts/summary"
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return GetAccountsSummary401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	totals, err := h.service.GetUserBalancesByCurrency(ctx, domain.UserID(userID))
+	if err != nil {
+		problem, _ := MapError(err, instance)
+		return GetAccountsSummary500ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	locale := parseLocale(request.Params.Locale)
+	balances := make([]Money, 0, len(totals))
+	for _, currency := range domain.CurrencyValues() {
+		if total, ok := totals[currency]; ok {
+			balances = append(balances, *localizedMoneyToAPI(total, locale))
+		}
+	}
+
+	return GetAccountsSummary200JSONResponse{Balances: &balances}, nil
+}
+
 // GetAccountBalance returns the balance of a specific account.
 func (h *APIHandler) GetAccountBalance(ctx context.Context, request GetAccountBalanceRequestObject) (GetAccountBalanceResponseObject, error) {
-	_, err := UserIDFromContext(ctx)
+	userID, err := UserIDFromContext(ctx)
 	if err != nil {
 		return GetAccountBalance401ApplicationProblemPlusJSONResponse(UnauthorizedError("/accounts/" + request.AccountId.String() + "/balance")), nil
 	}
 
+	err = h.service.AssertAccountOwnership(ctx, domain.AccountID(request.AccountId), domain.UserID(userID))
+	if err != nil {
+		problem, status := MapError(err, "/accounts/"+request.AccountId.String()+"/balance")
+		switch status {
+		case http.StatusForbidden:
+			return GetAccountBalance403ApplicationProblemPlusJSONResponse(problem), nil
+		case http.StatusNotFound:
+			return GetAccountBalance404ApplicationProblemPlusJSONResponse(problem), nil
+		default:
+			return GetAccountBalance500ApplicationProblemPlusJSONResponse(problem), nil
+		}
+	}
+
 	balance, err := h.service.GetAccountBalance(ctx, domain.AccountID(request.AccountId))
 	if err != nil {
 		var notFoundErr *domain.AccountNotFoundError
@@ -143,18 +317,303 @@ func (h *APIHandler) GetAccountBalance(ctx context.Context, request GetAccountBa
 			return GetAccountBalance404ApplicationProblemPlusJSONResponse(problem), nil
 		}
 		problem, _ := MapError(err, "/accounts/"+request.AccountId.String()+"/balance")
-		return GetAccountBalance401ApplicationProblemPlusJSONResponse(problem), nil
+		return GetAccountBalance500ApplicationProblemPlusJSONResponse(problem), nil
 	}
 
 	return GetAccountBalance200JSONResponse{
 		AccountId: ptr(request.AccountId),
-		Balance:   domainMoneyToAPI(balance),
+		Balance:   localizedMoneyToAPI(balance, parseLocale(request.Params.Locale)),
+	}, nil
+}
+
+// GetAccountBalanceAtDate returns the balance one of the user's accounts had at
+// a past time.
+func (h *APIHandler) GetAccountBalanceAtDate(ctx context.Context, request GetAccountBalanceAtDateRequestObject) (GetAccountBalanceAtDateResponseObject, error) {
+	instance := "/accounts/" + request.AccountId.String() + "/balance/history"
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return GetAccountBalanceAtDate401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	balance, err := h.service.GetAccountBalanceAtDate(ctx, domain.AccountID(request.AccountId), domain.UserID(userID), request.Params.At)
+	if err != nil {
+		problem, status := MapError(err, instance)
+		switch status {
+		case http.StatusForbidden:
+			return GetAccountBalanceAtDate403ApplicationProblemPlusJSONResponse(problem), nil
+		case http.StatusNotFound:
+			return GetAccountBalanceAtDate404ApplicationProblemPlusJSONResponse(problem), nil
+		default:
+			return GetAccountBalanceAtDate500ApplicationProblemPlusJSONResponse(problem), nil
+		}
+	}
+
+	return GetAccountBalanceAtDate200JSONResponse{
+		AccountId: request.AccountId,
+		At:        request.Params.At,
+		Balance:   *domainMoneyToAPI(balance),
+	}, nil
+}
+
+// UpdateAccount sets the display name of one of the user's accounts.
+func (h *APIHandler) UpdateAccount(ctx context.Context, request UpdateAccountRequestObject) (UpdateAccountResponseObject, error) {
+	instance := "/accounts/" + request.AccountId.String()
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return UpdateAccount401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	accountID := domain.AccountID(request.AccountId)
+	err = h.service.SetAccountLabel(ctx, accountID, domain.UserID(userID), request.Body.Label)
+	if err != nil {
+		return mapUpdateAccountError(err, instance)
+	}
+
+	account, err := h.service.GetAccount(ctx, accountID)
+	if err != nil {
+		return mapUpdateAccountError(err, instance)
+	}
+
+	return UpdateAccount200JSONResponse(domainAccountToAPI(account)), nil
+}
+
+func mapUpdateAccountError(err error, instance string) (UpdateAccountResponseObject, error) {
+	problem, status := MapError(err, instance)
+	switch status {
+	case http.StatusBadRequest:
+		return UpdateAccount400ApplicationProblemPlusJSONResponse(problem), nil
+	case http.StatusForbidden:
+		return UpdateAccount403ApplicationProblemPlusJSONResponse(problem), nil
+	case http.StatusNotFound:
+		return UpdateAccount404ApplicationProblemPlusJSONResponse(problem), nil
+	default:
+		return UpdateAccount500ApplicationProblemPlusJSONResponse(problem), nil
+	}
+}
+
+// UpdateAccountStatus freezes, unfreezes or closes one of the user's accounts.
+func (h *APIHandler) UpdateAccountStatus(ctx context.Context, request UpdateAccountStatusRequestObject) (UpdateAccountStatusResponseObject, error) {
+	instance := "/accounts/" + request.AccountId.String() + "/status"
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return UpdateAccountStatus401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	if err := ValidateStruct(request.Body); err != nil {
+		problem, _ := MapError(err, instance)
+		return UpdateAccountStatus400ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	accountID := domain.AccountID(request.AccountId)
+	switch request.Body.Status {
+	case UpdateAccountStatusRequestStatusActive:
+		err = h.service.UnfreezeAccount(ctx, accountID, domain.UserID(userID))
+	case UpdateAccountStatusRequestStatusFrozen:
+		err = h.service.FreezeAccount(ctx, accountID, domain.UserID(userID))
+	case UpdateAccountStatusRequestStatusClosed:
+		err = h.service.CloseAccount(ctx, accountID, domain.UserID(userID))
+	}
+	if err != nil {
+		return h.mapUpdateAccountStatusError(err, instance)
+	}
+
+	account, err := h.service.GetAccount(ctx, accountID)
+	if err != nil {
+		return h.mapUpdateAccountStatusError(err, instance)
+	}
+
+	return UpdateAccountStatus200JSONResponse(domainAccountToAPI(account)), nil
+}
+
+func (h *APIHandler) mapUpdateAccountStatusError(err error, instance string) (UpdateAccountStatusResponseObject, error) {
+	problem, status := MapError(err, instance)
+	switch status {
+	case http.StatusForbidden:
+		return UpdateAccountStatus403ApplicationProblemPlusJSONResponse(problem), nil
+	case http.StatusNotFound:
+		return UpdateAccountStatus404ApplicationProblemPlusJSONResponse(problem), nil
+	case http.StatusConflict:
+		return UpdateAccountStatus409ApplicationProblemPlusJSONResponse(problem), nil
+	default:
+		return UpdateAccountStatus500ApplicationProblemPlusJSONResponse(problem), nil
+	}
+}
+
+// Deposit pays money into one of the user's accounts.
+func (h *APIHandler) Deposit(ctx context.Context, request DepositRequestObject) (DepositResponseObject, error) {
+	instance := "/accounts/" + request.AccountId.String() + "/deposit"
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return Deposit401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	if err := ValidateStruct(request.Body); err != nil {
+		problem, _ := MapError(err, instance)
+		return Deposit400ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	err = h.service.AssertAccountOwnership(ctx, domain.AccountID(request.AccountId), domain.UserID(userID))
+	if err != nil {
+		return mapDepositError(err, instance)
+	}
+
+	cmd, err := service.NewCashCommand(uuid.UUID(request.AccountId), request.Body.Amount, time.Now().UTC())
+	if err != nil {
+		problem, _ := MapError(err, instance)
+		return Deposit400ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	result, err := h.service.Deposit(ctx, cmd)
+	if err != nil {
+		return mapDepositError(err, instance)
+	}
+
+	return Deposit200JSONResponse(cashResultToAPI(request.AccountId, result)), nil
+}
+
+func mapDepositError(err error, instance string) (DepositResponseObject, error) {
+	problem, status := MapError(err, instance)
+	switch status {
+	case http.StatusBadRequest:
+		return Deposit400ApplicationProblemPlusJSONResponse(problem), nil
+	case http.StatusForbidden:
+		return Deposit403ApplicationProblemPlusJSONResponse(problem), nil
+	case http.StatusNotFound:
+		return Deposit404ApplicationProblemPlusJSONResponse(problem), nil
+	case http.StatusTooManyRequests:
+		var tooManyRequestsErr *domain.TooManyRequestsError
+		if errors.As(err, &tooManyRequestsErr) {
+			return Deposit429ApplicationProblemPlusJSONResponse{
+				Body:    problem,
+				Headers: Deposit429ResponseHeaders{RetryAfter: retryAfterSeconds(tooManyRequestsErr.RetryAfter)},
+			}, nil
+		}
+		return Deposit500ApplicationProblemPlusJSONResponse(problem), nil
+	case http.StatusServiceUnavailable:
+		var maintenanceErr *domain.MaintenanceModeError
+		if errors.As(err, &maintenanceErr) {
+			return Deposit503ApplicationProblemPlusJSONResponse{
+				Body:    problem,
+				Headers: Deposit503ResponseHeaders{RetryAfter: retryAfterSeconds(maintenanceErr.RetryAfter)},
+			}, nil
+		}
+		return Deposit500ApplicationProblemPlusJSONResponse(problem), nil
+	default:
+		return Deposit500ApplicationProblemPlusJSONResponse(problem), nil
+	}
+}
+
+// Withdraw pays money out of one of the user's accounts.
+func (h *APIHandler) Withdraw(ctx context.Context, request WithdrawRequestObject) (WithdrawResponseObject, error) {
+	instance := "/accounts/" + request.AccountId.String() + "/withdraw"
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return Withdraw401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	if err := ValidateStruct(request.Body); err != nil {
+		problem, _ := MapError(err, instance)
+		return Withdraw400ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	err = h.service.AssertAccountOwnership(ctx, domain.AccountID(request.AccountId), domain.UserID(userID))
+	if err != nil {
+		return mapWithdrawError(err, instance)
+	}
+
+	cmd, err := service.NewCashCommand(uuid.UUID(request.AccountId), request.Body.Amount, time.Now().UTC())
+	if err != nil {
+		problem, _ := MapError(err, instance)
+		return Withdraw400ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	result, err := h.service.Withdraw(ctx, cmd)
+	if err != nil {
+		return mapWithdrawError(err, instance)
+	}
+
+	return Withdraw200JSONResponse(cashResultToAPI(request.AccountId, result)), nil
+}
+
+func mapWithdrawError(err error, instance string) (WithdrawResponseObject, error) {
+	problem, status := MapError(err, instance)
+	switch status {
+	case http.StatusBadRequest:
+		return Withdraw400ApplicationProblemPlusJSONResponse(problem), nil
+	case http.StatusForbidden:
+		return Withdraw403ApplicationProblemPlusJSONResponse(problem), nil
+	case http.StatusNotFound:
+		return Withdraw404ApplicationProblemPlusJSONResponse(problem), nil
+	case http.StatusTooManyRequests:
+		var tooManyRequestsErr *domain.TooManyRequestsError
+		if errors.As(err, &tooManyRequestsErr) {
+			return Withdraw429ApplicationProblemPlusJSONResponse{
+				Body:    problem,
+				Headers: Withdraw429ResponseHeaders{RetryAfter: retryAfterSeconds(tooManyRequestsErr.RetryAfter)},
+			}, nil
+		}
+		return Withdraw500ApplicationProblemPlusJSONResponse(problem), nil
+	case http.StatusServiceUnavailable:
+		var maintenanceErr *domain.MaintenanceModeError
+		if errors.As(err, &maintenanceErr) {
+			return Withdraw503ApplicationProblemPlusJSONResponse{
+				Body:    problem,
+				Headers: Withdraw503ResponseHeaders{RetryAfter: retryAfterSeconds(maintenanceErr.RetryAfter)},
+			}, nil
+		}
+		return Withdraw500ApplicationProblemPlusJSONResponse(problem), nil
+	default:
+		return Withdraw500ApplicationProblemPlusJSONResponse(problem), nil
+	}
+}
+
+// GetAccountLedger returns the ledger records of a specific account with a running balance.
+func (h *APIHandler) GetAccountLedger(ctx context.Context, request GetAccountLedgerRequestObject) (GetAccountLedgerResponseObject, error) {
+	instance := "/accounts/" + request.AccountId.String() + "/ledger"
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return GetAccountLedger401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	ledger, err := h.service.GetAccountLedger(ctx, domain.AccountID(request.AccountId), domain.UserID(userID))
+	if err != nil {
+		problem, status := MapError(err, instance)
+		switch status {
+		case http.StatusForbidden:
+			return GetAccountLedger403ApplicationProblemPlusJSONResponse(problem), nil
+		case http.StatusNotFound:
+			return GetAccountLedger404ApplicationProblemPlusJSONResponse(problem), nil
+		default:
+			return GetAccountLedger500ApplicationProblemPlusJSONResponse(problem), nil
+		}
+	}
+
+	entries := make([]AccountLedgerEntry, len(ledger.Entries))
+	for i, entry := range ledger.Entries {
+		entries[i] = AccountLedgerEntry{
+			Id:             ptr(openapi_types.UUID(entry.RecordID)),
+			TransactionId:  ptr(openapi_types.UUID(entry.TransactionID)),
+			Amount:         domainMoneyToAPI(entry.Amount),
+			RunningBalance: domainMoneyToAPI(entry.RunningBalance),
+			Timestamp:      ptr(entry.Timestamp),
+		}
+	}
+
+	return GetAccountLedger200JSONResponse{
+		AccountId: ptr(request.AccountId),
+		Entries:   &entries,
 	}, nil
 }
 
 // Transfer handles money transfer between accounts.
 func (h *APIHandler) Transfer(ctx context.Context, request TransferRequestObject) (TransferResponseObject, error) {
-	_, err := UserIDFromContext(ctx)
+	userID, err := UserIDFromContext(ctx)
 	if err != nil {
 		return Transfer401ApplicationProblemPlusJSONResponse(UnauthorizedError("/transactions/transfer")), nil
 	}
@@ -165,34 +624,211 @@ func (h *APIHandler) Transfer(ctx context.Context, request TransferRequestObject
 		return Transfer400ApplicationProblemPlusJSONResponse(problem), nil
 	}
 
-	now := time.Now()
+	// Without a currency the service transfers in the source account's currency
+	var currency string
+	if request.Body.Currency != nil {
+		currency = string(*request.Body.Currency)
+	}
+
+	now := time.Now().UTC()
 	cmd, err := service.NewTransferCommand(
 		uuid.UUID(request.Body.FromAccountId),
 		uuid.UUID(request.Body.ToAccountId),
 		request.Body.Amount,
-		string(request.Body.Currency),
+		currency,
 		now,
 	)
 	if err != nil {
 		problem, _ := MapError(err, "/transactions/transfer")
 		return Transfer400ApplicationProblemPlusJSONResponse(problem), nil
 	}
+	cmd.UserID = domain.UserID(userID)
 
-	err = h.service.Transfer(ctx, cmd)
+	err = h.service.AssertAccountOwnership(ctx, cmd.From, cmd.UserID)
 	if err != nil {
 		return h.mapTransferError(err, request.Body.FromAccountId, request.Body.ToAccountId)
 	}
 
-	return Transfer200JSONResponse{
-		TransactionId: ptr(openapi_types.UUID(uuid.New())), // Note: service doesn't return transaction ID yet
-		FromAccountId: ptr(request.Body.FromAccountId),
-		ToAccountId:   ptr(request.Body.ToAccountId),
-		Amount: &Money{
-			Amount:   ptr(request.Body.Amount),
-			Currency: ptr(request.Body.Currency),
-		},
-		Timestamp: ptr(now),
-	}, nil
+	var response Transfer200JSONResponse
+	transfer := func(ctx context.Context) (any, error) {
+		result, err := h.service.Transfer(ctx, cmd)
+		if err != nil {
+			return nil, err
+		}
+
+		response = Transfer200JSONResponse{
+			TransactionId: ptr(openapi_types.UUID(result.TransactionID)),
+			FromAccountId: ptr(request.Body.FromAccountId),
+			ToAccountId:   ptr(request.Body.ToAccountId),
+			Amount:        domainMoneyToAPI(result.Money),
+			Timestamp:     ptr(now),
+		}
+		return response, nil
+	}
+
+	if isDryRun(request.Params.XDryRun) {
+		balances, err := h.runDryRun(ctx, cmd.UserID, transfer, request.Body.FromAccountId, request.Body.ToAccountId)
+		if err != nil {
+			return h.mapTransferError(err, request.Body.FromAccountId, request.Body.ToAccountId)
+		}
+		response.DryRun = ptr(true)
+		response.Balances = &balances
+
+		return response, nil
+	}
+
+	err = h.runIdempotent(ctx, cmd.UserID, request.Params.IdempotencyKey,
+		service.IdempotentRequest{Endpoint: "/transactions/transfer", Body: request.Body}, &response, transfer)
+	if err != nil {
+		return h.mapTransferError(err, request.Body.FromAccountId, request.Body.ToAccountId)
+	}
+
+	return response, nil
+}
+
+// BatchTransfer runs the user's transfers in a single transaction.
+func (h *APIHandler) BatchTransfer(ctx context.Context, request BatchTransferRequestObject) (BatchTransferResponseObject, error) {
+	const instance = "/transactions/transfers/batch"
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return BatchTransfer401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	if err := ValidateStruct(request.Body); err != nil {
+		problem, _ := MapError(err, instance)
+		return BatchTransfer400ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	now := time.Now().UTC()
+	cmds := make([]*service.TransferCommand, 0, len(request.Body.Transfers))
+	for i, transfer := range request.Body.Transfers {
+		var currency string
+		if transfer.Currency != nil {
+			currency = string(*transfer.Currency)
+		}
+
+		cmd, err := service.NewTransferCommand(
+			uuid.UUID(transfer.FromAccountId),
+			uuid.UUID(transfer.ToAccountId),
+			transfer.Amount,
+			currency,
+			now,
+		)
+		if err != nil {
+			problem, _ := MapError(err, instance)
+			problem.Set("index", i)
+			return BatchTransfer400ApplicationProblemPlusJSONResponse(problem), nil
+		}
+		cmd.UserID = domain.UserID(userID)
+		cmds = append(cmds, cmd)
+	}
+
+	result, err := h.service.BatchTransfer(ctx, cmds)
+
+	// Only a failing transfer has an outcome to report, other errors are the request's
+	var batchErr *domain.BatchTransferError
+	if err != nil && !errors.As(err, &batchErr) {
+		problem, status := MapError(err, instance)
+		var (
+			tooManyRequestsErr *domain.TooManyRequestsError
+			maintenanceErr     *domain.MaintenanceModeError
+		)
+		switch {
+		case status == http.StatusBadRequest:
+			return BatchTransfer400ApplicationProblemPlusJSONResponse(problem), nil
+		case errors.As(err, &tooManyRequestsErr):
+			return BatchTransfer429ApplicationProblemPlusJSONResponse{
+				Body:    problem,
+				Headers: BatchTransfer429ResponseHeaders{RetryAfter: retryAfterSeconds(tooManyRequestsErr.RetryAfter)},
+			}, nil
+		case errors.As(err, &maintenanceErr):
+			return BatchTransfer503ApplicationProblemPlusJSONResponse{
+				Body:    problem,
+				Headers: BatchTransfer503ResponseHeaders{RetryAfter: retryAfterSeconds(maintenanceErr.RetryAfter)},
+			}, nil
+		}
+		return BatchTransfer500ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	response := batchTransferResultToAPI(result, err == nil, instance)
+	if err != nil {
+		return BatchTransfer422JSONResponse(response), nil
+	}
+
+	return BatchTransfer200JSONResponse(response), nil
+}
+
+// runIdempotent runs op, which fills response. With an idempotency key op runs
+// at most once per user and key, and a response stored by an earlier identical
+// request with the key is decoded into response instead.
+func (h *APIHandler) runIdempotent(
+	ctx context.Context,
+	userID domain.UserID,
+	key *string,
+	request service.IdempotentRequest,
+	response any,
+	op func(ctx context.Context) (any, error),
+) error {
+	if key == nil || *key == "" {
+		_, err := op(ctx)
+		return err
+	}
+
+	stored, err := h.service.WithIdempotencyKey(ctx, userID, *key, request, op)
+	if err != nil {
+		return err
+	}
+
+	if stored != nil {
+		if err := json.Unmarshal(stored, response); err != nil {
+			return fmt.Errorf("decoding stored response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func isDryRun(header *bool) bool {
+	return header != nil && *header
+}
+
+// runDryRun runs op, which fills its response, as a dry run and returns the
+// balances the given accounts would have after it. Accounts of other users are
+// left out, their balances aren't the caller's to see.
+func (h *APIHandler) runDryRun(
+	ctx context.Context,
+	userID domain.UserID,
+	op func(ctx context.Context) (any, error),
+	accountIDs ...openapi_types.UUID,
+) ([]Balance, error) {
+	var balances []Balance
+	err := h.service.DryRun(ctx, func(ctx context.Context) error {
+		if _, err := op(ctx); err != nil {
+			return err
+		}
+
+		accounts, err := h.service.GetUserAccounts(ctx, userID, true)
+		if err != nil {
+			return fmt.Errorf("getting resulting balances: %w", err)
+		}
+
+		balances = make([]Balance, 0, len(accountIDs))
+		for _, accountID := range accountIDs {
+			for _, account := range accounts {
+				if account.ID() == domain.AccountID(accountID) {
+					balances = append(balances, Balance{
+						AccountId: ptr(accountID),
+						Balance:   domainMoneyToAPI(account.Balance()),
+					})
+				}
+			}
+		}
+
+		return nil
+	})
+
+	return balances, err
 }
 
 func (h *APIHandler) mapTransferError(err error, fromAccount, toAccount openapi_types.UUID) (TransferResponseObject, error) {
@@ -202,13 +838,67 @@ func (h *APIHandler) mapTransferError(err error, fromAccount, toAccount openapi_
 		return Transfer404ApplicationProblemPlusJSONResponse(problem), nil
 	}
 
+	var duplicateErr *domain.DuplicateTransferInProgressError
+	if errors.As(err, &duplicateErr) {
+		problem, _ := MapError(err, "/transactions/transfer")
+		return Transfer409ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	var idempotencyMismatchErr *domain.IdempotencyKeyMismatchError
+	if errors.As(err, &idempotencyMismatchErr) {
+		problem, _ := MapError(err, "/transactions/transfer")
+		return Transfer422ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	var accessDeniedErr *domain.AccountAccessDeniedError
+	if errors.As(err, &accessDeniedErr) {
+		problem, _ := MapError(err, "/transactions/transfer")
+		return Transfer403ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	var cashbookErr *domain.CashbookTransferError
+	if errors.As(err, &cashbookErr) {
+		problem, _ := MapError(err, "/transactions/transfer")
+		return Transfer403ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	var frozenErr *domain.FrozenAccountError
+	if errors.As(err, &frozenErr) {
+		problem, _ := MapError(err, "/transactions/transfer")
+		return Transfer403ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	var closedErr *domain.ClosedAccountError
+	if errors.As(err, &closedErr) {
+		problem, _ := MapError(err, "/transactions/transfer")
+		return Transfer403ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	var tooManyRequestsErr *domain.TooManyRequestsError
+	if errors.As(err, &tooManyRequestsErr) {
+		problem, _ := MapError(err, "/transactions/transfer")
+		return Transfer429ApplicationProblemPlusJSONResponse{
+			Body:    problem,
+			Headers: Transfer429ResponseHeaders{RetryAfter: retryAfterSeconds(tooManyRequestsErr.RetryAfter)},
+		}, nil
+	}
+
+	var maintenanceErr *domain.MaintenanceModeError
+	if errors.As(err, &maintenanceErr) {
+		problem, _ := MapError(err, "/transactions/transfer")
+		return Transfer503ApplicationProblemPlusJSONResponse{
+			Body:    problem,
+			Headers: Transfer503ResponseHeaders{RetryAfter: retryAfterSeconds(maintenanceErr.RetryAfter)},
+		}, nil
+	}
+
 	problem, _ := MapError(err, "/transactions/transfer")
 	return Transfer400ApplicationProblemPlusJSONResponse(problem), nil
 }
 
 // Exchange handles currency exchange between user's accounts.
 func (h *APIHandler) Exchange(ctx context.Context, request ExchangeRequestObject) (ExchangeResponseObject, error) {
-	_, err := UserIDFromContext(ctx)
+	userID, err := UserIDFromContext(ctx)
 	if err != nil {
 		return Exchange401ApplicationProblemPlusJSONResponse(UnauthorizedError("/transactions/exchange")), nil
 	}
@@ -232,7 +922,7 @@ func (h *APIHandler) Exchange(ctx context.Context, request ExchangeRequestObject
 		return Exchange400ApplicationProblemPlusJSONResponse(problem), nil
 	}
 
-	now := time.Now()
+	now := time.Now().UTC()
 	cmd, err := service.NewExchangeCommand(
 		uuid.UUID(request.Body.SourceAccountId),
 		uuid.UUID(request.Body.TargetAccountId),
@@ -244,23 +934,47 @@ func (h *APIHandler) Exchange(ctx context.Context, request ExchangeRequestObject
 		problem, _ := MapError(err, "/transactions/exchange")
 		return Exchange400ApplicationProblemPlusJSONResponse(problem), nil
 	}
+	cmd.UserID = domain.UserID(userID)
+
+	var response Exchange200JSONResponse
+	exchange := func(ctx context.Context) (any, error) {
+		result, err := h.service.Exchange(ctx, cmd)
+		if err != nil {
+			return nil, err
+		}
+
+		details := result.Details
+		response = Exchange200JSONResponse{
+			ExchangeId:      ptr(openapi_types.UUID(details.ID())),
+			TransactionId:   ptr(openapi_types.UUID(details.TransactionID())),
+			SourceAccountId: ptr(openapi_types.UUID(details.SourceAccount())),
+			TargetAccountId: ptr(openapi_types.UUID(details.TargetAccount())),
+			SourceAmount:    domainMoneyToAPI(details.SourceAmount()),
+			TargetAmount:    domainMoneyToAPI(details.TargetAmount()),
+			ExchangeRate:    ptr(details.ExchangeRate().String()),
+			Timestamp:       ptr(details.Time()),
+		}
+		return response, nil
+	}
+
+	if isDryRun(request.Params.XDryRun) {
+		balances, err := h.runDryRun(ctx, domain.UserID(userID), exchange, request.Body.SourceAccountId, request.Body.TargetAccountId)
+		if err != nil {
+			return h.mapExchangeError(err)
+		}
+		response.DryRun = ptr(true)
+		response.Balances = &balances
+
+		return response, nil
+	}
 
-	err = h.service.Exchange(ctx, cmd)
+	err = h.runIdempotent(ctx, domain.UserID(userID), request.Params.IdempotencyKey,
+		service.IdempotentRequest{Endpoint: "/transactions/exchange", Body: request.Body}, &response, exchange)
 	if err != nil {
 		return h.mapExchangeError(err)
 	}
 
-	// Note: Service doesn't return exchange details, so we return a basic response
-	return Exchange200JSONResponse{
-		TransactionId:   ptr(openapi_types.UUID(uuid.New())),
-		SourceAccountId: ptr(request.Body.SourceAccountId),
-		TargetAccountId: ptr(request.Body.TargetAccountId),
-		SourceAmount: &Money{
-			Amount:   ptr(request.Body.Amount),
-			Currency: ptr(Currency(sourceBalance.Currency())),
-		},
-		Timestamp: ptr(now),
-	}, nil
+	return response, nil
 }
 
 func (h *APIHandler) mapExchangeError(err error) (ExchangeResponseObject, error) {
@@ -270,6 +984,60 @@ func (h *APIHandler) mapExchangeError(err error) (ExchangeResponseObject, error)
 		return Exchange404ApplicationProblemPlusJSONResponse(problem), nil
 	}
 
+	var directionNotAllowedErr *domain.ExchangeDirectionNotAllowedError
+	if errors.As(err, &directionNotAllowedErr) {
+		problem, _ := MapError(err, "/transactions/exchange")
+		return Exchange403ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	var accessDeniedErr *domain.AccountAccessDeniedError
+	if errors.As(err, &accessDeniedErr) {
+		problem, _ := MapError(err, "/transactions/exchange")
+		return Exchange403ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	var cashbookErr *domain.CashbookTransferError
+	if errors.As(err, &cashbookErr) {
+		problem, _ := MapError(err, "/transactions/exchange")
+		return Exchange403ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	var frozenErr *domain.FrozenAccountError
+	if errors.As(err, &frozenErr) {
+		problem, _ := MapError(err, "/transactions/exchange")
+		return Exchange403ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	var closedErr *domain.ClosedAccountError
+	if errors.As(err, &closedErr) {
+		problem, _ := MapError(err, "/transactions/exchange")
+		return Exchange403ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	var idempotencyMismatchErr *domain.IdempotencyKeyMismatchError
+	if errors.As(err, &idempotencyMismatchErr) {
+		problem, _ := MapError(err, "/transactions/exchange")
+		return Exchange422ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	var tooManyRequestsErr *domain.TooManyRequestsError
+	if errors.As(err, &tooManyRequestsErr) {
+		problem, _ := MapError(err, "/transactions/exchange")
+		return Exchange429ApplicationProblemPlusJSONResponse{
+			Body:    problem,
+			Headers: Exchange429ResponseHeaders{RetryAfter: retryAfterSeconds(tooManyRequestsErr.RetryAfter)},
+		}, nil
+	}
+
+	var maintenanceErr *domain.MaintenanceModeError
+	if errors.As(err, &maintenanceErr) {
+		problem, _ := MapError(err, "/transactions/exchange")
+		return Exchange503ApplicationProblemPlusJSONResponse{
+			Body:    problem,
+			Headers: Exchange503ResponseHeaders{RetryAfter: retryAfterSeconds(maintenanceErr.RetryAfter)},
+		}, nil
+	}
+
 	problem, _ := MapError(err, "/transactions/exchange")
 	return Exchange400ApplicationProblemPlusJSONResponse(problem), nil
 }
@@ -315,21 +1083,18 @@ func (h *APIHandler) CalculateExchange(ctx context.Context, request CalculateExc
 	}
 
 	// Calculate exchange
-	result, err := h.service.CalculateExchangeAmount(sourceAmount, targetCurrency)
+	result, err := h.service.CalculateExchangeAmount(ctx, sourceAmount, targetCurrency)
 	if err != nil {
-		problem, _ := MapError(err, "/transactions/exchange/calculate")
+		problem, status := MapError(err, "/transactions/exchange/calculate")
+		if status == http.StatusForbidden {
+			return CalculateExchange403ApplicationProblemPlusJSONResponse(problem), nil
+		}
 		return CalculateExchange400ApplicationProblemPlusJSONResponse(problem), nil
 	}
 
 	return CalculateExchange200JSONResponse{
-		SourceAmount: &Money{
-			Amount:   ptr(result.SourceAmount.Amount.String()),
-			Currency: ptr(Currency(result.SourceAmount.Currency)),
-		},
-		TargetAmount: &Money{
-			Amount:   ptr(result.TargetAmount.Amount.String()),
-			Currency: ptr(Currency(result.TargetAmount.Currency)),
-		},
+		SourceAmount: domainMoneyToAPI(result.SourceAmount),
+		TargetAmount: domainMoneyToAPI(result.TargetAmount),
 		ExchangeRate: &struct {
 			Rate           *string   `json:"rate,omitempty"`
 			SourceCurrency *Currency `json:"sourceCurrency,omitempty"`
@@ -342,6 +1107,155 @@ func (h *APIHandler) CalculateExchange(ctx context.Context, request CalculateExc
 	}, nil
 }
 
+// GetExchangeRateHistory returns the recorded rates of a currency pair.
+func (h *APIHandler) GetExchangeRateHistory(ctx context.Context, request GetExchangeRateHistoryRequestObject) (GetExchangeRateHistoryResponseObject, error) {
+	const instance = "/exchange-rates/history"
+
+	_, err := UserIDFromContext(ctx)
+	if err != nil {
+		return GetExchangeRateHistory401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	from, err := mapAPICurrencyToDomain(request.Params.From)
+	if err != nil {
+		problem, _ := MapError(err, instance)
+		return GetExchangeRateHistory400ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	to, err := mapAPICurrencyToDomain(request.Params.To)
+	if err != nil {
+		problem, _ := MapError(err, instance)
+		return GetExchangeRateHistory400ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	var since time.Time
+	if request.Params.Since != nil {
+		since = *request.Params.Since
+	}
+
+	entries, err := h.service.GetExchangeRateHistory(ctx, from, to, since)
+	if err != nil {
+		problem, status := MapError(err, instance)
+		if status == http.StatusBadRequest {
+			return GetExchangeRateHistory400ApplicationProblemPlusJSONResponse(problem), nil
+		}
+		return GetExchangeRateHistory500ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	apiEntries := make([]ExchangeRateHistoryEntry, len(entries))
+	for i, entry := range entries {
+		apiEntries[i] = ExchangeRateHistoryEntry{
+			Rate:        entry.Rate.String(),
+			EffectiveAt: entry.EffectiveAt,
+			Source:      entry.Source,
+		}
+	}
+
+	return GetExchangeRateHistory200JSONResponse{
+		From:    request.Params.From,
+		To:      request.Params.To,
+		Entries: apiEntries,
+	}, nil
+}
+
+// GetExchange returns a single exchange by its exchange ID.
+func (h *APIHandler) GetExchange(ctx context.Context, request GetExchangeRequestObject) (GetExchangeResponseObject, error) {
+	instance := "/transactions/exchanges/" + request.ExchangeId.String()
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return GetExchange401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	exchange, err := h.service.GetExchange(ctx, domain.UserID(userID), domain.ExchangeDetailsID(request.ExchangeId))
+	if err != nil {
+		problem, status := MapError(err, instance)
+		switch status {
+		case http.StatusForbidden:
+			return GetExchange403ApplicationProblemPlusJSONResponse(problem), nil
+		case http.StatusNotFound:
+			return GetExchange404ApplicationProblemPlusJSONResponse(problem), nil
+		default:
+			return GetExchange500ApplicationProblemPlusJSONResponse(problem), nil
+		}
+	}
+
+	details := exchange.ExchangeDetails()
+	return GetExchange200JSONResponse{
+		ExchangeId:      ptr(request.ExchangeId),
+		TransactionId:   ptr(openapi_types.UUID(exchange.Transaction().ID())),
+		SourceAccountId: ptr(openapi_types.UUID(details.SourceAccount())),
+		TargetAccountId: ptr(openapi_types.UUID(details.TargetAccount())),
+		SourceAmount:    domainMoneyToAPI(details.SourceAmount()),
+		TargetAmount:    domainMoneyToAPI(details.TargetAmount()),
+		ExchangeRate:    ptr(details.ExchangeRate().String()),
+		Timestamp:       ptr(exchange.Transaction().Time()),
+	}, nil
+}
+
+// GetTransaction returns a single transaction the user took part in.
+func (h *APIHandler) GetTransaction(ctx context.Context, request GetTransactionRequestObject) (GetTransactionResponseObject, error) {
+	instance := "/transactions/" + request.TransactionId.String()
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return GetTransaction401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	transaction, err := h.service.GetTransactionByID(ctx, domain.TransactionID(request.TransactionId), domain.UserID(userID))
+	if err != nil {
+		problem, status := MapError(err, instance)
+		switch status {
+		case http.StatusForbidden:
+			return GetTransaction403ApplicationProblemPlusJSONResponse(problem), nil
+		case http.StatusNotFound:
+			return GetTransaction404ApplicationProblemPlusJSONResponse(problem), nil
+		default:
+			return GetTransaction500ApplicationProblemPlusJSONResponse(problem), nil
+		}
+	}
+
+	return GetTransaction200JSONResponse(domainTransactionToAPI(transaction, parseLocale(request.Params.Locale))), nil
+}
+
+// GetTransactionLedger returns the ledger records booked by a transaction.
+func (h *APIHandler) GetTransactionLedger(ctx context.Context, request GetTransactionLedgerRequestObject) (GetTransactionLedgerResponseObject, error) {
+	instance := "/transactions/" + request.TransactionId.String() + "/ledger"
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return GetTransactionLedger401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	records, err := h.service.GetTransactionLedger(ctx, domain.TransactionID(request.TransactionId), domain.UserID(userID))
+	if err != nil {
+		problem, status := MapError(err, instance)
+		switch status {
+		case http.StatusForbidden:
+			return GetTransactionLedger403ApplicationProblemPlusJSONResponse(problem), nil
+		case http.StatusNotFound:
+			return GetTransactionLedger404ApplicationProblemPlusJSONResponse(problem), nil
+		default:
+			return GetTransactionLedger500ApplicationProblemPlusJSONResponse(problem), nil
+		}
+	}
+
+	apiRecords := make([]TransactionLedgerRecord, len(records))
+	for i, record := range records {
+		apiRecords[i] = TransactionLedgerRecord{
+			Id:        ptr(openapi_types.UUID(record.ID())),
+			AccountId: ptr(openapi_types.UUID(record.Account())),
+			Amount:    domainMoneyToAPI(record.Money()),
+			Timestamp: ptr(record.Time()),
+		}
+	}
+
+	return GetTransactionLedger200JSONResponse{
+		TransactionId: ptr(request.TransactionId),
+		Records:       &apiRecords,
+	}, nil
+}
+
 // ListTransactions returns a paginated list of transactions.
 func (h *APIHandler) ListTransactions(ctx context.Context, request ListTransactionsRequestObject) (ListTransactionsResponseObject, error) {
 	userID, err := UserIDFromContext(ctx)
@@ -351,7 +1265,7 @@ func (h *APIHandler) ListTransactions(ctx context.Context, request ListTransacti
 
 	// Default pagination
 	page := 1
-	limit := 20
+	limit := h.service.DefaultPageSize()
 	if request.Params.Page != nil && *request.Params.Page > 0 {
 		page = *request.Params.Page
 	}
@@ -364,6 +1278,16 @@ func (h *APIHandler) ListTransactions(ctx context.Context, request ListTransacti
 
 	offset := (page - 1) * limit
 
+	var cursor *domain.TransactionCursor
+	if request.Params.Cursor != nil {
+		parsed, err := domain.ParseTransactionCursor(*request.Params.Cursor)
+		if err != nil {
+			problem, _ := MapError(err, "/transactions")
+			return ListTransactions400ApplicationProblemPlusJSONResponse(problem), nil
+		}
+		cursor = &parsed
+	}
+
 	// Map transaction type filter
 	var txType *domain.TransactionType
 	if request.Params.Type != nil {
@@ -373,29 +1297,53 @@ func (h *APIHandler) ListTransactions(ctx context.Context, request ListTransacti
 		}
 	}
 
+	var accountID *domain.AccountID
+	if request.Params.AccountId != nil {
+		err = h.service.AssertAccountOwnership(ctx, domain.AccountID(*request.Params.AccountId), domain.UserID(userID))
+		if err != nil {
+			problem, status := MapError(err, "/transactions")
+			switch status {
+			case http.StatusForbidden:
+				return ListTransactions403ApplicationProblemPlusJSONResponse(problem), nil
+			case http.StatusNotFound:
+				return ListTransactions404ApplicationProblemPlusJSONResponse(problem), nil
+			default:
+				return ListTransactions500ApplicationProblemPlusJSONResponse(problem), nil
+			}
+		}
+		accountID = ptr(domain.AccountID(*request.Params.AccountId))
+	}
+
 	cmd := &service.GetTransactionsCommand{
 		UserID:          domain.UserID(userID),
 		TransactionType: txType,
+		AccountID:       accountID,
+		From:            request.Params.From,
+		To:              request.Params.To,
 		Limit:           limit,
 		Offset:          offset,
+		Cursor:          cursor,
+
+		IncludeTypeCounts: request.Params.IncludeTypeCounts != nil && *request.Params.IncludeTypeCounts,
 	}
 
 	result, err := h.service.GetTransactions(ctx, cmd)
 	if err != nil {
 		problem, _ := MapError(err, "/transactions")
-		return ListTransactions401ApplicationProblemPlusJSONResponse(problem), nil
+		return ListTransactions500ApplicationProblemPlusJSONResponse(problem), nil
 	}
 
 	// Map transactions
+	locale := parseLocale(request.Params.Locale)
 	transactions := make([]Transaction, len(result.Transactions))
 	for i, tx := range result.Transactions {
-		transactions[i] = domainTransactionToAPI(tx)
+		transactions[i] = domainTransactionToAPI(tx, locale)
 	}
 
 	// Calculate pagination
 	totalPages := (result.Total + limit - 1) / limit
 
-	return ListTransactions200JSONResponse{
+	response := ListTransactions200JSONResponse{
 		Transactions: &transactions,
 		Pagination: &Pagination{
 			Total:      ptr(result.Total),
@@ -403,7 +1351,23 @@ func (h *APIHandler) ListTransactions(ctx context.Context, request ListTransacti
 			Limit:      ptr(limit),
 			TotalPages: ptr(totalPages),
 		},
-	}, nil
+	}
+	if cursor != nil {
+		response.Pagination = &Pagination{Limit: ptr(limit)}
+	}
+	if result.NextCursor != nil {
+		response.NextCursor = ptr(result.NextCursor.String())
+	}
+	if result.TypeCounts != nil {
+		response.TypeCounts = &TransactionTypeCounts{
+			Transfer:   ptr(result.TypeCounts[domain.TransactionTypeTransfer]),
+			Exchange:   ptr(result.TypeCounts[domain.TransactionTypeExchange]),
+			Deposit:    ptr(result.TypeCounts[domain.TransactionTypeDeposit]),
+			Withdrawal: ptr(result.TypeCounts[domain.TransactionTypeWithdrawal]),
+		}
+	}
+
+	return response, nil
 }
 
 // Reconcile performs a reconciliation check and returns the report.
@@ -441,25 +1405,180 @@ func (h *APIHandler) Reconcile(ctx context.Context, _ ReconcileRequestObject) (R
 		}
 	}
 
+	// Map ledger records booked in the wrong currency
+	currencyMismatches := make([]LedgerCurrencyMismatch, len(report.LedgerCurrencyMismatches))
+	for i, cm := range report.LedgerCurrencyMismatches {
+		currencyMismatches[i] = LedgerCurrencyMismatch{
+			RecordId:        ptr(openapi_types.UUID(cm.RecordID)),
+			TransactionId:   ptr(openapi_types.UUID(cm.TransactionID)),
+			AccountId:       ptr(openapi_types.UUID(cm.AccountID)),
+			LedgerCurrency:  ptr(Currency(cm.LedgerCurrency)),
+			AccountCurrency: ptr(Currency(cm.AccountCurrency)),
+		}
+	}
+
+	// Map transactions whose details don't match their type
+	detailMismatches := make([]TransactionDetailMismatch, len(report.DetailMismatches))
+	for i, dm := range report.DetailMismatches {
+		detailMismatches[i] = TransactionDetailMismatch{
+			TransactionId:   ptr(openapi_types.UUID(dm.TransactionID)),
+			Type:            ptr(TransactionType(dm.Type)),
+			TransferDetails: ptr(dm.TransferDetails),
+			ExchangeDetails: ptr(dm.ExchangeDetails),
+		}
+	}
+
 	return Reconcile200JSONResponse{
-		Timestamp:            ptr(report.Timestamp),
-		IsConsistent:         ptr(report.IsConsistent),
-		LedgerBalances:       &ledgerBalances,
-		AccountMismatches:    &accountMismatches,
-		TotalAccountsChecked: ptr(report.TotalAccountsChecked),
+		Timestamp:                   ptr(report.Timestamp),
+		IsConsistent:                ptr(report.IsConsistent),
+		LedgerBalances:              &ledgerBalances,
+		AccountMismatches:           &accountMismatches,
+		LedgerCurrencyMismatches:    &currencyMismatches,
+		TransactionDetailMismatches: &detailMismatches,
+		TotalAccountsChecked:        ptr(report.TotalAccountsChecked),
 	}, nil
 }
 
+// SweepAccount moves the entire balance of one account to another. Admin only.
+func (h *APIHandler) SweepAccount(ctx context.Context, request SweepAccountRequestObject) (SweepAccountResponseObject, error) {
+	const instance = "/system/accounts/sweep"
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return SweepAccount401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	if err := h.service.RequireAdmin(domain.UserID(userID)); err != nil {
+		problem, _ := MapError(err, instance)
+		return SweepAccount403ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	if err := ValidateStruct(request.Body); err != nil {
+		problem, _ := MapError(err, instance)
+		return SweepAccount400ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	result, err := h.service.SweepAccount(
+		ctx,
+		domain.AccountID(request.Body.FromAccountId),
+		domain.AccountID(request.Body.ToAccountId),
+	)
+	if err != nil {
+		problem, status := MapError(err, instance)
+
+		var tooManyRequestsErr *domain.TooManyRequestsError
+		if errors.As(err, &tooManyRequestsErr) {
+			return SweepAccount429ApplicationProblemPlusJSONResponse{
+				Body:    problem,
+				Headers: SweepAccount429ResponseHeaders{RetryAfter: retryAfterSeconds(tooManyRequestsErr.RetryAfter)},
+			}, nil
+		}
+
+		var maintenanceErr *domain.MaintenanceModeError
+		if errors.As(err, &maintenanceErr) {
+			return SweepAccount503ApplicationProblemPlusJSONResponse{
+				Body:    problem,
+				Headers: SweepAccount503ResponseHeaders{RetryAfter: retryAfterSeconds(maintenanceErr.RetryAfter)},
+			}, nil
+		}
+
+		switch status {
+		case http.StatusBadRequest:
+			return SweepAccount400ApplicationProblemPlusJSONResponse(problem), nil
+		case http.StatusNotFound:
+			return SweepAccount404ApplicationProblemPlusJSONResponse(problem), nil
+		default:
+			return SweepAccount500ApplicationProblemPlusJSONResponse(problem), nil
+		}
+	}
+
+	response := SweepAccount200JSONResponse{
+		FromAccountId: ptr(request.Body.FromAccountId),
+		ToAccountId:   ptr(request.Body.ToAccountId),
+		Amount:        domainMoneyToAPI(result.Amount),
+		Timestamp:     ptr(result.Time),
+	}
+	if result.TransactionID != nil {
+		response.TransactionId = ptr(openapi_types.UUID(*result.TransactionID))
+	}
+
+	return response, nil
+}
+
+// SetMaintenanceMode turns maintenance mode on or off.
+func (h *APIHandler) SetMaintenanceMode(ctx context.Context, request SetMaintenanceModeRequestObject) (SetMaintenanceModeResponseObject, error) {
+	const instance = "/system/maintenance"
+
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return SetMaintenanceMode401ApplicationProblemPlusJSONResponse(UnauthorizedError(instance)), nil
+	}
+
+	if err := h.service.RequireAdmin(domain.UserID(userID)); err != nil {
+		problem, _ := MapError(err, instance)
+		return SetMaintenanceMode403ApplicationProblemPlusJSONResponse(problem), nil
+	}
+
+	h.service.SetMaintenanceMode(request.Body.Enabled)
+
+	return SetMaintenanceMode200JSONResponse{Enabled: h.service.InMaintenanceMode()}, nil
+}
+
 // Helper functions
 
+// optionalString returns nil for an empty s, so that it is omitted from responses.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 func domainAccountToAPI(acc *domain.Account) Account {
 	return Account{
-		Id:      ptr(openapi_types.UUID(acc.ID())),
-		UserId:  ptr(openapi_types.UUID(acc.UserID())),
-		Balance: domainMoneyToAPI(acc.Balance()),
+		Id:       ptr(openapi_types.UUID(acc.ID())),
+		UserId:   ptr(openapi_types.UUID(acc.UserID())),
+		Balance:  domainMoneyToAPI(acc.Balance()),
+		IsClosed: ptr(acc.IsClosed()),
+		Status:   ptr(AccountStatus(acc.Status())),
+		Label:    optionalString(acc.Label()),
+	}
+}
+
+func cashResultToAPI(accountID openapi_types.UUID, result *service.CashResult) CashResponse {
+	return CashResponse{
+		TransactionId: ptr(openapi_types.UUID(result.TransactionID)),
+		AccountId:     ptr(accountID),
+		Amount:        domainMoneyToAPI(result.Amount),
+		Balance:       domainMoneyToAPI(result.Balance),
+		Timestamp:     ptr(result.Time),
 	}
 }
 
+func batchTransferResultToAPI(result *service.BatchTransferResult, committed bool, instance string) BatchTransferResponse {
+	response := BatchTransferResponse{
+		Committed: committed,
+		Transfers: make([]BatchTransferOutcome, 0, len(result.Transfers)),
+	}
+
+	for i, transfer := range result.Transfers {
+		outcome := BatchTransferOutcome{
+			Index:  i,
+			Status: BatchTransferOutcomeStatus(transfer.Status),
+		}
+		if transfer.Status == service.BatchTransferSucceeded {
+			outcome.TransactionId = ptr(openapi_types.UUID(transfer.TransactionID))
+		}
+		if transfer.Err != nil {
+			problem, _ := MapError(transfer.Err, instance)
+			outcome.Error = &problem
+		}
+		response.Transfers = append(response.Transfers, outcome)
+	}
+
+	return response
+}
+
 func domainMoneyToAPI(m domain.Money) *Money {
 	return &Money{
 		Amount:   ptr(m.Amount().String()),
@@ -467,23 +1586,34 @@ func domainMoneyToAPI(m domain.Money) *Money {
 	}
 }
 
-func domainTransactionToAPI(tx *domain.TransactionWithDetails) Transaction {
+// localizedMoneyToAPI maps m like domainMoneyToAPI and, when a locale was
+// requested, adds its display form.
+func localizedMoneyToAPI(m domain.Money, locale *language.Tag) *Money {
+	result := domainMoneyToAPI(m)
+	if locale != nil {
+		result.Formatted = ptr(FormatMoney(m, *locale))
+	}
+
+	return result
+}
+
+func domainTransactionToAPI(tx *domain.TransactionWithDetails, locale *language.Tag) Transaction {
 	result := Transaction{
 		Id:        ptr(openapi_types.UUID(tx.Transaction().ID())),
 		Type:      ptr(TransactionType(tx.Transaction().Type())),
 		AccountId: ptr(openapi_types.UUID(tx.Transaction().Account())),
 		Timestamp: ptr(tx.Transaction().Time()),
 	}
+	if tx.Inconsistency() != nil {
+		result.DetailsUnavailable = ptr(true)
+	}
 
 	// Map transfer details if present
 	if td := tx.TransferDetails(); td != nil {
 		result.TransferDetails = &TransferDetails{
 			Id:                 ptr(openapi_types.UUID(td.ID())),
 			RecipientAccountId: ptr(openapi_types.UUID(td.RecipientAccount())),
-			Amount: &Money{
-				Amount:   ptr(td.Amount().Amount().String()),
-				Currency: ptr(Currency(td.Amount().Currency())),
-			},
+			Amount:             localizedMoneyToAPI(td.Amount(), locale),
 		}
 	}
 
@@ -493,30 +1623,19 @@ func domainTransactionToAPI(tx *domain.TransactionWithDetails) Transaction {
 			Id:              ptr(openapi_types.UUID(ed.ID())),
 			SourceAccountId: ptr(openapi_types.UUID(ed.SourceAccount())),
 			TargetAccountId: ptr(openapi_types.UUID(ed.TargetAccount())),
-			SourceAmount: &Money{
-				Amount:   ptr(ed.SourceAmount().Amount().String()),
-				Currency: ptr(Currency(ed.SourceAmount().Currency())),
-			},
-			TargetAmount: &Money{
-				Amount:   ptr(ed.TargetAmount().Amount().String()),
-				Currency: ptr(Currency(ed.TargetAmount().Currency())),
-			},
-			ExchangeRate: ptr(ed.ExchangeRate().String()),
+			SourceAmount:    localizedMoneyToAPI(ed.SourceAmount(), locale),
+			TargetAmount:    localizedMoneyToAPI(ed.TargetAmount(), locale),
+			ExchangeRate:    ptr(ed.ExchangeRate().String()),
 		}
 	}
 
 	return result
 }
 
+// mapAPICurrencyToDomain parses a currency given in a request. Clients may pad
+// it or use lower case, like " usd ".
 func mapAPICurrencyToDomain(c Currency) (domain.Currency, error) {
-	switch c {
-	case USD:
-		return domain.CurrencyUSD, nil
-	case EUR:
-		return domain.CurrencyEUR, nil
-	default:
-		return "", domain.NewUnsupportedCurrencyError(domain.Currency(c))
-	}
+	return domain.ParseSupportedCurrency(string(c))
 }
 
 func mapAPITransactionTypeToDomain(t TransactionType) (domain.TransactionType, error) {
@@ -535,5 +1654,5 @@ func mapAPITransactionTypeToDomain(t TransactionType) (domain.TransactionType, e
 }
 
 func parseDecimalAmount(amount string) (decimal.Decimal, error) {
-	return decimal.NewFromString(amount)
+	return domain.ParseAmount(amount)
 }
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// readinessTimeout bounds how long the readiness probe waits for the database.
+const readinessTimeout = 2 * time.Second
+
+// HealthChecker verifies that the application's dependencies are usable.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// HealthCheckFunc adapts a function such as pgxpool.Pool.Ping to HealthChecker.
+type HealthCheckFunc func(ctx context.Context) error
+
+func (f HealthCheckFunc) HealthCheck(ctx context.Context) error {
+	return f(ctx)
+}
+
+// LivenessHandler responds 200 as long as the server is able to serve requests.
+func LivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// ReadinessHandler responds 200 when the health check passes and 503 with
+// ProblemDetails otherwise.
+func ReadinessHandler(checker HealthChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+
+		if err := checker.HealthCheck(ctx); err != nil {
+			writeProblem(w, ProblemDetails{
+				Type:     problemBaseURL + "not-ready",
+				Title:    "Service Unavailable",
+				Status:   http.StatusServiceUnavailable,
+				Detail:   ptr("Database is not available"),
+				Instance: ptr(r.URL.Path),
+			})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
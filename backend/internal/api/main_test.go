@@ -0,0 +1,109 @@
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// The database is started by the first test that needs it, so the handler
+// tests that don't can run without Docker.
+var (
+	testDatabaseOnce sync.Once
+	testContainer    *postgres.PostgresContainer
+	testPool         *pgxpool.Pool
+	testDatabaseErr  error
+)
+
+func TestMain(m *testing.M) {
+	exitCode := m.Run()
+
+	if testPool != nil {
+		testPool.Close()
+	}
+
+	if testContainer != nil {
+		if err := testContainer.Terminate(context.Background()); err != nil {
+			log.Printf("failed to terminate container: %v", err)
+		}
+	}
+
+	os.Exit(exitCode)
+}
+
+// databasePool returns the pool of a migrated postgres container.
+func databasePool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	testDatabaseOnce.Do(func() {
+		testDatabaseErr = startDatabase(context.Background())
+	})
+	require.NoError(t, testDatabaseErr, "starting postgres container")
+
+	return testPool
+}
+
+func startDatabase(ctx context.Context) (err error) {
+	// testcontainers panics when there is no Docker; fail the tests that need
+	// the database rather than the whole package.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	testContainer, err = postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:16"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(30*time.Second)),
+	)
+	if err != nil {
+		return err
+	}
+
+	postgresURL, err := testContainer.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		return err
+	}
+
+	testPool, err = pgxpool.New(ctx, postgresURL)
+	if err != nil {
+		return err
+	}
+
+	return applyMigrations(ctx, testPool)
+}
+
+func applyMigrations(ctx context.Context, pool *pgxpool.Pool) error {
+	migrations, err := filepath.Glob(filepath.Join("..", "..", "migrations", "*.up.sql"))
+	if err != nil {
+		return err
+	}
+
+	for _, migrationPath := range migrations {
+		migration, err := os.ReadFile(migrationPath)
+		if err != nil {
+			return err
+		}
+
+		_, err = pool.Exec(ctx, string(migration))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
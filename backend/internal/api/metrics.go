@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// unmatchedRoute labels requests that never reached a route, because none
+// matched or a middleware such as AuthMiddleware answered first. Probing random
+// URLs therefore doesn't create a series per URL.
+const unmatchedRoute = "unmatched"
+
+// PrometheusMiddleware records request counts, latencies and in-flight
+// requests in reg. Requests are labeled with their chi route pattern, such as
+// /accounts/{accountId}, rather than the raw URL.
+func PrometheusMiddleware(reg prometheus.Registerer) func(http.Handler) http.Handler {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Number of HTTP requests handled, by method, route and status code.",
+	}, []string{"method", "path", "status"})
+
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time taken to handle HTTP requests, by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "path"})
+
+	inFlight := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "Number of HTTP requests currently being handled.",
+	})
+
+	reg.MustRegister(requests, duration, inFlight)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			inFlight.Inc()
+			defer inFlight.Dec()
+
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+
+			// The route pattern is only known once chi has routed the request.
+			path := unmatchedRoute
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				path = rctx.RoutePattern()
+			}
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			requests.WithLabelValues(r.Method, path, strconv.Itoa(status)).Inc()
+			duration.WithLabelValues(r.Method, path).Observe(time.Since(start).Seconds())
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"minibankingplatform/internal/api"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusMiddleware(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	reg := prometheus.NewRegistry()
+	router := chi.NewRouter()
+	router.Use(api.PrometheusMiddleware(reg))
+	router.Get("/accounts/{accountId}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	router.Post("/transactions/transfer", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	})
+
+	// Act
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/accounts/6a1f1e4e-0d55-4c4f-9d58-1b1f7f3e2a11", nil),
+		httptest.NewRequest(http.MethodGet, "/accounts/3c7d0c7a-6f7e-4d2b-8f0a-2b6c1e9d4f22", nil),
+		httptest.NewRequest(http.MethodPost, "/transactions/transfer", nil),
+		httptest.NewRequest(http.MethodGet, "/wp-admin", nil),
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// Assert
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	assert.Equal(t, 2.0, counterValue(t, families, "http_requests_total", "GET", "/accounts/{accountId}", "200"),
+		"requests are labeled with the route pattern, not the raw URL")
+	assert.Equal(t, 1.0, counterValue(t, families, "http_requests_total", "POST", "/transactions/transfer", "422"))
+	assert.Equal(t, 1.0, counterValue(t, families, "http_requests_total", "GET", "unmatched", "404"))
+
+	requireFamily(t, families, "http_request_duration_seconds")
+	inFlight := requireFamily(t, families, "http_requests_in_flight")
+	assert.Zero(t, inFlight.GetMetric()[0].GetGauge().GetValue())
+}
+
+func requireFamily(t *testing.T, families []*dto.MetricFamily, name string) *dto.MetricFamily {
+	t.Helper()
+
+	for _, family := range families {
+		if family.GetName() == name {
+			return family
+		}
+	}
+	require.Failf(t, "metric not found", "%s is not registered", name)
+	return nil
+}
+
+// counterValue returns the value of the counter labeled with method, path and
+// status, or zero when it was never incremented.
+func counterValue(t *testing.T, families []*dto.MetricFamily, name, method, path, status string) float64 {
+	t.Helper()
+
+	want := map[string]string{"method": method, "path": path, "status": status}
+	for _, metric := range requireFamily(t, families, name).GetMetric() {
+		labels := make(map[string]string)
+		for _, label := range metric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		if assert.ObjectsAreEqual(want, labels) {
+			return metric.GetCounter().GetValue()
+		}
+	}
+	return 0
+}
@@ -2,20 +2,37 @@ package api
 
 import (
 	"encoding/json"
+	"math"
+	"mime"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"minibankingplatform/pkg/jwt"
+
+	"github.com/go-chi/chi/v5/middleware"
 )
 
-// publicPaths are endpoints that don't require authentication.
+// publicPaths are endpoints that don't require authentication. /auth/refresh
+// validates the possibly expired token itself.
 var publicPaths = map[string]bool{
 	"/auth/login":    true,
 	"/auth/register": true,
+	"/auth/refresh":  true,
+	"/health":        true,
+	"/ready":         true,
+	"/metrics":       true,
+}
+
+// TokenValidator validates bearer tokens and returns their claims.
+type TokenValidator interface {
+	ValidateToken(tokenString string) (*jwt.Claims, error)
 }
 
 // AuthMiddleware creates a middleware that validates JWT tokens and injects claims into context.
-func AuthMiddleware(tm *jwt.TokenManager) func(http.Handler) http.Handler {
+func AuthMiddleware(tm TokenValidator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Skip authentication for public paths
@@ -58,17 +75,120 @@ func AuthMiddleware(tm *jwt.TokenManager) func(http.Handler) http.Handler {
 	}
 }
 
+// RateLimiter admits a limited number of requests per key. Rejected requests
+// come with the time after which the key is admitted again.
+type RateLimiter interface {
+	Allow(key string) (allowed bool, retryAfter time.Duration)
+}
+
+// LoginRateLimit limits login attempts per client IP, answering attempts over
+// the limit with 429 Too Many Requests and a Retry-After header. The client IP
+// is the request's remote address, which middleware.RealIP sets from proxy
+// headers.
+func LoginRateLimit(limiter RateLimiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.URL.Path != "/auth/login" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			allowed, retryAfter := limiter.Allow(clientIP(r))
+			if allowed {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			retryAfterSeconds := max(1, int(math.Ceil(retryAfter.Seconds())))
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+			writeProblem(w, ProblemDetails{
+				Type:     problemBaseURL + "too-many-requests",
+				Title:    "Too Many Requests",
+				Status:   http.StatusTooManyRequests,
+				Detail:   ptr("Too many login attempts, please retry later"),
+				Instance: ptr(r.URL.Path),
+			})
+		})
+	}
+}
+
+// clientIP returns the host of the remote address, which is the bare IP once
+// middleware.RealIP has run and host:port otherwise.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// RequireJSONContentType rejects write requests whose body is not declared as JSON
+// with 415 Unsupported Media Type.
+func RequireJSONContentType(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !hasRequestBody(r) || isJSONContentType(r.Header.Get("Content-Type")) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		writeProblem(w, ProblemDetails{
+			Type:     problemBaseURL + "unsupported-media-type",
+			Title:    "Unsupported Media Type",
+			Status:   http.StatusUnsupportedMediaType,
+			Detail:   ptr("Request body must be sent with Content-Type application/json"),
+			Instance: ptr(r.URL.Path),
+		})
+	})
+}
+
+// compressibleContentTypes are the response bodies worth compressing: JSON
+// payloads, problem details and CSV exports.
+var compressibleContentTypes = []string{
+	"application/json",
+	"application/problem+json",
+	"text/csv",
+}
+
+// Compress encodes responses with gzip or deflate when the client advertises
+// support in Accept-Encoding. Level follows compress/flate; 0 disables compression.
+func Compress(level int) func(http.Handler) http.Handler {
+	if level == 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	return middleware.Compress(level, compressibleContentTypes...)
+}
+
+func hasRequestBody(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return r.ContentLength != 0
+	default:
+		return false
+	}
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
 // writeUnauthorized writes a 401 response with ProblemDetails.
 func writeUnauthorized(w http.ResponseWriter, instance string, detail string) {
-	problem := ProblemDetails{
+	writeProblem(w, ProblemDetails{
 		Type:     problemBaseURL + "unauthorized",
 		Title:    "Unauthorized",
 		Status:   http.StatusUnauthorized,
 		Detail:   ptr(detail),
 		Instance: ptr(instance),
-	}
+	})
+}
 
+// writeProblem writes the ProblemDetails with its status code.
+func writeProblem(w http.ResponseWriter, problem ProblemDetails) {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(http.StatusUnauthorized)
+	w.WriteHeader(problem.Status)
 	_ = json.NewEncoder(w).Encode(problem)
 }
@@ -0,0 +1,164 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/api"
+	"minibankingplatform/internal/infrastructure"
+	"minibankingplatform/pkg/jwt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireJSONContentType(t *testing.T) {
+	t.Parallel()
+
+	const transferBody = `{"fromAccountId":"6a1f1e4e-0d55-4c4f-9d58-1b1f7f3e2a11","toAccountId":"3c7d0c7a-6f7e-4d2b-8f0a-2b6c1e9d4f22","amount":"10.00","currency":"USD"}`
+
+	tests := []struct {
+		name           string
+		method         string
+		contentType    string
+		body           string
+		expectedStatus int
+	}{
+		{name: "transfer with text/plain is rejected", method: http.MethodPost, contentType: "text/plain", body: transferBody, expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "transfer with form encoding is rejected", method: http.MethodPost, contentType: "application/x-www-form-urlencoded", body: "amount=10", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "transfer without content type is rejected", method: http.MethodPost, body: transferBody, expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "transfer with json is accepted", method: http.MethodPost, contentType: "application/json", body: transferBody, expectedStatus: http.StatusOK},
+		{name: "json with charset is accepted", method: http.MethodPost, contentType: "application/json; charset=utf-8", body: transferBody, expectedStatus: http.StatusOK},
+		{name: "get without body is accepted", method: http.MethodGet, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			handler := api.RequireJSONContentType(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(tt.method, "/transactions/transfer", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus != http.StatusUnsupportedMediaType {
+				return
+			}
+
+			assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+			var problem api.ProblemDetails
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+			assert.Equal(t, http.StatusUnsupportedMediaType, problem.Status)
+			assert.Equal(t, "https://minibankingplatform.com/problems/unsupported-media-type", problem.Type)
+		})
+	}
+}
+
+func TestAuthMiddleware_RefreshIsPublic(t *testing.T) {
+	t.Parallel()
+
+	// Requests without a usable bearer token are refused before the service is used
+	router := chi.NewRouter()
+	router.Use(api.AuthMiddleware(jwt.NewTokenManager("test-secret-key", time.Hour)))
+	api.HandlerFromMux(api.NewStrictHandler(api.NewAPIHandler(nil), nil), router)
+
+	tests := []struct {
+		name          string
+		authorization string
+	}{
+		{name: "missing header"},
+		{name: "not a bearer token", authorization: "Basic dXNlcjpwYXNz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			request := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+			recorder := httptest.NewRecorder()
+
+			router.ServeHTTP(recorder, request)
+
+			// The handler, not the middleware, answers
+			require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			var problem api.ProblemDetails
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+			assert.Equal(t, "Authentication required", *problem.Detail)
+			assert.Equal(t, "/auth/refresh", *problem.Instance)
+		})
+	}
+}
+
+func TestLoginRateLimit(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	const limit = 10
+	handler := api.LoginRateLimit(infrastructure.NewInMemoryRateLimiter(limit, time.Minute))(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	login := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(`{}`))
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Act
+	statuses := make([]int, 0, 20)
+	var limited *httptest.ResponseRecorder
+	for range 20 {
+		rec := login("203.0.113.7:51234")
+		statuses = append(statuses, rec.Code)
+		if rec.Code == http.StatusTooManyRequests && limited == nil {
+			limited = rec
+		}
+	}
+
+	// Assert
+	for i, status := range statuses {
+		if i < limit {
+			assert.Equal(t, http.StatusOK, status, "attempt %d", i+1)
+		} else {
+			assert.Equal(t, http.StatusTooManyRequests, status, "attempt %d", i+1)
+		}
+	}
+
+	require.NotNil(t, limited)
+	assert.Equal(t, "60", limited.Header().Get("Retry-After"))
+	var problem api.ProblemDetails
+	require.NoError(t, json.NewDecoder(limited.Body).Decode(&problem))
+	assert.Equal(t, "https://minibankingplatform.com/problems/too-many-requests", problem.Type)
+
+	// Other clients and other endpoints are not limited
+	assert.Equal(t, http.StatusOK, login("198.51.100.2:40000").Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", strings.NewReader(`{}`))
+	req.RemoteAddr = "203.0.113.7:51234"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
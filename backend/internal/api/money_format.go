@@ -0,0 +1,86 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"minibankingplatform/internal/domain"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+var currencySymbols = map[domain.Currency]string{
+	domain.CurrencyUSD: "$",
+	domain.CurrencyEUR: "€",
+	domain.CurrencyGBP: "£",
+}
+
+// symbolAfterAmount lists the languages that write the currency symbol after
+// the amount, separated by a space.
+var symbolAfterAmount = map[language.Base]bool{
+	language.MustParseBase("de"): true,
+	language.MustParseBase("fr"): true,
+	language.MustParseBase("es"): true,
+	language.MustParseBase("it"): true,
+	language.MustParseBase("pl"): true,
+	language.MustParseBase("cs"): true,
+	language.MustParseBase("fi"): true,
+	language.MustParseBase("sv"): true,
+}
+
+// FormatMoney renders m for display in the given locale, e.g. $1,000.50 for
+// en-US and 1.000,50 € for de-DE. Digit grouping and the decimal separator come
+// from x/text; the symbol placement is decided by symbolAfterAmount.
+func FormatMoney(m domain.Money, locale language.Tag) string {
+	printer := message.NewPrinter(locale)
+	decimals := m.Currency().MinorUnitDecimals()
+	amount := m.Amount().Round(decimals)
+
+	whole := amount.Truncate(0).Abs()
+	formatted := printer.Sprint(number.Decimal(whole.IntPart()))
+	if decimals > 0 {
+		fraction := amount.Abs().Sub(whole).Shift(decimals)
+		formatted += decimalSeparator(printer) + fmt.Sprintf("%0*d", decimals, fraction.IntPart())
+	}
+
+	sign := ""
+	if amount.IsNegative() {
+		sign = "-"
+	}
+
+	symbol, ok := currencySymbols[m.Currency()]
+	if !ok {
+		symbol = m.Currency().String()
+	}
+
+	base, _ := locale.Base()
+	if symbolAfterAmount[base] {
+		return sign + formatted + " " + symbol
+	}
+
+	return sign + symbol + formatted
+}
+
+// decimalSeparator returns the separator the printer's locale puts between the
+// integer and fractional digits.
+func decimalSeparator(printer *message.Printer) string {
+	return strings.TrimFunc(printer.Sprint(number.Decimal(0.5, number.Scale(1))), unicode.IsDigit)
+}
+
+// parseLocale parses the optional ?locale= parameter. Unparseable values are
+// ignored the same way an unknown transaction type filter is.
+func parseLocale(param *string) *language.Tag {
+	if param == nil || *param == "" {
+		return nil
+	}
+
+	tag, err := language.Parse(*param)
+	if err != nil {
+		return nil
+	}
+
+	return &tag
+}
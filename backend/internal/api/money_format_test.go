@@ -0,0 +1,48 @@
+package api_test
+
+import (
+	"testing"
+
+	"minibankingplatform/internal/api"
+	"minibankingplatform/internal/domain"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestFormatMoney(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		amount   string
+		currency domain.Currency
+		locale   string
+		expected string
+	}{
+		{name: "USD in en-US", amount: "1000.50", currency: domain.CurrencyUSD, locale: "en-US", expected: "$1,000.50"},
+		{name: "EUR in de-DE", amount: "1000.50", currency: domain.CurrencyEUR, locale: "de-DE", expected: "1.000,50 €"},
+		{name: "whole amount gets minor units", amount: "1000", currency: domain.CurrencyUSD, locale: "en-US", expected: "$1,000.00"},
+		{name: "negative amount", amount: "-1234567.8", currency: domain.CurrencyEUR, locale: "de-DE", expected: "-1.234.567,80 €"},
+		{name: "GBP in en-GB", amount: "300", currency: domain.CurrencyGBP, locale: "en-GB", expected: "£300.00"},
+		{name: "small amount", amount: "0.05", currency: domain.CurrencyEUR, locale: "en-US", expected: "€0.05"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			money, err := domain.NewMoney(decimal.RequireFromString(tt.amount), tt.currency)
+			require.NoError(t, err)
+
+			// Act
+			formatted := api.FormatMoney(money, language.MustParse(tt.locale))
+
+			// Assert
+			assert.Equal(t, tt.expected, formatted)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package api_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/api"
+	"minibankingplatform/internal/infrastructure"
+	"minibankingplatform/internal/service"
+	"minibankingplatform/pkg/jwt"
+	"minibankingplatform/pkg/trm"
+	"minibankingplatform/pkg/trm/pgxfactory"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDatabaseService wires a Service with real repositories on the pool.
+func newDatabaseService(t *testing.T, pool *pgxpool.Pool) *service.Service {
+	t.Helper()
+
+	factory, err := pgxfactory.New(context.Background(), pool)
+	require.NoError(t, err)
+
+	injector := trm.NewInjector[infrastructure.DBTX](pool)
+
+	return service.NewService(
+		trm.NewTransactionManager(factory),
+		service.Repositories{
+			Users:               infrastructure.NewUsersRepository(injector),
+			Accounts:            infrastructure.NewAccountsRepository(injector),
+			Transfers:           infrastructure.NewTransfersRepository(injector),
+			Exchanges:           infrastructure.NewExchangesRepository(injector),
+			Transactions:        infrastructure.NewTransactionsRepository(injector),
+			Ledger:              infrastructure.NewLedgerRepository(injector),
+			Health:              infrastructure.NewHealthRepository(injector),
+			IdempotencyKeys:     infrastructure.NewIdempotencyKeysRepository(injector),
+			ExchangeRateHistory: infrastructure.NewExchangeRateHistoryRepository(injector),
+		},
+		infrastructure.NewFixedExchangeRateProvider(decimal.NewFromFloat(0.92)),
+		jwt.NewTokenManager("test-secret-key", time.Hour),
+	)
+}
+
+// testUser holds a registered user and the IDs of their accounts.
+type testUser struct {
+	UserID       uuid.UUID
+	Email        string
+	USDAccountID uuid.UUID
+	EURAccountID uuid.UUID
+}
+
+// registerUser registers a new user, who gets 1000 USD and 500 EUR from the cashbooks.
+func registerUser(ctx context.Context, t *testing.T, svc *service.Service, pool *pgxpool.Pool) *testUser {
+	t.Helper()
+
+	email := uuid.New().String() + "@test.com"
+	result, err := svc.Register(ctx, &service.RegisterCommand{
+		Email:    email,
+		Password: "testpassword123",
+	})
+	require.NoError(t, err)
+
+	user := &testUser{UserID: result.UserID, Email: email}
+	err = pool.QueryRow(ctx, `SELECT id FROM accounts WHERE user_id = $1 AND currency = 'USD'`, result.UserID).Scan(&user.USDAccountID)
+	require.NoError(t, err)
+	err = pool.QueryRow(ctx, `SELECT id FROM accounts WHERE user_id = $1 AND currency = 'EUR'`, result.UserID).Scan(&user.EURAccountID)
+	require.NoError(t, err)
+
+	return user
+}
+
+func assertBalance(ctx context.Context, t *testing.T, pool *pgxpool.Pool, accountID uuid.UUID, expected int64) {
+	t.Helper()
+
+	var balance decimal.Decimal
+	err := pool.QueryRow(ctx, `SELECT balance FROM accounts WHERE id = $1`, accountID).Scan(&balance)
+	require.NoError(t, err)
+	assert.True(t, balance.Equal(decimal.NewFromInt(expected)), "expected %d, got %s", expected, balance)
+}
+
+func TestAPI_UserCannotDrainAnotherUsersAccount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	pool := databasePool(t)
+	svc := newDatabaseService(t, pool)
+	handler := api.NewAPIHandler(svc)
+
+	// Arrange - register two users (each gets 1000 USD, 500 EUR)
+	victim := registerUser(ctx, t, svc, pool)
+	attacker := registerUser(ctx, t, svc, pool)
+	attackerCtx := api.ContextWithClaims(ctx, &jwt.Claims{UserID: attacker.UserID, Email: attacker.Email})
+
+	t.Run("balance", func(t *testing.T) {
+		resp, err := handler.GetAccountBalance(attackerCtx, api.GetAccountBalanceRequestObject{
+			AccountId: victim.USDAccountID,
+		})
+
+		require.NoError(t, err)
+		assert.IsType(t, api.GetAccountBalance403ApplicationProblemPlusJSONResponse{}, resp)
+	})
+
+	t.Run("transfer", func(t *testing.T) {
+		resp, err := handler.Transfer(attackerCtx, api.TransferRequestObject{
+			Body: &api.TransferRequest{
+				FromAccountId: victim.USDAccountID,
+				ToAccountId:   attacker.USDAccountID,
+				Amount:        "1000.00",
+			},
+		})
+
+		require.NoError(t, err)
+		assert.IsType(t, api.Transfer403ApplicationProblemPlusJSONResponse{}, resp)
+	})
+
+	t.Run("exchange", func(t *testing.T) {
+		resp, err := handler.Exchange(attackerCtx, api.ExchangeRequestObject{
+			Body: &api.ExchangeRequest{
+				SourceAccountId: victim.USDAccountID,
+				TargetAccountId: attacker.EURAccountID,
+				Amount:          "1000.00",
+			},
+		})
+
+		require.NoError(t, err)
+		assert.IsType(t, api.Exchange403ApplicationProblemPlusJSONResponse{}, resp)
+	})
+
+	t.Run("exchange into another user's account", func(t *testing.T) {
+		resp, err := handler.Exchange(attackerCtx, api.ExchangeRequestObject{
+			Body: &api.ExchangeRequest{
+				SourceAccountId: attacker.USDAccountID,
+				TargetAccountId: victim.EURAccountID,
+				Amount:          "100.00",
+			},
+		})
+
+		require.NoError(t, err)
+		assert.IsType(t, api.Exchange403ApplicationProblemPlusJSONResponse{}, resp)
+	})
+
+	// Assert - the victim's money stayed where it was
+	assertBalance(ctx, t, pool, victim.USDAccountID, 1000)
+	assertBalance(ctx, t, pool, victim.EURAccountID, 500)
+	assertBalance(ctx, t, pool, attacker.USDAccountID, 1000)
+	require.NoError(t, svc.CheckLedgerBalanceByCurrency(ctx))
+}
@@ -0,0 +1,95 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/api"
+	"minibankingplatform/pkg/jwt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOversizedFieldsFailValidation(t *testing.T) {
+	t.Parallel()
+
+	tokenManager := jwt.NewTokenManager("test-secret-key", time.Hour)
+	token, err := tokenManager.GenerateToken(uuid.New(), "user@example.com")
+	require.NoError(t, err)
+
+	// Validation runs before the service is used, so no service is needed
+	router := chi.NewRouter()
+	router.Use(api.AuthMiddleware(tokenManager))
+	api.HandlerFromMux(api.NewStrictHandler(api.NewAPIHandler(nil), nil), router)
+
+	oversizedEmail := strings.Repeat("a", 1<<20) + "@example.com"
+	oversizedAmount := "1" + strings.Repeat("0", 1<<20)
+
+	tests := []struct {
+		name string
+		path string
+		body map[string]string
+	}{
+		{
+			name: "register with oversized email",
+			path: "/auth/register",
+			body: map[string]string{"email": oversizedEmail, "password": "securePassword123"},
+		},
+		{
+			name: "login with oversized email",
+			path: "/auth/login",
+			body: map[string]string{"email": oversizedEmail, "password": "securePassword123"},
+		},
+		{
+			name: "transfer with oversized amount",
+			path: "/transactions/transfer",
+			body: map[string]string{
+				"fromAccountId": uuid.NewString(),
+				"toAccountId":   uuid.NewString(),
+				"amount":        oversizedAmount,
+				"currency":      "USD",
+			},
+		},
+		{
+			name: "exchange with oversized amount",
+			path: "/transactions/exchange",
+			body: map[string]string{
+				"sourceAccountId": uuid.NewString(),
+				"targetAccountId": uuid.NewString(),
+				"amount":          oversizedAmount,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			body, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			request := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(string(body)))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", "Bearer "+token)
+			recorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(recorder, request)
+
+			// Assert
+			require.Equal(t, http.StatusBadRequest, recorder.Code, recorder.Body.String())
+
+			var problem api.ProblemDetails
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+			assert.Equal(t, "https://minibankingplatform.com/problems/validation-error", problem.Type)
+		})
+	}
+}
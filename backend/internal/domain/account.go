@@ -1,19 +1,47 @@
 package domain
 
+//go:generate go tool go-enum --marshal --names --values
+
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 type AccountID uuid.UUID
 type UserID uuid.UUID
 type Version uint64
 
+// ENUM(active, frozen, closed)
+type AccountStatus string
+
+// AccountLabel is the display name a user gives an account, such as
+// "Holidays". An empty label means the account has none.
+type AccountLabel = string
+
+// MaxAccountLabelLength is the longest label, in characters, an account can have.
+const MaxAccountLabelLength = 100
+
+// ParseAccountLabel trims surrounding whitespace from raw and checks it fits
+// MaxAccountLabelLength.
+func ParseAccountLabel(raw string) (AccountLabel, error) {
+	label := strings.TrimSpace(raw)
+	if utf8.RuneCountInString(label) > MaxAccountLabelLength {
+		return "", NewInvalidAccountLabelError(label)
+	}
+	return label, nil
+}
+
 type Account struct {
-	id      AccountID
-	userID  UserID
-	balance Money
+	id         AccountID
+	userID     UserID
+	balance    Money
+	status     AccountStatus
+	dailyLimit *decimal.Decimal
+	label      AccountLabel
 }
 
 func NewAccount(id AccountID, userID UserID, balance Money) *Account {
@@ -21,6 +49,17 @@ func NewAccount(id AccountID, userID UserID, balance Money) *Account {
 		id:      id,
 		userID:  userID,
 		balance: balance,
+		status:  AccountStatusActive,
+	}
+}
+
+func NewAccountFromDB(id AccountID, userID UserID, balance Money, status AccountStatus, dailyLimit *decimal.Decimal) *Account {
+	return &Account{
+		id:         id,
+		userID:     userID,
+		balance:    balance,
+		status:     status,
+		dailyLimit: dailyLimit,
 	}
 }
 
@@ -36,11 +75,99 @@ func (a *Account) Balance() Money {
 	return a.balance
 }
 
+func (a *Account) Status() AccountStatus {
+	return a.status
+}
+
+func (a *Account) Label() AccountLabel {
+	return a.label
+}
+
+// WithLabel sets the account's label, or removes it when label is empty, and
+// returns the account. The label is expected to come from ParseAccountLabel
+// or storage.
+func (a *Account) WithLabel(label AccountLabel) *Account {
+	a.label = label
+	return a
+}
+
+// DailyLimit is the most that can leave the account per UTC day through
+// transfers, withdrawals and exchanges, in its currency. It is nil when the
+// outflow is not limited.
+func (a *Account) DailyLimit() *decimal.Decimal {
+	if a.dailyLimit == nil {
+		return nil
+	}
+	limit := *a.dailyLimit
+	return &limit
+}
+
+// SetDailyLimit sets the daily outflow limit, or removes it when limit is nil.
+func (a *Account) SetDailyLimit(limit *decimal.Decimal) error {
+	if limit == nil {
+		a.dailyLimit = nil
+		return nil
+	}
+
+	if limit.IsNegative() {
+		return NewInvalidDailyLimitError(a.id, *limit)
+	}
+
+	copied := *limit
+	a.dailyLimit = &copied
+
+	return nil
+}
+
+func (a *Account) IsClosed() bool {
+	return a.status == AccountStatusClosed
+}
+
+// Freeze stops the account from being debited until it is unfrozen. Closed
+// accounts cannot be frozen.
+func (a *Account) Freeze() error {
+	if a.status == AccountStatusClosed {
+		return NewClosedAccountError(a.id)
+	}
+
+	a.status = AccountStatusFrozen
+
+	return nil
+}
+
+// Unfreeze lets a frozen account be debited again. Active accounts are left as
+// they are; closed accounts cannot be reopened.
+func (a *Account) Unfreeze() error {
+	if a.status == AccountStatusClosed {
+		return NewClosedAccountError(a.id)
+	}
+
+	a.status = AccountStatusActive
+
+	return nil
+}
+
+// Close marks the account as closed. Closed accounts are hidden from listings
+// and can no longer be debited or credited. Only an empty account can be closed.
+func (a *Account) Close() error {
+	if !a.balance.IsZero() {
+		return NewAccountNotEmptyError(a.id, a.balance)
+	}
+
+	a.status = AccountStatusClosed
+
+	return nil
+}
+
 func (a *Account) IsCashbook() bool {
 	return a.userID == CashbookUserID
 }
 
 func (a *Account) Credit(money Money) error {
+	if a.status == AccountStatusClosed {
+		return NewClosedAccountError(a.id)
+	}
+
 	updated, err := a.balance.Add(money)
 	if err != nil {
 		return fmt.Errorf("money adding money to account: %w", err)
@@ -52,8 +179,20 @@ func (a *Account) Credit(money Money) error {
 }
 
 func (a *Account) Debit(money Money) error {
-	if !a.IsCashbook() && a.balance.Amount().LessThan(money.Amount()) {
-		return NewInsufficientFundsError(a.id, money.Amount(), a.balance.Amount())
+	switch a.status {
+	case AccountStatusFrozen:
+		return NewFrozenAccountError(a.id)
+	case AccountStatusClosed:
+		return NewClosedAccountError(a.id)
+	}
+
+	insufficient, err := a.balance.LessThan(money)
+	if err != nil {
+		return fmt.Errorf("debiting account %s: %w", a.id, err)
+	}
+
+	if !a.IsCashbook() && insufficient {
+		return NewInsufficientFundsError(a.id, money, a.balance)
 	}
 
 	updated, err := a.balance.Sub(money)
@@ -0,0 +1,250 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"minibankingplatform/internal/domain"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAccount(t *testing.T, balance int64) *domain.Account {
+	t.Helper()
+
+	money, err := domain.NewMoney(decimal.NewFromInt(balance), domain.CurrencyUSD)
+	require.NoError(t, err)
+
+	return domain.NewAccount(domain.GenerateAccountID(), domain.UserID{1}, money)
+}
+
+func TestAccount_DebitDependsOnStatus(t *testing.T) {
+	t.Parallel()
+
+	amount, err := domain.NewMoney(decimal.NewFromInt(10), domain.CurrencyUSD)
+	require.NoError(t, err)
+
+	t.Run("active", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		account := newTestAccount(t, 100)
+
+		// Act
+		err := account.Debit(amount)
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, account.Balance().Amount().Equal(decimal.NewFromInt(90)))
+	})
+
+	t.Run("frozen", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		account := newTestAccount(t, 100)
+		require.NoError(t, account.Freeze())
+
+		// Act
+		err := account.Debit(amount)
+
+		// Assert
+		var frozenErr *domain.FrozenAccountError
+		require.ErrorAs(t, err, &frozenErr)
+		assert.True(t, account.Balance().Amount().Equal(decimal.NewFromInt(100)))
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		account := newTestAccount(t, 0)
+		require.NoError(t, account.Close())
+
+		// Act
+		err := account.Debit(amount)
+
+		// Assert
+		var closedErr *domain.ClosedAccountError
+		assert.ErrorAs(t, err, &closedErr)
+	})
+}
+
+func TestAccount_CreditRejectsClosedAccount(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	account := newTestAccount(t, 0)
+	require.NoError(t, account.Close())
+	amount, err := domain.NewMoney(decimal.NewFromInt(10), domain.CurrencyUSD)
+	require.NoError(t, err)
+
+	// Act
+	err = account.Credit(amount)
+
+	// Assert
+	var closedErr *domain.ClosedAccountError
+	require.ErrorAs(t, err, &closedErr)
+	assert.True(t, account.Balance().IsZero())
+}
+
+func TestAccount_DebitRejectsOtherCurrency(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	account := newTestAccount(t, 100)
+	amount, err := domain.NewMoney(decimal.NewFromInt(10), domain.CurrencyEUR)
+	require.NoError(t, err)
+
+	// Act
+	err = account.Debit(amount)
+
+	// Assert
+	var mismatchErr *domain.CurrencyMismatchError
+	require.ErrorAs(t, err, &mismatchErr)
+	assert.True(t, account.Balance().Amount().Equal(decimal.NewFromInt(100)))
+}
+
+func TestAccount_Close(t *testing.T) {
+	t.Parallel()
+
+	t.Run("non-empty account stays active", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		account := newTestAccount(t, 25)
+
+		// Act
+		err := account.Close()
+
+		// Assert
+		var notEmptyErr *domain.AccountNotEmptyError
+		require.ErrorAs(t, err, &notEmptyErr)
+		assert.Equal(t, domain.AccountStatusActive, account.Status())
+	})
+
+	t.Run("closed account cannot be frozen", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		account := newTestAccount(t, 0)
+		require.NoError(t, account.Close())
+
+		// Act
+		err := account.Freeze()
+
+		// Assert
+		var closedErr *domain.ClosedAccountError
+		require.ErrorAs(t, err, &closedErr)
+		assert.True(t, account.IsClosed())
+	})
+
+	t.Run("closed account cannot be unfrozen", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		account := newTestAccount(t, 0)
+		require.NoError(t, account.Close())
+
+		// Act
+		err := account.Unfreeze()
+
+		// Assert
+		var closedErr *domain.ClosedAccountError
+		require.ErrorAs(t, err, &closedErr)
+		assert.True(t, account.IsClosed())
+	})
+}
+
+func TestAccount_Unfreeze(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	account := newTestAccount(t, 100)
+	require.NoError(t, account.Freeze())
+	amount, err := domain.NewMoney(decimal.NewFromInt(10), domain.CurrencyUSD)
+	require.NoError(t, err)
+
+	// Act
+	err = account.Unfreeze()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.AccountStatusActive, account.Status())
+	require.NoError(t, account.Debit(amount))
+}
+
+func TestAccount_SetDailyLimit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sets and removes the limit", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		account := newTestAccount(t, 100)
+		limit := decimal.NewFromInt(500)
+
+		// Act
+		err := account.SetDailyLimit(&limit)
+
+		// Assert
+		require.NoError(t, err)
+		require.NotNil(t, account.DailyLimit())
+		assert.True(t, account.DailyLimit().Equal(limit))
+
+		require.NoError(t, account.SetDailyLimit(nil))
+		assert.Nil(t, account.DailyLimit())
+	})
+
+	t.Run("negative limit is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		account := newTestAccount(t, 100)
+		limit := decimal.NewFromInt(-1)
+
+		// Act
+		err := account.SetDailyLimit(&limit)
+
+		// Assert
+		var invalidErr *domain.InvalidDailyLimitError
+		require.ErrorAs(t, err, &invalidErr)
+		assert.Nil(t, account.DailyLimit())
+	})
+}
+
+func TestParseAccountLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		raw      string
+		expected string
+		wantErr  bool
+	}{
+		{name: "label is trimmed", raw: "  Holidays ", expected: "Holidays"},
+		{name: "blank label removes it", raw: "   ", expected: ""},
+		{name: "longest label is accepted", raw: strings.Repeat("é", domain.MaxAccountLabelLength), expected: strings.Repeat("é", domain.MaxAccountLabelLength)},
+		{name: "longer label is rejected", raw: strings.Repeat("a", domain.MaxAccountLabelLength+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			label, err := domain.ParseAccountLabel(tt.raw)
+
+			// Assert
+			if tt.wantErr {
+				var invalidErr *domain.InvalidAccountLabelError
+				require.ErrorAs(t, err, &invalidErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, label)
+		})
+	}
+}
@@ -6,6 +6,7 @@ var (
 	CashbookUserID = UserID(uuid.MustParse("00000000-0000-0000-0000-000000000001"))
 	CashbookUSD    = AccountID(uuid.MustParse("00000000-0000-0000-0000-000000000010"))
 	CashbookEUR    = AccountID(uuid.MustParse("00000000-0000-0000-0000-000000000011"))
+	CashbookGBP    = AccountID(uuid.MustParse("00000000-0000-0000-0000-000000000012"))
 )
 
 func GetCashbookAccount(currency Currency) AccountID {
@@ -14,7 +15,24 @@ func GetCashbookAccount(currency Currency) AccountID {
 		return CashbookUSD
 	case CurrencyEUR:
 		return CashbookEUR
+	case CurrencyGBP:
+		return CashbookGBP
 	default:
 		return CashbookUSD
 	}
 }
+
+// CashbookCurrency returns the currency held by the cashbook account, or false
+// when the account is not a cashbook.
+func CashbookCurrency(account AccountID) (Currency, bool) {
+	switch account {
+	case CashbookUSD:
+		return CurrencyUSD, true
+	case CashbookEUR:
+		return CurrencyEUR, true
+	case CashbookGBP:
+		return CurrencyGBP, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type DepositService struct{}
+
+// Execute pays amount into the account from the cashbook of its currency. The
+// deposit is booked like a transfer from the cashbook, so the ledger stays
+// balanced.
+func (ds *DepositService) Execute(
+	account *Account,
+	cashbook *Account,
+	amount Money,
+	now time.Time,
+) (*TransferDetails, error) {
+	if err := checkCashbookMovement(account, cashbook, amount); err != nil {
+		return nil, err
+	}
+
+	if err := cashbook.Debit(amount); err != nil {
+		return nil, fmt.Errorf("cannot debit from %s: %w", cashbook.ID(), err)
+	}
+
+	if err := account.Credit(amount); err != nil {
+		return nil, fmt.Errorf("cannot credit to %s: %w", account.ID(), err)
+	}
+
+	deposit, err := newTransferDetailsOfType(NewTransferDetailsID(), TransactionTypeDeposit, cashbook.ID(), account.ID(), amount, now)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create deposit details: %w", err)
+	}
+
+	return deposit, nil
+}
+
+// checkCashbookMovement validates a deposit or withdrawal of amount between a
+// user account and the cashbook of the account's currency.
+func checkCashbookMovement(account *Account, cashbook *Account, amount Money) error {
+	if amount.IsNegative() {
+		return NewNegativeTransferError(amount)
+	}
+
+	if amount.IsZero() {
+		return NewZeroAmountError(amount.Currency())
+	}
+
+	if account.IsCashbook() {
+		return NewCashbookTransferError(account.ID())
+	}
+
+	if amount.Currency() != account.Balance().Currency() {
+		return NewCurrencyMismatchError(account.Balance().Currency(), amount.Currency())
+	}
+
+	if cashbook.ID() != GetCashbookAccount(amount.Currency()) {
+		return NewCurrencyMismatchError(amount.Currency(), cashbook.Balance().Currency())
+	}
+
+	return nil
+}
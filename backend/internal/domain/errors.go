@@ -2,7 +2,10 @@ package domain
 
 import (
 	"fmt"
+	"time"
+	"unicode/utf8"
 
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
@@ -19,6 +22,18 @@ func (err CurrencyMismatchError) Error() string {
 	return fmt.Sprintf("%s and %s are not equal currencies", err.first, err.second)
 }
 
+type ZeroAmountError struct {
+	Currency Currency
+}
+
+func NewZeroAmountError(currency Currency) *ZeroAmountError {
+	return &ZeroAmountError{Currency: currency}
+}
+
+func (err ZeroAmountError) Error() string {
+	return fmt.Sprintf("amount of %s must be greater than zero", err.Currency)
+}
+
 type NegativeTransferError struct {
 	money Money
 }
@@ -30,7 +45,7 @@ func NewNegativeTransferError(money Money) *NegativeTransferError {
 func (err NegativeTransferError) Error() string {
 	return fmt.Sprintf(
 		"cannot transfer a negative amount: %s",
-		err.money.amount.String(),
+		err.money,
 	)
 }
 
@@ -46,6 +61,27 @@ func (err UnsupportedCurrencyError) Error() string {
 	return fmt.Sprintf("unsupported currency %s", err.currency)
 }
 
+// Unwrap lets errors.Is match the generated ErrInvalidCurrency sentinel.
+func (err UnsupportedCurrencyError) Unwrap() error {
+	return ErrInvalidCurrency
+}
+
+// InvalidStoredCurrencyError reports a persisted row whose currency is not
+// supported, e.g. after a bad migration or a manual edit.
+type InvalidStoredCurrencyError struct {
+	Table    string
+	RowID    uuid.UUID
+	Currency Currency
+}
+
+func NewInvalidStoredCurrencyError(table string, rowID uuid.UUID, currency Currency) *InvalidStoredCurrencyError {
+	return &InvalidStoredCurrencyError{Table: table, RowID: rowID, Currency: currency}
+}
+
+func (err InvalidStoredCurrencyError) Error() string {
+	return fmt.Sprintf("%s row %s holds unsupported currency %q", err.Table, err.RowID, err.Currency)
+}
+
 type AccountNotFoundError struct {
 	AccountID AccountID
 }
@@ -58,6 +94,58 @@ func (err AccountNotFoundError) Error() string {
 	return fmt.Sprintf("account %v not found", err.AccountID)
 }
 
+type AccountAccessDeniedError struct {
+	AccountID AccountID
+}
+
+func NewAccountAccessDeniedError(accountID AccountID) *AccountAccessDeniedError {
+	return &AccountAccessDeniedError{AccountID: accountID}
+}
+
+func (err AccountAccessDeniedError) Error() string {
+	return fmt.Sprintf("account %s does not belong to the user", uuid.UUID(err.AccountID))
+}
+
+type FrozenAccountError struct {
+	AccountID AccountID
+}
+
+func NewFrozenAccountError(accountID AccountID) *FrozenAccountError {
+	return &FrozenAccountError{AccountID: accountID}
+}
+
+func (err FrozenAccountError) Error() string {
+	return fmt.Sprintf("account %s is frozen", uuid.UUID(err.AccountID))
+}
+
+type ClosedAccountError struct {
+	AccountID AccountID
+}
+
+func NewClosedAccountError(accountID AccountID) *ClosedAccountError {
+	return &ClosedAccountError{AccountID: accountID}
+}
+
+func (err ClosedAccountError) Error() string {
+	return fmt.Sprintf("account %s is closed", uuid.UUID(err.AccountID))
+}
+
+type AccountNotEmptyError struct {
+	AccountID AccountID
+	Balance   Money
+}
+
+func NewAccountNotEmptyError(accountID AccountID, balance Money) *AccountNotEmptyError {
+	return &AccountNotEmptyError{AccountID: accountID, Balance: balance}
+}
+
+func (err AccountNotEmptyError) Error() string {
+	return fmt.Sprintf(
+		"account %s still holds %s %s",
+		uuid.UUID(err.AccountID), err.Balance.Amount().String(), err.Balance.Currency(),
+	)
+}
+
 type AccountBalanceMismatchError struct {
 	AccountID      AccountID
 	AccountBalance decimal.Decimal
@@ -103,7 +191,7 @@ func NewNegativeExchangeError(money Money) *NegativeExchangeError {
 func (err NegativeExchangeError) Error() string {
 	return fmt.Sprintf(
 		"cannot exchange a negative amount: %s",
-		err.money.amount.String(),
+		err.money,
 	)
 }
 
@@ -143,15 +231,39 @@ func (err SameCurrencyExchangeError) Error() string {
 	return fmt.Sprintf("cannot exchange within the same currency: %s", err.currency)
 }
 
+// SameAccountExchangeError is returned when an exchange names the same account
+// as its source and target.
+type SameAccountExchangeError struct {
+	AccountID AccountID
+}
+
+func NewSameAccountExchangeError(accountID AccountID) *SameAccountExchangeError {
+	return &SameAccountExchangeError{AccountID: accountID}
+}
+
+func (err SameAccountExchangeError) Error() string {
+	return fmt.Sprintf("cannot exchange from account %s into itself", uuid.UUID(err.AccountID))
+}
+
+// UserNotFoundError is returned when a user looked up either by email or by
+// id doesn't exist. Only the field used for the lookup is set.
 type UserNotFoundError struct {
-	Email string
+	Email  string
+	UserID UserID
 }
 
 func NewUserNotFoundError(email string) *UserNotFoundError {
 	return &UserNotFoundError{Email: email}
 }
 
+func NewUserIDNotFoundError(userID UserID) *UserNotFoundError {
+	return &UserNotFoundError{UserID: userID}
+}
+
 func (err UserNotFoundError) Error() string {
+	if err.Email == "" {
+		return fmt.Sprintf("user %s not found", uuid.UUID(err.UserID))
+	}
 	return fmt.Sprintf("user with email %s not found", err.Email)
 }
 
@@ -165,6 +277,19 @@ func (err InvalidCredentialsError) Error() string {
 	return "invalid credentials"
 }
 
+// InvalidPasswordError is returned for passwords breaking the password policy.
+type InvalidPasswordError struct {
+	Reason string
+}
+
+func NewInvalidPasswordError(reason string) *InvalidPasswordError {
+	return &InvalidPasswordError{Reason: reason}
+}
+
+func (err InvalidPasswordError) Error() string {
+	return fmt.Sprintf("password %s", err.Reason)
+}
+
 type UserAlreadyExistsError struct {
 	Email string
 }
@@ -173,13 +298,21 @@ func NewUserAlreadyExistsError(email string) *UserAlreadyExistsError {
 	return &UserAlreadyExistsError{Email: email}
 }
 
+type BlockedEmailDomainError struct {
+	Domain string
+}
+
+func NewBlockedEmailDomainError(domain string) *BlockedEmailDomainError {
+	return &BlockedEmailDomainError{Domain: domain}
+}
+
 type InsufficientFundsError struct {
 	AccountID        AccountID
-	RequestedAmount  decimal.Decimal
-	AvailableBalance decimal.Decimal
+	RequestedAmount  Money
+	AvailableBalance Money
 }
 
-func NewInsufficientFundsError(accountID AccountID, requestedAmount, availableBalance decimal.Decimal) *InsufficientFundsError {
+func NewInsufficientFundsError(accountID AccountID, requestedAmount, availableBalance Money) *InsufficientFundsError {
 	return &InsufficientFundsError{
 		AccountID:        accountID,
 		RequestedAmount:  requestedAmount,
@@ -190,10 +323,413 @@ func NewInsufficientFundsError(accountID AccountID, requestedAmount, availableBa
 func (err InsufficientFundsError) Error() string {
 	return fmt.Sprintf(
 		"insufficient funds in account %v: requested %s, available %s",
-		err.AccountID, err.RequestedAmount.String(), err.AvailableBalance.String(),
+		err.AccountID, err.RequestedAmount, err.AvailableBalance,
 	)
 }
 
 func (err UserAlreadyExistsError) Error() string {
 	return fmt.Sprintf("user with email %s already exists", err.Email)
 }
+
+func (err BlockedEmailDomainError) Error() string {
+	return fmt.Sprintf("registrations from email domain %s are not allowed", err.Domain)
+}
+
+type DuplicateTransferInProgressError struct {
+	From AccountID
+	To   AccountID
+}
+
+func NewDuplicateTransferInProgressError(from, to AccountID) *DuplicateTransferInProgressError {
+	return &DuplicateTransferInProgressError{From: from, To: to}
+}
+
+func (err DuplicateTransferInProgressError) Error() string {
+	return fmt.Sprintf("an identical transfer from %v to %v is already being processed", err.From, err.To)
+}
+
+// TooManyRequestsError is returned when a money operation is turned away
+// because too many are already running. It can be retried after RetryAfter.
+type TooManyRequestsError struct {
+	RetryAfter time.Duration
+}
+
+func NewTooManyRequestsError(retryAfter time.Duration) *TooManyRequestsError {
+	return &TooManyRequestsError{RetryAfter: retryAfter}
+}
+
+func (err TooManyRequestsError) Error() string {
+	return fmt.Sprintf("too many money operations in progress, retry after %s", err.RetryAfter)
+}
+
+// MaintenanceModeError is returned when a money operation is attempted while
+// the platform is in maintenance mode. It can be retried after RetryAfter.
+type MaintenanceModeError struct {
+	RetryAfter time.Duration
+}
+
+func NewMaintenanceModeError(retryAfter time.Duration) *MaintenanceModeError {
+	return &MaintenanceModeError{RetryAfter: retryAfter}
+}
+
+func (err MaintenanceModeError) Error() string {
+	return fmt.Sprintf("the platform is under maintenance, retry after %s", err.RetryAfter)
+}
+
+type SubUnitAmountError struct {
+	Amount   decimal.Decimal
+	Currency Currency
+}
+
+func NewSubUnitAmountError(money Money) *SubUnitAmountError {
+	return &SubUnitAmountError{Amount: money.Amount(), Currency: money.Currency()}
+}
+
+func (err SubUnitAmountError) Error() string {
+	return fmt.Sprintf(
+		"amount %s is finer than the minor unit of %s (%d decimal places)",
+		err.Amount.String(), err.Currency, err.Currency.MinorUnitDecimals(),
+	)
+}
+
+type AccountCurrencyChangeError struct {
+	AccountID AccountID
+	Currency  Currency
+}
+
+func NewAccountCurrencyChangeError(accountID AccountID, currency Currency) *AccountCurrencyChangeError {
+	return &AccountCurrencyChangeError{AccountID: accountID, Currency: currency}
+}
+
+func (err AccountCurrencyChangeError) Error() string {
+	return fmt.Sprintf("account %s cannot be changed to currency %s", uuid.UUID(err.AccountID), err.Currency)
+}
+
+// LedgerCurrencyMismatchError is returned when a ledger record would be booked
+// in a currency other than the one of its account.
+type LedgerCurrencyMismatchError struct {
+	RecordID  LedgerRecordID
+	AccountID AccountID
+	Currency  Currency
+}
+
+func NewLedgerCurrencyMismatchError(recordID LedgerRecordID, accountID AccountID, currency Currency) *LedgerCurrencyMismatchError {
+	return &LedgerCurrencyMismatchError{RecordID: recordID, AccountID: accountID, Currency: currency}
+}
+
+func (err LedgerCurrencyMismatchError) Error() string {
+	return fmt.Sprintf(
+		"ledger record %s in %s does not match the currency of account %s",
+		uuid.UUID(err.RecordID), err.Currency, uuid.UUID(err.AccountID),
+	)
+}
+
+// InconsistentTransactionDetailsError is returned when a stored transaction's
+// detail rows don't match its type, e.g. a transfer without transfer details.
+type InconsistentTransactionDetailsError struct {
+	TransactionID TransactionID
+	Type          TransactionType
+	Reason        string
+}
+
+func NewInconsistentTransactionDetailsError(transactionID TransactionID, transactionType TransactionType, reason string) *InconsistentTransactionDetailsError {
+	return &InconsistentTransactionDetailsError{TransactionID: transactionID, Type: transactionType, Reason: reason}
+}
+
+func (err InconsistentTransactionDetailsError) Error() string {
+	return fmt.Sprintf("%s transaction %s has inconsistent details: %s", err.Type, uuid.UUID(err.TransactionID), err.Reason)
+}
+
+type TokenRotationLimitError struct {
+	UserID UserID
+}
+
+func NewTokenRotationLimitError(userID UserID) *TokenRotationLimitError {
+	return &TokenRotationLimitError{UserID: userID}
+}
+
+func (err TokenRotationLimitError) Error() string {
+	return fmt.Sprintf("user %s rotated the token too recently", uuid.UUID(err.UserID))
+}
+
+type TransactionNotFoundError struct {
+	TransactionID TransactionID
+}
+
+func NewTransactionNotFoundError(transactionID TransactionID) *TransactionNotFoundError {
+	return &TransactionNotFoundError{TransactionID: transactionID}
+}
+
+func (err TransactionNotFoundError) Error() string {
+	return fmt.Sprintf("transaction %s not found", uuid.UUID(err.TransactionID))
+}
+
+type TransactionAccessDeniedError struct {
+	TransactionID TransactionID
+}
+
+func NewTransactionAccessDeniedError(transactionID TransactionID) *TransactionAccessDeniedError {
+	return &TransactionAccessDeniedError{TransactionID: transactionID}
+}
+
+func (err TransactionAccessDeniedError) Error() string {
+	return fmt.Sprintf("transaction %s does not belong to the user", uuid.UUID(err.TransactionID))
+}
+
+type ExchangeNotFoundError struct {
+	ExchangeID ExchangeDetailsID
+}
+
+func NewExchangeNotFoundError(exchangeID ExchangeDetailsID) *ExchangeNotFoundError {
+	return &ExchangeNotFoundError{ExchangeID: exchangeID}
+}
+
+func (err ExchangeNotFoundError) Error() string {
+	return fmt.Sprintf("exchange %s not found", uuid.UUID(err.ExchangeID))
+}
+
+type ExchangeAccessDeniedError struct {
+	ExchangeID ExchangeDetailsID
+}
+
+func NewExchangeAccessDeniedError(exchangeID ExchangeDetailsID) *ExchangeAccessDeniedError {
+	return &ExchangeAccessDeniedError{ExchangeID: exchangeID}
+}
+
+func (err ExchangeAccessDeniedError) Error() string {
+	return fmt.Sprintf("exchange %s does not belong to the user", uuid.UUID(err.ExchangeID))
+}
+
+type ExchangeQuoteExpiredError struct {
+	ExpiredAt time.Time
+}
+
+func NewExchangeQuoteExpiredError(expiredAt time.Time) *ExchangeQuoteExpiredError {
+	return &ExchangeQuoteExpiredError{ExpiredAt: expiredAt}
+}
+
+func (err ExchangeQuoteExpiredError) Error() string {
+	return fmt.Sprintf("exchange quote expired at %s", err.ExpiredAt.Format(time.RFC3339))
+}
+
+// InvalidExchangeQuoteError is returned for quotes that were not issued by the
+// platform, were altered or were already executed.
+type InvalidExchangeQuoteError struct {
+	Reason string
+}
+
+func NewInvalidExchangeQuoteError(reason string) *InvalidExchangeQuoteError {
+	return &InvalidExchangeQuoteError{Reason: reason}
+}
+
+func (err InvalidExchangeQuoteError) Error() string {
+	return fmt.Sprintf("invalid exchange quote: %s", err.Reason)
+}
+
+type ExchangeDirectionNotAllowedError struct {
+	From Currency
+	To   Currency
+}
+
+func NewExchangeDirectionNotAllowedError(from, to Currency) *ExchangeDirectionNotAllowedError {
+	return &ExchangeDirectionNotAllowedError{From: from, To: to}
+}
+
+func (err ExchangeDirectionNotAllowedError) Error() string {
+	return fmt.Sprintf("exchange from %s to %s is not allowed", err.From, err.To)
+}
+
+// ExchangeRoundsToZeroError is returned when the exchanged amount is so small
+// that the converted amount rounds to zero.
+type ExchangeRoundsToZeroError struct {
+	Amount Money
+	To     Currency
+}
+
+func NewExchangeRoundsToZeroError(amount Money, to Currency) *ExchangeRoundsToZeroError {
+	return &ExchangeRoundsToZeroError{Amount: amount, To: to}
+}
+
+func (err ExchangeRoundsToZeroError) Error() string {
+	return fmt.Sprintf(
+		"exchanging %s %s to %s rounds to zero",
+		err.Amount.Amount().String(), err.Amount.Currency(), err.To,
+	)
+}
+
+type ExchangeBelowMinimumError struct {
+	Amount  Money
+	Minimum Money
+	To      Currency
+}
+
+func NewExchangeBelowMinimumError(amount Money, minimum Money, to Currency) *ExchangeBelowMinimumError {
+	return &ExchangeBelowMinimumError{Amount: amount, Minimum: minimum, To: to}
+}
+
+func (err ExchangeBelowMinimumError) Error() string {
+	return fmt.Sprintf(
+		"exchanging %s %s to %s requires at least %s %s",
+		err.Amount.Amount().String(), err.Amount.Currency(), err.To,
+		err.Minimum.Amount().String(), err.Minimum.Currency(),
+	)
+}
+
+type AdminRequiredError struct {
+	UserID UserID
+}
+
+func NewAdminRequiredError(userID UserID) *AdminRequiredError {
+	return &AdminRequiredError{UserID: userID}
+}
+
+func (err AdminRequiredError) Error() string {
+	return fmt.Sprintf("user %s is not an administrator", uuid.UUID(err.UserID))
+}
+
+type CashbookTransferError struct {
+	AccountID AccountID
+}
+
+func NewCashbookTransferError(accountID AccountID) *CashbookTransferError {
+	return &CashbookTransferError{AccountID: accountID}
+}
+
+func (err CashbookTransferError) Error() string {
+	return fmt.Sprintf("account %s is a cashbook account and cannot take part in user operations", uuid.UUID(err.AccountID))
+}
+
+// IdempotencyKeyUsedError is returned when a response is stored under an
+// idempotency key that already has one.
+type IdempotencyKeyUsedError struct {
+	Key string
+}
+
+func NewIdempotencyKeyUsedError(key string) *IdempotencyKeyUsedError {
+	return &IdempotencyKeyUsedError{Key: key}
+}
+
+func (err IdempotencyKeyUsedError) Error() string {
+	return fmt.Sprintf("idempotency key %q was already used", err.Key)
+}
+
+// IdempotencyKeyMismatchError is returned when an idempotency key is reused for
+// a request other than the one it was first used with.
+type IdempotencyKeyMismatchError struct {
+	Key string
+}
+
+func NewIdempotencyKeyMismatchError(key string) *IdempotencyKeyMismatchError {
+	return &IdempotencyKeyMismatchError{Key: key}
+}
+
+func (err IdempotencyKeyMismatchError) Error() string {
+	return fmt.Sprintf("idempotency key %q was used for a different request", err.Key)
+}
+
+// InvalidDailyLimitError is returned when an account's daily outflow limit
+// would be set below zero.
+type InvalidDailyLimitError struct {
+	AccountID AccountID
+	Limit     decimal.Decimal
+}
+
+func NewInvalidDailyLimitError(accountID AccountID, limit decimal.Decimal) *InvalidDailyLimitError {
+	return &InvalidDailyLimitError{AccountID: accountID, Limit: limit}
+}
+
+func (err InvalidDailyLimitError) Error() string {
+	return fmt.Sprintf("daily limit %s of account %s must not be negative", err.Limit, uuid.UUID(err.AccountID))
+}
+
+// DailyLimitExceededError is returned when a transfer, withdrawal or exchange
+// would take an account's outflow of the day above its daily limit.
+type DailyLimitExceededError struct {
+	AccountID AccountID
+	Limit     decimal.Decimal
+	Outflow   decimal.Decimal
+	Amount    Money
+}
+
+func NewDailyLimitExceededError(accountID AccountID, limit, outflow decimal.Decimal, amount Money) *DailyLimitExceededError {
+	return &DailyLimitExceededError{AccountID: accountID, Limit: limit, Outflow: outflow, Amount: amount}
+}
+
+func (err DailyLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"moving %s %s out of account %s exceeds its daily limit of %s, %s left it today",
+		err.Amount.Amount(), err.Amount.Currency(), uuid.UUID(err.AccountID), err.Limit, err.Outflow,
+	)
+}
+
+// InvalidCursorError is returned for pagination cursors that were not issued
+// by the platform or were altered.
+type InvalidCursorError struct {
+	Cursor string
+}
+
+func NewInvalidCursorError(cursor string) *InvalidCursorError {
+	return &InvalidCursorError{Cursor: cursor}
+}
+
+func (err InvalidCursorError) Error() string {
+	return fmt.Sprintf("invalid pagination cursor %q", err.Cursor)
+}
+
+type DivisionByZeroError struct {
+	Money Money
+}
+
+func NewDivisionByZeroError(money Money) *DivisionByZeroError {
+	return &DivisionByZeroError{Money: money}
+}
+
+func (err DivisionByZeroError) Error() string {
+	return fmt.Sprintf("cannot divide %s by zero", err.Money)
+}
+
+// InvalidAccountLabelError is returned for account labels longer than
+// MaxAccountLabelLength.
+type InvalidAccountLabelError struct {
+	Label string
+}
+
+func NewInvalidAccountLabelError(label string) *InvalidAccountLabelError {
+	return &InvalidAccountLabelError{Label: label}
+}
+
+func (err InvalidAccountLabelError) Error() string {
+	return fmt.Sprintf("account label must be at most %d characters long, got %d", MaxAccountLabelLength, utf8.RuneCountInString(err.Label))
+}
+
+// BatchTransferError is returned when a transfer of a batch fails, which rolls
+// back the whole batch. Index is the failed transfer's position in the batch.
+type BatchTransferError struct {
+	Index int
+	Err   error
+}
+
+func NewBatchTransferError(index int, err error) *BatchTransferError {
+	return &BatchTransferError{Index: index, Err: err}
+}
+
+func (err BatchTransferError) Error() string {
+	return fmt.Sprintf("transfer %d of the batch failed: %v", err.Index, err.Err)
+}
+
+func (err BatchTransferError) Unwrap() error {
+	return err.Err
+}
+
+type BatchTransferSizeError struct {
+	Size int
+	Max  int
+}
+
+func NewBatchTransferSizeError(size, maxSize int) *BatchTransferSizeError {
+	return &BatchTransferSizeError{Size: size, Max: maxSize}
+}
+
+func (err BatchTransferSizeError) Error() string {
+	return fmt.Sprintf("a batch must hold between 1 and %d transfers, got %d", err.Max, err.Size)
+}
@@ -13,18 +13,30 @@ type ExchangeService struct{}
 func (es *ExchangeService) Execute(
 	sourceAccount *Account,
 	targetAccount *Account,
-	cashbookUSD *Account,
-	cashbookEUR *Account,
+	sourceCashbook *Account,
+	targetCashbook *Account,
 	sourceAmount Money,
 	exchangeRate ExchangeRate,
 	now time.Time,
 ) (*ExchangeDetails, error) {
+	if sourceAccount.ID() == targetAccount.ID() {
+		return nil, NewSameAccountExchangeError(sourceAccount.ID())
+	}
+
 	if sourceAmount.IsNegative() {
 		return nil, NewNegativeExchangeError(sourceAmount)
 	}
 
 	if sourceAmount.IsZero() {
-		return nil, fmt.Errorf("exchange amount cannot be zero")
+		return nil, NewZeroAmountError(sourceAmount.Currency())
+	}
+
+	if sourceAccount.IsCashbook() {
+		return nil, NewCashbookTransferError(sourceAccount.ID())
+	}
+
+	if targetAccount.IsCashbook() {
+		return nil, NewCashbookTransferError(targetAccount.ID())
 	}
 
 	if sourceAccount.Balance().Currency() == targetAccount.Balance().Currency() {
@@ -38,11 +50,23 @@ func (es *ExchangeService) Execute(
 		return nil, NewCurrencyMismatchError(exchangeRate.To(), targetAccount.Balance().Currency())
 	}
 
+	if sourceCashbook.ID() != GetCashbookAccount(sourceAmount.Currency()) {
+		return nil, NewCurrencyMismatchError(sourceAmount.Currency(), sourceCashbook.Balance().Currency())
+	}
+	if targetCashbook.ID() != GetCashbookAccount(exchangeRate.To()) {
+		return nil, NewCurrencyMismatchError(exchangeRate.To(), targetCashbook.Balance().Currency())
+	}
+
 	targetAmount, err := CalculateExchangeAmount(sourceAmount, exchangeRate)
 	if err != nil {
 		return nil, fmt.Errorf("cannot calculate exchange amount: %w", err)
 	}
 
+	// The source is non-zero here, so a zero target would take money for nothing
+	if targetAmount.IsZero() {
+		return nil, NewExchangeRoundsToZeroError(sourceAmount, targetAmount.Currency())
+	}
+
 	if err := sourceAccount.Debit(sourceAmount); err != nil {
 		return nil, fmt.Errorf("cannot debit from source account %s: %w", sourceAccount.ID(), err)
 	}
@@ -51,12 +75,10 @@ func (es *ExchangeService) Execute(
 		return nil, fmt.Errorf("cannot credit to target account %s: %w", targetAccount.ID(), err)
 	}
 
-	sourceCashbook := getCashbookForCurrency(cashbookUSD, cashbookEUR, sourceAmount.Currency())
 	if err := sourceCashbook.Credit(sourceAmount); err != nil {
 		return nil, fmt.Errorf("cannot credit source cashbook: %w", err)
 	}
 
-	targetCashbook := getCashbookForCurrency(cashbookUSD, cashbookEUR, targetAmount.Currency())
 	if err := targetCashbook.Debit(targetAmount); err != nil {
 		return nil, fmt.Errorf("cannot debit target cashbook: %w", err)
 	}
@@ -67,6 +89,7 @@ func (es *ExchangeService) Execute(
 		targetAccount.ID(),
 		sourceAmount,
 		targetAmount,
+		exchangeRate.Rate(),
 		now,
 	)
 	if err != nil {
@@ -76,13 +99,6 @@ func (es *ExchangeService) Execute(
 	return exchange, nil
 }
 
-func getCashbookForCurrency(cashbookUSD, cashbookEUR *Account, currency Currency) *Account {
-	if currency == CurrencyUSD {
-		return cashbookUSD
-	}
-	return cashbookEUR
-}
-
 func CalculateExchangeAmount(sourceAmount Money, exchangeRate ExchangeRate) (Money, error) {
 	return exchangeRate.Convert(sourceAmount)
 }
@@ -100,6 +116,7 @@ type ExchangeDetails struct {
 	targetAccount AccountID
 	sourceAmount  Money
 	targetAmount  Money
+	exchangeRate  decimal.Decimal
 	time          time.Time
 }
 
@@ -109,6 +126,7 @@ func NewExchangeDetails(
 	targetAccount AccountID,
 	sourceAmount Money,
 	targetAmount Money,
+	exchangeRate decimal.Decimal,
 	time time.Time,
 ) (*ExchangeDetails, error) {
 	if sourceAmount.Currency() == targetAmount.Currency() {
@@ -122,7 +140,8 @@ func NewExchangeDetails(
 		targetAccount: targetAccount,
 		sourceAmount:  sourceAmount,
 		targetAmount:  targetAmount,
-		time:          time,
+		exchangeRate:  exchangeRate,
+		time:          time.UTC(),
 	}, nil
 }
 
@@ -154,13 +173,19 @@ func (ed *ExchangeDetails) Time() time.Time {
 	return ed.time
 }
 
+// ExchangeRate returns the quoted rate the exchange was executed at. It is not
+// derived from the amounts, which are rounded to minor units.
 func (ed *ExchangeDetails) ExchangeRate() decimal.Decimal {
-	return ed.targetAmount.Amount().Div(ed.sourceAmount.Amount())
+	return ed.exchangeRate
 }
 
 func (ed *ExchangeDetails) GetLedgerEntries() (ExchangeLedgerEntries, error) {
-	sourceCashbook := GetCashbookAccount(ed.SourceAmount().Currency())
-	targetCashbook := GetCashbookAccount(ed.TargetAmount().Currency())
+	return ed.getLedgerEntries(GetCashbookAccount)
+}
+
+func (ed *ExchangeDetails) getLedgerEntries(cashbookFor func(Currency) AccountID) (ExchangeLedgerEntries, error) {
+	sourceCashbook := cashbookFor(ed.SourceAmount().Currency())
+	targetCashbook := cashbookFor(ed.TargetAmount().Currency())
 
 	sourceCurrencyEntry, err := ed.buildSourceCurrencyEntry(sourceCashbook)
 	if err != nil {
@@ -172,10 +197,17 @@ func (ed *ExchangeDetails) GetLedgerEntries() (ExchangeLedgerEntries, error) {
 		return ExchangeLedgerEntries{}, fmt.Errorf("building target currency entry: %w", err)
 	}
 
-	return ExchangeLedgerEntries{
+	entries := ExchangeLedgerEntries{
 		SourceCurrencyEntry: sourceCurrencyEntry,
 		TargetCurrencyEntry: targetCurrencyEntry,
-	}, nil
+	}
+
+	err = validateExchangeLedgerCurrencies(entries, ed.SourceAmount().Currency(), ed.TargetAmount().Currency())
+	if err != nil {
+		return ExchangeLedgerEntries{}, fmt.Errorf("validating exchange ledger currencies: %w", err)
+	}
+
+	return entries, nil
 }
 
 func (ed *ExchangeDetails) buildSourceCurrencyEntry(cashbook AccountID) (LedgerEntry, error) {
@@ -227,7 +259,7 @@ func (ed *ExchangeDetails) buildTargetCurrencyEntry(cashbook AccountID) (LedgerE
 }
 
 func validateBalancedEntry(a, b *LedgerRecord) error {
-	sum, err := a.Money().Add(b.Money())
+	sum, err := SumMoney(a.Money().Currency(), a.Money(), b.Money())
 	if err != nil {
 		return fmt.Errorf("cannot sum records: %w", err)
 	}
@@ -236,3 +268,30 @@ func validateBalancedEntry(a, b *LedgerRecord) error {
 	}
 	return nil
 }
+
+// validateExchangeLedgerCurrencies checks that the records span exactly the
+// source and target currencies and that every cashbook record is booked to
+// the cashbook of its own currency.
+func validateExchangeLedgerCurrencies(entries ExchangeLedgerEntries, source, target Currency) error {
+	currencies := make(map[Currency]struct{}, 2)
+	for _, record := range entries.Records() {
+		currency := record.Money().Currency()
+		if currency != source && currency != target {
+			return fmt.Errorf("record %s is in %s, expected %s or %s", uuid.UUID(record.ID()), currency, source, target)
+		}
+		currencies[currency] = struct{}{}
+
+		if cashbookCurrency, ok := CashbookCurrency(record.Account()); ok && cashbookCurrency != currency {
+			return fmt.Errorf(
+				"record %s books %s to the %s cashbook: %w",
+				uuid.UUID(record.ID()), currency, cashbookCurrency, NewCurrencyMismatchError(currency, cashbookCurrency),
+			)
+		}
+	}
+
+	if len(currencies) != 2 {
+		return fmt.Errorf("exchange records span %d currencies, expected 2", len(currencies))
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+type ExchangeDirection struct {
+	From Currency
+	To   Currency
+}
+
+func NewExchangeDirection(from, to Currency) (ExchangeDirection, error) {
+	if from == to {
+		return ExchangeDirection{}, NewSameCurrencyExchangeRateError(from)
+	}
+
+	if !from.IsValid() {
+		return ExchangeDirection{}, NewUnsupportedCurrencyError(from)
+	}
+
+	if !to.IsValid() {
+		return ExchangeDirection{}, NewUnsupportedCurrencyError(to)
+	}
+
+	return ExchangeDirection{From: from, To: to}, nil
+}
+
+// ParseExchangeDirections parses a comma separated list of directions written
+// as FROM:TO, for example "USD:EUR,EUR:USD".
+func ParseExchangeDirections(value string) ([]ExchangeDirection, error) {
+	var directions []ExchangeDirection
+	for _, raw := range strings.Split(value, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		from, to, ok := strings.Cut(raw, ":")
+		if !ok {
+			return nil, fmt.Errorf("exchange direction %q must have the form FROM:TO", raw)
+		}
+
+		direction, err := NewExchangeDirection(Currency(strings.TrimSpace(from)), Currency(strings.TrimSpace(to)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid exchange direction %q: %w", raw, err)
+		}
+		directions = append(directions, direction)
+	}
+
+	return directions, nil
+}
+
+func (d ExchangeDirection) String() string {
+	return string(d.From) + ":" + string(d.To)
+}
+
+// ExchangeMinimum is the smallest source amount accepted when exchanging in
+// Direction. The amount is in the source currency.
+type ExchangeMinimum struct {
+	Direction ExchangeDirection
+	Amount    decimal.Decimal
+}
+
+// ParseExchangeMinimums parses a comma separated list of minimums written as
+// FROM:TO=AMOUNT, for example "USD:EUR=10,EUR:GBP=5".
+func ParseExchangeMinimums(value string) ([]ExchangeMinimum, error) {
+	var minimums []ExchangeMinimum
+	for _, raw := range strings.Split(value, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		rawDirection, rawAmount, ok := strings.Cut(raw, "=")
+		if !ok {
+			return nil, fmt.Errorf("exchange minimum %q must have the form FROM:TO=AMOUNT", raw)
+		}
+
+		directions, err := ParseExchangeDirections(rawDirection)
+		if err != nil {
+			return nil, err
+		}
+		if len(directions) != 1 {
+			return nil, fmt.Errorf("exchange minimum %q must name exactly one direction", raw)
+		}
+
+		amount, err := decimal.NewFromString(strings.TrimSpace(rawAmount))
+		if err != nil {
+			return nil, fmt.Errorf("invalid exchange minimum %q: %w", raw, err)
+		}
+		if amount.IsNegative() {
+			return nil, fmt.Errorf("exchange minimum %q must not be negative", raw)
+		}
+
+		minimums = append(minimums, ExchangeMinimum{Direction: directions[0], Amount: amount})
+	}
+
+	return minimums, nil
+}
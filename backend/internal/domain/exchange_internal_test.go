@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExchangeDetails(t *testing.T) *ExchangeDetails {
+	t.Helper()
+
+	source, err := NewMoney(decimal.NewFromInt(100), CurrencyUSD)
+	require.NoError(t, err)
+	target, err := NewMoney(decimal.NewFromInt(92), CurrencyEUR)
+	require.NoError(t, err)
+
+	details, err := NewExchangeDetails(NewExchangeDetailsID(), GenerateAccountID(), GenerateAccountID(), source, target, decimal.NewFromFloat(0.92), time.Now())
+	require.NoError(t, err)
+
+	return details
+}
+
+func TestExchangeDetails_GetLedgerEntriesSpanTwoCurrencies(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	entries, err := newTestExchangeDetails(t).GetLedgerEntries()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, CashbookUSD, entries.SourceCurrencyEntry[1].Account())
+	assert.Equal(t, CashbookEUR, entries.TargetCurrencyEntry[0].Account())
+}
+
+func TestExchangeDetails_GetLedgerEntriesRejectsWrongCashbook(t *testing.T) {
+	t.Parallel()
+
+	// Arrange - a lookup that hands out the USD cashbook for every currency
+	alwaysUSD := func(Currency) AccountID { return CashbookUSD }
+
+	// Act
+	_, err := newTestExchangeDetails(t).getLedgerEntries(alwaysUSD)
+
+	// Assert
+	var mismatchErr *CurrencyMismatchError
+	assert.ErrorAs(t, err, &mismatchErr)
+}
+
+func TestValidateExchangeLedgerCurrencies_ThirdCurrency(t *testing.T) {
+	t.Parallel()
+
+	// Arrange - USD->EUR entries checked against expected currencies that omit USD
+	entries, err := newTestExchangeDetails(t).GetLedgerEntries()
+	require.NoError(t, err)
+
+	// Act
+	err = validateExchangeLedgerCurrencies(entries, CurrencyEUR, CurrencyEUR)
+
+	// Assert
+	assert.ErrorContains(t, err, "expected EUR or EUR")
+}
@@ -1,11 +1,75 @@
 package domain
 
-import "github.com/shopspring/decimal"
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// RoundingBias decides which party benefits when a converted amount has to be
+// rounded to minor units.
+type RoundingBias string
+
+const (
+	// RoundingBiasNone rounds half away from zero.
+	RoundingBiasNone RoundingBias = "none"
+	// RoundingBiasUser always rounds the target amount up.
+	RoundingBiasUser RoundingBias = "user"
+	// RoundingBiasPlatform always rounds the target amount down.
+	RoundingBiasPlatform RoundingBias = "platform"
+)
+
+func ParseRoundingBias(value string) (RoundingBias, error) {
+	switch bias := RoundingBias(value); bias {
+	case RoundingBiasNone, RoundingBiasUser, RoundingBiasPlatform:
+		return bias, nil
+	case "":
+		return RoundingBiasNone, nil
+	default:
+		return "", fmt.Errorf("%q is not a valid rounding bias", value)
+	}
+}
+
+func (b RoundingBias) round(amount decimal.Decimal, places int32) decimal.Decimal {
+	switch b {
+	case RoundingBiasUser:
+		return amount.RoundCeil(places)
+	case RoundingBiasPlatform:
+		return amount.RoundFloor(places)
+	default:
+		return amount.Round(places)
+	}
+}
+
+// exchangeRateDecimals is the precision exchange rates are stored with.
+const exchangeRateDecimals = 10
+
+// ParseExchangeSpread parses the fraction of the mid-rate kept by the platform
+// on every exchange, e.g. "0.005" for 0.5%. It must be at least zero and below
+// one; an empty value means no spread.
+func ParseExchangeSpread(value string) (decimal.Decimal, error) {
+	if value == "" {
+		return decimal.Zero, nil
+	}
+
+	spread, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Decimal{}, fmt.Errorf("%q is not a valid exchange spread: %w", value, err)
+	}
+
+	if spread.IsNegative() || spread.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return decimal.Decimal{}, fmt.Errorf("exchange spread %s must be at least 0 and below 1", spread)
+	}
+
+	return spread, nil
+}
 
 type ExchangeRate struct {
 	from Currency
 	to   Currency
 	rate decimal.Decimal
+	bias RoundingBias
 }
 
 func NewExchangeRate(from, to Currency, rate decimal.Decimal) (ExchangeRate, error) {
@@ -29,9 +93,21 @@ func NewExchangeRate(from, to Currency, rate decimal.Decimal) (ExchangeRate, err
 		from: from,
 		to:   to,
 		rate: rate,
+		bias: RoundingBiasNone,
 	}, nil
 }
 
+// ParseExchangeRate builds an ExchangeRate from its textual form, as received
+// from a rate source. It validates like NewExchangeRate.
+func ParseExchangeRate(from, to, rate string) (ExchangeRate, error) {
+	decimalRate, err := decimal.NewFromString(rate)
+	if err != nil {
+		return ExchangeRate{}, fmt.Errorf("parsing exchange rate %q: %w", rate, err)
+	}
+
+	return NewExchangeRate(Currency(from), Currency(to), decimalRate)
+}
+
 func (e ExchangeRate) From() Currency {
 	return e.from
 }
@@ -44,18 +120,39 @@ func (e ExchangeRate) Rate() decimal.Decimal {
 	return e.rate
 }
 
+func (e ExchangeRate) RoundingBias() RoundingBias {
+	return e.bias
+}
+
+// WithRoundingBias returns a copy of the rate that rounds converted amounts
+// according to the given bias.
+func (e ExchangeRate) WithRoundingBias(bias RoundingBias) ExchangeRate {
+	e.bias = bias
+	return e
+}
+
+// WithSpread returns a copy of the rate worsened by spread, a fraction of the
+// rate as returned by ParseExchangeSpread. The result is rounded down to the
+// precision rates are stored with.
+func (e ExchangeRate) WithSpread(spread decimal.Decimal) ExchangeRate {
+	e.rate = e.rate.Mul(decimal.NewFromInt(1).Sub(spread)).RoundFloor(exchangeRateDecimals)
+	return e
+}
+
 func (e ExchangeRate) Convert(amount Money) (Money, error) {
 	if amount.Currency() != e.from {
 		return Money{}, NewCurrencyMismatchError(e.from, amount.Currency())
 	}
 
-	convertedAmount := amount.Amount().Mul(e.rate).Round(2)
+	convertedAmount := e.bias.round(amount.Amount().Mul(e.rate), 2)
 
 	return NewMoney(convertedAmount, e.to)
 }
 
+// ExchangeRateProvider returns the current rate of a currency pair. Providers
+// may fetch it remotely, bounded by ctx.
 type ExchangeRateProvider interface {
-	GetRate(from Currency, to Currency) (ExchangeRate, error)
+	GetRate(ctx context.Context, from Currency, to Currency) (ExchangeRate, error)
 }
 
 type ExchangeRateNotFoundError struct {
@@ -0,0 +1,157 @@
+package domain_test
+
+import (
+	"testing"
+
+	"minibankingplatform/internal/domain"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExchangeRate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid rate", func(t *testing.T) {
+		t.Parallel()
+
+		// Act
+		rate, err := domain.ParseExchangeRate("USD", "EUR", "0.9215")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, domain.CurrencyUSD, rate.From())
+		assert.Equal(t, domain.CurrencyEUR, rate.To())
+		assert.True(t, rate.Rate().Equal(decimal.RequireFromString("0.9215")))
+	})
+
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		rate     string
+		checkErr func(t *testing.T, err error)
+	}{
+		{
+			name: "zero rate",
+			from: "USD", to: "EUR", rate: "0",
+			checkErr: func(t *testing.T, err error) {
+				var rateErr *domain.InvalidExchangeRateError
+				assert.ErrorAs(t, err, &rateErr)
+			},
+		},
+		{
+			name: "negative rate",
+			from: "USD", to: "EUR", rate: "-0.92",
+			checkErr: func(t *testing.T, err error) {
+				var rateErr *domain.InvalidExchangeRateError
+				assert.ErrorAs(t, err, &rateErr)
+			},
+		},
+		{
+			name: "same currency",
+			from: "USD", to: "USD", rate: "1",
+			checkErr: func(t *testing.T, err error) {
+				var sameCurrencyErr *domain.SameCurrencyExchangeRateError
+				assert.ErrorAs(t, err, &sameCurrencyErr)
+			},
+		},
+		{
+			name: "unsupported currency",
+			from: "USD", to: "JPY", rate: "150",
+			checkErr: func(t *testing.T, err error) {
+				var unsupportedErr *domain.UnsupportedCurrencyError
+				assert.ErrorAs(t, err, &unsupportedErr)
+			},
+		},
+		{
+			name: "not a number",
+			from: "USD", to: "EUR", rate: "0,92",
+			checkErr: func(t *testing.T, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			_, err := domain.ParseExchangeRate(tt.from, tt.to, tt.rate)
+
+			// Assert
+			tt.checkErr(t, err)
+		})
+	}
+}
+
+func TestParseExchangeSpread(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "empty means none", value: "", expected: "0"},
+		{name: "fraction", value: "0.005", expected: "0.005"},
+		{name: "zero", value: "0", expected: "0"},
+		{name: "negative", value: "-0.01", wantErr: true},
+		{name: "whole rate", value: "1", wantErr: true},
+		{name: "not a number", value: "half a percent", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			spread, err := domain.ParseExchangeSpread(tt.value)
+
+			// Assert
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, spread.Equal(decimal.RequireFromString(tt.expected)), "got %s", spread)
+		})
+	}
+}
+
+func TestExchangeRate_WithSpread(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		rate     string
+		spread   string
+		expected string
+	}{
+		{name: "no spread", rate: "0.92", spread: "0", expected: "0.92"},
+		{name: "exact", rate: "1.086957", spread: "0.0025", expected: "1.0842396075"},
+		{name: "rounds down to stored precision", rate: "0.123456789", spread: "0.005", expected: "0.122839505"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			rate, err := domain.NewExchangeRate(domain.CurrencyEUR, domain.CurrencyUSD, decimal.RequireFromString(tt.rate))
+			require.NoError(t, err)
+
+			// Act
+			spreadRate := rate.WithSpread(decimal.RequireFromString(tt.spread))
+
+			// Assert
+			assert.True(t, spreadRate.Rate().Equal(decimal.RequireFromString(tt.expected)), "got %s", spreadRate.Rate())
+			assert.Equal(t, domain.CurrencyEUR, spreadRate.From())
+			assert.Equal(t, domain.CurrencyUSD, spreadRate.To())
+			assert.True(t, rate.Rate().Equal(decimal.RequireFromString(tt.rate)), "the original rate must not change")
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newExchangeTestAccount(t *testing.T, id domain.AccountID, userID domain.UserID, amount int64, currency domain.Currency) *domain.Account {
+	t.Helper()
+
+	balance, err := domain.NewMoney(decimal.NewFromInt(amount), currency)
+	require.NoError(t, err)
+
+	return domain.NewAccount(id, userID, balance)
+}
+
+func TestExchangeService_RejectsSameAccount(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	account := newExchangeTestAccount(t, domain.GenerateAccountID(), domain.UserID{1}, 100, domain.CurrencyUSD)
+	sourceCashbook := newExchangeTestAccount(t, domain.CashbookUSD, domain.CashbookUserID, 0, domain.CurrencyUSD)
+	targetCashbook := newExchangeTestAccount(t, domain.CashbookEUR, domain.CashbookUserID, 0, domain.CurrencyEUR)
+
+	amount, err := domain.NewMoney(decimal.NewFromInt(10), domain.CurrencyUSD)
+	require.NoError(t, err)
+	rate, err := domain.NewExchangeRate(domain.CurrencyUSD, domain.CurrencyEUR, decimal.NewFromFloat(0.92))
+	require.NoError(t, err)
+
+	// Act
+	exchangeService := domain.ExchangeService{}
+	_, err = exchangeService.Execute(account, account, sourceCashbook, targetCashbook, amount, rate, time.Now())
+
+	// Assert
+	var sameAccountErr *domain.SameAccountExchangeError
+	require.ErrorAs(t, err, &sameAccountErr)
+	assert.Equal(t, account.ID(), sameAccountErr.AccountID)
+	assert.True(t, account.Balance().Amount().Equal(decimal.NewFromInt(100)))
+}
+
+func TestExchangeService_RejectsTargetRoundingToZero(t *testing.T) {
+	t.Parallel()
+
+	// Arrange - 0.005 USD at 0.92 is 0.0046 EUR, which rounds to 0.00
+	source := newExchangeTestAccount(t, domain.GenerateAccountID(), domain.UserID{1}, 100, domain.CurrencyUSD)
+	target := newExchangeTestAccount(t, domain.GenerateAccountID(), domain.UserID{1}, 0, domain.CurrencyEUR)
+	sourceCashbook := newExchangeTestAccount(t, domain.CashbookUSD, domain.CashbookUserID, 0, domain.CurrencyUSD)
+	targetCashbook := newExchangeTestAccount(t, domain.CashbookEUR, domain.CashbookUserID, 0, domain.CurrencyEUR)
+
+	amount, err := domain.NewMoney(decimal.RequireFromString("0.005"), domain.CurrencyUSD)
+	require.NoError(t, err)
+	rate, err := domain.NewExchangeRate(domain.CurrencyUSD, domain.CurrencyEUR, decimal.NewFromFloat(0.92))
+	require.NoError(t, err)
+
+	// Act
+	exchangeService := domain.ExchangeService{}
+	_, err = exchangeService.Execute(source, target, sourceCashbook, targetCashbook, amount, rate, time.Now())
+
+	// Assert
+	var roundsToZeroErr *domain.ExchangeRoundsToZeroError
+	require.ErrorAs(t, err, &roundsToZeroErr)
+	assert.Equal(t, domain.CurrencyEUR, roundsToZeroErr.To)
+	assert.True(t, source.Balance().Amount().Equal(decimal.NewFromInt(100)))
+	assert.True(t, target.Balance().IsZero())
+}
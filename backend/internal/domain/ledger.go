@@ -30,7 +30,7 @@ func NewLedgerRecord(id LedgerRecordID, transaction TransactionID, account Accou
 		transaction: transaction,
 		account:     account,
 		money:       money,
-		time:        time,
+		time:        time.UTC(),
 	}
 }
 
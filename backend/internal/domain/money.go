@@ -4,13 +4,93 @@ package domain
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/shopspring/decimal"
 )
 
-// ENUM(USD, EUR)
+// ENUM(USD, EUR, GBP)
 type Currency string
 
+// MinorUnitDecimals returns the number of decimal places of the currency's
+// minor unit.
+func (c Currency) MinorUnitDecimals() int32 {
+	return 2
+}
+
+// ParseSupportedCurrency converts a string to a Currency, normalizing it with
+// NormalizeCurrencyCode first. Unlike the generated ParseCurrency it fails with
+// *UnsupportedCurrencyError, which also matches ErrInvalidCurrency.
+func ParseSupportedCurrency(name string) (Currency, error) {
+	currency, err := ParseCurrency(NormalizeCurrencyCode(name))
+	if err != nil {
+		return "", NewUnsupportedCurrencyError(Currency(name))
+	}
+
+	return currency, nil
+}
+
+// maxFastPathDigits keeps the fast path of ParseAmount within int64.
+const maxFastPathDigits = 18
+
+// ParseAmount parses a decimal amount such as "1000.50". It returns exactly
+// what decimal.NewFromString does, but plain amounts of up to 18 digits with
+// an optional sign and fraction skip the general parser and allocate less.
+func ParseAmount(s string) (decimal.Decimal, error) {
+	if amount, ok := parsePlainAmount(s); ok {
+		return amount, nil
+	}
+
+	return decimal.NewFromString(s)
+}
+
+// parsePlainAmount parses [-]digits[.digits]. It reports false for anything
+// else, leaving exponents, leading dots and long inputs to decimal.NewFromString.
+func parsePlainAmount(s string) (decimal.Decimal, bool) {
+	i := 0
+	negative := len(s) > 0 && s[0] == '-'
+	if negative {
+		i++
+	}
+
+	var (
+		value     int64
+		digits    int
+		fraction  = -1
+		intDigits int
+	)
+	for ; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits++
+			if digits > maxFastPathDigits {
+				return decimal.Decimal{}, false
+			}
+			value = value*10 + int64(c-'0')
+			if fraction >= 0 {
+				fraction++
+			} else {
+				intDigits++
+			}
+		case c == '.' && fraction < 0 && intDigits > 0:
+			fraction = 0
+		default:
+			return decimal.Decimal{}, false
+		}
+	}
+
+	if intDigits == 0 || fraction == 0 {
+		return decimal.Decimal{}, false
+	}
+
+	if negative {
+		value = -value
+	}
+
+	return decimal.New(value, -int32(max(fraction, 0))), true
+}
+
 type Money struct {
 	amount   decimal.Decimal
 	currency Currency
@@ -27,6 +107,28 @@ func NewMoney(amount decimal.Decimal, currency Currency) (Money, error) {
 	}, nil
 }
 
+func ZeroMoney(currency Currency) (Money, error) {
+	return NewMoney(decimal.Zero, currency)
+}
+
+// SumMoney adds up amounts of the given currency. It fails with
+// CurrencyMismatchError if any of them is in another currency.
+func SumMoney(currency Currency, ms ...Money) (Money, error) {
+	total, err := ZeroMoney(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	for _, m := range ms {
+		total, err = total.Add(m)
+		if err != nil {
+			return Money{}, fmt.Errorf("summing money: %w", err)
+		}
+	}
+
+	return total, nil
+}
+
 func (m Money) CheckIsNotEqualCurrencies(other Money) error {
 	if m.currency != other.currency {
 		return NewCurrencyMismatchError(m.currency, other.currency)
@@ -65,6 +167,29 @@ func (m Money) Sub(other Money) (Money, error) {
 	}, nil
 }
 
+// Mul scales the amount by factor, e.g. a fee percentage. The result is not
+// rounded to the currency's minor unit.
+func (m Money) Mul(factor decimal.Decimal) Money {
+	return Money{
+		currency: m.currency,
+		amount:   m.amount.Mul(factor),
+	}
+}
+
+// Div divides the amount by divisor with decimal.DivisionPrecision digits. The
+// result is not rounded to the currency's minor unit. It fails with
+// DivisionByZeroError if divisor is zero.
+func (m Money) Div(divisor decimal.Decimal) (Money, error) {
+	if divisor.IsZero() {
+		return Money{}, NewDivisionByZeroError(m)
+	}
+
+	return Money{
+		currency: m.currency,
+		amount:   m.amount.Div(divisor),
+	}, nil
+}
+
 func (m Money) ToNegative() Money {
 	return Money{
 		currency: m.currency,
@@ -87,3 +212,20 @@ func (m Money) Amount() decimal.Decimal {
 func (m Money) IsZero() bool {
 	return m.amount.IsZero()
 }
+
+// String renders m as "<amount> <currency>", e.g. "100.00 USD". The amount has
+// at least the currency's minor unit decimals; finer amounts keep all theirs.
+func (m Money) String() string {
+	return m.formatAmount() + " " + m.currency.String()
+}
+
+// formatAmount renders the amount with at least the currency's minor unit
+// decimals, without digit grouping.
+func (m Money) formatAmount() string {
+	decimals := m.currency.MinorUnitDecimals()
+	if _, fraction, ok := strings.Cut(m.amount.String(), "."); ok && int32(len(fraction)) > decimals {
+		decimals = int32(len(fraction))
+	}
+
+	return m.amount.StringFixed(decimals)
+}
@@ -15,6 +15,8 @@ const (
 	CurrencyUSD Currency = "USD"
 	// CurrencyEUR is a Currency of type EUR.
 	CurrencyEUR Currency = "EUR"
+	// CurrencyGBP is a Currency of type GBP.
+	CurrencyGBP Currency = "GBP"
 )
 
 var ErrInvalidCurrency = fmt.Errorf("not a valid Currency, try [%s]", strings.Join(_CurrencyNames, ", "))
@@ -22,6 +24,7 @@ var ErrInvalidCurrency = fmt.Errorf("not a valid Currency, try [%s]", strings.Jo
 var _CurrencyNames = []string{
 	string(CurrencyUSD),
 	string(CurrencyEUR),
+	string(CurrencyGBP),
 }
 
 // CurrencyNames returns a list of possible string values of Currency.
@@ -36,6 +39,7 @@ func CurrencyValues() []Currency {
 	return []Currency{
 		CurrencyUSD,
 		CurrencyEUR,
+		CurrencyGBP,
 	}
 }
 
@@ -54,6 +58,7 @@ func (x Currency) IsValid() bool {
 var _CurrencyValue = map[string]Currency{
 	"USD": CurrencyUSD,
 	"EUR": CurrencyEUR,
+	"GBP": CurrencyGBP,
 }
 
 // ParseCurrency attempts to convert a string to a Currency.
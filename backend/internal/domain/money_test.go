@@ -0,0 +1,306 @@
+package domain_test
+
+import (
+	"testing"
+
+	"minibankingplatform/internal/domain"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZeroMoney(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	money, err := domain.ZeroMoney(domain.CurrencyUSD)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, money.IsZero())
+	assert.Equal(t, domain.CurrencyUSD, money.Currency())
+}
+
+func TestZeroMoney_UnsupportedCurrency(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	_, err := domain.ZeroMoney(domain.Currency("XYZ"))
+
+	// Assert
+	var unsupportedErr *domain.UnsupportedCurrencyError
+	assert.ErrorAs(t, err, &unsupportedErr)
+}
+
+func TestParseSupportedCurrency(t *testing.T) {
+	t.Parallel()
+
+	t.Run("supported currency", func(t *testing.T) {
+		t.Parallel()
+
+		currency, err := domain.ParseSupportedCurrency("EUR")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.CurrencyEUR, currency)
+	})
+
+	t.Run("padded lower case currency", func(t *testing.T) {
+		t.Parallel()
+
+		currency, err := domain.ParseSupportedCurrency(" usd ")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.CurrencyUSD, currency)
+	})
+
+	t.Run("unsupported currency matches sentinel and type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := domain.ParseSupportedCurrency("JPY")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidCurrency)
+
+		var unsupportedErr *domain.UnsupportedCurrencyError
+		assert.ErrorAs(t, err, &unsupportedErr)
+	})
+}
+
+func TestNewMoney_UnsupportedCurrencyMatchesSentinel(t *testing.T) {
+	t.Parallel()
+
+	_, err := domain.NewMoney(decimal.NewFromInt(1), domain.Currency("JPY"))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCurrency)
+}
+
+func TestSumMoney(t *testing.T) {
+	t.Parallel()
+
+	t.Run("same currency", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		a, _ := domain.NewMoney(decimal.RequireFromString("10.25"), domain.CurrencyUSD)
+		b, _ := domain.NewMoney(decimal.RequireFromString("-3.10"), domain.CurrencyUSD)
+		c, _ := domain.NewMoney(decimal.RequireFromString("100"), domain.CurrencyUSD)
+
+		// Act
+		sum, err := domain.SumMoney(domain.CurrencyUSD, a, b, c)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, domain.CurrencyUSD, sum.Currency())
+		assert.True(t, sum.Amount().Equal(decimal.RequireFromString("107.15")), "got %s", sum.Amount())
+	})
+
+	t.Run("no values is zero", func(t *testing.T) {
+		t.Parallel()
+
+		sum, err := domain.SumMoney(domain.CurrencyEUR)
+
+		require.NoError(t, err)
+		assert.True(t, sum.IsZero())
+		assert.Equal(t, domain.CurrencyEUR, sum.Currency())
+	})
+
+	t.Run("mixed currencies", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		usd, _ := domain.NewMoney(decimal.NewFromInt(10), domain.CurrencyUSD)
+		eur, _ := domain.NewMoney(decimal.NewFromInt(10), domain.CurrencyEUR)
+
+		// Act
+		_, err := domain.SumMoney(domain.CurrencyUSD, usd, eur)
+
+		// Assert
+		var mismatchErr *domain.CurrencyMismatchError
+		assert.ErrorAs(t, err, &mismatchErr)
+	})
+}
+
+func TestMoney_Mul(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		amount   string
+		factor   string
+		expected string
+	}{
+		{name: "percentage fee", amount: "250.00", factor: "0.015", expected: "3.75"},
+		{name: "keeps precision beyond minor unit", amount: "10.01", factor: "0.005", expected: "0.05005"},
+		{name: "negative factor", amount: "100", factor: "-0.5", expected: "-50"},
+		{name: "zero factor", amount: "100", factor: "0", expected: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			money, _ := domain.NewMoney(decimal.RequireFromString(tt.amount), domain.CurrencyEUR)
+
+			// Act
+			result := money.Mul(decimal.RequireFromString(tt.factor))
+
+			// Assert
+			assert.Equal(t, domain.CurrencyEUR, result.Currency())
+			assert.True(t, result.Amount().Equal(decimal.RequireFromString(tt.expected)), "got %s", result.Amount())
+		})
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		amount   string
+		currency domain.Currency
+		expected string
+	}{
+		{name: "whole amount gets minor unit decimals", amount: "100", currency: domain.CurrencyUSD, expected: "100.00 USD"},
+		{name: "zero", amount: "0", currency: domain.CurrencyEUR, expected: "0.00 EUR"},
+		{name: "negative", amount: "-25.5", currency: domain.CurrencyGBP, expected: "-25.50 GBP"},
+		{name: "decimals beyond minor unit are kept", amount: "0.05005", currency: domain.CurrencyEUR, expected: "0.05005 EUR"},
+		{name: "trailing zeros beyond minor unit are dropped", amount: "12.3400", currency: domain.CurrencyUSD, expected: "12.34 USD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			money, err := domain.NewMoney(decimal.RequireFromString(tt.amount), tt.currency)
+			require.NoError(t, err)
+
+			// Act
+			result := money.String()
+
+			// Assert
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestInsufficientFundsError_Message(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	requested, _ := domain.NewMoney(decimal.RequireFromString("150"), domain.CurrencyUSD)
+	available, _ := domain.NewMoney(decimal.RequireFromString("99.5"), domain.CurrencyUSD)
+
+	// Act
+	err := domain.NewInsufficientFundsError(domain.AccountID{}, requested, available)
+
+	// Assert
+	assert.Contains(t, err.Error(), "requested 150.00 USD, available 99.50 USD")
+}
+
+func TestMoney_Div(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		amount   string
+		divisor  string
+		expected string
+	}{
+		{name: "even split", amount: "100.50", divisor: "2", expected: "50.25"},
+		{name: "keeps division precision", amount: "10", divisor: "3", expected: "3.3333333333333333"},
+		{name: "negative divisor", amount: "100", divisor: "-4", expected: "-25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			money, _ := domain.NewMoney(decimal.RequireFromString(tt.amount), domain.CurrencyUSD)
+
+			// Act
+			result, err := money.Div(decimal.RequireFromString(tt.divisor))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, domain.CurrencyUSD, result.Currency())
+			assert.True(t, result.Amount().Equal(decimal.RequireFromString(tt.expected)), "got %s", result.Amount())
+		})
+	}
+
+	t.Run("division by zero", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		money, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
+
+		// Act
+		_, err := money.Div(decimal.Zero)
+
+		// Assert
+		var divErr *domain.DivisionByZeroError
+		require.ErrorAs(t, err, &divErr)
+		assert.Equal(t, money, divErr.Money)
+	})
+}
+
+func TestParseAmount_MatchesNewFromString(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{
+		"0", "-0", "1", "100", "1000.50", "0.01", "-12.345", "00012.30",
+		"123456789012345678", "1234567890123456.78", "1234567890123456789",
+		"99999999999999999999.99", "1e3", "1.5E-2", ".5", "1.", "+1", "-", "",
+		"1.2.3", "12a", " 1", "0x10", "1_000",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			amount, err := domain.ParseAmount(input)
+			expected, expectedErr := decimal.NewFromString(input)
+
+			// Assert
+			if expectedErr != nil {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, expected.Equal(amount), "expected %s, got %s", expected, amount)
+			assert.Equal(t, expected.String(), amount.String())
+			assert.Equal(t, expected.Exponent(), amount.Exponent())
+		})
+	}
+}
+
+func TestParseAmount_PlainAmountsAllocateLess(t *testing.T) {
+	// Act
+	fast := testing.AllocsPerRun(100, func() { _, _ = domain.ParseAmount("1234.56") })
+	general := testing.AllocsPerRun(100, func() { _, _ = decimal.NewFromString("1234.56") })
+
+	// Assert
+	assert.Less(t, fast, general)
+}
+
+var parsedAmount decimal.Decimal
+
+func BenchmarkParseAmount(b *testing.B) {
+	for _, input := range []string{"1234.56", "0.01", "1234567890123456789.12"} {
+		b.Run("ParseAmount/"+input, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				parsedAmount, _ = domain.ParseAmount(input)
+			}
+		})
+		b.Run("NewFromString/"+input, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				parsedAmount, _ = decimal.NewFromString(input)
+			}
+		})
+	}
+}
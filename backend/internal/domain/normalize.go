@@ -0,0 +1,30 @@
+package domain
+
+import "strings"
+
+// NormalizeEmail strips the whitespace clients commonly leave around an email
+// address. The address is otherwise kept as entered.
+func NormalizeEmail(email string) string {
+	return strings.TrimSpace(email)
+}
+
+// EmailDomain returns the lower-cased part of an email address after its last
+// "@", or an empty string when there is none.
+func EmailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(email[at+1:])
+}
+
+// NormalizeCurrencyCode strips surrounding whitespace and upper-cases a
+// currency code, so " usd " reads as "USD".
+func NormalizeCurrencyCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// NormalizeAmount strips surrounding whitespace from a decimal amount.
+func NormalizeAmount(amount string) string {
+	return strings.TrimSpace(amount)
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SubUnitPolicy decides what happens to amounts that are finer than the minor
+// unit of their currency, such as 0.0001 USD.
+//
+// Account balances are stored with the precision of the minor unit while ledger
+// records keep extra decimal places, so letting sub-unit amounts through makes
+// the stored balance drift from the ledger. Rejecting them is the safe default.
+type SubUnitPolicy int
+
+const (
+	// RejectSubUnit fails the operation with a SubUnitAmountError.
+	RejectSubUnit SubUnitPolicy = iota
+	// AllowSubUnit keeps the amount as it is.
+	AllowSubUnit
+	// RoundSubUnit rounds the amount half away from zero to the minor unit.
+	// Amounts that round to zero are rejected.
+	RoundSubUnit
+)
+
+func ParseSubUnitPolicy(value string) (SubUnitPolicy, error) {
+	switch strings.ToLower(value) {
+	case "", "reject":
+		return RejectSubUnit, nil
+	case "allow":
+		return AllowSubUnit, nil
+	case "round":
+		return RoundSubUnit, nil
+	default:
+		return RejectSubUnit, fmt.Errorf("%q is not a valid sub-unit policy", value)
+	}
+}
+
+func (p SubUnitPolicy) String() string {
+	switch p {
+	case AllowSubUnit:
+		return "allow"
+	case RoundSubUnit:
+		return "round"
+	default:
+		return "reject"
+	}
+}
+
+func (p SubUnitPolicy) Apply(money Money) (Money, error) {
+	places := money.Currency().MinorUnitDecimals()
+	if money.Amount().Equal(money.Amount().Truncate(places)) {
+		return money, nil
+	}
+
+	switch p {
+	case AllowSubUnit:
+		return money, nil
+	case RoundSubUnit:
+		rounded := money.Amount().Round(places)
+		if rounded.IsZero() {
+			return Money{}, NewSubUnitAmountError(money)
+		}
+		return NewMoney(rounded, money.Currency())
+	default:
+		return Money{}, NewSubUnitAmountError(money)
+	}
+}
@@ -0,0 +1,64 @@
+package domain_test
+
+import (
+	"testing"
+
+	"minibankingplatform/internal/domain"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubUnitPolicy_Apply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		policy    domain.SubUnitPolicy
+		amount    string
+		expected  string
+		expectErr bool
+	}{
+		{name: "reject passes whole minor units", policy: domain.RejectSubUnit, amount: "10.50", expected: "10.5"},
+		{name: "reject fails sub-unit amount", policy: domain.RejectSubUnit, amount: "0.0001", expectErr: true},
+		{name: "allow keeps sub-unit amount", policy: domain.AllowSubUnit, amount: "0.0001", expected: "0.0001"},
+		{name: "round rounds to minor unit", policy: domain.RoundSubUnit, amount: "10.005", expected: "10.01"},
+		{name: "round fails amount rounding to zero", policy: domain.RoundSubUnit, amount: "0.0001", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			money, err := domain.NewMoney(decimal.RequireFromString(tt.amount), domain.CurrencyUSD)
+			require.NoError(t, err)
+
+			// Act
+			result, err := tt.policy.Apply(money)
+
+			// Assert
+			if tt.expectErr {
+				var subUnitErr *domain.SubUnitAmountError
+				assert.ErrorAs(t, err, &subUnitErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, result.Amount().Equal(decimal.RequireFromString(tt.expected)),
+				"expected %s, got %s", tt.expected, result.Amount())
+			assert.Equal(t, domain.CurrencyUSD, result.Currency())
+		})
+	}
+}
+
+func TestParseSubUnitPolicy_DefaultsToReject(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	policy, err := domain.ParseSubUnitPolicy("")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.RejectSubUnit, policy)
+}
@@ -30,7 +30,7 @@ func NewTransaction(id TransactionID, transactionType TransactionType, account A
 		id:              id,
 		transactionType: transactionType,
 		account:         account,
-		time:            time,
+		time:            time.UTC(),
 	}
 }
 
@@ -131,6 +131,7 @@ type TransactionWithDetails struct {
 	transaction     *Transaction
 	transferDetails *TransferDetailsView
 	exchangeDetails *ExchangeDetailsView
+	inconsistency   *InconsistentTransactionDetailsError
 }
 
 func NewTransactionWithDetails(
@@ -145,6 +146,18 @@ func NewTransactionWithDetails(
 	}
 }
 
+// NewInconsistentTransactionWithDetails marks a stored transaction whose
+// detail rows don't match its type. It carries no details.
+func NewInconsistentTransactionWithDetails(
+	transaction *Transaction,
+	inconsistency *InconsistentTransactionDetailsError,
+) *TransactionWithDetails {
+	return &TransactionWithDetails{
+		transaction:   transaction,
+		inconsistency: inconsistency,
+	}
+}
+
 func (t *TransactionWithDetails) Transaction() *Transaction {
 	return t.transaction
 }
@@ -156,3 +169,9 @@ func (t *TransactionWithDetails) TransferDetails() *TransferDetailsView {
 func (t *TransactionWithDetails) ExchangeDetails() *ExchangeDetailsView {
 	return t.exchangeDetails
 }
+
+// Inconsistency is set when the stored details of the transaction don't match
+// its type, in which case neither kind of details is available.
+func (t *TransactionWithDetails) Inconsistency() *InconsistentTransactionDetailsError {
+	return t.inconsistency
+}
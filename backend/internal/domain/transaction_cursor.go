@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransactionCursor points at the last transaction of a page of a list
+// ordered newest first. The next page holds the transactions before it.
+type TransactionCursor struct {
+	Time time.Time
+	ID   TransactionID
+}
+
+// NewTransactionCursor returns the cursor pointing at the transaction.
+func NewTransactionCursor(transaction *Transaction) TransactionCursor {
+	return TransactionCursor{Time: transaction.Time(), ID: transaction.ID()}
+}
+
+// ParseTransactionCursor decodes a cursor produced by TransactionCursor.String.
+func ParseTransactionCursor(s string) (TransactionCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return TransactionCursor{}, NewInvalidCursorError(s)
+	}
+
+	// The timestamp holds colons itself, the id never does.
+	separator := strings.LastIndexByte(string(decoded), ':')
+	if separator < 0 {
+		return TransactionCursor{}, NewInvalidCursorError(s)
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, string(decoded[:separator]))
+	if err != nil {
+		return TransactionCursor{}, NewInvalidCursorError(s)
+	}
+
+	id, err := uuid.Parse(string(decoded[separator+1:]))
+	if err != nil {
+		return TransactionCursor{}, NewInvalidCursorError(s)
+	}
+
+	return TransactionCursor{Time: timestamp.UTC(), ID: TransactionID(id)}, nil
+}
+
+// String encodes the cursor as base64 of "timestamp:id", opaque to clients.
+func (c TransactionCursor) String() string {
+	raw := fmt.Sprintf("%s:%s", c.Time.UTC().Format(time.RFC3339Nano), uuid.UUID(c.ID))
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
@@ -0,0 +1,61 @@
+package domain_test
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionCursor_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	at := time.Date(2026, 1, 15, 10, 30, 0, 123456000, time.FixedZone("CET", 3600))
+	cursor := domain.TransactionCursor{Time: at, ID: domain.NewTransactionID()}
+
+	// Act
+	parsed, err := domain.ParseTransactionCursor(cursor.String())
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, parsed.Time.Equal(at))
+	assert.Equal(t, time.UTC, parsed.Time.Location())
+	assert.Equal(t, cursor.ID, parsed.ID)
+}
+
+func TestParseTransactionCursor_Invalid(t *testing.T) {
+	t.Parallel()
+
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "not a cursor!"},
+		{name: "no separator", cursor: encode("2026-01-15T10:30:00Z")},
+		{name: "bad timestamp", cursor: encode("yesterday:6a1f1e4e-0d55-4c4f-9d58-1b1f7f3e2a11")},
+		{name: "bad id", cursor: encode("2026-01-15T10:30:00Z:42")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			_, err := domain.ParseTransactionCursor(tt.cursor)
+
+			// Assert
+			var invalidErr *domain.InvalidCursorError
+			require.ErrorAs(t, err, &invalidErr)
+			assert.Equal(t, tt.cursor, invalidErr.Cursor)
+		})
+	}
+}
@@ -9,6 +9,22 @@ import (
 
 type TransferService struct{}
 
+// ExecuteUserTransfer is Execute for transfers requested by users, who may not
+// pay into a cashbook account. System operations such as registration funding
+// and account sweeps call Execute directly.
+func (ts *TransferService) ExecuteUserTransfer(
+	from *Account,
+	to *Account,
+	money Money,
+	now time.Time,
+) (*TransferDetails, error) {
+	if to.IsCashbook() {
+		return nil, NewCashbookTransferError(to.ID())
+	}
+
+	return ts.Execute(from, to, money, now)
+}
+
 func (ts *TransferService) Execute(
 	from *Account,
 	to *Account,
@@ -19,6 +35,10 @@ func (ts *TransferService) Execute(
 		return nil, NewNegativeTransferError(money)
 	}
 
+	if money.Currency() != from.Balance().Currency() {
+		return nil, NewCurrencyMismatchError(from.Balance().Currency(), money.Currency())
+	}
+
 	if err := from.Debit(money); err != nil {
 		return nil, fmt.Errorf("cannot debit from %s: %w", from.ID(), err)
 	}
@@ -50,12 +70,25 @@ type TransferDetails struct {
 }
 
 func NewTransferDetails(id TransferDetailsID, from AccountID, to AccountID, money Money, time time.Time) (*TransferDetails, error) {
+	return newTransferDetailsOfType(id, TransactionTypeTransfer, from, to, money, time)
+}
+
+// newTransferDetailsOfType builds details of a single-currency movement that
+// is booked like a transfer, such as a deposit or a withdrawal.
+func newTransferDetailsOfType(
+	id TransferDetailsID,
+	transactionType TransactionType,
+	from AccountID,
+	to AccountID,
+	money Money,
+	time time.Time,
+) (*TransferDetails, error) {
 	return &TransferDetails{
 		id:          id,
-		transaction: NewTransaction(NewTransactionID(), TransactionTypeTransfer, from, time),
+		transaction: NewTransaction(NewTransactionID(), transactionType, from, time),
 		recipient:   to,
 		money:       money,
-		time:        time,
+		time:        time.UTC(),
 	}, nil
 }
 
@@ -67,6 +100,10 @@ func (td *TransferDetails) TransactionID() TransactionID {
 	return td.transaction.ID()
 }
 
+func (td *TransferDetails) Type() TransactionType {
+	return td.transaction.Type()
+}
+
 func (td *TransferDetails) Sender() AccountID {
 	return td.transaction.Account()
 }
@@ -87,7 +124,7 @@ func (td *TransferDetails) GetLedgerEntry() (LedgerEntry, error) {
 	first := NewLedgerRecord(NewLedgerRecordID(), td.TransactionID(), td.Sender(), td.Money().ToNegative(), td.Time())
 	second := NewLedgerRecord(NewLedgerRecordID(), td.TransactionID(), td.Recipient(), td.Money(), td.Time())
 
-	sum, err := first.Money().Add(second.Money())
+	sum, err := SumMoney(td.Money().Currency(), first.Money(), second.Money())
 	if err != nil {
 		return LedgerEntry{}, fmt.Errorf("cannot add money to first: %w", err)
 	}
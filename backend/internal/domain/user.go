@@ -1,12 +1,21 @@
 package domain
 
 import (
+	"fmt"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	// MinPasswordLength is the least number of characters of a password.
+	MinPasswordLength = 8
+	// MaxPasswordLength is the most bytes of a password, bcrypt ignores the rest.
+	MaxPasswordLength = 72
+)
+
 type User struct {
 	id           UserID
 	email        string
@@ -16,12 +25,16 @@ type User struct {
 }
 
 func NewUser(id UserID, email string, password string) (*User, error) {
+	if err := ValidatePassword(password); err != nil {
+		return nil, err
+	}
+
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
 		return nil, err
 	}
 
-	now := time.Now()
+	now := time.Now().UTC()
 	return &User{
 		id:           id,
 		email:        email,
@@ -36,8 +49,8 @@ func NewUserFromDB(id UserID, email string, passwordHash string, createdAt, upda
 		id:           id,
 		email:        email,
 		passwordHash: passwordHash,
-		createdAt:    createdAt,
-		updatedAt:    updatedAt,
+		createdAt:    createdAt.UTC(),
+		updatedAt:    updatedAt.UTC(),
 	}
 }
 
@@ -66,6 +79,42 @@ func (u *User) CheckPassword(password string) bool {
 	return err == nil
 }
 
+// ChangePassword replaces the password once oldPassword is confirmed to be the
+// current one. It fails with *InvalidCredentialsError otherwise and with
+// *InvalidPasswordError when newPassword breaks the password policy.
+func (u *User) ChangePassword(oldPassword, newPassword string, now time.Time) error {
+	if !u.CheckPassword(oldPassword) {
+		return NewInvalidCredentialsError()
+	}
+
+	if err := ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("hashing password: %w", err)
+	}
+
+	u.passwordHash = string(hash)
+	u.updatedAt = now.UTC()
+
+	return nil
+}
+
+// ValidatePassword checks the password against the password policy.
+func ValidatePassword(password string) error {
+	if utf8.RuneCountInString(password) < MinPasswordLength {
+		return NewInvalidPasswordError(fmt.Sprintf("must be at least %d characters long", MinPasswordLength))
+	}
+
+	if len(password) > MaxPasswordLength {
+		return NewInvalidPasswordError(fmt.Sprintf("must be at most %d bytes long", MaxPasswordLength))
+	}
+
+	return nil
+}
+
 func GenerateUserID() UserID {
 	return UserID(uuid.New())
 }
@@ -0,0 +1,90 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePassword(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  bool
+	}{
+		{name: "minimum length", password: "12345678"},
+		{name: "too short", password: "1234567", wantErr: true},
+		{name: "multi-byte characters count once", password: "ääääääää"},
+		{name: "bcrypt limit", password: strings.Repeat("a", 72)},
+		{name: "beyond bcrypt limit", password: strings.Repeat("a", 73), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			err := domain.ValidatePassword(tt.password)
+
+			// Assert
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			var invalidErr *domain.InvalidPasswordError
+			assert.ErrorAs(t, err, &invalidErr)
+		})
+	}
+}
+
+func TestUser_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	t.Run("replaces the password", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		user, err := domain.NewUser(domain.GenerateUserID(), "user@example.com", "oldPassword1")
+		require.NoError(t, err)
+		now := time.Now().Add(time.Hour)
+
+		// Act
+		err = user.ChangePassword("oldPassword1", "newPassword2", now)
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, user.CheckPassword("oldPassword1"))
+		assert.True(t, user.CheckPassword("newPassword2"))
+		assert.True(t, user.UpdatedAt().Equal(now))
+	})
+
+	t.Run("wrong old password", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		user, err := domain.NewUser(domain.GenerateUserID(), "user@example.com", "oldPassword1")
+		require.NoError(t, err)
+
+		// Act
+		err = user.ChangePassword("guessedPassword", "newPassword2", time.Now())
+
+		// Assert
+		var credentialsErr *domain.InvalidCredentialsError
+		require.ErrorAs(t, err, &credentialsErr)
+		assert.True(t, user.CheckPassword("oldPassword1"))
+	})
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "user@example.com", domain.NormalizeEmail("  user@example.com\t\n"))
+	assert.Equal(t, "User@Example.com", domain.NormalizeEmail("User@Example.com"), "case is kept")
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type WithdrawalService struct{}
+
+// Execute pays amount out of the account into the cashbook of its currency.
+// It fails with InsufficientFundsError when the account balance would go
+// negative.
+func (ws *WithdrawalService) Execute(
+	account *Account,
+	cashbook *Account,
+	amount Money,
+	now time.Time,
+) (*TransferDetails, error) {
+	if err := checkCashbookMovement(account, cashbook, amount); err != nil {
+		return nil, err
+	}
+
+	if err := account.Debit(amount); err != nil {
+		return nil, fmt.Errorf("cannot debit from %s: %w", account.ID(), err)
+	}
+
+	if err := cashbook.Credit(amount); err != nil {
+		return nil, fmt.Errorf("cannot credit to %s: %w", cashbook.ID(), err)
+	}
+
+	withdrawal, err := newTransferDetailsOfType(NewTransferDetailsID(), TransactionTypeWithdrawal, account.ID(), cashbook.ID(), amount, now)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create withdrawal details: %w", err)
+	}
+
+	return withdrawal, nil
+}
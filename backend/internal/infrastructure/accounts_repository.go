@@ -28,20 +28,26 @@ func (ar *AccountsRepository) GetForUpdate(ctx context.Context, accountID domain
 		    id,
 		    user_id,
 		    balance,
-		    currency
+		    currency,
+		    status,
+		    daily_limit,
+		    label
 		FROM accounts
 		WHERE id = $1
 		FOR UPDATE
 	`
 
 	var (
-		id       uuid.UUID
-		userID   uuid.UUID
-		amount   decimal.Decimal
-		currency string
+		id         uuid.UUID
+		userID     uuid.UUID
+		amount     decimal.Decimal
+		currency   string
+		status     string
+		dailyLimit *decimal.Decimal
+		label      *string
 	)
 
-	err := ar.injector.DB(ctx).QueryRow(ctx, query, uuid.UUID(accountID)).Scan(&id, &userID, &amount, &currency)
+	err := ar.injector.DB(ctx).QueryRow(ctx, query, uuid.UUID(accountID)).Scan(&id, &userID, &amount, &currency, &status, &dailyLimit, &label)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.NewAccountNotFoundError(accountID)
@@ -49,36 +55,47 @@ func (ar *AccountsRepository) GetForUpdate(ctx context.Context, accountID domain
 		return nil, fmt.Errorf("querying account: %w", err)
 	}
 
-	balance, err := domain.NewMoney(amount, domain.Currency(currency))
+	balance, err := storedMoney("accounts", id, amount, domain.Currency(currency))
 	if err != nil {
 		return nil, fmt.Errorf("creating money: %w", err)
 	}
 
-	return domain.NewAccount(domain.AccountID(id), domain.UserID(userID), balance), nil
+	return newAccount(id, userID, balance, status, dailyLimit, label), nil
 }
 
 func (ar *AccountsRepository) Save(ctx context.Context, account *domain.Account) error {
 	const query = `
-		INSERT INTO accounts (id, user_id, balance, currency)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO accounts (id, user_id, balance, currency, status, daily_limit, label)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''))
 		ON CONFLICT (id) DO UPDATE
 		SET 
 		    balance = EXCLUDED.balance,
-		    currency = EXCLUDED.currency
+		    status = EXCLUDED.status,
+		    daily_limit = EXCLUDED.daily_limit,
+		    label = EXCLUDED.label
+		WHERE accounts.currency = EXCLUDED.currency
 	`
 
-	_, err := ar.injector.DB(ctx).Exec(
+	tag, err := ar.injector.DB(ctx).Exec(
 		ctx,
 		query,
 		uuid.UUID(account.ID()),
 		uuid.UUID(account.UserID()),
 		account.Balance().Amount(),
 		account.Balance().Currency(),
+		account.Status(),
+		account.DailyLimit(),
+		account.Label(),
 	)
 	if err != nil {
 		return fmt.Errorf("upserting account: %w", err)
 	}
 
+	// The currency is fixed at creation, an existing row in another currency is left untouched
+	if tag.RowsAffected() == 0 {
+		return domain.NewAccountCurrencyChangeError(account.ID(), account.Balance().Currency())
+	}
+
 	return nil
 }
 
@@ -94,18 +111,23 @@ func (ar *AccountsRepository) Count(ctx context.Context) (int, error) {
 	return count, nil
 }
 
-func (ar *AccountsRepository) GetByUserID(ctx context.Context, userID domain.UserID) ([]*domain.Account, error) {
+// GetByUserID returns the user's accounts. Closed accounts are skipped unless includeClosed is set.
+func (ar *AccountsRepository) GetByUserID(ctx context.Context, userID domain.UserID, includeClosed bool) ([]*domain.Account, error) {
 	const query = `
 		SELECT 
 		    id,
 		    user_id,
 		    balance,
-		    currency
+		    currency,
+		    status,
+		    daily_limit,
+		    label
 		FROM accounts
 		WHERE user_id = $1
+		  AND ($2 OR status <> 'closed')
 	`
 
-	rows, err := ar.injector.DB(ctx).Query(ctx, query, uuid.UUID(userID))
+	rows, err := readDB(ctx, ar.injector).Query(ctx, query, uuid.UUID(userID), includeClosed)
 	if err != nil {
 		return nil, fmt.Errorf("querying accounts by user_id: %w", err)
 	}
@@ -114,22 +136,25 @@ func (ar *AccountsRepository) GetByUserID(ctx context.Context, userID domain.Use
 	var accounts []*domain.Account
 	for rows.Next() {
 		var (
-			id       uuid.UUID
-			uid      uuid.UUID
-			amount   decimal.Decimal
-			currency string
+			id         uuid.UUID
+			uid        uuid.UUID
+			amount     decimal.Decimal
+			currency   string
+			status     string
+			dailyLimit *decimal.Decimal
+			label      *string
 		)
 
-		if err := rows.Scan(&id, &uid, &amount, &currency); err != nil {
+		if err := rows.Scan(&id, &uid, &amount, &currency, &status, &dailyLimit, &label); err != nil {
 			return nil, fmt.Errorf("scanning account row: %w", err)
 		}
 
-		balance, err := domain.NewMoney(amount, domain.Currency(currency))
+		balance, err := storedMoney("accounts", id, amount, domain.Currency(currency))
 		if err != nil {
 			return nil, fmt.Errorf("creating money: %w", err)
 		}
 
-		accounts = append(accounts, domain.NewAccount(domain.AccountID(id), domain.UserID(uid), balance))
+		accounts = append(accounts, newAccount(id, uid, balance, status, dailyLimit, label))
 	}
 
 	if err := rows.Err(); err != nil {
@@ -139,25 +164,74 @@ func (ar *AccountsRepository) GetByUserID(ctx context.Context, userID domain.Use
 	return accounts, nil
 }
 
+// SumBalancesByUserID returns the total balance of the user's accounts per
+// currency. Currencies the user has no account in are absent.
+func (ar *AccountsRepository) SumBalancesByUserID(ctx context.Context, userID domain.UserID) (map[domain.Currency]domain.Money, error) {
+	// One account of each currency is selected, so an unsupported stored
+	// currency is reported with a row holding it.
+	const query = `
+		SELECT currency, SUM(balance), (array_agg(id ORDER BY id))[1]
+		FROM accounts
+		WHERE user_id = $1
+		GROUP BY currency
+	`
+
+	rows, err := readDB(ctx, ar.injector).Query(ctx, query, uuid.UUID(userID))
+	if err != nil {
+		return nil, fmt.Errorf("summing balances by currency: %w", err)
+	}
+	defer rows.Close()
+
+	totals := make(map[domain.Currency]domain.Money)
+	for rows.Next() {
+		var (
+			currency  string
+			total     decimal.Decimal
+			accountID uuid.UUID
+		)
+		if err := rows.Scan(&currency, &total, &accountID); err != nil {
+			return nil, fmt.Errorf("scanning balance total: %w", err)
+		}
+
+		money, err := storedMoney("accounts", accountID, total, domain.Currency(currency))
+		if err != nil {
+			return nil, err
+		}
+		totals[money.Currency()] = money
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating balance totals: %w", err)
+	}
+
+	return totals, nil
+}
+
 func (ar *AccountsRepository) Get(ctx context.Context, accountID domain.AccountID) (*domain.Account, error) {
 	const query = `
 		SELECT
 		    id,
 		    user_id,
 		    balance,
-		    currency
+		    currency,
+		    status,
+		    daily_limit,
+		    label
 		FROM accounts
 		WHERE id = $1
 	`
 
 	var (
-		id       uuid.UUID
-		userID   uuid.UUID
-		amount   decimal.Decimal
-		currency string
+		id         uuid.UUID
+		userID     uuid.UUID
+		amount     decimal.Decimal
+		currency   string
+		status     string
+		dailyLimit *decimal.Decimal
+		label      *string
 	)
 
-	err := ar.injector.DB(ctx).QueryRow(ctx, query, uuid.UUID(accountID)).Scan(&id, &userID, &amount, &currency)
+	err := readDB(ctx, ar.injector).QueryRow(ctx, query, uuid.UUID(accountID)).Scan(&id, &userID, &amount, &currency, &status, &dailyLimit, &label)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.NewAccountNotFoundError(accountID)
@@ -165,10 +239,18 @@ func (ar *AccountsRepository) Get(ctx context.Context, accountID domain.AccountI
 		return nil, fmt.Errorf("querying account: %w", err)
 	}
 
-	balance, err := domain.NewMoney(amount, domain.Currency(currency))
+	balance, err := storedMoney("accounts", id, amount, domain.Currency(currency))
 	if err != nil {
 		return nil, fmt.Errorf("creating money: %w", err)
 	}
 
-	return domain.NewAccount(domain.AccountID(id), domain.UserID(userID), balance), nil
+	return newAccount(id, userID, balance, status, dailyLimit, label), nil
+}
+
+func newAccount(id uuid.UUID, userID uuid.UUID, balance domain.Money, status string, dailyLimit *decimal.Decimal, label *string) *domain.Account {
+	account := domain.NewAccountFromDB(domain.AccountID(id), domain.UserID(userID), balance, domain.AccountStatus(status), dailyLimit)
+	if label != nil {
+		account.WithLabel(*label)
+	}
+	return account
 }
@@ -0,0 +1,58 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"minibankingplatform/internal/domain"
+)
+
+// CachingExchangeRateProvider remembers the rates of another provider, asking
+// it again for a pair at most once per TTL. Failed lookups are not cached.
+type CachingExchangeRateProvider struct {
+	provider domain.ExchangeRateProvider
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	rates map[currencyPair]cachedExchangeRate
+}
+
+type cachedExchangeRate struct {
+	rate      domain.ExchangeRate
+	fetchedAt time.Time
+}
+
+func NewCachingExchangeRateProvider(provider domain.ExchangeRateProvider, ttl time.Duration) *CachingExchangeRateProvider {
+	return &CachingExchangeRateProvider{
+		provider: provider,
+		ttl:      ttl,
+		now:      time.Now,
+		rates:    make(map[currencyPair]cachedExchangeRate),
+	}
+}
+
+func (p *CachingExchangeRateProvider) GetRate(ctx context.Context, from domain.Currency, to domain.Currency) (domain.ExchangeRate, error) {
+	pair := currencyPair{from: from, to: to}
+
+	p.mu.Lock()
+	cached, ok := p.rates[pair]
+	p.mu.Unlock()
+	if ok && p.now().Sub(cached.fetchedAt) < p.ttl {
+		return cached.rate, nil
+	}
+
+	// Fetched without holding the lock, so a slow provider doesn't hold up
+	// pairs that are cached. Concurrent misses of a pair may fetch it twice.
+	rate, err := p.provider.GetRate(ctx, from, to)
+	if err != nil {
+		return domain.ExchangeRate{}, err
+	}
+
+	p.mu.Lock()
+	p.rates[pair] = cachedExchangeRate{rate: rate, fetchedAt: p.now()}
+	p.mu.Unlock()
+
+	return rate, nil
+}
@@ -0,0 +1,28 @@
+package infrastructure
+
+import (
+	"log/slog"
+
+	"minibankingplatform/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// LogCashbookAlerter reports cashbook threshold alerts to a logger.
+type LogCashbookAlerter struct {
+	logger *slog.Logger
+}
+
+// NewLogCashbookAlerter reports cashbook threshold alerts to logger.
+func NewLogCashbookAlerter(logger *slog.Logger) *LogCashbookAlerter {
+	return &LogCashbookAlerter{logger: logger}
+}
+
+func (a *LogCashbookAlerter) CashbookThresholdCrossed(cashbook domain.AccountID, balance domain.Money, threshold domain.Money) {
+	a.logger.Warn("cashbook balance crossed threshold",
+		slog.String("cashbook", uuid.UUID(cashbook).String()),
+		slog.String("balance", balance.Amount().String()),
+		slog.String("currency", string(balance.Currency())),
+		slog.String("threshold", threshold.Amount().String()),
+	)
+}
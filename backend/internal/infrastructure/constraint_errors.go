@@ -0,0 +1,54 @@
+package infrastructure
+
+import (
+	"errors"
+
+	"minibankingplatform/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// checkViolation is the SQLSTATE of a violated CHECK constraint.
+const checkViolation = "23514"
+
+// uniqueViolation is the SQLSTATE of a violated unique constraint or index.
+const uniqueViolation = "23505"
+
+// usersEmailLowerIndex keeps emails unique regardless of case.
+const usersEmailLowerIndex = "idx_users_email_lower"
+
+// isUniqueViolation reports whether err is a violation of the named unique
+// constraint or index.
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == constraint
+}
+
+// positiveAmountConstraints are the CHECK constraints requiring amounts above
+// zero, with the error reported for a negative amount.
+var positiveAmountConstraints = map[string]func(domain.Money) error{
+	"transfer_positive_amount":        func(m domain.Money) error { return domain.NewNegativeTransferError(m) },
+	"exchange_positive_source_amount": func(m domain.Money) error { return domain.NewNegativeExchangeError(m) },
+	"exchange_positive_target_amount": func(m domain.Money) error { return domain.NewNegativeExchangeError(m) },
+}
+
+// amountConstraintError turns a violated positive-amount constraint into a
+// domain error for the amount checked by that constraint. Other errors are
+// returned unchanged.
+func amountConstraintError(err error, amounts map[string]domain.Money) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != checkViolation {
+		return err
+	}
+
+	negativeAmountError, ok := positiveAmountConstraints[pgErr.ConstraintName]
+	amount, checked := amounts[pgErr.ConstraintName]
+	if !ok || !checked {
+		return err
+	}
+
+	if amount.IsNegative() {
+		return negativeAmountError(amount)
+	}
+	return domain.NewZeroAmountError(amount.Currency())
+}
@@ -0,0 +1,131 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/pkg/trm"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// ExchangeRateHistoryEntry is a rate reported by a provider at a point in time.
+type ExchangeRateHistoryEntry struct {
+	Rate        domain.ExchangeRate
+	EffectiveAt time.Time
+	Source      string
+}
+
+type ExchangeRateHistoryRepository struct {
+	injector *trm.Injector[DBTX]
+}
+
+func NewExchangeRateHistoryRepository(injector *trm.Injector[DBTX]) *ExchangeRateHistoryRepository {
+	return &ExchangeRateHistoryRepository{injector: injector}
+}
+
+func (r *ExchangeRateHistoryRepository) Insert(ctx context.Context, entry ExchangeRateHistoryEntry) error {
+	const query = `
+		INSERT INTO exchange_rate_history (id, from_currency, to_currency, rate, effective_at, source)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.injector.DB(ctx).Exec(ctx, query,
+		uuid.New(),
+		entry.Rate.From(),
+		entry.Rate.To(),
+		entry.Rate.Rate(),
+		entry.EffectiveAt,
+		entry.Source,
+	)
+	if err != nil {
+		return fmt.Errorf("inserting exchange rate history entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetLatestRate returns the most recent entry of the pair. It fails with
+// *domain.ExchangeRateNotFoundError when the pair has no history.
+func (r *ExchangeRateHistoryRepository) GetLatestRate(ctx context.Context, from, to domain.Currency) (ExchangeRateHistoryEntry, error) {
+	const query = `
+		SELECT rate, effective_at, source
+		FROM exchange_rate_history
+		WHERE from_currency = $1 AND to_currency = $2
+		ORDER BY effective_at DESC, id DESC
+		LIMIT 1
+	`
+
+	var (
+		rate        decimal.Decimal
+		effectiveAt time.Time
+		source      string
+	)
+	err := readDB(ctx, r.injector).QueryRow(ctx, query, from, to).Scan(&rate, &effectiveAt, &source)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ExchangeRateHistoryEntry{}, domain.NewExchangeRateNotFoundError(from, to)
+		}
+		return ExchangeRateHistoryEntry{}, fmt.Errorf("querying latest exchange rate: %w", err)
+	}
+
+	return newExchangeRateHistoryEntry(from, to, rate, effectiveAt, source)
+}
+
+// GetSince returns the entries of the pair that took effect at or after since,
+// oldest first.
+func (r *ExchangeRateHistoryRepository) GetSince(ctx context.Context, from, to domain.Currency, since time.Time) ([]ExchangeRateHistoryEntry, error) {
+	const query = `
+		SELECT rate, effective_at, source
+		FROM exchange_rate_history
+		WHERE from_currency = $1 AND to_currency = $2 AND effective_at >= $3
+		ORDER BY effective_at, id
+	`
+
+	rows, err := readDB(ctx, r.injector).Query(ctx, query, from, to, since)
+	if err != nil {
+		return nil, fmt.Errorf("querying exchange rate history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []ExchangeRateHistoryEntry
+	for rows.Next() {
+		var (
+			rate        decimal.Decimal
+			effectiveAt time.Time
+			source      string
+		)
+		if err := rows.Scan(&rate, &effectiveAt, &source); err != nil {
+			return nil, fmt.Errorf("scanning row: %w", err)
+		}
+
+		entry, err := newExchangeRateHistoryEntry(from, to, rate, effectiveAt, source)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+
+	return entries, nil
+}
+
+func newExchangeRateHistoryEntry(from, to domain.Currency, rate decimal.Decimal, effectiveAt time.Time, source string) (ExchangeRateHistoryEntry, error) {
+	exchangeRate, err := domain.NewExchangeRate(from, to, rate)
+	if err != nil {
+		return ExchangeRateHistoryEntry{}, fmt.Errorf("creating stored exchange rate: %w", err)
+	}
+
+	return ExchangeRateHistoryEntry{
+		Rate:        exchangeRate,
+		EffectiveAt: effectiveAt.UTC(),
+		Source:      source,
+	}, nil
+}
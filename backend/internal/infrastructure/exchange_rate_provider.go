@@ -1,32 +1,52 @@
 package infrastructure
 
 import (
+	"context"
+
 	"minibankingplatform/internal/domain"
 
 	"github.com/shopspring/decimal"
 )
 
+type currencyPair struct {
+	from domain.Currency
+	to   domain.Currency
+}
+
+// fixedGBPRates are the hard-coded GBP rates until a real rate source is wired in.
+var fixedGBPRates = map[currencyPair]decimal.Decimal{
+	{from: domain.CurrencyUSD, to: domain.CurrencyGBP}: decimal.RequireFromString("0.79"),
+	{from: domain.CurrencyEUR, to: domain.CurrencyGBP}: decimal.RequireFromString("0.86"),
+}
+
 type FixedExchangeRateProvider struct {
-	usdToEurRate decimal.Decimal
+	rates map[currencyPair]decimal.Decimal
 }
 
 func NewFixedExchangeRateProvider(usdToEurRate decimal.Decimal) *FixedExchangeRateProvider {
+	rates := map[currencyPair]decimal.Decimal{
+		{from: domain.CurrencyUSD, to: domain.CurrencyEUR}: usdToEurRate,
+	}
+	for pair, rate := range fixedGBPRates {
+		rates[pair] = rate
+	}
+
 	return &FixedExchangeRateProvider{
-		usdToEurRate: usdToEurRate,
+		rates: rates,
 	}
 }
 
-func (p *FixedExchangeRateProvider) GetRate(from domain.Currency, to domain.Currency) (domain.ExchangeRate, error) {
+func (p *FixedExchangeRateProvider) GetRate(_ context.Context, from domain.Currency, to domain.Currency) (domain.ExchangeRate, error) {
 	if from == to {
 		return domain.ExchangeRate{}, domain.NewSameCurrencyExchangeRateError(from)
 	}
 
-	if from == domain.CurrencyUSD && to == domain.CurrencyEUR {
-		return domain.NewExchangeRate(from, to, p.usdToEurRate)
+	if rate, ok := p.rates[currencyPair{from: from, to: to}]; ok {
+		return domain.NewExchangeRate(from, to, rate)
 	}
 
-	if from == domain.CurrencyEUR && to == domain.CurrencyUSD {
-		inverseRate := decimal.NewFromInt(1).Div(p.usdToEurRate).Round(6)
+	if rate, ok := p.rates[currencyPair{from: to, to: from}]; ok {
+		inverseRate := decimal.NewFromInt(1).Div(rate).Round(6)
 		return domain.NewExchangeRate(from, to, inverseRate)
 	}
 
@@ -0,0 +1,47 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/infrastructure"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixedExchangeRateProvider_GetRate(t *testing.T) {
+	t.Parallel()
+
+	provider := infrastructure.NewFixedExchangeRateProvider(decimal.RequireFromString("0.92"))
+
+	tests := []struct {
+		from     domain.Currency
+		to       domain.Currency
+		expected string
+	}{
+		{from: domain.CurrencyUSD, to: domain.CurrencyEUR, expected: "0.92"},
+		{from: domain.CurrencyEUR, to: domain.CurrencyUSD, expected: "1.086957"},
+		{from: domain.CurrencyUSD, to: domain.CurrencyGBP, expected: "0.79"},
+		{from: domain.CurrencyGBP, to: domain.CurrencyUSD, expected: "1.265823"},
+		{from: domain.CurrencyEUR, to: domain.CurrencyGBP, expected: "0.86"},
+		{from: domain.CurrencyGBP, to: domain.CurrencyEUR, expected: "1.162791"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from.String()+"->"+tt.to.String(), func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			rate, err := provider.GetRate(context.Background(), tt.from, tt.to)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.from, rate.From())
+			assert.Equal(t, tt.to, rate.To())
+			assert.True(t, rate.Rate().Equal(decimal.RequireFromString(tt.expected)), "got %s", rate.Rate())
+		})
+	}
+}
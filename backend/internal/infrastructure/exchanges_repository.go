@@ -2,11 +2,15 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"minibankingplatform/internal/domain"
 	"minibankingplatform/pkg/trm"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
 )
 
 type ExchangesRepository struct {
@@ -18,8 +22,9 @@ func NewExchangesRepository(injector *trm.Injector[DBTX]) *ExchangesRepository {
 }
 
 func (er *ExchangesRepository) Insert(ctx context.Context, exchange *domain.ExchangeDetails) error {
-	// TODO: it's better to have nested transaction here,
-	//  but pgx factory doesn't support it for now
+	// The inserts must succeed or fail together with the balance updates of the
+	// caller, so they join its transaction. Callers wanting to recover from a
+	// failed insert can wrap it in a nested trm.Do, which uses a savepoint.
 	if !er.injector.HasContextTransaction(ctx) {
 		return fmt.Errorf("insert command must be called inside of running transaction")
 	}
@@ -47,6 +52,73 @@ func (er *ExchangesRepository) Insert(ctx context.Context, exchange *domain.Exch
 	return nil
 }
 
+// GetByID returns the exchange with the given exchange_details id together
+// with its enclosing transaction.
+func (er *ExchangesRepository) GetByID(ctx context.Context, id domain.ExchangeDetailsID) (*domain.TransactionWithDetails, error) {
+	const query = `
+		SELECT
+			t.id, t.account_id, t.timestamp,
+			ed.source_account_id, ed.target_account_id,
+			ed.source_amount, ed.source_currency,
+			ed.target_amount, ed.target_currency, ed.exchange_rate
+		FROM exchange_details ed
+		JOIN transactions t ON t.id = ed.transaction_id
+		WHERE ed.id = $1
+	`
+
+	var (
+		txID           uuid.UUID
+		txAccountID    uuid.UUID
+		txTimestamp    time.Time
+		sourceAccount  uuid.UUID
+		targetAccount  uuid.UUID
+		sourceAmount   decimal.Decimal
+		sourceCurrency string
+		targetAmount   decimal.Decimal
+		targetCurrency string
+		exchangeRate   decimal.Decimal
+	)
+
+	err := readDB(ctx, er.injector).QueryRow(ctx, query, uuid.UUID(id)).Scan(
+		&txID, &txAccountID, &txTimestamp,
+		&sourceAccount, &targetAccount,
+		&sourceAmount, &sourceCurrency,
+		&targetAmount, &targetCurrency, &exchangeRate,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewExchangeNotFoundError(id)
+		}
+		return nil, fmt.Errorf("scanning exchange row: %w", err)
+	}
+
+	source, err := storedMoney("exchange_details", uuid.UUID(id), sourceAmount, domain.Currency(sourceCurrency))
+	if err != nil {
+		return nil, fmt.Errorf("creating exchange source money: %w", err)
+	}
+	target, err := storedMoney("exchange_details", uuid.UUID(id), targetAmount, domain.Currency(targetCurrency))
+	if err != nil {
+		return nil, fmt.Errorf("creating exchange target money: %w", err)
+	}
+
+	transaction := domain.NewTransaction(
+		domain.TransactionID(txID),
+		domain.TransactionTypeExchange,
+		domain.AccountID(txAccountID),
+		txTimestamp,
+	)
+	details := domain.NewExchangeDetailsView(
+		uuid.UUID(id),
+		domain.AccountID(sourceAccount),
+		domain.AccountID(targetAccount),
+		source,
+		target,
+		exchangeRate,
+	)
+
+	return domain.NewTransactionWithDetails(transaction, nil, details), nil
+}
+
 func (er *ExchangesRepository) insertTransaction(ctx context.Context, exchange *domain.ExchangeDetails) error {
 	const query = `
 		INSERT INTO transactions (id, type, account_id, timestamp)
@@ -77,9 +149,10 @@ func (er *ExchangesRepository) insertDetails(ctx context.Context, exchange *doma
 			source_currency,
 			target_amount,
 			target_currency,
-			exchange_rate
+			exchange_rate,
+			created_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 
 	_, err := er.injector.DB(ctx).Exec(
@@ -94,9 +167,13 @@ func (er *ExchangesRepository) insertDetails(ctx context.Context, exchange *doma
 		exchange.TargetAmount().Amount(),
 		exchange.TargetAmount().Currency(),
 		exchange.ExchangeRate(),
+		exchange.Time(),
 	)
 	if err != nil {
-		return fmt.Errorf("executing query: %w", err)
+		return fmt.Errorf("executing query: %w", amountConstraintError(err, map[string]domain.Money{
+			"exchange_positive_source_amount": exchange.SourceAmount(),
+			"exchange_positive_target_amount": exchange.TargetAmount(),
+		}))
 	}
 
 	return nil
@@ -104,7 +181,7 @@ func (er *ExchangesRepository) insertDetails(ctx context.Context, exchange *doma
 
 func (er *ExchangesRepository) insertLedgerEntries(ctx context.Context, entries domain.ExchangeLedgerEntries) error {
 	for i, record := range entries.Records() {
-		err := er.insertLedgerRecord(ctx, record)
+		err := insertLedgerRecord(ctx, er.injector.DB(ctx), record)
 		if err != nil {
 			return fmt.Errorf("inserting ledger record %d: %w", i+1, err)
 		}
@@ -112,24 +189,3 @@ func (er *ExchangesRepository) insertLedgerEntries(ctx context.Context, entries
 
 	return nil
 }
-
-func (er *ExchangesRepository) insertLedgerRecord(ctx context.Context, ledgerRecord *domain.LedgerRecord) error {
-	const query = `
-		INSERT INTO ledger (id, transaction, account, amount, currency, timestamp)
-		VALUES ($1, $2, $3, $4, $5, $6)
-	`
-
-	_, err := er.injector.DB(ctx).Exec(ctx, query,
-		uuid.UUID(ledgerRecord.ID()),
-		uuid.UUID(ledgerRecord.Transaction()),
-		uuid.UUID(ledgerRecord.Account()),
-		ledgerRecord.Money().Amount(),
-		ledgerRecord.Money().Currency(),
-		ledgerRecord.Time(),
-	)
-	if err != nil {
-		return fmt.Errorf("executing query: %w", err)
-	}
-
-	return nil
-}
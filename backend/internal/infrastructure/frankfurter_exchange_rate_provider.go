@@ -0,0 +1,74 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"minibankingplatform/internal/domain"
+)
+
+// FrankfurterBaseURL is the public Frankfurter API, serving the reference
+// rates of the European Central Bank.
+const FrankfurterBaseURL = "https://api.frankfurter.app"
+
+// FrankfurterExchangeRateProvider fetches the latest rate of every requested
+// pair from a Frankfurter API. Requests are bounded by the caller's ctx and
+// the client's timeout.
+type FrankfurterExchangeRateProvider struct {
+	client  *http.Client
+	baseURL string
+}
+
+func NewFrankfurterExchangeRateProvider(client *http.Client, baseURL string) *FrankfurterExchangeRateProvider {
+	return &FrankfurterExchangeRateProvider{
+		client:  client,
+		baseURL: baseURL,
+	}
+}
+
+// frankfurterLatest is the body of a /latest response. Rates are kept as
+// json.Number so that they are parsed into decimals without a float detour.
+type frankfurterLatest struct {
+	Base  string                 `json:"base"`
+	Rates map[string]json.Number `json:"rates"`
+}
+
+func (p *FrankfurterExchangeRateProvider) GetRate(ctx context.Context, from domain.Currency, to domain.Currency) (domain.ExchangeRate, error) {
+	if from == to {
+		return domain.ExchangeRate{}, domain.NewSameCurrencyExchangeRateError(from)
+	}
+
+	query := url.Values{"from": {string(from)}, "to": {string(to)}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/latest?"+query.Encode(), nil)
+	if err != nil {
+		return domain.ExchangeRate{}, fmt.Errorf("building frankfurter request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return domain.ExchangeRate{}, fmt.Errorf("requesting frankfurter rate %s/%s: %w", from, to, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return domain.ExchangeRate{}, domain.NewExchangeRateNotFoundError(from, to)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return domain.ExchangeRate{}, fmt.Errorf("requesting frankfurter rate %s/%s: unexpected status %s", from, to, resp.Status)
+	}
+
+	var latest frankfurterLatest
+	if err := json.NewDecoder(resp.Body).Decode(&latest); err != nil {
+		return domain.ExchangeRate{}, fmt.Errorf("decoding frankfurter response: %w", err)
+	}
+
+	rate, ok := latest.Rates[string(to)]
+	if !ok || latest.Base != string(from) {
+		return domain.ExchangeRate{}, domain.NewExchangeRateNotFoundError(from, to)
+	}
+
+	return domain.ParseExchangeRate(latest.Base, string(to), rate.String())
+}
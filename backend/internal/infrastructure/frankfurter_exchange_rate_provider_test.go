@@ -0,0 +1,189 @@
+package infrastructure_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/infrastructure"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFrankfurterServer simulates the Frankfurter /latest endpoint, answering
+// every request with status and body and counting the requests.
+func newFrankfurterServer(t *testing.T, status int, body string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, "/latest", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestFrankfurterExchangeRateProvider_GetRate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should parse the latest rate", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		var query string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+			_, _ = w.Write([]byte(`{"amount":1.0,"base":"USD","date":"2026-10-15","rates":{"EUR":0.91835}}`))
+		}))
+		t.Cleanup(server.Close)
+		provider := infrastructure.NewFrankfurterExchangeRateProvider(server.Client(), server.URL)
+
+		// Act
+		rate, err := provider.GetRate(context.Background(), domain.CurrencyUSD, domain.CurrencyEUR)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "from=USD&to=EUR", query)
+		assert.Equal(t, domain.CurrencyUSD, rate.From())
+		assert.Equal(t, domain.CurrencyEUR, rate.To())
+		assert.Equal(t, "0.91835", rate.Rate().String())
+	})
+
+	t.Run("should report a missing rate as not found", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		server, _ := newFrankfurterServer(t, http.StatusOK, `{"amount":1.0,"base":"USD","date":"2026-10-15","rates":{}}`)
+		provider := infrastructure.NewFrankfurterExchangeRateProvider(server.Client(), server.URL)
+
+		// Act
+		_, err := provider.GetRate(context.Background(), domain.CurrencyUSD, domain.CurrencyGBP)
+
+		// Assert
+		var notFoundErr *domain.ExchangeRateNotFoundError
+		require.ErrorAs(t, err, &notFoundErr)
+	})
+
+	t.Run("should fail on server errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		server, _ := newFrankfurterServer(t, http.StatusBadGateway, `{"message":"upstream unavailable"}`)
+		provider := infrastructure.NewFrankfurterExchangeRateProvider(server.Client(), server.URL)
+
+		// Act
+		_, err := provider.GetRate(context.Background(), domain.CurrencyUSD, domain.CurrencyEUR)
+
+		// Assert
+		require.ErrorContains(t, err, "502")
+	})
+
+	t.Run("should reject non-positive rates", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		server, _ := newFrankfurterServer(t, http.StatusOK, `{"amount":1.0,"base":"USD","date":"2026-10-15","rates":{"EUR":0}}`)
+		provider := infrastructure.NewFrankfurterExchangeRateProvider(server.Client(), server.URL)
+
+		// Act
+		_, err := provider.GetRate(context.Background(), domain.CurrencyUSD, domain.CurrencyEUR)
+
+		// Assert
+		var invalidRateErr *domain.InvalidExchangeRateError
+		require.ErrorAs(t, err, &invalidRateErr)
+	})
+
+	t.Run("should give up when the caller's context is done", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		server, _ := newFrankfurterServer(t, http.StatusOK, `{"amount":1.0,"base":"USD","date":"2026-10-15","rates":{"EUR":0.91835}}`)
+		provider := infrastructure.NewFrankfurterExchangeRateProvider(server.Client(), server.URL)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// Act
+		_, err := provider.GetRate(ctx, domain.CurrencyUSD, domain.CurrencyEUR)
+
+		// Assert
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("should not ask for a same currency rate", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		server, requests := newFrankfurterServer(t, http.StatusOK, `{}`)
+		provider := infrastructure.NewFrankfurterExchangeRateProvider(server.Client(), server.URL)
+
+		// Act
+		_, err := provider.GetRate(context.Background(), domain.CurrencyUSD, domain.CurrencyUSD)
+
+		// Assert
+		var sameCurrencyErr *domain.SameCurrencyExchangeRateError
+		require.ErrorAs(t, err, &sameCurrencyErr)
+		assert.Zero(t, requests.Load())
+	})
+}
+
+func TestCachingExchangeRateProvider_GetRate(t *testing.T) {
+	t.Parallel()
+
+	const body = `{"amount":1.0,"base":"USD","date":"2026-10-15","rates":{"EUR":0.92}}`
+
+	t.Run("should fetch a pair once per ttl", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		const ttl = 50 * time.Millisecond
+		server, requests := newFrankfurterServer(t, http.StatusOK, body)
+		provider := infrastructure.NewCachingExchangeRateProvider(
+			infrastructure.NewFrankfurterExchangeRateProvider(server.Client(), server.URL),
+			ttl,
+		)
+
+		// Act & Assert
+		for range 3 {
+			rate, err := provider.GetRate(context.Background(), domain.CurrencyUSD, domain.CurrencyEUR)
+			require.NoError(t, err)
+			assert.Equal(t, "0.92", rate.Rate().String())
+		}
+		assert.Equal(t, int32(1), requests.Load())
+
+		time.Sleep(ttl)
+
+		_, err := provider.GetRate(context.Background(), domain.CurrencyUSD, domain.CurrencyEUR)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("should not cache failures", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		server, requests := newFrankfurterServer(t, http.StatusInternalServerError, `{}`)
+		provider := infrastructure.NewCachingExchangeRateProvider(
+			infrastructure.NewFrankfurterExchangeRateProvider(server.Client(), server.URL),
+			time.Hour,
+		)
+
+		// Act
+		_, firstErr := provider.GetRate(context.Background(), domain.CurrencyUSD, domain.CurrencyEUR)
+		_, secondErr := provider.GetRate(context.Background(), domain.CurrencyUSD, domain.CurrencyEUR)
+
+		// Assert
+		require.Error(t, firstErr)
+		require.Error(t, secondErr)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+}
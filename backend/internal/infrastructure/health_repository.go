@@ -0,0 +1,26 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"minibankingplatform/pkg/trm"
+)
+
+type HealthRepository struct {
+	injector *trm.Injector[DBTX]
+}
+
+func NewHealthRepository(injector *trm.Injector[DBTX]) *HealthRepository {
+	return &HealthRepository{injector: injector}
+}
+
+// SelectOne runs a trivial query, inside the context transaction when there is one.
+func (hr *HealthRepository) SelectOne(ctx context.Context) error {
+	var one int
+	err := hr.injector.DB(ctx).QueryRow(ctx, `SELECT 1`).Scan(&one)
+	if err != nil {
+		return fmt.Errorf("selecting one: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/pkg/trm"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// IdempotencyRecord is the response stored under an idempotency key, with the
+// endpoint and the hash of the request it answered.
+type IdempotencyRecord struct {
+	Endpoint    string
+	RequestHash string
+	Response    json.RawMessage
+}
+
+type IdempotencyKeysRepository struct {
+	injector *trm.Injector[DBTX]
+}
+
+func NewIdempotencyKeysRepository(injector *trm.Injector[DBTX]) *IdempotencyKeysRepository {
+	return &IdempotencyKeysRepository{injector: injector}
+}
+
+// Find returns the record stored under the user's key at or after since. The
+// boolean is false when the key has not been used since then.
+func (ir *IdempotencyKeysRepository) Find(
+	ctx context.Context,
+	userID domain.UserID,
+	key string,
+	since time.Time,
+) (IdempotencyRecord, bool, error) {
+	const query = `
+		SELECT endpoint, request_hash, response_body
+		FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2 AND created_at >= $3
+	`
+
+	var record IdempotencyRecord
+	err := readDB(ctx, ir.injector).QueryRow(ctx, query, uuid.UUID(userID), key, since).
+		Scan(&record.Endpoint, &record.RequestHash, &record.Response)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return IdempotencyRecord{}, false, nil
+		}
+		return IdempotencyRecord{}, false, fmt.Errorf("querying idempotency key: %w", err)
+	}
+
+	return record, true, nil
+}
+
+// Insert stores the record under the user's key, replacing one stored before
+// expiredBefore. It fails with *domain.IdempotencyKeyUsedError when the key
+// already has a newer record, also one stored by a concurrent transaction.
+func (ir *IdempotencyKeysRepository) Insert(
+	ctx context.Context,
+	userID domain.UserID,
+	key string,
+	record IdempotencyRecord,
+	expiredBefore time.Time,
+) error {
+	const query = `
+		INSERT INTO idempotency_keys (user_id, key, endpoint, request_hash, response_body)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, key) DO UPDATE
+		SET endpoint = EXCLUDED.endpoint,
+		    request_hash = EXCLUDED.request_hash,
+		    response_body = EXCLUDED.response_body,
+		    created_at = NOW()
+		WHERE idempotency_keys.created_at < $6
+	`
+
+	tag, err := ir.injector.DB(ctx).Exec(ctx, query,
+		uuid.UUID(userID), key, record.Endpoint, record.RequestHash, record.Response, expiredBefore,
+	)
+	if err != nil {
+		return fmt.Errorf("inserting idempotency key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.NewIdempotencyKeyUsedError(key)
+	}
+
+	return nil
+}
+
+// DeleteExpired removes the records stored before expiredBefore and returns
+// how many there were.
+func (ir *IdempotencyKeysRepository) DeleteExpired(ctx context.Context, expiredBefore time.Time) (int64, error) {
+	const query = `DELETE FROM idempotency_keys WHERE created_at < $1`
+
+	tag, err := ir.injector.DB(ctx).Exec(ctx, query, expiredBefore)
+	if err != nil {
+		return 0, fmt.Errorf("deleting expired idempotency keys: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,90 @@
+package infrastructure
+
+import (
+	"sync"
+	"time"
+)
+
+// InMemoryInFlightRegistry tracks keys of requests that are being processed by
+// this instance. A key stays taken while its request is in flight and, if the
+// request succeeded, for the rest of the dedup window after it was acquired.
+// Entries are lost on restart.
+type InMemoryInFlightRegistry struct {
+	mu      sync.Mutex
+	window  time.Duration
+	now     func() time.Time
+	entries map[string]inFlightEntry
+	// acquisitions lists keys in the order they were acquired, so the entries
+	// whose window is over are found at its front without scanning them all.
+	acquisitions []inFlightAcquisition
+}
+
+type inFlightEntry struct {
+	acquiredAt time.Time
+	released   bool
+}
+
+type inFlightAcquisition struct {
+	key        string
+	acquiredAt time.Time
+}
+
+func NewInMemoryInFlightRegistry(window time.Duration) *InMemoryInFlightRegistry {
+	return &InMemoryInFlightRegistry{
+		window:  window,
+		now:     time.Now,
+		entries: make(map[string]inFlightEntry),
+	}
+}
+
+func (r *InMemoryInFlightRegistry) TryAcquire(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.evictExpired(now)
+
+	if _, taken := r.entries[key]; taken {
+		return false
+	}
+
+	r.entries[key] = inFlightEntry{acquiredAt: now}
+	r.acquisitions = append(r.acquisitions, inFlightAcquisition{key: key, acquiredAt: now})
+	return true
+}
+
+func (r *InMemoryInFlightRegistry) Release(key string, succeeded bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[key]
+	if !ok {
+		return
+	}
+
+	if !succeeded || r.now().Sub(entry.acquiredAt) >= r.window {
+		delete(r.entries, key)
+		return
+	}
+
+	entry.released = true
+	r.entries[key] = entry
+}
+
+// evictExpired drops released entries whose window is over. Entries still in
+// flight are dropped by Release instead, and acquisitions of keys that were
+// freed or acquired again since are skipped.
+func (r *InMemoryInFlightRegistry) evictExpired(now time.Time) {
+	for len(r.acquisitions) > 0 {
+		oldest := r.acquisitions[0]
+		if now.Sub(oldest.acquiredAt) < r.window {
+			return
+		}
+		r.acquisitions = r.acquisitions[1:]
+
+		entry, ok := r.entries[oldest.key]
+		if ok && entry.released && entry.acquiredAt.Equal(oldest.acquiredAt) {
+			delete(r.entries, oldest.key)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package infrastructure_test
+
+import (
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/infrastructure"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryInFlightRegistry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should keep a succeeded key taken until the window is over", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		const window = 50 * time.Millisecond
+		registry := infrastructure.NewInMemoryInFlightRegistry(window)
+
+		// Act & Assert
+		assert.True(t, registry.TryAcquire("transfer"))
+		assert.False(t, registry.TryAcquire("transfer"), "in flight")
+
+		registry.Release("transfer", true)
+		assert.False(t, registry.TryAcquire("transfer"), "within the window")
+
+		time.Sleep(window)
+		assert.True(t, registry.TryAcquire("transfer"))
+	})
+
+	t.Run("should free a failed key at once", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		registry := infrastructure.NewInMemoryInFlightRegistry(time.Minute)
+		assert.True(t, registry.TryAcquire("transfer"))
+
+		// Act
+		registry.Release("transfer", false)
+
+		// Assert
+		assert.True(t, registry.TryAcquire("transfer"))
+		assert.False(t, registry.TryAcquire("transfer"))
+	})
+
+	t.Run("should not evict a key acquired again after it failed", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange - the first acquisition fails, the second succeeds later
+		const window = 50 * time.Millisecond
+		registry := infrastructure.NewInMemoryInFlightRegistry(window)
+		assert.True(t, registry.TryAcquire("transfer"))
+		registry.Release("transfer", false)
+
+		time.Sleep(window / 2)
+		assert.True(t, registry.TryAcquire("transfer"))
+		registry.Release("transfer", true)
+
+		// Act - the first acquisition's window is over, the second one's isn't
+		time.Sleep(window / 2)
+
+		// Assert
+		assert.False(t, registry.TryAcquire("transfer"))
+	})
+}
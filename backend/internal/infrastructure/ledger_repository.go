@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"minibankingplatform/internal/domain"
 	"minibankingplatform/pkg/trm"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -66,6 +67,30 @@ func (lr *LedgerRepository) GetAccountBalance(ctx context.Context, accountID dom
 	return money, nil
 }
 
+// GetAccountBalanceAtDate sums the ledger records of the account booked at or
+// before asOf, which is the balance the account had at that time.
+func (lr *LedgerRepository) GetAccountBalanceAtDate(
+	ctx context.Context,
+	accountID domain.AccountID,
+	currency domain.Currency,
+	asOf time.Time,
+) (domain.Money, error) {
+	const query = `SELECT COALESCE(SUM(amount), 0) FROM ledger WHERE account = $1 AND timestamp <= $2`
+
+	var amount decimal.Decimal
+	err := readDB(ctx, lr.injector).QueryRow(ctx, query, uuid.UUID(accountID), asOf).Scan(&amount)
+	if err != nil {
+		return domain.Money{}, fmt.Errorf("querying account ledger balance at %s: %w", asOf.Format(time.RFC3339), err)
+	}
+
+	money, err := domain.NewMoney(amount, currency)
+	if err != nil {
+		return domain.Money{}, fmt.Errorf("creating money: %w", err)
+	}
+
+	return money, nil
+}
+
 type AccountBalanceMismatch struct {
 	AccountID      domain.AccountID
 	AccountBalance decimal.Decimal
@@ -82,10 +107,10 @@ func (lr *LedgerRepository) GetAccountBalanceMismatches(ctx context.Context) ([]
 			a.currency
 		FROM accounts a
 		LEFT JOIN (
-			SELECT account, SUM(amount) as ledger_sum
+			SELECT account, currency, SUM(amount) as ledger_sum
 			FROM ledger
-			GROUP BY account
-		) l ON a.id = l.account
+			GROUP BY account, currency
+		) l ON a.id = l.account AND l.currency = a.currency
 		WHERE a.balance != COALESCE(l.ledger_sum, 0)
 	`
 
@@ -110,3 +135,247 @@ func (lr *LedgerRepository) GetAccountBalanceMismatches(ctx context.Context) ([]
 
 	return mismatches, nil
 }
+
+// AccountLedgerBalance compares an account's stored balance with the sum of
+// its ledger records.
+type AccountLedgerBalance struct {
+	AccountID      domain.AccountID
+	Currency       domain.Currency
+	AccountBalance decimal.Decimal
+	LedgerBalance  decimal.Decimal
+}
+
+// ForEachAccountLedgerBalance calls fn with the ledger balance of every
+// account, ordered by account id. Rows are handed over as they are read, so
+// the whole table is never held in memory. Iteration stops at the first error
+// returned by fn.
+func (lr *LedgerRepository) ForEachAccountLedgerBalance(ctx context.Context, fn func(AccountLedgerBalance) error) error {
+	const query = `
+		SELECT
+			a.id,
+			a.currency,
+			a.balance,
+			COALESCE(l.ledger_sum, 0) as ledger_sum
+		FROM accounts a
+		LEFT JOIN (
+			SELECT account, currency, SUM(amount) as ledger_sum
+			FROM ledger
+			GROUP BY account, currency
+		) l ON a.id = l.account AND l.currency = a.currency
+		ORDER BY a.id
+	`
+
+	rows, err := readDB(ctx, lr.injector).Query(ctx, query)
+	if err != nil {
+		return fmt.Errorf("querying account ledger balances: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b AccountLedgerBalance
+		if err := rows.Scan(&b.AccountID, &b.Currency, &b.AccountBalance, &b.LedgerBalance); err != nil {
+			return fmt.Errorf("scanning row: %w", err)
+		}
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating rows: %w", err)
+	}
+
+	return nil
+}
+
+type LedgerCurrencyMismatch struct {
+	RecordID        domain.LedgerRecordID
+	TransactionID   domain.TransactionID
+	AccountID       domain.AccountID
+	LedgerCurrency  domain.Currency
+	AccountCurrency domain.Currency
+}
+
+// GetLedgerCurrencyMismatches returns the ledger records booked in a currency
+// other than the one of their account.
+func (lr *LedgerRepository) GetLedgerCurrencyMismatches(ctx context.Context) ([]LedgerCurrencyMismatch, error) {
+	const query = `
+		SELECT l.id, l.transaction, l.account, l.currency, a.currency
+		FROM ledger l
+		JOIN accounts a ON a.id = l.account
+		WHERE l.currency != a.currency
+		ORDER BY l.timestamp, l.id
+	`
+
+	rows, err := lr.injector.DB(ctx).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("querying ledger currency mismatches: %w", err)
+	}
+	defer rows.Close()
+
+	var mismatches []LedgerCurrencyMismatch
+	for rows.Next() {
+		var m LedgerCurrencyMismatch
+		if err := rows.Scan(&m.RecordID, &m.TransactionID, &m.AccountID, &m.LedgerCurrency, &m.AccountCurrency); err != nil {
+			return nil, fmt.Errorf("scanning row: %w", err)
+		}
+		mismatches = append(mismatches, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+
+	return mismatches, nil
+}
+
+type AccountLedgerEntry struct {
+	RecordID       domain.LedgerRecordID
+	TransactionID  domain.TransactionID
+	Amount         domain.Money
+	RunningBalance domain.Money
+	Timestamp      time.Time
+}
+
+// GetAccountLedger returns the account's ledger records in chronological order
+// together with the balance after each record. Records sharing a timestamp are
+// ordered by id so the running balance is deterministic.
+func (lr *LedgerRepository) GetAccountLedger(ctx context.Context, accountID domain.AccountID) ([]AccountLedgerEntry, error) {
+	const query = `
+		SELECT
+			id,
+			transaction,
+			amount,
+			currency,
+			timestamp,
+			SUM(amount) OVER (ORDER BY timestamp, id) AS running_balance
+		FROM ledger
+		WHERE account = $1
+		ORDER BY timestamp, id
+	`
+
+	rows, err := lr.injector.DB(ctx).Query(ctx, query, uuid.UUID(accountID))
+	if err != nil {
+		return nil, fmt.Errorf("querying account ledger: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []AccountLedgerEntry
+	for rows.Next() {
+		var (
+			recordID       uuid.UUID
+			transactionID  uuid.UUID
+			amount         decimal.Decimal
+			currency       domain.Currency
+			timestamp      time.Time
+			runningBalance decimal.Decimal
+		)
+		if err := rows.Scan(&recordID, &transactionID, &amount, &currency, &timestamp, &runningBalance); err != nil {
+			return nil, fmt.Errorf("scanning row: %w", err)
+		}
+
+		amountMoney, err := storedMoney("ledger", recordID, amount, currency)
+		if err != nil {
+			return nil, fmt.Errorf("creating money: %w", err)
+		}
+
+		runningBalanceMoney, err := storedMoney("ledger", recordID, runningBalance, currency)
+		if err != nil {
+			return nil, fmt.Errorf("creating running balance money: %w", err)
+		}
+
+		entries = append(entries, AccountLedgerEntry{
+			RecordID:       domain.LedgerRecordID(recordID),
+			TransactionID:  domain.TransactionID(transactionID),
+			Amount:         amountMoney,
+			RunningBalance: runningBalanceMoney,
+			Timestamp:      timestamp.UTC(),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetByTransaction returns the ledger records of the transaction, grouped by
+// currency with the debit before the credit.
+func (lr *LedgerRepository) GetByTransaction(ctx context.Context, transactionID domain.TransactionID) ([]*domain.LedgerRecord, error) {
+	const query = `
+		SELECT id, account, amount, currency, timestamp
+		FROM ledger
+		WHERE transaction = $1
+		ORDER BY currency, amount, id
+	`
+
+	rows, err := readDB(ctx, lr.injector).Query(ctx, query, uuid.UUID(transactionID))
+	if err != nil {
+		return nil, fmt.Errorf("querying transaction ledger: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*domain.LedgerRecord
+	for rows.Next() {
+		var (
+			recordID  uuid.UUID
+			accountID uuid.UUID
+			amount    decimal.Decimal
+			currency  domain.Currency
+			timestamp time.Time
+		)
+		if err := rows.Scan(&recordID, &accountID, &amount, &currency, &timestamp); err != nil {
+			return nil, fmt.Errorf("scanning row: %w", err)
+		}
+
+		money, err := storedMoney("ledger", recordID, amount, currency)
+		if err != nil {
+			return nil, fmt.Errorf("creating money: %w", err)
+		}
+
+		records = append(records, domain.NewLedgerRecord(
+			domain.LedgerRecordID(recordID),
+			transactionID,
+			domain.AccountID(accountID),
+			money,
+			timestamp,
+		))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+
+	return records, nil
+}
+
+// insertLedgerRecord stores a ledger record for the transfers and exchanges
+// repositories. The row is only written if the record's currency matches its
+// account's, otherwise *domain.LedgerCurrencyMismatchError is returned.
+func insertLedgerRecord(ctx context.Context, db DBTX, ledgerRecord *domain.LedgerRecord) error {
+	const query = `
+		INSERT INTO ledger (id, transaction, account, amount, currency, timestamp)
+		SELECT $1::uuid, $2::uuid, a.id, $4::numeric, a.currency, $6::timestamptz
+		FROM accounts a
+		WHERE a.id = $3 AND a.currency = $5
+	`
+
+	tag, err := db.Exec(ctx, query,
+		uuid.UUID(ledgerRecord.ID()),
+		uuid.UUID(ledgerRecord.Transaction()),
+		uuid.UUID(ledgerRecord.Account()),
+		ledgerRecord.Money().Amount(),
+		ledgerRecord.Money().Currency(),
+		ledgerRecord.Time(),
+	)
+	if err != nil {
+		return fmt.Errorf("executing query: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.NewLedgerCurrencyMismatchError(ledgerRecord.ID(), ledgerRecord.Account(), ledgerRecord.Money().Currency())
+	}
+
+	return nil
+}
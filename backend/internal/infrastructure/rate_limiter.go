@@ -0,0 +1,62 @@
+package infrastructure
+
+import (
+	"sync"
+	"time"
+)
+
+// InMemoryRateLimiter admits at most limit requests per key within a fixed
+// window that starts with the key's first request. Counters are kept by this
+// instance only and are lost on restart.
+type InMemoryRateLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	window  time.Duration
+	now     func() time.Time
+	windows map[string]rateWindow
+}
+
+type rateWindow struct {
+	startedAt time.Time
+	requests  int
+}
+
+func NewInMemoryRateLimiter(limit int, window time.Duration) *InMemoryRateLimiter {
+	return &InMemoryRateLimiter{
+		limit:   limit,
+		window:  window,
+		now:     time.Now,
+		windows: make(map[string]rateWindow),
+	}
+}
+
+// Allow counts a request for key. When the key is over its limit, the request
+// is not counted and retryAfter tells how long until the window resets.
+func (l *InMemoryRateLimiter) Allow(key string) (allowed bool, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.evictExpired(now)
+
+	current, ok := l.windows[key]
+	if !ok {
+		current = rateWindow{startedAt: now}
+	}
+
+	if current.requests >= l.limit {
+		return false, current.startedAt.Add(l.window).Sub(now)
+	}
+
+	current.requests++
+	l.windows[key] = current
+	return true, 0
+}
+
+func (l *InMemoryRateLimiter) evictExpired(now time.Time) {
+	for key, current := range l.windows {
+		if now.Sub(current.startedAt) >= l.window {
+			delete(l.windows, key)
+		}
+	}
+}
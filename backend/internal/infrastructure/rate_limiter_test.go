@@ -0,0 +1,53 @@
+package infrastructure_test
+
+import (
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/infrastructure"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should reject requests over the limit until the window resets", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		const window = 50 * time.Millisecond
+		limiter := infrastructure.NewInMemoryRateLimiter(2, window)
+
+		// Act & Assert
+		first, _ := limiter.Allow("10.0.0.1")
+		second, _ := limiter.Allow("10.0.0.1")
+		third, retryAfter := limiter.Allow("10.0.0.1")
+
+		assert.True(t, first)
+		assert.True(t, second)
+		assert.False(t, third)
+		assert.Positive(t, retryAfter)
+		assert.LessOrEqual(t, retryAfter, window)
+
+		time.Sleep(window)
+
+		afterReset, _ := limiter.Allow("10.0.0.1")
+		assert.True(t, afterReset)
+	})
+
+	t.Run("should count keys separately", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		limiter := infrastructure.NewInMemoryRateLimiter(1, time.Minute)
+
+		// Act
+		first, _ := limiter.Allow("10.0.0.1")
+		other, _ := limiter.Allow("10.0.0.2")
+
+		// Assert
+		assert.True(t, first)
+		assert.True(t, other)
+	})
+}
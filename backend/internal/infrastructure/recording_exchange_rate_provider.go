@@ -0,0 +1,118 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/pkg/trm"
+
+	"github.com/shopspring/decimal"
+)
+
+// recordTimeout bounds the database work of recording a rate.
+const recordTimeout = 2 * time.Second
+
+// ExchangeRateRecorder stores the rates reported by a provider.
+type ExchangeRateRecorder interface {
+	Insert(ctx context.Context, entry ExchangeRateHistoryEntry) error
+	GetLatestRate(ctx context.Context, from, to domain.Currency) (ExchangeRateHistoryEntry, error)
+}
+
+// RecordingExchangeRateProvider passes on the rates of another provider and
+// records them in the exchange rate history whenever a pair's rate differs from
+// the one recorded last. Recording failures are logged, the rate is returned
+// regardless.
+type RecordingExchangeRateProvider struct {
+	provider domain.ExchangeRateProvider
+	recorder ExchangeRateRecorder
+	source   string
+	logger   *slog.Logger
+	now      func() time.Time
+
+	mu       sync.Mutex
+	recorded map[currencyPair]decimal.Decimal
+}
+
+// NewRecordingExchangeRateProvider records the rates of provider under the
+// given source name, such as "frankfurter".
+func NewRecordingExchangeRateProvider(
+	provider domain.ExchangeRateProvider,
+	recorder ExchangeRateRecorder,
+	source string,
+	logger *slog.Logger,
+) *RecordingExchangeRateProvider {
+	return &RecordingExchangeRateProvider{
+		provider: provider,
+		recorder: recorder,
+		source:   source,
+		logger:   logger,
+		now:      time.Now,
+		recorded: make(map[currencyPair]decimal.Decimal),
+	}
+}
+
+// GetRate returns the rate of provider. When ctx carries a transaction the rate
+// is recorded only after it commits, outside of it, so the recording neither
+// waits for nor holds the transaction's locks, and a rolled back one, like a
+// dry run, records nothing.
+func (p *RecordingExchangeRateProvider) GetRate(ctx context.Context, from domain.Currency, to domain.Currency) (domain.ExchangeRate, error) {
+	rate, err := p.provider.GetRate(ctx, from, to)
+	if err != nil {
+		return domain.ExchangeRate{}, err
+	}
+
+	trm.AfterCommit(ctx, func() {
+		p.record(trm.WithoutTransaction(ctx), rate)
+	})
+
+	return rate, nil
+}
+
+// record stores rate unless it is the pair's last recorded rate. The lock only
+// guards the known rates, so concurrent first lookups of a pair may both
+// record it.
+func (p *RecordingExchangeRateProvider) record(ctx context.Context, rate domain.ExchangeRate) {
+	pair := currencyPair{from: rate.From(), to: rate.To()}
+
+	ctx, cancel := context.WithTimeout(ctx, recordTimeout)
+	defer cancel()
+
+	p.mu.Lock()
+	last, known := p.recorded[pair]
+	p.mu.Unlock()
+
+	if !known {
+		// After a restart the history tells whether the rate changed meanwhile.
+		latest, err := p.recorder.GetLatestRate(ctx, pair.from, pair.to)
+		var notFoundErr *domain.ExchangeRateNotFoundError
+		switch {
+		case err == nil:
+			last, known = latest.Rate.Rate(), true
+		case !errors.As(err, &notFoundErr):
+			p.logger.LogAttrs(ctx, slog.LevelWarn, "getting latest recorded exchange rate failed",
+				slog.String("from", string(pair.from)), slog.String("to", string(pair.to)), slog.Any("error", err))
+			return
+		}
+	}
+
+	if !known || !last.Equal(rate.Rate()) {
+		err := p.recorder.Insert(ctx, ExchangeRateHistoryEntry{
+			Rate:        rate,
+			EffectiveAt: p.now().UTC(),
+			Source:      p.source,
+		})
+		if err != nil {
+			p.logger.LogAttrs(ctx, slog.LevelWarn, "recording exchange rate failed",
+				slog.String("from", string(pair.from)), slog.String("to", string(pair.to)), slog.Any("error", err))
+			return
+		}
+	}
+
+	p.mu.Lock()
+	p.recorded[pair] = rate.Rate()
+	p.mu.Unlock()
+}
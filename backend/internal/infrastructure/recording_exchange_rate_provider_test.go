@@ -0,0 +1,162 @@
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/infrastructure"
+	"minibankingplatform/pkg/trm"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// settableRateProvider reports a USD->EUR rate the test can change.
+type settableRateProvider struct {
+	rate string
+}
+
+func (p *settableRateProvider) GetRate(_ context.Context, from, to domain.Currency) (domain.ExchangeRate, error) {
+	return domain.NewExchangeRate(from, to, decimal.RequireFromString(p.rate))
+}
+
+// memoryRateRecorder keeps recorded entries in memory.
+type memoryRateRecorder struct {
+	entries   []infrastructure.ExchangeRateHistoryEntry
+	insertErr error
+}
+
+func (r *memoryRateRecorder) Insert(_ context.Context, entry infrastructure.ExchangeRateHistoryEntry) error {
+	if r.insertErr != nil {
+		return r.insertErr
+	}
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *memoryRateRecorder) GetLatestRate(_ context.Context, from, to domain.Currency) (infrastructure.ExchangeRateHistoryEntry, error) {
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		if r.entries[i].Rate.From() == from && r.entries[i].Rate.To() == to {
+			return r.entries[i], nil
+		}
+	}
+	return infrastructure.ExchangeRateHistoryEntry{}, domain.NewExchangeRateNotFoundError(from, to)
+}
+
+func TestRecordingExchangeRateProvider_RecordsChanges(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	source := &settableRateProvider{rate: "0.92"}
+	recorder := &memoryRateRecorder{}
+	sut := infrastructure.NewRecordingExchangeRateProvider(source, recorder, "test", slog.New(slog.DiscardHandler))
+
+	// Act - the same rate twice, then a new one
+	for _, rate := range []string{"0.92", "0.92", "0.93"} {
+		source.rate = rate
+		_, err := sut.GetRate(context.Background(), domain.CurrencyUSD, domain.CurrencyEUR)
+		require.NoError(t, err)
+	}
+
+	// Assert
+	require.Len(t, recorder.entries, 2)
+	assert.True(t, recorder.entries[0].Rate.Rate().Equal(decimal.RequireFromString("0.92")))
+	assert.True(t, recorder.entries[1].Rate.Rate().Equal(decimal.RequireFromString("0.93")))
+	assert.Equal(t, "test", recorder.entries[1].Source)
+	assert.False(t, recorder.entries[1].EffectiveAt.IsZero())
+}
+
+func TestRecordingExchangeRateProvider_ContinuesRecordedHistory(t *testing.T) {
+	t.Parallel()
+
+	// Arrange - the rate was recorded before a restart
+	recorder := &memoryRateRecorder{}
+	_, err := infrastructure.NewRecordingExchangeRateProvider(&settableRateProvider{rate: "0.92"}, recorder, "test", slog.New(slog.DiscardHandler)).
+		GetRate(context.Background(), domain.CurrencyUSD, domain.CurrencyEUR)
+	require.NoError(t, err)
+
+	sut := infrastructure.NewRecordingExchangeRateProvider(&settableRateProvider{rate: "0.92"}, recorder, "test", slog.New(slog.DiscardHandler))
+
+	// Act
+	_, err = sut.GetRate(context.Background(), domain.CurrencyUSD, domain.CurrencyEUR)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, recorder.entries, 1)
+}
+
+func TestRecordingExchangeRateProvider_RecordingFailureKeepsRate(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	recorder := &memoryRateRecorder{insertErr: errors.New("database unreachable")}
+	sut := infrastructure.NewRecordingExchangeRateProvider(&settableRateProvider{rate: "0.92"}, recorder, "test", slog.New(slog.DiscardHandler))
+
+	// Act
+	rate, err := sut.GetRate(context.Background(), domain.CurrencyUSD, domain.CurrencyEUR)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, rate.Rate().Equal(decimal.RequireFromString("0.92")))
+
+	// The rate is recorded once the recorder works again
+	recorder.insertErr = nil
+	_, err = sut.GetRate(context.Background(), domain.CurrencyUSD, domain.CurrencyEUR)
+	require.NoError(t, err)
+	assert.Len(t, recorder.entries, 1)
+}
+
+// noopTx is a transaction that only carries the callbacks registered in it.
+type noopTx struct{}
+
+func (noopTx) Raw() noopTx {
+	return noopTx{}
+}
+
+func (noopTx) Commit() error {
+	return nil
+}
+
+func (noopTx) Rollback() error {
+	return nil
+}
+
+func TestRecordingExchangeRateProvider_RecordsAfterCommit(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	ctx := context.Background()
+	manager := trm.NewTransactionManager(func(context.Context, any) (trm.Transaction[noopTx], error) {
+		return noopTx{}, nil
+	})
+	source := &settableRateProvider{rate: "0.92"}
+	recorder := &memoryRateRecorder{}
+	sut := infrastructure.NewRecordingExchangeRateProvider(source, recorder, "test", slog.New(slog.DiscardHandler))
+
+	// Act - a rate fetched in a committed transaction, then a new one in a
+	// rolled back transaction
+	err := manager.Do(ctx, func(ctx context.Context) error {
+		_, err := sut.GetRate(ctx, domain.CurrencyUSD, domain.CurrencyEUR)
+		require.NoError(t, err)
+		assert.Empty(t, recorder.entries, "the rate should not be recorded before the commit")
+		return nil
+	})
+	require.NoError(t, err)
+
+	source.rate = "0.93"
+	errRollback := errors.New("rolled back")
+	err = manager.Do(ctx, func(ctx context.Context) error {
+		_, err := sut.GetRate(ctx, domain.CurrencyUSD, domain.CurrencyEUR)
+		require.NoError(t, err)
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+
+	// Assert
+	require.Len(t, recorder.entries, 1)
+	assert.True(t, recorder.entries[0].Rate.Rate().Equal(decimal.RequireFromString("0.92")))
+}
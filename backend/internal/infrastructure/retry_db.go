@@ -0,0 +1,104 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"minibankingplatform/pkg/trm"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// readRetryDelay is how long a read waits before its single retry.
+const readRetryDelay = 50 * time.Millisecond
+
+// RetryingDBTX retries a failed query once when the failure happened at the
+// connection level, such as a dropped pooled connection. Query and logic
+// errors are returned as is. Exec is never retried: it is meant for reads only.
+type RetryingDBTX struct {
+	db    DBTX
+	delay time.Duration
+}
+
+func NewRetryingDBTX(db DBTX, delay time.Duration) *RetryingDBTX {
+	return &RetryingDBTX{db: db, delay: delay}
+}
+
+// readDB returns the DB to use for a read-only query. Reads outside of a
+// transaction are retried once on connection errors; inside a transaction a
+// broken connection has already doomed the transaction, so nothing is retried.
+func readDB(ctx context.Context, injector *trm.Injector[DBTX]) DBTX {
+	if injector.HasContextTransaction(ctx) {
+		return injector.DB(ctx)
+	}
+
+	return NewRetryingDBTX(injector.DB(ctx), readRetryDelay)
+}
+
+func (r *RetryingDBTX) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return r.db.Exec(ctx, sql, arguments...)
+}
+
+func (r *RetryingDBTX) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err == nil || !isConnectionError(err) || !r.wait(ctx) {
+		return rows, err
+	}
+
+	return r.db.Query(ctx, sql, args...)
+}
+
+func (r *RetryingDBTX) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return &retryingRow{ctx: ctx, db: r, sql: sql, args: args}
+}
+
+// wait sleeps for the retry delay and reports whether the context is still alive.
+func (r *RetryingDBTX) wait(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	timer := time.NewTimer(r.delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// retryingRow defers the query to Scan, where pgx reports QueryRow errors.
+type retryingRow struct {
+	ctx  context.Context
+	db   *RetryingDBTX
+	sql  string
+	args []any
+}
+
+func (row *retryingRow) Scan(dest ...any) error {
+	err := row.db.db.QueryRow(row.ctx, row.sql, row.args...).Scan(dest...)
+	if err == nil || !isConnectionError(err) || !row.db.wait(row.ctx) {
+		return err
+	}
+
+	return row.db.db.QueryRow(row.ctx, row.sql, row.args...).Scan(dest...)
+}
+
+func isConnectionError(err error) bool {
+	if pgconn.SafeToRetry(err) {
+		return true
+	}
+
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,134 @@
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"minibankingplatform/internal/infrastructure"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errConnectionReset = &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+
+// flakyDB fails the first calls with the given errors and succeeds afterwards.
+type flakyDB struct {
+	failures []error
+	calls    int
+}
+
+func (f *flakyDB) next() error {
+	f.calls++
+	if f.calls <= len(f.failures) {
+		return f.failures[f.calls-1]
+	}
+	return nil
+}
+
+func (f *flakyDB) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, f.next()
+}
+
+func (f *flakyDB) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return nil, f.next()
+}
+
+func (f *flakyDB) QueryRow(context.Context, string, ...any) pgx.Row {
+	return scanRow{err: f.next()}
+}
+
+type scanRow struct {
+	err error
+}
+
+func (r scanRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*dest[0].(*int) = 1
+	return nil
+}
+
+func TestRetryingDBTX_QueryRow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		failures      []error
+		expectedErr   error
+		expectedCalls int
+	}{
+		{name: "succeeds without retry", expectedCalls: 1},
+		{name: "connection error is retried once", failures: []error{errConnectionReset}, expectedCalls: 2},
+		{name: "second connection error is returned", failures: []error{errConnectionReset, errConnectionReset}, expectedErr: errConnectionReset, expectedCalls: 2},
+		{name: "query error is not retried", failures: []error{pgx.ErrNoRows}, expectedErr: pgx.ErrNoRows, expectedCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			db := &flakyDB{failures: tt.failures}
+			retrying := infrastructure.NewRetryingDBTX(db, 0)
+
+			// Act
+			var one int
+			err := retrying.QueryRow(context.Background(), "SELECT 1").Scan(&one)
+
+			// Assert
+			assert.Equal(t, tt.expectedCalls, db.calls)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 1, one)
+		})
+	}
+}
+
+func TestRetryingDBTX_Query(t *testing.T) {
+	t.Parallel()
+
+	db := &flakyDB{failures: []error{errConnectionReset}}
+	retrying := infrastructure.NewRetryingDBTX(db, 0)
+
+	_, err := retrying.Query(context.Background(), "SELECT 1")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, db.calls)
+}
+
+func TestRetryingDBTX_ExecIsNotRetried(t *testing.T) {
+	t.Parallel()
+
+	db := &flakyDB{failures: []error{errConnectionReset}}
+	retrying := infrastructure.NewRetryingDBTX(db, 0)
+
+	_, err := retrying.Exec(context.Background(), "UPDATE accounts SET balance = 0")
+
+	require.ErrorIs(t, err, errConnectionReset)
+	assert.Equal(t, 1, db.calls)
+}
+
+func TestRetryingDBTX_CancelledContextIsNotRetried(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	db := &flakyDB{failures: []error{errConnectionReset}}
+	retrying := infrastructure.NewRetryingDBTX(db, 0)
+
+	var one int
+	err := retrying.QueryRow(ctx, "SELECT 1").Scan(&one)
+
+	require.ErrorIs(t, err, errConnectionReset)
+	assert.Equal(t, 1, db.calls)
+}
@@ -0,0 +1,40 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"minibankingplatform/pkg/trm"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type SchemaRepository struct {
+	injector *trm.Injector[DBTX]
+}
+
+func NewSchemaRepository(injector *trm.Injector[DBTX]) *SchemaRepository {
+	return &SchemaRepository{injector: injector}
+}
+
+// GetNumericScale returns the declared scale of a numeric column in the current schema.
+func (sr *SchemaRepository) GetNumericScale(ctx context.Context, table, column string) (int32, error) {
+	const query = `
+		SELECT numeric_scale
+		FROM information_schema.columns
+		WHERE table_schema = current_schema()
+		  AND table_name = $1
+		  AND column_name = $2
+		  AND numeric_scale IS NOT NULL`
+
+	var scale int32
+	err := sr.injector.DB(ctx).QueryRow(ctx, query, table, column).Scan(&scale)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("numeric column %s.%s not found", table, column)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("querying numeric scale of %s.%s: %w", table, column, err)
+	}
+
+	return scale, nil
+}
@@ -0,0 +1,19 @@
+package infrastructure
+
+import (
+	"minibankingplatform/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// storedMoney restores Money read from the given table row. An unsupported
+// currency is reported together with the row, so the bad data can be found.
+func storedMoney(table string, rowID uuid.UUID, amount decimal.Decimal, currency domain.Currency) (domain.Money, error) {
+	money, err := domain.NewMoney(amount, currency)
+	if err != nil {
+		return domain.Money{}, domain.NewInvalidStoredCurrencyError(table, rowID, currency)
+	}
+
+	return money, nil
+}
@@ -2,20 +2,51 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"minibankingplatform/internal/domain"
 	"minibankingplatform/pkg/trm"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/shopspring/decimal"
 )
 
 type TransactionsFilter struct {
 	UserID          domain.UserID
 	TransactionType *domain.TransactionType
-	Limit           int
-	Offset          int
+	// AccountID narrows the list to transactions on either side of which the
+	// account stands.
+	AccountID *domain.AccountID
+	// From and To bound the transaction timestamp, both inclusive.
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
+	// After keeps only the transactions listed after the cursor, that is
+	// older ones or, at the same time, those with a smaller id.
+	After *domain.TransactionCursor
+}
+
+// args converts the optional filters into query arguments, leaving nil for
+// filters that are not set.
+func (f TransactionsFilter) args() (transactionType, accountID any) {
+	if f.TransactionType != nil {
+		transactionType = string(*f.TransactionType)
+	}
+	if f.AccountID != nil {
+		accountID = uuid.UUID(*f.AccountID)
+	}
+	return transactionType, accountID
+}
+
+// afterArgs converts the cursor into query arguments, both nil without one.
+func (f TransactionsFilter) afterArgs() (timestamp, id any) {
+	if f.After == nil {
+		return nil, nil
+	}
+	return f.After.Time, uuid.UUID(f.After.ID)
 }
 
 type TransactionsRepository struct {
@@ -26,6 +57,9 @@ func NewTransactionsRepository(injector *trm.Injector[DBTX]) *TransactionsReposi
 	return &TransactionsRepository{injector: injector}
 }
 
+// GetList returns a page of the filtered transactions. Transactions whose
+// detail rows don't match their type are listed marked, without details, so
+// one broken row doesn't hide the rest of the page.
 func (r *TransactionsRepository) GetList(ctx context.Context, filter TransactionsFilter) ([]*domain.TransactionWithDetails, error) {
 	const query = `
 		SELECT
@@ -36,22 +70,26 @@ func (r *TransactionsRepository) GetList(ctx context.Context, filter Transaction
 			ed.target_amount, ed.target_currency, ed.exchange_rate
 		FROM transactions t
 		JOIN accounts a ON t.account_id = a.id
-		LEFT JOIN transfer_details td ON t.id = td.transaction_id AND t.type = 'transfer'
+		LEFT JOIN transfer_details td ON t.id = td.transaction_id AND t.type IN ('transfer', 'deposit', 'withdrawal')
 		LEFT JOIN accounts a_recipient ON td.recipient_account_id = a_recipient.id
 		LEFT JOIN exchange_details ed ON t.id = ed.transaction_id AND t.type = 'exchange'
 		LEFT JOIN accounts a_target ON ed.target_account_id = a_target.id
 		WHERE ($1::transaction_type IS NULL OR t.type = $1)
 		  AND (a.user_id = $4 OR a_recipient.user_id = $4 OR a_target.user_id = $4)
-		ORDER BY t.timestamp DESC
+		  AND t.timestamp BETWEEN COALESCE($5, '-infinity'::timestamptz) AND COALESCE($6, 'infinity'::timestamptz)
+		  AND ($7::uuid IS NULL OR $7 IN (t.account_id, td.recipient_account_id, ed.target_account_id))
+		  AND ($8::timestamptz IS NULL OR (t.timestamp, t.id) < ($8, $9::uuid))
+		ORDER BY t.timestamp DESC, t.id DESC
 		LIMIT $2 OFFSET $3
 	`
 
-	var typeArg any
-	if filter.TransactionType != nil {
-		typeArg = string(*filter.TransactionType)
-	}
+	typeArg, accountArg := filter.args()
+	afterTimeArg, afterIDArg := filter.afterArgs()
 
-	rows, err := r.injector.DB(ctx).Query(ctx, query, typeArg, filter.Limit, filter.Offset, uuid.UUID(filter.UserID))
+	rows, err := readDB(ctx, r.injector).Query(ctx, query,
+		typeArg, filter.Limit, filter.Offset, uuid.UUID(filter.UserID), filter.From, filter.To, accountArg,
+		afterTimeArg, afterIDArg,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("querying transactions: %w", err)
 	}
@@ -59,86 +97,11 @@ func (r *TransactionsRepository) GetList(ctx context.Context, filter Transaction
 
 	var result []*domain.TransactionWithDetails
 	for rows.Next() {
-		var (
-			txID        uuid.UUID
-			txType      string
-			txAccountID uuid.UUID
-			txTimestamp time.Time
-
-			tdID          *uuid.UUID
-			tdRecipientID *uuid.UUID
-			tdAmount      *decimal.Decimal
-			tdCurrency    *string
-
-			edID             *uuid.UUID
-			edSourceAccID    *uuid.UUID
-			edTargetAccID    *uuid.UUID
-			edSourceAmount   *decimal.Decimal
-			edSourceCurrency *string
-			edTargetAmount   *decimal.Decimal
-			edTargetCurrency *string
-			edExchangeRate   *decimal.Decimal
-		)
-
-		err := rows.Scan(
-			&txID, &txType, &txAccountID, &txTimestamp,
-			&tdID, &tdRecipientID, &tdAmount, &tdCurrency,
-			&edID, &edSourceAccID, &edTargetAccID,
-			&edSourceAmount, &edSourceCurrency,
-			&edTargetAmount, &edTargetCurrency, &edExchangeRate,
-		)
+		transaction, err := scanTransactionWithDetails(rows)
 		if err != nil {
-			return nil, fmt.Errorf("scanning transaction row: %w", err)
-		}
-
-		transaction := domain.NewTransaction(
-			domain.TransactionID(txID),
-			domain.TransactionType(txType),
-			domain.AccountID(txAccountID),
-			txTimestamp,
-		)
-
-		var transferDetails *domain.TransferDetailsView
-		var exchangeDetails *domain.ExchangeDetailsView
-
-		if tdID != nil && tdRecipientID != nil && tdAmount != nil && tdCurrency != nil {
-			amount, err := domain.NewMoney(*tdAmount, domain.Currency(*tdCurrency))
-			if err != nil {
-				return nil, fmt.Errorf("creating transfer money: %w", err)
-			}
-			transferDetails = domain.NewTransferDetailsView(
-				*tdID,
-				domain.AccountID(*tdRecipientID),
-				amount,
-			)
+			return nil, err
 		}
-
-		if edID != nil && edSourceAccID != nil && edTargetAccID != nil &&
-			edSourceAmount != nil && edSourceCurrency != nil &&
-			edTargetAmount != nil && edTargetCurrency != nil && edExchangeRate != nil {
-			sourceAmount, err := domain.NewMoney(*edSourceAmount, domain.Currency(*edSourceCurrency))
-			if err != nil {
-				return nil, fmt.Errorf("creating exchange source money: %w", err)
-			}
-			targetAmount, err := domain.NewMoney(*edTargetAmount, domain.Currency(*edTargetCurrency))
-			if err != nil {
-				return nil, fmt.Errorf("creating exchange target money: %w", err)
-			}
-			exchangeDetails = domain.NewExchangeDetailsView(
-				*edID,
-				domain.AccountID(*edSourceAccID),
-				domain.AccountID(*edTargetAccID),
-				sourceAmount,
-				targetAmount,
-				*edExchangeRate,
-			)
-		}
-
-		result = append(result, domain.NewTransactionWithDetails(
-			transaction,
-			transferDetails,
-			exchangeDetails,
-		))
+		result = append(result, transaction)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -153,24 +116,323 @@ func (r *TransactionsRepository) Count(ctx context.Context, filter TransactionsF
 		SELECT COUNT(*)
 		FROM transactions t
 		JOIN accounts a ON t.account_id = a.id
-		LEFT JOIN transfer_details td ON t.id = td.transaction_id AND t.type = 'transfer'
+		LEFT JOIN transfer_details td ON t.id = td.transaction_id AND t.type IN ('transfer', 'deposit', 'withdrawal')
 		LEFT JOIN accounts a_recipient ON td.recipient_account_id = a_recipient.id
 		LEFT JOIN exchange_details ed ON t.id = ed.transaction_id AND t.type = 'exchange'
 		LEFT JOIN accounts a_target ON ed.target_account_id = a_target.id
 		WHERE ($1::transaction_type IS NULL OR t.type = $1)
 		  AND (a.user_id = $2 OR a_recipient.user_id = $2 OR a_target.user_id = $2)
+		  AND t.timestamp BETWEEN COALESCE($3, '-infinity'::timestamptz) AND COALESCE($4, 'infinity'::timestamptz)
+		  AND ($5::uuid IS NULL OR $5 IN (t.account_id, td.recipient_account_id, ed.target_account_id))
 	`
 
-	var typeArg any
-	if filter.TransactionType != nil {
-		typeArg = string(*filter.TransactionType)
-	}
+	typeArg, accountArg := filter.args()
 
 	var count int
-	err := r.injector.DB(ctx).QueryRow(ctx, query, typeArg, uuid.UUID(filter.UserID)).Scan(&count)
+	err := readDB(ctx, r.injector).QueryRow(ctx, query, typeArg, uuid.UUID(filter.UserID), filter.From, filter.To, accountArg).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("counting transactions: %w", err)
 	}
 
 	return count, nil
 }
+
+// CountByType counts the filtered transactions per type, ignoring pagination.
+// Types without transactions are absent from the result.
+func (r *TransactionsRepository) CountByType(ctx context.Context, filter TransactionsFilter) (map[domain.TransactionType]int, error) {
+	const query = `
+		SELECT t.type, COUNT(*)
+		FROM transactions t
+		JOIN accounts a ON t.account_id = a.id
+		LEFT JOIN transfer_details td ON t.id = td.transaction_id AND t.type IN ('transfer', 'deposit', 'withdrawal')
+		LEFT JOIN accounts a_recipient ON td.recipient_account_id = a_recipient.id
+		LEFT JOIN exchange_details ed ON t.id = ed.transaction_id AND t.type = 'exchange'
+		LEFT JOIN accounts a_target ON ed.target_account_id = a_target.id
+		WHERE ($1::transaction_type IS NULL OR t.type = $1)
+		  AND (a.user_id = $2 OR a_recipient.user_id = $2 OR a_target.user_id = $2)
+		  AND t.timestamp BETWEEN COALESCE($3, '-infinity'::timestamptz) AND COALESCE($4, 'infinity'::timestamptz)
+		  AND ($5::uuid IS NULL OR $5 IN (t.account_id, td.recipient_account_id, ed.target_account_id))
+		GROUP BY t.type
+	`
+
+	typeArg, accountArg := filter.args()
+
+	rows, err := readDB(ctx, r.injector).Query(ctx, query, typeArg, uuid.UUID(filter.UserID), filter.From, filter.To, accountArg)
+	if err != nil {
+		return nil, fmt.Errorf("counting transactions by type: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[domain.TransactionType]int)
+	for rows.Next() {
+		var (
+			transactionType string
+			count           int
+		)
+		if err := rows.Scan(&transactionType, &count); err != nil {
+			return nil, fmt.Errorf("scanning transaction type count: %w", err)
+		}
+		counts[domain.TransactionType(transactionType)] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating transaction type counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (r *TransactionsRepository) GetByID(ctx context.Context, id domain.TransactionID) (*domain.TransactionWithDetails, error) {
+	const query = `
+		SELECT
+			t.id, t.type, t.account_id, t.timestamp,
+			td.id, td.recipient_account_id, td.amount, td.currency,
+			ed.id, ed.source_account_id, ed.target_account_id,
+			ed.source_amount, ed.source_currency,
+			ed.target_amount, ed.target_currency, ed.exchange_rate
+		FROM transactions t
+		LEFT JOIN transfer_details td ON t.id = td.transaction_id AND t.type IN ('transfer', 'deposit', 'withdrawal')
+		LEFT JOIN exchange_details ed ON t.id = ed.transaction_id AND t.type = 'exchange'
+		WHERE t.id = $1
+	`
+
+	transaction, err := scanTransactionWithDetails(r.injector.DB(ctx).QueryRow(ctx, query, uuid.UUID(id)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewTransactionNotFoundError(id)
+		}
+		return nil, err
+	}
+	if inconsistency := transaction.Inconsistency(); inconsistency != nil {
+		return nil, inconsistency
+	}
+
+	return transaction, nil
+}
+
+// IsParticipant reports whether the user owns the initiating account or the
+// receiving account of the transaction.
+func (r *TransactionsRepository) IsParticipant(ctx context.Context, id domain.TransactionID, userID domain.UserID) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM transactions t
+			JOIN accounts a ON t.account_id = a.id
+			LEFT JOIN transfer_details td ON t.id = td.transaction_id AND t.type IN ('transfer', 'deposit', 'withdrawal')
+			LEFT JOIN accounts a_recipient ON td.recipient_account_id = a_recipient.id
+			LEFT JOIN exchange_details ed ON t.id = ed.transaction_id AND t.type = 'exchange'
+			LEFT JOIN accounts a_target ON ed.target_account_id = a_target.id
+			WHERE t.id = $1
+			  AND (a.user_id = $2 OR a_recipient.user_id = $2 OR a_target.user_id = $2)
+		)
+	`
+
+	var isParticipant bool
+	err := r.injector.DB(ctx).QueryRow(ctx, query, uuid.UUID(id), uuid.UUID(userID)).Scan(&isParticipant)
+	if err != nil {
+		return false, fmt.Errorf("checking transaction participant: %w", err)
+	}
+
+	return isParticipant, nil
+}
+
+// TransactionDetailMismatch is a transaction whose detail rows don't match its
+// type.
+type TransactionDetailMismatch struct {
+	TransactionID   domain.TransactionID
+	Type            domain.TransactionType
+	TransferDetails int
+	ExchangeDetails int
+}
+
+// GetDetailMismatches returns the transactions that don't have exactly one
+// detail row of the table their type is stored in: transfer_details for
+// transfers, deposits and withdrawals, exchange_details for exchanges. Rows in
+// the other detail table are mismatches too.
+func (r *TransactionsRepository) GetDetailMismatches(ctx context.Context) ([]TransactionDetailMismatch, error) {
+	const query = `
+		SELECT c.id, c.type, c.transfer_details, c.exchange_details
+		FROM (
+			SELECT
+				t.id, t.type, t.timestamp,
+				(SELECT COUNT(*) FROM transfer_details td WHERE td.transaction_id = t.id) AS transfer_details,
+				(SELECT COUNT(*) FROM exchange_details ed WHERE ed.transaction_id = t.id) AS exchange_details
+			FROM transactions t
+		) c
+		WHERE (c.type = 'exchange' AND (c.transfer_details != 0 OR c.exchange_details != 1))
+		   OR (c.type IN ('transfer', 'deposit', 'withdrawal') AND (c.transfer_details != 1 OR c.exchange_details != 0))
+		ORDER BY c.timestamp, c.id
+	`
+
+	rows, err := readDB(ctx, r.injector).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("querying transaction detail mismatches: %w", err)
+	}
+	defer rows.Close()
+
+	var mismatches []TransactionDetailMismatch
+	for rows.Next() {
+		var m TransactionDetailMismatch
+		if err := rows.Scan(&m.TransactionID, &m.Type, &m.TransferDetails, &m.ExchangeDetails); err != nil {
+			return nil, fmt.Errorf("scanning transaction detail mismatch: %w", err)
+		}
+		mismatches = append(mismatches, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating transaction detail mismatches: %w", err)
+	}
+
+	return mismatches, nil
+}
+
+func scanTransactionWithDetails(row pgx.Row) (*domain.TransactionWithDetails, error) {
+	var (
+		txID        uuid.UUID
+		txType      string
+		txAccountID uuid.UUID
+		txTimestamp time.Time
+
+		tdID          *uuid.UUID
+		tdRecipientID *uuid.UUID
+		tdAmount      *decimal.Decimal
+		tdCurrency    *string
+
+		edID             *uuid.UUID
+		edSourceAccID    *uuid.UUID
+		edTargetAccID    *uuid.UUID
+		edSourceAmount   *decimal.Decimal
+		edSourceCurrency *string
+		edTargetAmount   *decimal.Decimal
+		edTargetCurrency *string
+		edExchangeRate   *decimal.Decimal
+	)
+
+	err := row.Scan(
+		&txID, &txType, &txAccountID, &txTimestamp,
+		&tdID, &tdRecipientID, &tdAmount, &tdCurrency,
+		&edID, &edSourceAccID, &edTargetAccID,
+		&edSourceAmount, &edSourceCurrency,
+		&edTargetAmount, &edTargetCurrency, &edExchangeRate,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("scanning transaction row: %w", err)
+	}
+
+	transaction := domain.NewTransaction(
+		domain.TransactionID(txID),
+		domain.TransactionType(txType),
+		domain.AccountID(txAccountID),
+		txTimestamp,
+	)
+
+	inconsistency := checkDetailColumns(
+		transaction,
+		countPresent(tdID != nil, tdRecipientID != nil, tdAmount != nil, tdCurrency != nil),
+		countPresent(edID != nil, edSourceAccID != nil, edTargetAccID != nil,
+			edSourceAmount != nil, edSourceCurrency != nil,
+			edTargetAmount != nil, edTargetCurrency != nil, edExchangeRate != nil),
+	)
+	if inconsistency != nil {
+		return domain.NewInconsistentTransactionWithDetails(transaction, inconsistency), nil
+	}
+
+	var transferDetails *domain.TransferDetailsView
+	var exchangeDetails *domain.ExchangeDetailsView
+
+	if tdID != nil && tdRecipientID != nil && tdAmount != nil && tdCurrency != nil {
+		amount, err := storedMoney("transfer_details", *tdID, *tdAmount, domain.Currency(*tdCurrency))
+		if err != nil {
+			return nil, fmt.Errorf("creating transfer money: %w", err)
+		}
+		transferDetails = domain.NewTransferDetailsView(
+			*tdID,
+			domain.AccountID(*tdRecipientID),
+			amount,
+		)
+	}
+
+	if edID != nil && edSourceAccID != nil && edTargetAccID != nil &&
+		edSourceAmount != nil && edSourceCurrency != nil &&
+		edTargetAmount != nil && edTargetCurrency != nil && edExchangeRate != nil {
+		sourceAmount, err := storedMoney("exchange_details", *edID, *edSourceAmount, domain.Currency(*edSourceCurrency))
+		if err != nil {
+			return nil, fmt.Errorf("creating exchange source money: %w", err)
+		}
+		targetAmount, err := storedMoney("exchange_details", *edID, *edTargetAmount, domain.Currency(*edTargetCurrency))
+		if err != nil {
+			return nil, fmt.Errorf("creating exchange target money: %w", err)
+		}
+		exchangeDetails = domain.NewExchangeDetailsView(
+			*edID,
+			domain.AccountID(*edSourceAccID),
+			domain.AccountID(*edTargetAccID),
+			sourceAmount,
+			targetAmount,
+			*edExchangeRate,
+		)
+	}
+
+	return domain.NewTransactionWithDetails(
+		transaction,
+		transferDetails,
+		exchangeDetails,
+	), nil
+}
+
+const (
+	transferDetailColumns = 4
+	exchangeDetailColumns = 8
+)
+
+// checkDetailColumns verifies that a joined row carries exactly the details its
+// transaction type calls for, so an inconsistent row is marked as such rather
+// than passed off as a transaction without details.
+func checkDetailColumns(transaction *domain.Transaction, transferColumns, exchangeColumns int) *domain.InconsistentTransactionDetailsError {
+	inconsistent := func(reason string) *domain.InconsistentTransactionDetailsError {
+		return domain.NewInconsistentTransactionDetailsError(transaction.ID(), transaction.Type(), reason)
+	}
+
+	switch transaction.Type() {
+	case domain.TransactionTypeTransfer, domain.TransactionTypeDeposit, domain.TransactionTypeWithdrawal:
+		if exchangeColumns > 0 {
+			return inconsistent("unexpected exchange details")
+		}
+		return checkDetailsComplete(inconsistent, "transfer", transferColumns, transferDetailColumns)
+	case domain.TransactionTypeExchange:
+		if transferColumns > 0 {
+			return inconsistent("unexpected transfer details")
+		}
+		return checkDetailsComplete(inconsistent, "exchange", exchangeColumns, exchangeDetailColumns)
+	default:
+		if transferColumns > 0 || exchangeColumns > 0 {
+			return inconsistent("unexpected transfer or exchange details")
+		}
+		return nil
+	}
+}
+
+func checkDetailsComplete(
+	inconsistent func(reason string) *domain.InconsistentTransactionDetailsError,
+	kind string,
+	present, expected int,
+) *domain.InconsistentTransactionDetailsError {
+	switch present {
+	case expected:
+		return nil
+	case 0:
+		return inconsistent("missing " + kind + " details")
+	default:
+		return inconsistent(fmt.Sprintf("%d of %d %s detail columns are set", present, expected, kind))
+	}
+}
+
+func countPresent(present ...bool) int {
+	count := 0
+	for _, p := range present {
+		if p {
+			count++
+		}
+	}
+	return count
+}
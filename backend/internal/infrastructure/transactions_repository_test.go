@@ -0,0 +1,196 @@
+package infrastructure_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/infrastructure"
+	"minibankingplatform/pkg/trm"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// joinedRowDB answers every QueryRow with a single row of the given column values.
+type joinedRowDB struct {
+	columns []any
+}
+
+func (db joinedRowDB) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+
+func (db joinedRowDB) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return &joinedRows{row: joinedRow(db)}, nil
+}
+
+func (db joinedRowDB) QueryRow(context.Context, string, ...any) pgx.Row {
+	return joinedRow(db)
+}
+
+type joinedRow joinedRowDB
+
+// Scan copies each non-nil column into its destination. Nil columns leave the
+// destination pointer nil, like a NULL from a LEFT JOIN.
+func (r joinedRow) Scan(dest ...any) error {
+	for i, column := range r.columns {
+		if column == nil {
+			continue
+		}
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(column))
+	}
+	return nil
+}
+
+// joinedRows is a result set of the single joined row.
+type joinedRows struct {
+	pgx.Rows
+	row  joinedRow
+	read bool
+}
+
+func (r *joinedRows) Next() bool {
+	next := !r.read
+	r.read = true
+	return next
+}
+
+func (r *joinedRows) Scan(dest ...any) error {
+	return r.row.Scan(dest...)
+}
+
+func (r *joinedRows) Err() error {
+	return nil
+}
+
+func (r *joinedRows) Close() {}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
+
+func TestTransactionsRepository_GetByID_InconsistentDetails(t *testing.T) {
+	t.Parallel()
+
+	transactionID := uuid.New()
+	transactionColumns := func(transactionType string) []any {
+		return []any{transactionID, transactionType, uuid.New(), time.Now()}
+	}
+	transferColumns := []any{ptrTo(uuid.New()), ptrTo(uuid.New()), ptrTo(decimal.NewFromInt(10)), ptrTo("USD")}
+	noTransferColumns := []any{nil, nil, nil, nil}
+	noExchangeColumns := []any{nil, nil, nil, nil, nil, nil, nil, nil}
+	partialExchangeColumns := []any{ptrTo(uuid.New()), ptrTo(uuid.New()), nil, ptrTo(decimal.NewFromInt(10)), ptrTo("USD"), nil, nil, nil}
+
+	row := func(parts ...[]any) []any {
+		var columns []any
+		for _, part := range parts {
+			columns = append(columns, part...)
+		}
+		return columns
+	}
+
+	tests := []struct {
+		name           string
+		columns        []any
+		expectedType   domain.TransactionType
+		expectedReason string
+	}{
+		{
+			name:           "transfer without transfer details",
+			columns:        row(transactionColumns("transfer"), noTransferColumns, noExchangeColumns),
+			expectedType:   domain.TransactionTypeTransfer,
+			expectedReason: "missing transfer details",
+		},
+		{
+			name:           "transfer with partial exchange columns",
+			columns:        row(transactionColumns("transfer"), transferColumns, partialExchangeColumns),
+			expectedType:   domain.TransactionTypeTransfer,
+			expectedReason: "unexpected exchange details",
+		},
+		{
+			name:           "exchange with partial exchange columns",
+			columns:        row(transactionColumns("exchange"), noTransferColumns, partialExchangeColumns),
+			expectedType:   domain.TransactionTypeExchange,
+			expectedReason: "4 of 8 exchange detail columns are set",
+		},
+		{
+			name:           "deposit without transfer details",
+			columns:        row(transactionColumns("deposit"), noTransferColumns, noExchangeColumns),
+			expectedType:   domain.TransactionTypeDeposit,
+			expectedReason: "missing transfer details",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			repo := infrastructure.NewTransactionsRepository(trm.NewInjector[infrastructure.DBTX](joinedRowDB{columns: tt.columns}))
+
+			// Act
+			transaction, err := repo.GetByID(context.Background(), domain.TransactionID(transactionID))
+
+			// Assert
+			assert.Nil(t, transaction)
+			var inconsistentErr *domain.InconsistentTransactionDetailsError
+			require.ErrorAs(t, err, &inconsistentErr)
+			assert.Equal(t, domain.TransactionID(transactionID), inconsistentErr.TransactionID)
+			assert.Equal(t, tt.expectedType, inconsistentErr.Type)
+			assert.Equal(t, tt.expectedReason, inconsistentErr.Reason)
+		})
+	}
+}
+
+func TestTransactionsRepository_GetByID_ConsistentTransfer(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	transactionID := uuid.New()
+	columns := []any{
+		transactionID, "transfer", uuid.New(), time.Now(),
+		ptrTo(uuid.New()), ptrTo(uuid.New()), ptrTo(decimal.NewFromInt(10)), ptrTo("USD"),
+		nil, nil, nil, nil, nil, nil, nil, nil,
+	}
+	repo := infrastructure.NewTransactionsRepository(trm.NewInjector[infrastructure.DBTX](joinedRowDB{columns: columns}))
+
+	// Act
+	transaction, err := repo.GetByID(context.Background(), domain.TransactionID(transactionID))
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, transaction.TransferDetails())
+	assert.True(t, transaction.TransferDetails().Amount().Amount().Equal(decimal.NewFromInt(10)))
+	assert.Nil(t, transaction.ExchangeDetails())
+}
+
+func TestTransactionsRepository_GetList_MarksInconsistentDetails(t *testing.T) {
+	t.Parallel()
+
+	// Arrange - a transfer without transfer details
+	transactionID := uuid.New()
+	columns := []any{
+		transactionID, "transfer", uuid.New(), time.Now(),
+		nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil,
+	}
+	repo := infrastructure.NewTransactionsRepository(trm.NewInjector[infrastructure.DBTX](joinedRowDB{columns: columns}))
+
+	// Act
+	transactions, err := repo.GetList(context.Background(), infrastructure.TransactionsFilter{UserID: domain.UserID(uuid.New()), Limit: 10})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, transactions, 1)
+	assert.Equal(t, domain.TransactionID(transactionID), transactions[0].Transaction().ID())
+	assert.Nil(t, transactions[0].TransferDetails())
+	require.NotNil(t, transactions[0].Inconsistency())
+	assert.Equal(t, "missing transfer details", transactions[0].Inconsistency().Reason)
+}
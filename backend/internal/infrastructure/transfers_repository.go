@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"minibankingplatform/internal/domain"
 	"minibankingplatform/pkg/trm"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 type TransfersRepository struct {
@@ -18,8 +20,9 @@ func NewTransfersRepository(injector *trm.Injector[DBTX]) *TransfersRepository {
 }
 
 func (tr *TransfersRepository) Insert(ctx context.Context, transfer *domain.TransferDetails) error {
-	// TODO: it's better to have nested transaction here,
-	//  but pgx factory doesn't support it for now
+	// The inserts must succeed or fail together with the balance updates of the
+	// caller, so they join its transaction. Callers wanting to recover from a
+	// failed insert can wrap it in a nested trm.Do, which uses a savepoint.
 	if !tr.injector.HasContextTransaction(ctx) {
 		return fmt.Errorf("insert command must be called inside of running transaction")
 	}
@@ -47,6 +50,50 @@ func (tr *TransfersRepository) Insert(ctx context.Context, transfer *domain.Tran
 	return nil
 }
 
+// GetDailyOutflowByAccount sums the transfers, withdrawals and exchanges that
+// took money out of the account during the UTC day of date. All of them are
+// made in the account's currency, so the sum is too.
+func (tr *TransfersRepository) GetDailyOutflowByAccount(ctx context.Context, accountID domain.AccountID, date time.Time) (decimal.Decimal, error) {
+	const query = `
+		SELECT
+			COALESCE((
+				SELECT SUM(td.amount)
+				FROM transactions t
+				JOIN transfer_details td ON td.transaction_id = t.id
+				WHERE t.type IN ($1, $2)
+				  AND t.account_id = $4
+				  AND t.timestamp >= $5
+				  AND t.timestamp < $6
+			), 0)
+			+ COALESCE((
+				SELECT SUM(ed.source_amount)
+				FROM transactions t
+				JOIN exchange_details ed ON ed.transaction_id = t.id
+				WHERE t.type = $3
+				  AND ed.source_account_id = $4
+				  AND t.timestamp >= $5
+				  AND t.timestamp < $6
+			), 0)
+	`
+
+	dayStart := date.UTC().Truncate(24 * time.Hour)
+
+	var outflow decimal.Decimal
+	err := tr.injector.DB(ctx).QueryRow(ctx, query,
+		domain.TransactionTypeTransfer,
+		domain.TransactionTypeWithdrawal,
+		domain.TransactionTypeExchange,
+		uuid.UUID(accountID),
+		dayStart,
+		dayStart.Add(24*time.Hour),
+	).Scan(&outflow)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("executing query: %w", err)
+	}
+
+	return outflow, nil
+}
+
 func (tr *TransfersRepository) insertTransaction(ctx context.Context, transfer *domain.TransferDetails) error {
 	const query = `
 		INSERT INTO transactions (id, type, account_id, timestamp)
@@ -55,7 +102,7 @@ func (tr *TransfersRepository) insertTransaction(ctx context.Context, transfer *
 
 	_, err := tr.injector.DB(ctx).Exec(ctx, query,
 		uuid.UUID(transfer.TransactionID()),
-		domain.TransactionTypeTransfer,
+		transfer.Type(),
 		uuid.UUID(transfer.Sender()),
 		transfer.Time(),
 	)
@@ -68,8 +115,8 @@ func (tr *TransfersRepository) insertTransaction(ctx context.Context, transfer *
 
 func (tr *TransfersRepository) insertDetails(ctx context.Context, transfer *domain.TransferDetails) error {
 	const query = `
-		INSERT INTO transfer_details (id, transaction_id, recipient_account_id, amount, currency)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO transfer_details (id, transaction_id, recipient_account_id, amount, currency, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
 	`
 
 	_, err := tr.injector.DB(ctx).Exec(
@@ -80,9 +127,12 @@ func (tr *TransfersRepository) insertDetails(ctx context.Context, transfer *doma
 		uuid.UUID(transfer.Recipient()),
 		transfer.Money().Amount(),
 		transfer.Money().Currency(),
+		transfer.Time(),
 	)
 	if err != nil {
-		return fmt.Errorf("executing query: %w", err)
+		return fmt.Errorf("executing query: %w", amountConstraintError(err, map[string]domain.Money{
+			"transfer_positive_amount": transfer.Money(),
+		}))
 	}
 
 	return nil
@@ -92,36 +142,15 @@ func (tr *TransfersRepository) insertLedgerEntry(ctx context.Context, ledgerEntr
 	first := ledgerEntry[0]
 	second := ledgerEntry[1]
 
-	err := tr.insertLedgerRecord(ctx, first)
+	err := insertLedgerRecord(ctx, tr.injector.DB(ctx), first)
 	if err != nil {
 		return fmt.Errorf("inserting ledger entry: %w", err)
 	}
 
-	err = tr.insertLedgerRecord(ctx, second)
+	err = insertLedgerRecord(ctx, tr.injector.DB(ctx), second)
 	if err != nil {
 		return fmt.Errorf("inserting ledger entry: %w", err)
 	}
 
 	return nil
 }
-
-func (tr *TransfersRepository) insertLedgerRecord(ctx context.Context, ledgerRecord *domain.LedgerRecord) error {
-	const query = `
-		INSERT INTO ledger (id, transaction, account, amount, currency, timestamp)
-		VALUES ($1, $2, $3, $4, $5, $6)
-	`
-
-	_, err := tr.injector.DB(ctx).Exec(ctx, query,
-		uuid.UUID(ledgerRecord.ID()),
-		uuid.UUID(ledgerRecord.Transaction()),
-		uuid.UUID(ledgerRecord.Account()),
-		ledgerRecord.Money().Amount(),
-		ledgerRecord.Money().Currency(),
-		ledgerRecord.Time(),
-	)
-	if err != nil {
-		return fmt.Errorf("executing query: %w", err)
-	}
-
-	return nil
-}
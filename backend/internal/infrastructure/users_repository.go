@@ -31,7 +31,7 @@ func (ur *UsersRepository) GetByEmail(ctx context.Context, email string) (*domai
 		    created_at,
 		    updated_at
 		FROM users
-		WHERE email = $1
+		WHERE lower(email) = lower($1)
 	`
 
 	var (
@@ -76,7 +76,7 @@ func (ur *UsersRepository) GetByID(ctx context.Context, userID domain.UserID) (*
 	err := ur.injector.DB(ctx).QueryRow(ctx, query, uuid.UUID(userID)).Scan(&id, &email, &passwordHash, &createdAt, &updatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("user with id %v not found", userID)
+			return nil, domain.NewUserIDNotFoundError(userID)
 		}
 		return nil, fmt.Errorf("querying user by id: %w", err)
 	}
@@ -105,6 +105,9 @@ func (ur *UsersRepository) Save(ctx context.Context, user *domain.User) error {
 		user.UpdatedAt(),
 	)
 	if err != nil {
+		if isUniqueViolation(err, usersEmailLowerIndex) {
+			return domain.NewUserAlreadyExistsError(user.Email())
+		}
 		return fmt.Errorf("upserting user: %w", err)
 	}
 
@@ -112,7 +115,7 @@ func (ur *UsersRepository) Save(ctx context.Context, user *domain.User) error {
 }
 
 func (ur *UsersRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
-	const query = `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)`
+	const query = `SELECT EXISTS(SELECT 1 FROM users WHERE lower(email) = lower($1))`
 
 	var exists bool
 	err := ur.injector.DB(ctx).QueryRow(ctx, query, email).Scan(&exists)
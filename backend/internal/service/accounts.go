@@ -2,11 +2,57 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/infrastructure"
+	"time"
 )
 
-func (s *Service) GetUserAccounts(ctx context.Context, userID domain.UserID) ([]*domain.Account, error) {
-	return s.accounts.GetByUserID(ctx, userID)
+func (s *Service) GetUserAccounts(ctx context.Context, userID domain.UserID, includeClosed bool) ([]*domain.Account, error) {
+	return s.accounts.GetByUserID(ctx, userID, includeClosed)
+}
+
+// AccountLedgerBalance is the balance the ledger records of an account add up
+// to, which may differ from the balance stored on the account.
+type AccountLedgerBalance struct {
+	AccountID domain.AccountID
+	Balance   domain.Money
+}
+
+// GetUserAccountsFromLedger returns the balance of each of the accounts
+// GetUserAccounts lists, computed from the account's ledger records rather
+// than read from the stored balance, so the two can be compared when the
+// stored balance is in doubt.
+func (s *Service) GetUserAccountsFromLedger(ctx context.Context, userID domain.UserID, includeClosed bool) ([]AccountLedgerBalance, error) {
+	accounts, err := s.accounts.GetByUserID(ctx, userID, includeClosed)
+	if err != nil {
+		return nil, fmt.Errorf("getting accounts: %w", err)
+	}
+
+	balances := make([]AccountLedgerBalance, len(accounts))
+	for i, account := range accounts {
+		balance, err := s.ledger.GetAccountBalance(ctx, account.ID(), account.Balance().Currency())
+		if err != nil {
+			return nil, fmt.Errorf("getting ledger balance: %w", err)
+		}
+		balances[i] = AccountLedgerBalance{AccountID: account.ID(), Balance: balance}
+	}
+
+	return balances, nil
+}
+
+func (s *Service) GetAccount(ctx context.Context, accountID domain.AccountID) (*domain.Account, error) {
+	return s.accounts.Get(ctx, accountID)
+}
+
+// GetUserBalancesByCurrency returns the summed balance of the user's accounts
+// in each currency they hold an account in.
+func (s *Service) GetUserBalancesByCurrency(ctx context.Context, userID domain.UserID) (map[domain.Currency]domain.Money, error) {
+	balances, err := s.accounts.SumBalancesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("summing account balances: %w", err)
+	}
+	return balances, nil
 }
 
 func (s *Service) GetAccountBalance(ctx context.Context, accountID domain.AccountID) (domain.Money, error) {
@@ -16,3 +62,137 @@ func (s *Service) GetAccountBalance(ctx context.Context, accountID domain.Accoun
 	}
 	return account.Balance(), nil
 }
+
+// AssertAccountOwnership returns *domain.AccountAccessDeniedError unless the
+// account belongs to userID, and *domain.AccountNotFoundError if it does not
+// exist.
+func (s *Service) AssertAccountOwnership(ctx context.Context, accountID domain.AccountID, userID domain.UserID) error {
+	account, err := s.accounts.Get(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("getting account: %w", err)
+	}
+
+	if account.UserID() != userID {
+		return domain.NewAccountAccessDeniedError(accountID)
+	}
+
+	return nil
+}
+
+// GetAccountBalanceAtDate returns the balance the user's account had at asOf,
+// as booked in the ledger. It fails like AssertAccountOwnership when the
+// account is not the user's.
+func (s *Service) GetAccountBalanceAtDate(
+	ctx context.Context,
+	accountID domain.AccountID,
+	userID domain.UserID,
+	asOf time.Time,
+) (domain.Money, error) {
+	account, err := s.accounts.Get(ctx, accountID)
+	if err != nil {
+		return domain.Money{}, fmt.Errorf("getting account: %w", err)
+	}
+
+	if account.UserID() != userID {
+		return domain.Money{}, domain.NewAccountAccessDeniedError(accountID)
+	}
+
+	balance, err := s.ledger.GetAccountBalanceAtDate(ctx, accountID, account.Balance().Currency(), asOf)
+	if err != nil {
+		return domain.Money{}, fmt.Errorf("getting balance at date: %w", err)
+	}
+
+	return balance, nil
+}
+
+// FreezeAccount stops the user's account from being debited.
+func (s *Service) FreezeAccount(ctx context.Context, accountID domain.AccountID, userID domain.UserID) error {
+	return s.updateAccount(ctx, accountID, userID, (*domain.Account).Freeze)
+}
+
+// UnfreezeAccount lets the user's frozen account be debited again.
+func (s *Service) UnfreezeAccount(ctx context.Context, accountID domain.AccountID, userID domain.UserID) error {
+	return s.updateAccount(ctx, accountID, userID, (*domain.Account).Unfreeze)
+}
+
+// CloseAccount closes the user's account. The account must be empty.
+func (s *Service) CloseAccount(ctx context.Context, accountID domain.AccountID, userID domain.UserID) error {
+	return s.updateAccount(ctx, accountID, userID, (*domain.Account).Close)
+}
+
+// SetAccountLabel gives the user's account a display name, or removes it when
+// label is blank.
+func (s *Service) SetAccountLabel(ctx context.Context, accountID domain.AccountID, userID domain.UserID, label string) error {
+	parsed, err := domain.ParseAccountLabel(label)
+	if err != nil {
+		return err
+	}
+
+	return s.updateAccount(ctx, accountID, userID, func(account *domain.Account) error {
+		account.WithLabel(parsed)
+		return nil
+	})
+}
+
+func (s *Service) updateAccount(
+	ctx context.Context,
+	accountID domain.AccountID,
+	userID domain.UserID,
+	change func(*domain.Account) error,
+) error {
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		account, err := s.accounts.GetForUpdate(ctx, accountID)
+		if err != nil {
+			return fmt.Errorf("getting account: %w", err)
+		}
+
+		if account.UserID() != userID {
+			return domain.NewAccountAccessDeniedError(accountID)
+		}
+
+		if err := change(account); err != nil {
+			return err
+		}
+
+		err = s.accounts.Save(ctx, account)
+		if err != nil {
+			return fmt.Errorf("saving account: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("doing atomic operation: %w", err)
+	}
+
+	return nil
+}
+
+type AccountLedger struct {
+	Account *domain.Account
+	Entries []infrastructure.AccountLedgerEntry
+}
+
+// GetAccountLedger returns the ledger records of the user's account. It fails
+// like AssertAccountOwnership when the account is not the user's, before the
+// ledger is read.
+func (s *Service) GetAccountLedger(ctx context.Context, accountID domain.AccountID, userID domain.UserID) (*AccountLedger, error) {
+	account, err := s.accounts.Get(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("getting account: %w", err)
+	}
+
+	if account.UserID() != userID {
+		return nil, domain.NewAccountAccessDeniedError(accountID)
+	}
+
+	entries, err := s.ledger.GetAccountLedger(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("getting account ledger: %w", err)
+	}
+
+	return &AccountLedger{
+		Account: account,
+		Entries: entries,
+	}, nil
+}
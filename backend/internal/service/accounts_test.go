@@ -0,0 +1,643 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/infrastructure"
+	"minibankingplatform/internal/service"
+	"minibankingplatform/pkg/trm"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountLedger_RunningBalance(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - register two users (each gets 1000 USD, 500 EUR)
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	// The last two transfers share a timestamp to exercise the id tie-break
+	base := time.Now()
+	transfers := []struct {
+		amount int64
+		time   time.Time
+	}{
+		{amount: 100, time: base.Add(time.Second)},
+		{amount: 50, time: base.Add(2 * time.Second)},
+		{amount: 25, time: base.Add(2 * time.Second)},
+	}
+	for _, tr := range transfers {
+		_, err := svc.Transfer(ctx, &service.TransferCommand{
+			From:     domain.AccountID(fromUser.USDAccountID),
+			To:       domain.AccountID(toUser.USDAccountID),
+			Amount:   decimal.NewFromInt(tr.amount),
+			Currency: domain.CurrencyUSD,
+			Time:     tr.time,
+		})
+		require.NoError(t, err)
+	}
+
+	// Act
+	ledger, err := svc.GetAccountLedger(ctx, domain.AccountID(fromUser.USDAccountID), domain.UserID(fromUser.UserID))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, ledger.Entries, 4, "1 funding record + 3 transfers")
+
+	first := ledger.Entries[0]
+	assert.True(t, first.Amount.Amount().Equal(decimal.NewFromInt(1000)))
+	assert.True(t, first.RunningBalance.Amount().Equal(decimal.NewFromInt(1000)))
+	assert.True(t, ledger.Entries[1].RunningBalance.Amount().Equal(decimal.NewFromInt(900)))
+
+	// Every running balance is the previous one plus the record's amount
+	for i := 1; i < len(ledger.Entries); i++ {
+		prev := ledger.Entries[i-1]
+		curr := ledger.Entries[i]
+		assert.False(t, curr.Timestamp.Before(prev.Timestamp), "entries must be chronological")
+		assert.True(t, prev.RunningBalance.Amount().Add(curr.Amount.Amount()).Equal(curr.RunningBalance.Amount()),
+			"entry %d: expected %s + %s, got %s", i, prev.RunningBalance.Amount(), curr.Amount.Amount(), curr.RunningBalance.Amount())
+		assert.Equal(t, domain.CurrencyUSD, curr.RunningBalance.Currency())
+	}
+
+	last := ledger.Entries[len(ledger.Entries)-1]
+	assert.True(t, last.RunningBalance.Amount().Equal(decimal.NewFromInt(825)))
+	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.NewFromInt(825))
+}
+
+func TestGetAccountLedger_AccountNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Act
+	_, err := svc.GetAccountLedger(ctx, domain.GenerateAccountID(), domain.GenerateUserID())
+
+	// Assert
+	var notFoundErr *domain.AccountNotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+}
+
+func TestGetAccountLedger_AccountOfAnotherUser(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	owner := registerTestUser(ctx, t, svc, testPool)
+	other := registerTestUser(ctx, t, svc, testPool)
+
+	// Act
+	ledger, err := svc.GetAccountLedger(ctx, domain.AccountID(owner.USDAccountID), domain.UserID(other.UserID))
+
+	// Assert
+	var accessDeniedErr *domain.AccountAccessDeniedError
+	assert.ErrorAs(t, err, &accessDeniedErr)
+	assert.Nil(t, ledger)
+}
+
+func TestGetAccountBalanceAtDate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - register two users (each gets 1000 USD) and transfer twice, an hour apart
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	base := time.Now()
+	for i, amount := range []int64{100, 50} {
+		_, err := svc.Transfer(ctx, &service.TransferCommand{
+			From:     domain.AccountID(fromUser.USDAccountID),
+			To:       domain.AccountID(toUser.USDAccountID),
+			Amount:   decimal.NewFromInt(amount),
+			Currency: domain.CurrencyUSD,
+			Time:     base.Add(time.Duration(i+1) * time.Hour),
+		})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name     string
+		asOf     time.Time
+		expected int64
+	}{
+		{name: "before funding", asOf: base.Add(-time.Hour), expected: 0},
+		{name: "before the transfers", asOf: base.Add(30 * time.Minute), expected: 1000},
+		{name: "between the transfers", asOf: base.Add(90 * time.Minute), expected: 900},
+		{name: "at the second transfer", asOf: base.Add(2 * time.Hour), expected: 850},
+		{name: "after the transfers", asOf: base.Add(3 * time.Hour), expected: 850},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			balance, err := svc.GetAccountBalanceAtDate(ctx, domain.AccountID(fromUser.USDAccountID), domain.UserID(fromUser.UserID), tt.asOf)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, domain.CurrencyUSD, balance.Currency())
+			assert.True(t, balance.Amount().Equal(decimal.NewFromInt(tt.expected)), "expected %d, got %s", tt.expected, balance.Amount())
+		})
+	}
+
+	t.Run("another user's account", func(t *testing.T) {
+		// Act
+		_, err := svc.GetAccountBalanceAtDate(ctx, domain.AccountID(fromUser.USDAccountID), domain.UserID(toUser.UserID), base)
+
+		// Assert
+		var accessDeniedErr *domain.AccountAccessDeniedError
+		assert.ErrorAs(t, err, &accessDeniedErr)
+	})
+}
+
+func TestGetUserAccounts_ClosedAccounts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - close the user's EUR account
+	user := registerTestUser(ctx, t, svc, testPool)
+	_, err := testPool.Exec(ctx, `UPDATE accounts SET status = 'closed' WHERE id = $1`, user.EURAccountID)
+	require.NoError(t, err)
+
+	t.Run("hidden by default", func(t *testing.T) {
+		accounts, err := svc.GetUserAccounts(ctx, domain.UserID(user.UserID), false)
+
+		require.NoError(t, err)
+		require.Len(t, accounts, 2)
+		for _, account := range accounts {
+			assert.NotEqual(t, user.EURAccountID, uuid.UUID(account.ID()))
+			assert.False(t, account.IsClosed())
+		}
+	})
+
+	t.Run("shown with includeClosed", func(t *testing.T) {
+		accounts, err := svc.GetUserAccounts(ctx, domain.UserID(user.UserID), true)
+
+		require.NoError(t, err)
+		require.Len(t, accounts, 3)
+
+		closed := make(map[uuid.UUID]bool, len(accounts))
+		for _, account := range accounts {
+			closed[uuid.UUID(account.ID())] = account.IsClosed()
+		}
+		assert.False(t, closed[user.USDAccountID])
+		assert.True(t, closed[user.EURAccountID])
+		assert.False(t, closed[user.GBPAccountID])
+	})
+}
+
+// Not parallel: it briefly corrupts a stored balance, which other tests
+// checking every account against the ledger must not see.
+func TestGetUserAccountsFromLedger(t *testing.T) {
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	_, err := svc.Transfer(ctx, &service.TransferCommand{
+		From:     domain.AccountID(fromUser.USDAccountID),
+		To:       domain.AccountID(toUser.USDAccountID),
+		Amount:   decimal.NewFromInt(150),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
+	})
+	require.NoError(t, err)
+
+	balances := func(accounts []*domain.Account) map[uuid.UUID]string {
+		byID := make(map[uuid.UUID]string, len(accounts))
+		for _, account := range accounts {
+			byID[uuid.UUID(account.ID())] = account.Balance().String()
+		}
+		return byID
+	}
+	ledgerBalances := func(balances []service.AccountLedgerBalance) map[uuid.UUID]string {
+		byID := make(map[uuid.UUID]string, len(balances))
+		for _, balance := range balances {
+			byID[uuid.UUID(balance.AccountID)] = balance.Balance.String()
+		}
+		return byID
+	}
+
+	t.Run("agrees with stored balances after a transfer", func(t *testing.T) {
+		stored, err := svc.GetUserAccounts(ctx, domain.UserID(fromUser.UserID), false)
+		require.NoError(t, err)
+		fromLedger, err := svc.GetUserAccountsFromLedger(ctx, domain.UserID(fromUser.UserID), false)
+		require.NoError(t, err)
+
+		assert.Equal(t, balances(stored), ledgerBalances(fromLedger))
+		assert.Equal(t, "850.00 USD", ledgerBalances(fromLedger)[fromUser.USDAccountID])
+	})
+
+	t.Run("differs from a corrupted stored balance", func(t *testing.T) {
+		_, err := testPool.Exec(ctx, `UPDATE accounts SET balance = 999999 WHERE id = $1`, toUser.USDAccountID)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_, err := testPool.Exec(context.Background(), `UPDATE accounts SET balance = 1150 WHERE id = $1`, toUser.USDAccountID)
+			require.NoError(t, err)
+		})
+
+		stored, err := svc.GetUserAccounts(ctx, domain.UserID(toUser.UserID), false)
+		require.NoError(t, err)
+		fromLedger, err := svc.GetUserAccountsFromLedger(ctx, domain.UserID(toUser.UserID), false)
+		require.NoError(t, err)
+
+		assert.Equal(t, "999999.00 USD", balances(stored)[toUser.USDAccountID])
+		assert.Equal(t, "1150.00 USD", ledgerBalances(fromLedger)[toUser.USDAccountID])
+		assert.Equal(t, balances(stored)[toUser.EURAccountID], ledgerBalances(fromLedger)[toUser.EURAccountID])
+	})
+}
+
+func TestAccountsRepository_SaveKeepsCurrency(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	accounts := infrastructure.NewAccountsRepository(trm.NewInjector[infrastructure.DBTX](testPool))
+
+	// Arrange - a USD account is saved again with a EUR balance
+	user := registerTestUser(ctx, t, svc, testPool)
+	eurBalance, _ := domain.NewMoney(decimal.NewFromInt(5), domain.CurrencyEUR)
+	changed := domain.NewAccount(domain.AccountID(user.USDAccountID), domain.UserID(user.UserID), eurBalance)
+
+	// Act
+	err := accounts.Save(ctx, changed)
+
+	// Assert
+	var currencyChangeErr *domain.AccountCurrencyChangeError
+	require.ErrorAs(t, err, &currencyChangeErr)
+	assert.Equal(t, domain.AccountID(user.USDAccountID), currencyChangeErr.AccountID)
+
+	stored, err := accounts.Get(ctx, domain.AccountID(user.USDAccountID))
+	require.NoError(t, err)
+	assert.Equal(t, domain.CurrencyUSD, stored.Balance().Currency())
+	assert.True(t, stored.Balance().Amount().Equal(decimal.NewFromInt(1000)), "balance should be left untouched")
+}
+
+func TestAccountsRepository_LabelRoundTrip(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	accounts := infrastructure.NewAccountsRepository(trm.NewInjector[infrastructure.DBTX](testPool))
+
+	// Arrange
+	user := registerTestUser(ctx, t, svc, testPool)
+	accountID := domain.AccountID(user.EURAccountID)
+
+	account, err := accounts.Get(ctx, accountID)
+	require.NoError(t, err)
+	assert.Empty(t, account.Label(), "new accounts have no label")
+
+	// Act
+	err = accounts.Save(ctx, account.WithLabel("Holidays"))
+
+	// Assert
+	require.NoError(t, err)
+
+	stored, err := accounts.Get(ctx, accountID)
+	require.NoError(t, err)
+	assert.Equal(t, "Holidays", stored.Label())
+
+	owned, err := accounts.GetByUserID(ctx, domain.UserID(user.UserID), false)
+	require.NoError(t, err)
+	for _, a := range owned {
+		if a.ID() == accountID {
+			assert.Equal(t, "Holidays", a.Label())
+		} else {
+			assert.Empty(t, a.Label(), "other accounts keep no label")
+		}
+	}
+
+	require.NoError(t, accounts.Save(ctx, stored.WithLabel("")))
+	cleared, err := accounts.GetForUpdate(ctx, accountID)
+	require.NoError(t, err)
+	assert.Empty(t, cleared.Label())
+}
+
+func TestSetAccountLabel(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	owner := registerTestUser(ctx, t, svc, testPool)
+	other := registerTestUser(ctx, t, svc, testPool)
+	accountID := domain.AccountID(owner.USDAccountID)
+
+	t.Run("another user cannot label the account", func(t *testing.T) {
+		err := svc.SetAccountLabel(ctx, accountID, domain.UserID(other.UserID), "Mine now")
+
+		var accessDeniedErr *domain.AccountAccessDeniedError
+		assert.ErrorAs(t, err, &accessDeniedErr)
+	})
+
+	t.Run("too long label is rejected", func(t *testing.T) {
+		err := svc.SetAccountLabel(ctx, accountID, domain.UserID(owner.UserID), strings.Repeat("a", domain.MaxAccountLabelLength+1))
+
+		var invalidErr *domain.InvalidAccountLabelError
+		assert.ErrorAs(t, err, &invalidErr)
+	})
+
+	// Act
+	err := svc.SetAccountLabel(ctx, accountID, domain.UserID(owner.UserID), "  Everyday spending ")
+
+	// Assert
+	require.NoError(t, err)
+	account, err := svc.GetAccount(ctx, accountID)
+	require.NoError(t, err)
+	assert.Equal(t, "Everyday spending", account.Label())
+}
+
+// Not parallel: it extends the currency enum and briefly stores an account
+// that other tests scanning all accounts must not see.
+func TestGetUserAccounts_InvalidStoredCurrency(t *testing.T) {
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Arrange
+	badAccountID := insertAccountInUnknownCurrency(ctx, t, user.UserID)
+
+	// Act
+	_, err := svc.GetUserAccounts(ctx, domain.UserID(user.UserID), false)
+
+	// Assert
+	var storedCurrencyErr *domain.InvalidStoredCurrencyError
+	require.ErrorAs(t, err, &storedCurrencyErr)
+	assert.Equal(t, "accounts", storedCurrencyErr.Table)
+	assert.Equal(t, badAccountID, storedCurrencyErr.RowID)
+	assert.Equal(t, domain.Currency("XTS"), storedCurrencyErr.Currency)
+	assert.Contains(t, err.Error(), badAccountID.String())
+}
+
+// Not parallel, see TestGetUserAccounts_InvalidStoredCurrency.
+func TestGetUserBalancesByCurrency_InvalidStoredCurrency(t *testing.T) {
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Arrange
+	badAccountID := insertAccountInUnknownCurrency(ctx, t, user.UserID)
+
+	// Act
+	_, err := svc.GetUserBalancesByCurrency(ctx, domain.UserID(user.UserID))
+
+	// Assert
+	var storedCurrencyErr *domain.InvalidStoredCurrencyError
+	require.ErrorAs(t, err, &storedCurrencyErr)
+	assert.Equal(t, "accounts", storedCurrencyErr.Table)
+	assert.Equal(t, badAccountID, storedCurrencyErr.RowID)
+	assert.Equal(t, domain.Currency("XTS"), storedCurrencyErr.Currency)
+}
+
+// insertAccountInUnknownCurrency simulates a migration that added a currency
+// the code doesn't know about, and gives the user an account in it until the
+// test ends.
+func insertAccountInUnknownCurrency(ctx context.Context, t *testing.T, userID uuid.UUID) uuid.UUID {
+	t.Helper()
+
+	_, err := testPool.Exec(ctx, `ALTER TYPE currency ADD VALUE IF NOT EXISTS 'XTS'`)
+	require.NoError(t, err)
+
+	accountID := uuid.New()
+	_, err = testPool.Exec(ctx,
+		`INSERT INTO accounts (id, user_id, balance, currency) VALUES ($1, $2, 0, 'XTS')`,
+		accountID, userID,
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := testPool.Exec(context.Background(), `DELETE FROM accounts WHERE id = $1`, accountID)
+		require.NoError(t, err)
+	})
+
+	return accountID
+}
+
+func TestAssertAccountOwnership(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - register two users (each gets 1000 USD, 500 EUR)
+	owner := registerTestUser(ctx, t, svc, testPool)
+	other := registerTestUser(ctx, t, svc, testPool)
+
+	t.Run("owner", func(t *testing.T) {
+		err := svc.AssertAccountOwnership(ctx, domain.AccountID(owner.USDAccountID), domain.UserID(owner.UserID))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("another user", func(t *testing.T) {
+		err := svc.AssertAccountOwnership(ctx, domain.AccountID(owner.USDAccountID), domain.UserID(other.UserID))
+
+		var accessDeniedErr *domain.AccountAccessDeniedError
+		require.ErrorAs(t, err, &accessDeniedErr)
+		assert.Equal(t, domain.AccountID(owner.USDAccountID), accessDeniedErr.AccountID)
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		err := svc.AssertAccountOwnership(ctx, domain.AccountID(uuid.New()), domain.UserID(owner.UserID))
+
+		var notFoundErr *domain.AccountNotFoundError
+		assert.ErrorAs(t, err, &notFoundErr)
+	})
+}
+
+func TestFreezeAccount_BlocksDebits(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	owner := registerTestUser(ctx, t, svc, testPool)
+	other := registerTestUser(ctx, t, svc, testPool)
+
+	t.Run("another user cannot freeze the account", func(t *testing.T) {
+		err := svc.FreezeAccount(ctx, domain.AccountID(owner.USDAccountID), domain.UserID(other.UserID))
+
+		var accessDeniedErr *domain.AccountAccessDeniedError
+		assert.ErrorAs(t, err, &accessDeniedErr)
+	})
+
+	// Arrange
+	err := svc.FreezeAccount(ctx, domain.AccountID(owner.USDAccountID), domain.UserID(owner.UserID))
+	require.NoError(t, err)
+
+	t.Run("frozen account cannot be debited", func(t *testing.T) {
+		_, err := svc.Transfer(ctx, &service.TransferCommand{
+			From:     domain.AccountID(owner.USDAccountID),
+			To:       domain.AccountID(other.USDAccountID),
+			Amount:   decimal.NewFromInt(10),
+			Currency: domain.CurrencyUSD,
+			Time:     time.Now(),
+		})
+
+		var frozenErr *domain.FrozenAccountError
+		require.ErrorAs(t, err, &frozenErr)
+		assert.Equal(t, domain.AccountID(owner.USDAccountID), frozenErr.AccountID)
+		assertBalanceEquals(t, ctx, testPool, owner.USDAccountID, decimal.NewFromInt(1000))
+	})
+
+	t.Run("frozen account can still be credited", func(t *testing.T) {
+		_, err := svc.Transfer(ctx, &service.TransferCommand{
+			From:     domain.AccountID(other.USDAccountID),
+			To:       domain.AccountID(owner.USDAccountID),
+			Amount:   decimal.NewFromInt(10),
+			Currency: domain.CurrencyUSD,
+			Time:     time.Now(),
+		})
+
+		require.NoError(t, err)
+		assertBalanceEquals(t, ctx, testPool, owner.USDAccountID, decimal.NewFromInt(1010))
+	})
+
+	account, err := svc.GetAccount(ctx, domain.AccountID(owner.USDAccountID))
+	require.NoError(t, err)
+	assert.Equal(t, domain.AccountStatusFrozen, account.Status())
+
+	t.Run("unfrozen account can be debited again", func(t *testing.T) {
+		err := svc.UnfreezeAccount(ctx, domain.AccountID(owner.USDAccountID), domain.UserID(owner.UserID))
+		require.NoError(t, err)
+
+		_, err = svc.Transfer(ctx, &service.TransferCommand{
+			From:     domain.AccountID(owner.USDAccountID),
+			To:       domain.AccountID(other.USDAccountID),
+			Amount:   decimal.NewFromInt(10),
+			Currency: domain.CurrencyUSD,
+			Time:     time.Now(),
+		})
+
+		require.NoError(t, err)
+		assertBalanceEquals(t, ctx, testPool, owner.USDAccountID, decimal.NewFromInt(1000))
+	})
+
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestCloseAccount_RequiresZeroBalance(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	owner := registerTestUser(ctx, t, svc, testPool)
+	other := registerTestUser(ctx, t, svc, testPool)
+
+	t.Run("account with money cannot be closed", func(t *testing.T) {
+		err := svc.CloseAccount(ctx, domain.AccountID(owner.GBPAccountID), domain.UserID(owner.UserID))
+
+		var notEmptyErr *domain.AccountNotEmptyError
+		require.ErrorAs(t, err, &notEmptyErr)
+		assert.True(t, notEmptyErr.Balance.Amount().Equal(decimal.NewFromInt(300)))
+	})
+
+	// Arrange - empty the GBP account
+	_, err := svc.Transfer(ctx, &service.TransferCommand{
+		From:     domain.AccountID(owner.GBPAccountID),
+		To:       domain.AccountID(other.GBPAccountID),
+		Amount:   decimal.NewFromInt(300),
+		Currency: domain.CurrencyGBP,
+		Time:     time.Now(),
+	})
+	require.NoError(t, err)
+
+	// Act
+	err = svc.CloseAccount(ctx, domain.AccountID(owner.GBPAccountID), domain.UserID(owner.UserID))
+
+	// Assert
+	require.NoError(t, err)
+
+	accounts, err := svc.GetUserAccounts(ctx, domain.UserID(owner.UserID), false)
+	require.NoError(t, err)
+	assert.Len(t, accounts, 2, "closed account should be hidden from listings")
+
+	_, err = svc.Transfer(ctx, &service.TransferCommand{
+		From:     domain.AccountID(other.GBPAccountID),
+		To:       domain.AccountID(owner.GBPAccountID),
+		Amount:   decimal.NewFromInt(300),
+		Currency: domain.CurrencyGBP,
+		Time:     time.Now(),
+	})
+	var creditClosedErr *domain.ClosedAccountError
+	require.ErrorAs(t, err, &creditClosedErr, "closed account should not be credited")
+
+	err = svc.FreezeAccount(ctx, domain.AccountID(owner.GBPAccountID), domain.UserID(owner.UserID))
+	var closedErr *domain.ClosedAccountError
+	assert.ErrorAs(t, err, &closedErr)
+}
+
+func TestGetUserBalancesByCurrency_OneAccountPerCurrency(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Act
+	balances, err := svc.GetUserBalancesByCurrency(ctx, domain.UserID(user.UserID))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, balances, 3)
+	assert.True(t, balances[domain.CurrencyUSD].Amount().Equal(decimal.NewFromInt(1000)))
+	assert.True(t, balances[domain.CurrencyEUR].Amount().Equal(decimal.NewFromInt(500)))
+	assert.True(t, balances[domain.CurrencyGBP].Amount().Equal(decimal.NewFromInt(300)))
+	for currency, balance := range balances {
+		assert.Equal(t, currency, balance.Currency())
+	}
+}
+
+func TestGetUserBalancesByCurrency_SumsAccountsOfSameCurrency(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+	payer := registerTestUser(ctx, t, svc, testPool)
+
+	// Arrange: give the user a second USD account and fund it from another user
+	secondUSD := uuid.New()
+	_, err := testPool.Exec(ctx,
+		`INSERT INTO accounts (id, user_id, balance, currency) VALUES ($1, $2, 0, 'USD')`,
+		secondUSD, user.UserID)
+	require.NoError(t, err)
+
+	_, err = svc.Transfer(ctx, &service.TransferCommand{
+		From:     domain.AccountID(payer.USDAccountID),
+		To:       domain.AccountID(secondUSD),
+		Amount:   decimal.RequireFromString("150.25"),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
+	})
+	require.NoError(t, err)
+
+	// Act
+	balances, err := svc.GetUserBalancesByCurrency(ctx, domain.UserID(user.UserID))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, balances, 3)
+	assert.True(t, balances[domain.CurrencyUSD].Amount().Equal(decimal.RequireFromString("1150.25")))
+	assert.True(t, balances[domain.CurrencyEUR].Amount().Equal(decimal.NewFromInt(500)))
+	assertLedgerBalanced(ctx, t, svc)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"minibankingplatform/internal/domain"
+)
+
+// RequireAdmin returns an error unless the user is a configured administrator.
+func (s *Service) RequireAdmin(userID domain.UserID) error {
+	if !slices.Contains(s.config.AdminUserIDs, userID) {
+		return domain.NewAdminRequiredError(userID)
+	}
+
+	return nil
+}
+
+// SweepResult describes the outcome of moving an account's entire balance.
+type SweepResult struct {
+	// TransactionID is nil when the source account was already empty.
+	TransactionID *domain.TransactionID
+	From          domain.AccountID
+	To            domain.AccountID
+	Amount        domain.Money
+	Time          time.Time
+}
+
+// SweepAccount moves the entire balance of one account to another account in
+// the same currency, leaving the source at exactly zero. It is meant for
+// closing or merging accounts and must only be exposed to administrators.
+func (s *Service) SweepAccount(ctx context.Context, fromAccountID, toAccountID domain.AccountID) (*SweepResult, error) {
+	now := s.now().UTC()
+	result := &SweepResult{
+		From: fromAccountID,
+		To:   toAccountID,
+		Time: now,
+	}
+
+	release, err := s.acquireMoneyOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	err = s.trm.Do(ctx, func(ctx context.Context) error {
+		from, err := s.accounts.GetForUpdate(ctx, fromAccountID)
+		if err != nil {
+			return fmt.Errorf("getting 'from' account: %w", err)
+		}
+
+		to, err := s.accounts.GetForUpdate(ctx, toAccountID)
+		if err != nil {
+			return fmt.Errorf("getting 'to' account: %w", err)
+		}
+
+		err = from.Balance().CheckIsNotEqualCurrencies(to.Balance())
+		if err != nil {
+			return fmt.Errorf("checking account currencies: %w", err)
+		}
+
+		result.Amount = from.Balance()
+		if result.Amount.IsZero() {
+			return nil
+		}
+
+		details, err := s.transfer.Execute(from, to, result.Amount, now)
+		if err != nil {
+			return fmt.Errorf("executing transfer domain service: %w", err)
+		}
+
+		if !from.Balance().IsZero() {
+			return fmt.Errorf("source account %v has balance %s left after sweep", fromAccountID, from.Balance().Amount())
+		}
+
+		err = s.transfers.Insert(ctx, details)
+		if err != nil {
+			return fmt.Errorf("inserting transfer: %w", err)
+		}
+
+		err = s.accounts.Save(ctx, from)
+		if err != nil {
+			return fmt.Errorf("saving 'from' account: %w", err)
+		}
+
+		err = s.accounts.Save(ctx, to)
+		if err != nil {
+			return fmt.Errorf("saving 'to' account: %w", err)
+		}
+
+		err = s.CheckLedgerBalanceByCurrency(ctx)
+		if err != nil {
+			return fmt.Errorf("checking ledger balance by currency: %w", err)
+		}
+
+		err = s.checkAccountLedgerConsistency(ctx, from)
+		if err != nil {
+			return fmt.Errorf("checking 'from' account ledger consistency: %w", err)
+		}
+
+		err = s.checkAccountLedgerConsistency(ctx, to)
+		if err != nil {
+			return fmt.Errorf("checking 'to' account ledger consistency: %w", err)
+		}
+
+		transactionID := details.TransactionID()
+		result.TransactionID = &transactionID
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("doing atomic operation: %w", err)
+	}
+
+	return result, nil
+}
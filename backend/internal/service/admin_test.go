@@ -0,0 +1,109 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweepAccount_MovesEntireBalance(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - register two users (each gets 1000 USD, 500 EUR)
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	// Act
+	result, err := svc.SweepAccount(ctx, domain.AccountID(fromUser.USDAccountID), domain.AccountID(toUser.USDAccountID))
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, result.TransactionID)
+	assert.True(t, result.Amount.Amount().Equal(decimal.NewFromInt(1000)))
+	assert.Equal(t, domain.CurrencyUSD, result.Amount.Currency())
+
+	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.Zero)
+	assertBalanceEquals(t, ctx, testPool, toUser.USDAccountID, decimal.NewFromInt(2000))
+
+	// 1 ledger record from registration + 1 from sweep = 2
+	assert.Equal(t, 2, countLedgerRecords(ctx, t, testPool, fromUser.USDAccountID))
+	assert.Equal(t, 2, countLedgerRecords(ctx, t, testPool, toUser.USDAccountID))
+
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestSweepAccount_EmptySourceIsNoop(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	_, err := svc.SweepAccount(ctx, domain.AccountID(fromUser.USDAccountID), domain.AccountID(toUser.USDAccountID))
+	require.NoError(t, err)
+
+	// Act - sweep the already empty account back again
+	result, err := svc.SweepAccount(ctx, domain.AccountID(fromUser.USDAccountID), domain.AccountID(toUser.USDAccountID))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, result.TransactionID)
+	assert.True(t, result.Amount.IsZero())
+	assert.Equal(t, 2, countLedgerRecords(ctx, t, testPool, fromUser.USDAccountID))
+
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestSweepAccount_Errors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	t.Run("currency mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		user := registerTestUser(ctx, t, svc, testPool)
+
+		_, err := svc.SweepAccount(ctx, domain.AccountID(user.USDAccountID), domain.AccountID(user.EURAccountID))
+
+		var mismatchErr *domain.CurrencyMismatchError
+		require.ErrorAs(t, err, &mismatchErr)
+		assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(1000))
+		assertBalanceEquals(t, ctx, testPool, user.EURAccountID, decimal.NewFromInt(500))
+	})
+
+	t.Run("source account not found", func(t *testing.T) {
+		t.Parallel()
+
+		user := registerTestUser(ctx, t, svc, testPool)
+
+		_, err := svc.SweepAccount(ctx, domain.AccountID(uuid.New()), domain.AccountID(user.USDAccountID))
+
+		var notFoundErr *domain.AccountNotFoundError
+		require.ErrorAs(t, err, &notFoundErr)
+	})
+}
+
+func TestRequireAdmin(t *testing.T) {
+	t.Parallel()
+
+	adminID := domain.UserID(uuid.New())
+	svc := setupService(t, testPool, withConfig(service.Config{AdminUserIDs: []domain.UserID{adminID}}))
+
+	assert.NoError(t, svc.RequireAdmin(adminID))
+
+	var adminErr *domain.AdminRequiredError
+	assert.ErrorAs(t, svc.RequireAdmin(domain.UserID(uuid.New())), &adminErr)
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"minibankingplatform/internal/domain"
+)
+
+// MaxBatchTransfers is the most transfers a single batch can hold.
+const MaxBatchTransfers = 100
+
+// BatchTransferStatus tells what became of one transfer of a batch.
+type BatchTransferStatus string
+
+const (
+	// BatchTransferSucceeded transfers were committed with the rest of the batch.
+	BatchTransferSucceeded BatchTransferStatus = "succeeded"
+	// BatchTransferRolledBack transfers went through but were undone because a
+	// later transfer of the batch failed.
+	BatchTransferRolledBack BatchTransferStatus = "rolledBack"
+	// BatchTransferFailed is the transfer that failed the batch.
+	BatchTransferFailed BatchTransferStatus = "failed"
+	// BatchTransferSkipped transfers were not attempted because an earlier one failed.
+	BatchTransferSkipped BatchTransferStatus = "skipped"
+)
+
+// BatchTransferOutcome is the outcome of one transfer of a batch.
+type BatchTransferOutcome struct {
+	Status BatchTransferStatus
+	// TransactionID is set for succeeded transfers.
+	TransactionID domain.TransactionID
+	// Err is why a failed transfer failed.
+	Err error
+}
+
+// BatchTransferResult holds the outcome of every transfer of a batch, in the
+// order they were submitted.
+type BatchTransferResult struct {
+	Transfers []BatchTransferOutcome
+}
+
+// BatchTransfer runs the transfers in order, in a single transaction: either
+// all of them are committed, or none is. Each command must carry the user
+// making the batch, who must own its source account. When a transfer fails,
+// the error is a BatchTransferError and the result, returned alongside it,
+// tells which transfers were rolled back, failed or skipped. The batch counts
+// as one money operation, and its transfers bypass Config.InFlightTransfers:
+// a batch may repeat a transfer on purpose, and a failed batch can be
+// resubmitted right away.
+func (s *Service) BatchTransfer(ctx context.Context, cmds []*TransferCommand) (*BatchTransferResult, error) {
+	if len(cmds) == 0 || len(cmds) > MaxBatchTransfers {
+		return nil, domain.NewBatchTransferSizeError(len(cmds), MaxBatchTransfers)
+	}
+
+	release, err := s.acquireMoneyOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var result BatchTransferResult
+	err = s.trm.Do(ctx, func(ctx context.Context) error {
+		// A retried attempt starts the batch over
+		result.Transfers = make([]BatchTransferOutcome, len(cmds))
+
+		for i, cmd := range cmds {
+			transfer, err := s.batchTransfer(ctx, cmd)
+			if err != nil {
+				result.Transfers[i] = BatchTransferOutcome{Status: BatchTransferFailed, Err: err}
+				return domain.NewBatchTransferError(i, err)
+			}
+
+			result.Transfers[i] = BatchTransferOutcome{
+				Status:        BatchTransferSucceeded,
+				TransactionID: transfer.TransactionID,
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		result.markFailed()
+		return &result, fmt.Errorf("doing atomic operation: %w", err)
+	}
+
+	s.logger.LogAttrs(ctx, slog.LevelInfo, "batch transfer completed", slog.Int("transfers", len(cmds)))
+
+	return &result, nil
+}
+
+func (s *Service) batchTransfer(ctx context.Context, cmd *TransferCommand) (*TransferResult, error) {
+	err := s.AssertAccountOwnership(ctx, cmd.From, cmd.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("checking account ownership: %w", err)
+	}
+
+	money, err := s.transferMoney(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.executeTransfer(ctx, cmd, money)
+}
+
+// markFailed updates the outcomes of a batch that was rolled back: transfers
+// that went through are undone, those never reached are skipped.
+func (r *BatchTransferResult) markFailed() {
+	for i := range r.Transfers {
+		switch r.Transfers[i].Status {
+		case BatchTransferSucceeded:
+			r.Transfers[i] = BatchTransferOutcome{Status: BatchTransferRolledBack}
+		case "":
+			r.Transfers[i].Status = BatchTransferSkipped
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/infrastructure"
+	"minibankingplatform/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// payrollCommands builds one USD transfer from payer to each recipient, of the
+// matching amount.
+func payrollCommands(payer *TestUserAccounts, recipients []*TestUserAccounts, amounts []int64) []*service.TransferCommand {
+	cmds := make([]*service.TransferCommand, 0, len(recipients))
+	for i, recipient := range recipients {
+		cmds = append(cmds, &service.TransferCommand{
+			UserID:   domain.UserID(payer.UserID),
+			From:     domain.AccountID(payer.USDAccountID),
+			To:       domain.AccountID(recipient.USDAccountID),
+			Amount:   decimal.NewFromInt(amounts[i]),
+			Currency: domain.CurrencyUSD,
+			Time:     time.Now(),
+		})
+	}
+	return cmds
+}
+
+func TestBatchTransfer_CommitsAllTransfers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange
+	payer := registerTestUser(ctx, t, svc, testPool)
+	recipients := []*TestUserAccounts{registerTestUser(ctx, t, svc, testPool), registerTestUser(ctx, t, svc, testPool)}
+
+	// Act
+	result, err := svc.BatchTransfer(ctx, payrollCommands(payer, recipients, []int64{100, 250}))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.Transfers, 2)
+	for _, transfer := range result.Transfers {
+		assert.Equal(t, service.BatchTransferSucceeded, transfer.Status)
+		assert.NotZero(t, transfer.TransactionID)
+	}
+
+	assertBalanceEquals(t, ctx, testPool, payer.USDAccountID, decimal.NewFromInt(650))
+	assertBalanceEquals(t, ctx, testPool, recipients[0].USDAccountID, decimal.NewFromInt(1100))
+	assertBalanceEquals(t, ctx, testPool, recipients[1].USDAccountID, decimal.NewFromInt(1250))
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestBatchTransfer_FailureRollsBackAllTransfers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - the 3rd transfer asks for more than the payer holds
+	payer := registerTestUser(ctx, t, svc, testPool)
+	recipients := make([]*TestUserAccounts, 5)
+	for i := range recipients {
+		recipients[i] = registerTestUser(ctx, t, svc, testPool)
+	}
+	cmds := payrollCommands(payer, recipients, []int64{100, 100, 2000, 100, 100})
+
+	// Act
+	result, err := svc.BatchTransfer(ctx, cmds)
+
+	// Assert
+	var batchErr *domain.BatchTransferError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, 2, batchErr.Index)
+
+	var insufficientFundsErr *domain.InsufficientFundsError
+	assert.ErrorAs(t, err, &insufficientFundsErr)
+
+	require.NotNil(t, result)
+	statuses := make([]service.BatchTransferStatus, 0, len(result.Transfers))
+	for _, transfer := range result.Transfers {
+		statuses = append(statuses, transfer.Status)
+	}
+	assert.Equal(t, []service.BatchTransferStatus{
+		service.BatchTransferRolledBack,
+		service.BatchTransferRolledBack,
+		service.BatchTransferFailed,
+		service.BatchTransferSkipped,
+		service.BatchTransferSkipped,
+	}, statuses)
+	assert.ErrorAs(t, result.Transfers[2].Err, &insufficientFundsErr)
+
+	assertBalanceEquals(t, ctx, testPool, payer.USDAccountID, decimal.NewFromInt(1000))
+	assert.Equal(t, 1, countLedgerRecords(ctx, t, testPool, payer.USDAccountID), "only the registration funding is booked")
+	for _, recipient := range recipients {
+		assertBalanceEquals(t, ctx, testPool, recipient.USDAccountID, decimal.NewFromInt(1000))
+	}
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestBatchTransfer_RetryIsNotTakenForADuplicate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool, withConfig(service.Config{
+		InFlightTransfers: infrastructure.NewInMemoryInFlightRegistry(time.Minute),
+	}))
+
+	// Arrange - a payroll paying one recipient twice, which fails on its last
+	// transfer
+	payer := registerTestUser(ctx, t, svc, testPool)
+	recipient := registerTestUser(ctx, t, svc, testPool)
+	other := registerTestUser(ctx, t, svc, testPool)
+	recipients := []*TestUserAccounts{recipient, recipient, other}
+
+	_, err := svc.BatchTransfer(ctx, payrollCommands(payer, recipients, []int64{100, 100, 2000}))
+	var insufficientFundsErr *domain.InsufficientFundsError
+	require.ErrorAs(t, err, &insufficientFundsErr)
+
+	// Act - the corrected batch, resubmitted within the deduplication window
+	result, err := svc.BatchTransfer(ctx, payrollCommands(payer, recipients, []int64{100, 100, 200}))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.Transfers, 3)
+	assertBalanceEquals(t, ctx, testPool, payer.USDAccountID, decimal.NewFromInt(600))
+	assertBalanceEquals(t, ctx, testPool, recipient.USDAccountID, decimal.NewFromInt(1200))
+	assertBalanceEquals(t, ctx, testPool, other.USDAccountID, decimal.NewFromInt(1200))
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestBatchTransfer_OppositeDirectionsDoNotDeadlock(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - two users paying each other back and forth in concurrent batches
+	first := registerTestUser(ctx, t, svc, testPool)
+	second := registerTestUser(ctx, t, svc, testPool)
+
+	const rounds = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*rounds)
+
+	// Act
+	for range rounds {
+		for _, pair := range [][2]*TestUserAccounts{{first, second}, {second, first}} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := svc.BatchTransfer(ctx, payrollCommands(pair[0], []*TestUserAccounts{pair[1], pair[1]}, []int64{1, 1}))
+				errs <- err
+			}()
+		}
+	}
+	wg.Wait()
+	close(errs)
+
+	// Assert - every batch went through, none was picked as a deadlock victim
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	assertBalanceEquals(t, ctx, testPool, first.USDAccountID, decimal.NewFromInt(1000))
+	assertBalanceEquals(t, ctx, testPool, second.USDAccountID, decimal.NewFromInt(1000))
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestBatchTransfer_RequiresOwnedSourceAccounts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - the second transfer drains another user's account
+	payer := registerTestUser(ctx, t, svc, testPool)
+	victim := registerTestUser(ctx, t, svc, testPool)
+	cmds := payrollCommands(payer, []*TestUserAccounts{victim, payer}, []int64{10, 10})
+	cmds[1].From = domain.AccountID(victim.USDAccountID)
+
+	// Act
+	_, err := svc.BatchTransfer(ctx, cmds)
+
+	// Assert
+	var accessDeniedErr *domain.AccountAccessDeniedError
+	require.ErrorAs(t, err, &accessDeniedErr)
+	assertBalanceEquals(t, ctx, testPool, payer.USDAccountID, decimal.NewFromInt(1000))
+	assertBalanceEquals(t, ctx, testPool, victim.USDAccountID, decimal.NewFromInt(1000))
+}
+
+func TestBatchTransfer_Size(t *testing.T) {
+	t.Parallel()
+
+	svc := setupService(t, testPool)
+
+	for _, size := range []int{0, service.MaxBatchTransfers + 1} {
+		_, err := svc.BatchTransfer(context.Background(), make([]*service.TransferCommand, size))
+
+		var sizeErr *domain.BatchTransferSizeError
+		assert.ErrorAs(t, err, &sizeErr, "a batch of %d transfers", size)
+	}
+}
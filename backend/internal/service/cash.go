@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/pkg/trm"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// CashCommand deposits money into or withdraws money from a user account. The
+// amount is in the account's currency.
+type CashCommand struct {
+	Account domain.AccountID
+	Amount  decimal.Decimal
+	Time    time.Time
+}
+
+func NewCashCommand(account uuid.UUID, amount string, time time.Time) (*CashCommand, error) {
+	decimalAmount, err := domain.ParseAmount(amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	return &CashCommand{
+		Account: domain.AccountID(account),
+		Amount:  decimalAmount,
+		Time:    time,
+	}, nil
+}
+
+// CashResult describes a booked deposit or withdrawal.
+type CashResult struct {
+	TransactionID domain.TransactionID
+	Amount        domain.Money
+	Balance       domain.Money
+	Time          time.Time
+}
+
+// Deposit pays money into the account from the cashbook of its currency.
+func (s *Service) Deposit(ctx context.Context, cmd *CashCommand) (*CashResult, error) {
+	return s.moveCash(ctx, cmd, s.deposit.Execute)
+}
+
+// Withdraw pays money out of the account into the cashbook of its currency.
+// It fails with *domain.InsufficientFundsError when the balance would go
+// negative.
+func (s *Service) Withdraw(ctx context.Context, cmd *CashCommand) (*CashResult, error) {
+	return s.moveCash(ctx, cmd, s.withdrawal.Execute)
+}
+
+// moveCash books a movement between the account and its cashbook. The account
+// is locked before the cashbook, in the same order as exchanges do.
+func (s *Service) moveCash(
+	ctx context.Context,
+	cmd *CashCommand,
+	execute func(account, cashbook *domain.Account, amount domain.Money, now time.Time) (*domain.TransferDetails, error),
+) (*CashResult, error) {
+	release, err := s.acquireMoneyOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	cashbooks := s.newCashbookWatch()
+
+	var result CashResult
+	err = s.trm.Do(ctx, func(ctx context.Context) error {
+		trm.AfterCommit(ctx, cashbooks.notify)
+
+		account, err := s.accounts.GetForUpdate(ctx, cmd.Account)
+		if err != nil {
+			return fmt.Errorf("getting account: %w", err)
+		}
+
+		amount, err := domain.NewMoney(cmd.Amount, account.Balance().Currency())
+		if err != nil {
+			return fmt.Errorf("getting money value: %w", err)
+		}
+
+		amount, err = s.config.SubUnitPolicy.Apply(amount)
+		if err != nil {
+			return fmt.Errorf("applying sub-unit policy: %w", err)
+		}
+
+		cashbook, err := s.accounts.GetForUpdate(ctx, domain.GetCashbookAccount(amount.Currency()))
+		if err != nil {
+			return fmt.Errorf("getting cashbook account: %w", err)
+		}
+		cashbooks.add(cashbook)
+
+		details, err := execute(account, cashbook, amount, cmd.Time)
+		if err != nil {
+			return fmt.Errorf("executing domain service: %w", err)
+		}
+
+		// Withdrawals count against the daily limit like transfers do.
+		if details.Type() == domain.TransactionTypeWithdrawal {
+			err = s.checkDailyLimit(ctx, account, amount, cmd.Time)
+			if err != nil {
+				return fmt.Errorf("checking daily limit: %w", err)
+			}
+		}
+
+		err = s.transfers.Insert(ctx, details)
+		if err != nil {
+			return fmt.Errorf("inserting %s: %w", details.Type(), err)
+		}
+
+		err = s.accounts.Save(ctx, account)
+		if err != nil {
+			return fmt.Errorf("saving account: %w", err)
+		}
+
+		err = s.accounts.Save(ctx, cashbook)
+		if err != nil {
+			return fmt.Errorf("saving cashbook account: %w", err)
+		}
+
+		err = s.CheckLedgerBalanceByCurrency(ctx)
+		if err != nil {
+			return fmt.Errorf("checking ledger balance by currency: %w", err)
+		}
+
+		err = s.checkAccountLedgerConsistency(ctx, account)
+		if err != nil {
+			return fmt.Errorf("checking account ledger consistency: %w", err)
+		}
+
+		err = s.checkAccountLedgerConsistency(ctx, cashbook)
+		if err != nil {
+			return fmt.Errorf("checking cashbook ledger consistency: %w", err)
+		}
+
+		result = CashResult{
+			TransactionID: details.TransactionID(),
+			Amount:        details.Money(),
+			Balance:       account.Balance(),
+			Time:          details.Time(),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("doing atomic operation: %w", err)
+	}
+
+	return &result, nil
+}
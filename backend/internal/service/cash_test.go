@@ -0,0 +1,139 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeposit_HappyPath(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - register a user (gets 1000 USD, 500 EUR, 300 GBP)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Act
+	result, err := svc.Deposit(ctx, &service.CashCommand{
+		Account: domain.AccountID(user.EURAccountID),
+		Amount:  decimal.RequireFromString("250.50"),
+		Time:    time.Now(),
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.CurrencyEUR, result.Amount.Currency())
+	assert.True(t, result.Balance.Amount().Equal(decimal.RequireFromString("750.50")))
+	assertBalanceEquals(t, ctx, testPool, user.EURAccountID, decimal.RequireFromString("750.50"))
+
+	var transactionType string
+	err = testPool.QueryRow(ctx, `SELECT type FROM transactions WHERE id = $1`, result.TransactionID).Scan(&transactionType)
+	require.NoError(t, err)
+	assert.Equal(t, "deposit", transactionType)
+
+	// 1 ledger record from registration + 1 from the deposit
+	assert.Equal(t, 2, countLedgerRecords(ctx, t, testPool, user.EURAccountID))
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestWithdraw_HappyPath(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Act
+	result, err := svc.Withdraw(ctx, &service.CashCommand{
+		Account: domain.AccountID(user.USDAccountID),
+		Amount:  decimal.NewFromInt(400),
+		Time:    time.Now(),
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, result.Balance.Amount().Equal(decimal.NewFromInt(600)))
+	assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(600))
+
+	var transactionType string
+	err = testPool.QueryRow(ctx, `SELECT type FROM transactions WHERE id = $1`, result.TransactionID).Scan(&transactionType)
+	require.NoError(t, err)
+	assert.Equal(t, "withdrawal", transactionType)
+
+	assert.Equal(t, 2, countLedgerRecords(ctx, t, testPool, user.USDAccountID))
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestWithdraw_InsufficientFunds(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Act - the GBP account only holds 300
+	_, err := svc.Withdraw(ctx, &service.CashCommand{
+		Account: domain.AccountID(user.GBPAccountID),
+		Amount:  decimal.RequireFromString("300.01"),
+		Time:    time.Now(),
+	})
+
+	// Assert
+	var insufficientErr *domain.InsufficientFundsError
+	require.ErrorAs(t, err, &insufficientErr)
+	assertBalanceEquals(t, ctx, testPool, user.GBPAccountID, decimal.NewFromInt(300))
+	assert.Equal(t, 1, countLedgerRecords(ctx, t, testPool, user.GBPAccountID))
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestDeposit_RejectsInvalidAmounts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	t.Run("zero", func(t *testing.T) {
+		_, err := svc.Deposit(ctx, &service.CashCommand{
+			Account: domain.AccountID(user.USDAccountID),
+			Amount:  decimal.Zero,
+			Time:    time.Now(),
+		})
+
+		var zeroErr *domain.ZeroAmountError
+		assert.ErrorAs(t, err, &zeroErr)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		_, err := svc.Deposit(ctx, &service.CashCommand{
+			Account: domain.AccountID(user.USDAccountID),
+			Amount:  decimal.NewFromInt(-10),
+			Time:    time.Now(),
+		})
+
+		var negativeErr *domain.NegativeTransferError
+		assert.ErrorAs(t, err, &negativeErr)
+	})
+
+	t.Run("cashbook account", func(t *testing.T) {
+		_, err := svc.Deposit(ctx, &service.CashCommand{
+			Account: domain.CashbookUSD,
+			Amount:  decimal.NewFromInt(10),
+			Time:    time.Now(),
+		})
+
+		var cashbookErr *domain.CashbookTransferError
+		assert.ErrorAs(t, err, &cashbookErr)
+	})
+
+	assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(1000))
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"slices"
+
+	"minibankingplatform/internal/domain"
+)
+
+// cashbookWatch remembers cashbook balances at the start of an operation so
+// that threshold crossings can be reported once the operation has committed.
+type cashbookWatch struct {
+	alerter    CashbookAlerter
+	thresholds []domain.Money
+	cashbooks  []*domain.Account
+	before     []domain.Money
+}
+
+func (s *Service) newCashbookWatch() *cashbookWatch {
+	return &cashbookWatch{
+		alerter:    s.config.CashbookAlerter,
+		thresholds: s.config.CashbookAlertThresholds,
+	}
+}
+
+// add starts watching the cashbook. It must be called before the cashbook's
+// balance is changed. Adding a cashbook again, as a retried transaction does
+// after reloading it, replaces the earlier copy.
+func (w *cashbookWatch) add(cashbooks ...*domain.Account) {
+	for _, cashbook := range cashbooks {
+		i := slices.IndexFunc(w.cashbooks, func(watched *domain.Account) bool {
+			return watched.ID() == cashbook.ID()
+		})
+		if i >= 0 {
+			w.cashbooks[i] = cashbook
+			w.before[i] = cashbook.Balance()
+			continue
+		}
+
+		w.cashbooks = append(w.cashbooks, cashbook)
+		w.before = append(w.before, cashbook.Balance())
+	}
+}
+
+// notify reports every watched cashbook whose balance went from above its
+// threshold to at or below it. Operations register it with trm.AfterCommit, so
+// balances a rollback discards, like those of a dry run, raise no alert.
+func (w *cashbookWatch) notify() {
+	if w.alerter == nil {
+		return
+	}
+
+	for i, cashbook := range w.cashbooks {
+		threshold, ok := w.threshold(cashbook.Balance().Currency())
+		if !ok {
+			continue
+		}
+
+		before := w.before[i].Amount()
+		after := cashbook.Balance().Amount()
+		if before.GreaterThan(threshold.Amount()) && after.LessThanOrEqual(threshold.Amount()) {
+			w.alerter.CashbookThresholdCrossed(cashbook.ID(), cashbook.Balance(), threshold)
+		}
+	}
+}
+
+func (w *cashbookWatch) threshold(currency domain.Currency) (domain.Money, bool) {
+	for _, threshold := range w.thresholds {
+		if threshold.Currency() == currency {
+			return threshold, true
+		}
+	}
+
+	return domain.Money{}, false
+}
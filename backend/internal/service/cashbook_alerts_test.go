@@ -0,0 +1,151 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cashbookAlert struct {
+	cashbook  domain.AccountID
+	balance   domain.Money
+	threshold domain.Money
+}
+
+type recordingCashbookAlerter struct {
+	mu     sync.Mutex
+	alerts []cashbookAlert
+}
+
+func (r *recordingCashbookAlerter) CashbookThresholdCrossed(cashbook domain.AccountID, balance domain.Money, threshold domain.Money) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.alerts = append(r.alerts, cashbookAlert{cashbook: cashbook, balance: balance, threshold: threshold})
+}
+
+func (r *recordingCashbookAlerter) Alerts() []cashbookAlert {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]cashbookAlert(nil), r.alerts...)
+}
+
+// cashbookThresholdBelow returns a threshold the given amount below the cashbook's current balance.
+func cashbookThresholdBelow(ctx context.Context, t *testing.T, svc *service.Service, currency domain.Currency, amount int64) domain.Money {
+	t.Helper()
+
+	balance, err := svc.GetAccountBalance(ctx, domain.GetCashbookAccount(currency))
+	require.NoError(t, err)
+
+	threshold, err := domain.NewMoney(balance.Amount().Sub(decimal.NewFromInt(amount)), currency)
+	require.NoError(t, err)
+
+	return threshold
+}
+
+// Not parallel: the thresholds are derived from the shared cashbook balances,
+// which concurrent registrations would move underneath the test.
+func TestCashbookAlerts_ThresholdCrossing(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("registration funding crosses USD threshold once", func(t *testing.T) {
+		// Arrange - registration takes 1000 USD from the cashbook
+		alerter := &recordingCashbookAlerter{}
+		threshold := cashbookThresholdBelow(ctx, t, setupService(t, testPool), domain.CurrencyUSD, 500)
+		svc := setupService(t, testPool, withConfig(service.Config{
+			CashbookAlertThresholds: []domain.Money{threshold},
+			CashbookAlerter:         alerter,
+		}))
+
+		// Act
+		registerTestUser(ctx, t, svc, testPool)
+		registerTestUser(ctx, t, svc, testPool)
+
+		// Assert - the second registration starts below the threshold and doesn't alert again
+		alerts := alerter.Alerts()
+		require.Len(t, alerts, 1)
+		assert.Equal(t, domain.GetCashbookAccount(domain.CurrencyUSD), alerts[0].cashbook)
+		assert.True(t, alerts[0].balance.Amount().Equal(threshold.Amount().Sub(decimal.NewFromInt(500))))
+		assert.True(t, alerts[0].threshold.Amount().Equal(threshold.Amount()))
+	})
+
+	t.Run("exchange crosses EUR threshold", func(t *testing.T) {
+		// Arrange - exchanging 100 USD pays 92 EUR out of the EUR cashbook
+		alerter := &recordingCashbookAlerter{}
+		user := registerTestUser(ctx, t, setupService(t, testPool), testPool)
+		threshold := cashbookThresholdBelow(ctx, t, setupService(t, testPool), domain.CurrencyEUR, 50)
+		svc := setupService(t, testPool, withConfig(service.Config{
+			CashbookAlertThresholds: []domain.Money{threshold},
+			CashbookAlerter:         alerter,
+		}))
+
+		amount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
+
+		// Act
+		_, err := svc.Exchange(ctx, &service.ExchangeCommand{
+			UserID:        domain.UserID(user.UserID),
+			SourceAccount: domain.AccountID(user.USDAccountID),
+			TargetAccount: domain.AccountID(user.EURAccountID),
+			SourceAmount:  amount,
+			Time:          time.Now(),
+		})
+
+		// Assert
+		require.NoError(t, err)
+		alerts := alerter.Alerts()
+		require.Len(t, alerts, 1)
+		assert.Equal(t, domain.GetCashbookAccount(domain.CurrencyEUR), alerts[0].cashbook)
+	})
+
+	t.Run("dry run exchange raises no alert", func(t *testing.T) {
+		// Arrange - the exchange would cross the threshold, but is rolled back
+		alerter := &recordingCashbookAlerter{}
+		user := registerTestUser(ctx, t, setupService(t, testPool), testPool)
+		threshold := cashbookThresholdBelow(ctx, t, setupService(t, testPool), domain.CurrencyEUR, 50)
+		svc := setupService(t, testPool, withConfig(service.Config{
+			CashbookAlertThresholds: []domain.Money{threshold},
+			CashbookAlerter:         alerter,
+		}))
+
+		amount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
+
+		// Act
+		err := svc.DryRun(ctx, func(ctx context.Context) error {
+			_, err := svc.Exchange(ctx, &service.ExchangeCommand{
+				UserID:        domain.UserID(user.UserID),
+				SourceAccount: domain.AccountID(user.USDAccountID),
+				TargetAccount: domain.AccountID(user.EURAccountID),
+				SourceAmount:  amount,
+				Time:          time.Now(),
+			})
+			return err
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, alerter.Alerts())
+	})
+
+	t.Run("no alert above threshold", func(t *testing.T) {
+		// Arrange
+		alerter := &recordingCashbookAlerter{}
+		threshold := cashbookThresholdBelow(ctx, t, setupService(t, testPool), domain.CurrencyUSD, 5000)
+		svc := setupService(t, testPool, withConfig(service.Config{
+			CashbookAlertThresholds: []domain.Money{threshold},
+			CashbookAlerter:         alerter,
+		}))
+
+		// Act
+		registerTestUser(ctx, t, svc, testPool)
+
+		// Assert
+		assert.Empty(t, alerter.Alerts())
+	})
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"minibankingplatform/internal/domain"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Config holds business policies that can be tuned per deployment.
+type Config struct {
+	// ExchangeRoundingBias decides who benefits from rounding converted amounts.
+	ExchangeRoundingBias domain.RoundingBias
+
+	// ExchangeSpread is the fraction of the provider's mid-rate the platform
+	// keeps on every exchange, e.g. 0.005 for 0.5%. Exchanges use the provider
+	// rate as is when zero.
+	ExchangeSpread decimal.Decimal
+
+	// AllowedExchangeDirections limits exchanges to the listed currency pairs.
+	// All directions are allowed when empty.
+	AllowedExchangeDirections []domain.ExchangeDirection
+
+	// MinimumExchangeAmount is the smallest source amount, in the source
+	// currency, accepted for pairs without an entry in ExchangeMinimums.
+	// There is no minimum when zero.
+	MinimumExchangeAmount decimal.Decimal
+
+	// ExchangeMinimums overrides MinimumExchangeAmount for specific pairs.
+	ExchangeMinimums []domain.ExchangeMinimum
+
+	// SubUnitPolicy decides how transferred amounts finer than the currency's
+	// minor unit are handled. The zero value rejects them.
+	SubUnitPolicy domain.SubUnitPolicy
+
+	// InFlightTransfers rejects identical transfers submitted while one is still
+	// being processed or, once it went through, for the rest of the registry's
+	// window. A failed transfer can be retried at once. Deduplication is
+	// disabled when nil.
+	InFlightTransfers InFlightRegistry
+
+	// MaxMoneyOperations caps how many money operations, such as transfers and
+	// exchanges, run at once. Operations beyond the cap are rejected with
+	// *domain.TooManyRequestsError rather than queued. Unlimited when zero.
+	MaxMoneyOperations int
+
+	// AdminUserIDs lists users allowed to run administrative operations.
+	AdminUserIDs []domain.UserID
+
+	// CashbookAlertThresholds holds, per currency, the cashbook balance at or
+	// below which CashbookAlerter is notified. Currencies without a threshold
+	// are not watched.
+	CashbookAlertThresholds []domain.Money
+
+	// CashbookAlerter receives cashbook threshold alerts. Alerting is disabled when nil.
+	CashbookAlerter CashbookAlerter
+
+	// DefaultPageSize is the page size of paginated lists when the client
+	// doesn't ask for one. Falls back to FallbackPageSize when zero.
+	DefaultPageSize int
+
+	// ExchangeQuoteTTL is how long a quote returned by QuoteExchange can be
+	// executed. Falls back to DefaultExchangeQuoteTTL when zero.
+	ExchangeQuoteTTL time.Duration
+
+	// ExecutedExchangeQuotes rejects quotes that were already executed. It must
+	// remember keys for at least ExchangeQuoteTTL. Quotes are neither issued
+	// nor executed when nil.
+	ExecutedExchangeQuotes InFlightRegistry
+
+	// FundingAccounts holds, per currency, the account new users are funded
+	// from at registration, such as a pre-capitalized promotions account. Its
+	// balance must cover the funding, like for any transfer. Currencies without
+	// an entry are funded from their cashbook.
+	FundingAccounts map[domain.Currency]domain.AccountID
+
+	// BlockedEmailDomains lists email domains, such as disposable mailbox
+	// providers, that can't be used to register. Matching is case-insensitive
+	// and exact: subdomains must be listed separately.
+	BlockedEmailDomains []string
+
+	// IdempotencyKeyTTL is how long a stored idempotency key is replayed; after
+	// that the key can be used afresh. Falls back to DefaultIdempotencyKeyTTL
+	// when zero.
+	IdempotencyKeyTTL time.Duration
+
+	// TokenRotations limits how often a user can rotate their token: a user
+	// key stays taken for the registry's window. Rotation is unlimited when nil.
+	TokenRotations InFlightRegistry
+
+	// MaintenanceRetryAfter is how long clients are asked to wait after a money
+	// operation was refused in maintenance mode. Falls back to
+	// DefaultMaintenanceRetryAfter when zero.
+	MaintenanceRetryAfter time.Duration
+}
+
+// FallbackPageSize is the default page size when Config.DefaultPageSize is unset.
+const FallbackPageSize = 20
+
+// DefaultExchangeQuoteTTL is the quote lifetime when Config.ExchangeQuoteTTL is unset.
+const DefaultExchangeQuoteTTL = 30 * time.Second
+
+// DefaultIdempotencyKeyTTL is the key lifetime when Config.IdempotencyKeyTTL is unset.
+const DefaultIdempotencyKeyTTL = 24 * time.Hour
+
+// DefaultMaintenanceRetryAfter is the retry delay when Config.MaintenanceRetryAfter is unset.
+const DefaultMaintenanceRetryAfter = 2 * time.Minute
+
+// MoneyOperationRetryAfter is how long clients are asked to wait after being
+// turned away by Config.MaxMoneyOperations.
+const MoneyOperationRetryAfter = time.Second
+
+// InFlightRegistry keeps track of requests that are currently being processed.
+type InFlightRegistry interface {
+	// TryAcquire marks the key as in flight and reports whether it was free.
+	TryAcquire(key string) bool
+	// Release ends the request holding a key acquired with TryAcquire. The key
+	// stays taken for the rest of the registry's window when the request
+	// succeeded and is freed at once when it failed, so it can be retried.
+	Release(key string, succeeded bool)
+}
+
+// CashbookAlerter is notified when a cashbook balance drops to or below its
+// alert threshold.
+type CashbookAlerter interface {
+	CashbookThresholdCrossed(cashbook domain.AccountID, balance domain.Money, threshold domain.Money)
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// errDryRun rolls back the transaction of a dry run whose operation succeeded.
+var errDryRun = errors.New("dry run")
+
+type dryRunKey struct{}
+
+// isDryRun reports whether ctx belongs to an operation run by DryRun.
+func isDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
+// DryRun runs op in a transaction that is rolled back after op returns, even
+// when it succeeded. Operations op calls with its ctx nest into that
+// transaction, so op sees their effects, e.g. the resulting balances, and can
+// capture them before they are discarded. Nothing op does persists, and
+// transfers it makes don't take a key in the in-flight registry, so the real
+// request can follow right after.
+func (s *Service) DryRun(ctx context.Context, op func(ctx context.Context) error) error {
+	ctx = context.WithValue(ctx, dryRunKey{}, true)
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		if err := op(ctx); err != nil {
+			return err
+		}
+
+		return errDryRun
+	})
+	if errors.Is(err, errDryRun) {
+		s.logger.LogAttrs(ctx, slog.LevelInfo, "dry run rolled back")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("doing dry run: %w", err)
+	}
+
+	return nil
+}
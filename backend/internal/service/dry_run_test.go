@@ -0,0 +1,128 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/infrastructure"
+	"minibankingplatform/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun_TransferLeavesBalancesUnchanged(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange
+	svc := setupService(t, testPool)
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	cmd := &service.TransferCommand{
+		UserID:   domain.UserID(fromUser.UserID),
+		From:     domain.AccountID(fromUser.USDAccountID),
+		To:       domain.AccountID(toUser.USDAccountID),
+		Amount:   decimal.NewFromInt(100),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
+	}
+
+	// Act
+	var result *service.TransferResult
+	var resultingBalance domain.Money
+	err := svc.DryRun(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = svc.Transfer(ctx, cmd)
+		if err != nil {
+			return err
+		}
+
+		resultingBalance, err = svc.GetAccountBalance(ctx, domain.AccountID(fromUser.USDAccountID))
+		return err
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.True(t, resultingBalance.Amount().Equal(decimal.NewFromInt(900)), "the dry run should see its own transfer")
+
+	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.NewFromInt(1000))
+	assertBalanceEquals(t, ctx, testPool, toUser.USDAccountID, decimal.NewFromInt(1000))
+	assert.Equal(t, 1, countLedgerRecords(ctx, t, testPool, fromUser.USDAccountID))
+	assert.Equal(t, 1, countLedgerRecords(ctx, t, testPool, toUser.USDAccountID))
+
+	_, err = svc.GetTransactionByID(ctx, result.TransactionID, domain.UserID(fromUser.UserID))
+	var notFoundErr *domain.TransactionNotFoundError
+	assert.ErrorAs(t, err, &notFoundErr, "the dry run's transaction must be rolled back")
+}
+
+func TestDryRun_ReturnsOperationError(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange
+	svc := setupService(t, testPool)
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	cmd := &service.TransferCommand{
+		UserID:   domain.UserID(fromUser.UserID),
+		From:     domain.AccountID(fromUser.USDAccountID),
+		To:       domain.AccountID(toUser.USDAccountID),
+		Amount:   decimal.NewFromInt(5000),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
+	}
+
+	// Act
+	err := svc.DryRun(ctx, func(ctx context.Context) error {
+		_, err := svc.Transfer(ctx, cmd)
+		return err
+	})
+
+	// Assert
+	var insufficientErr *domain.InsufficientFundsError
+	require.True(t, errors.As(err, &insufficientErr))
+	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.NewFromInt(1000))
+}
+
+func TestDryRun_TransferDoesNotBlockTheRealOne(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange
+	svc := setupService(t, testPool, withConfig(service.Config{
+		InFlightTransfers: infrastructure.NewInMemoryInFlightRegistry(time.Minute),
+	}))
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	cmd := &service.TransferCommand{
+		UserID:   domain.UserID(fromUser.UserID),
+		From:     domain.AccountID(fromUser.USDAccountID),
+		To:       domain.AccountID(toUser.USDAccountID),
+		Amount:   decimal.NewFromInt(100),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
+	}
+
+	err := svc.DryRun(ctx, func(ctx context.Context) error {
+		_, err := svc.Transfer(ctx, cmd)
+		return err
+	})
+	require.NoError(t, err)
+
+	// Act - the same transfer for real, right after its dry run
+	_, err = svc.Transfer(ctx, cmd)
+
+	// Assert
+	require.NoError(t, err)
+	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.NewFromInt(900))
+	assertBalanceEquals(t, ctx, testPool, toUser.USDAccountID, decimal.NewFromInt(1100))
+}
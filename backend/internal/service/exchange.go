@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"minibankingplatform/internal/domain"
+	"minibankingplatform/pkg/trm"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +13,7 @@ import (
 )
 
 type ExchangeCommand struct {
+	UserID        domain.UserID
 	SourceAccount domain.AccountID
 	TargetAccount domain.AccountID
 	SourceAmount  domain.Money
@@ -24,12 +27,12 @@ func NewExchangeCommand(
 	sourceCurrency string,
 	time time.Time,
 ) (*ExchangeCommand, error) {
-	decimalAmount, err := decimal.NewFromString(amount)
+	decimalAmount, err := domain.ParseAmount(domain.NormalizeAmount(amount))
 	if err != nil {
 		return nil, fmt.Errorf("invalid amount: %w", err)
 	}
 
-	currency, err := domain.ParseCurrency(sourceCurrency)
+	currency, err := domain.ParseSupportedCurrency(sourceCurrency)
 	if err != nil {
 		return nil, fmt.Errorf("invalid currency: %w", err)
 	}
@@ -47,44 +50,158 @@ func NewExchangeCommand(
 	}, nil
 }
 
-func (s *Service) Exchange(ctx context.Context, cmd *ExchangeCommand) error {
-	err := s.trm.Do(ctx, func(ctx context.Context) error {
+// Validate rejects clearly invalid commands before any account is locked.
+// The domain checks made while the accounts are locked stay authoritative.
+func (cmd *ExchangeCommand) Validate() error {
+	if cmd.SourceAccount == cmd.TargetAccount {
+		return domain.NewSameAccountExchangeError(cmd.SourceAccount)
+	}
+
+	if !cmd.SourceAmount.Currency().IsValid() {
+		return domain.NewUnsupportedCurrencyError(cmd.SourceAmount.Currency())
+	}
+
+	if cmd.SourceAmount.IsNegative() {
+		return domain.NewNegativeExchangeError(cmd.SourceAmount)
+	}
+
+	if cmd.SourceAmount.IsZero() {
+		return domain.NewZeroAmountError(cmd.SourceAmount.Currency())
+	}
+
+	return nil
+}
+
+// ExchangeResult carries the executed exchange, including the amounts and
+// rate it was actually booked at.
+type ExchangeResult struct {
+	Details *domain.ExchangeDetails
+}
+
+// Exchange converts money between two accounts of cmd.UserID at the current
+// rate. Accounts of other users are rejected with
+// *domain.AccountAccessDeniedError.
+func (s *Service) Exchange(ctx context.Context, cmd *ExchangeCommand) (_ *ExchangeResult, err error) {
+	ctx, span := s.startSpan(ctx, "service.Exchange",
+		accountSpanAttr(spanKeyAccountFrom, cmd.SourceAccount),
+		accountSpanAttr(spanKeyAccountTo, cmd.TargetAccount),
+	)
+	defer func() { endSpan(span, err) }()
+
+	if err := cmd.Validate(); err != nil {
+		err = fmt.Errorf("validating exchange command: %w", err)
+		s.logFailure(ctx, "exchange failed", err, accountIDAttr(cmd.SourceAccount))
+		return nil, err
+	}
+
+	release, err := s.acquireMoneyOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	exchangeRate, err := s.currentExchangeRate(ctx, cmd)
+	if err != nil {
+		err = fmt.Errorf("getting exchange rate: %w", err)
+		s.logFailure(ctx, "exchange failed", err, accountIDAttr(cmd.SourceAccount))
+		return nil, err
+	}
+
+	return s.executeExchange(ctx, cmd, exchangeRate)
+}
+
+// currentExchangeRate fetches the rate cmd would be booked at right now. The
+// target account is read without a lock, only for its currency, which never
+// changes.
+func (s *Service) currentExchangeRate(ctx context.Context, cmd *ExchangeCommand) (domain.ExchangeRate, error) {
+	targetAccount, err := s.accounts.Get(ctx, cmd.TargetAccount)
+	if err != nil {
+		return domain.ExchangeRate{}, fmt.Errorf("getting target account: %w", err)
+	}
+
+	return s.getExchangeRate(ctx, cmd.SourceAmount.Currency(), targetAccount.Balance().Currency())
+}
+
+// checkExchangeOwnership rejects the exchange unless its accounts belong to
+// userID. Cashbooks are left to the domain service, which rejects them with
+// *domain.CashbookTransferError.
+func checkExchangeOwnership(userID domain.UserID, accounts ...*domain.Account) error {
+	for _, account := range accounts {
+		if !account.IsCashbook() && account.UserID() != userID {
+			return domain.NewAccountAccessDeniedError(account.ID())
+		}
+	}
+
+	return nil
+}
+
+// executeExchange runs the exchange at the given rate, which the caller quoted
+// or fetched before, so that no lock is held while a provider is asked for it.
+func (s *Service) executeExchange(
+	ctx context.Context,
+	cmd *ExchangeCommand,
+	exchangeRate domain.ExchangeRate,
+) (_ *ExchangeResult, err error) {
+	defer func() {
+		if err != nil {
+			s.logFailure(ctx, "exchange failed", err, accountIDAttr(cmd.SourceAccount))
+		}
+	}()
+
+	cashbooks := s.newCashbookWatch()
+
+	var (
+		result ExchangeResult
+		userID domain.UserID
+	)
+	err = s.trm.Do(ctx, func(ctx context.Context) error {
+		trm.AfterCommit(ctx, cashbooks.notify)
+
 		sourceAccount, err := s.accounts.GetForUpdate(ctx, cmd.SourceAccount)
 		if err != nil {
 			return fmt.Errorf("getting source account: %w", err)
 		}
+		userID = sourceAccount.UserID()
 
 		targetAccount, err := s.accounts.GetForUpdate(ctx, cmd.TargetAccount)
 		if err != nil {
 			return fmt.Errorf("getting target account: %w", err)
 		}
 
-		usdCashbookID := domain.GetCashbookAccount(domain.CurrencyUSD)
-		eurCashbookID := domain.GetCashbookAccount(domain.CurrencyEUR)
+		err = checkExchangeOwnership(cmd.UserID, sourceAccount, targetAccount)
+		if err != nil {
+			return err
+		}
 
-		usdCashbookAccount, err := s.accounts.GetForUpdate(ctx, usdCashbookID)
+		err = s.checkExchangeMinimum(cmd.SourceAmount, targetAccount.Balance().Currency())
 		if err != nil {
-			return fmt.Errorf("getting USD cashbook account: %w", err)
+			return err
 		}
 
-		eurCashbookAccount, err := s.accounts.GetForUpdate(ctx, eurCashbookID)
+		err = s.checkDailyLimit(ctx, sourceAccount, cmd.SourceAmount, cmd.Time)
 		if err != nil {
-			return fmt.Errorf("getting EUR cashbook account: %w", err)
+			return fmt.Errorf("checking daily limit: %w", err)
 		}
 
-		exchangeRate, err := s.exchangeRateProvider.GetRate(
+		sourceCashbook, targetCashbook, err := s.lockExchangeCashbooks(
+			ctx,
 			cmd.SourceAmount.Currency(),
 			targetAccount.Balance().Currency(),
 		)
 		if err != nil {
-			return fmt.Errorf("getting exchange rate: %w", err)
+			return fmt.Errorf("locking cashbook accounts: %w", err)
+		}
+		cashbooks.add(sourceCashbook, targetCashbook)
+
+		if exchangeRate.To() != targetAccount.Balance().Currency() {
+			return domain.NewCurrencyMismatchError(exchangeRate.To(), targetAccount.Balance().Currency())
 		}
 
 		details, err := s.exchange.Execute(
 			sourceAccount,
 			targetAccount,
-			usdCashbookAccount,
-			eurCashbookAccount,
+			sourceCashbook,
+			targetCashbook,
 			cmd.SourceAmount,
 			exchangeRate,
 			cmd.Time,
@@ -97,6 +214,7 @@ func (s *Service) Exchange(ctx context.Context, cmd *ExchangeCommand) error {
 		if err != nil {
 			return fmt.Errorf("inserting exchange: %w", err)
 		}
+		result.Details = details
 
 		err = s.accounts.Save(ctx, sourceAccount)
 		if err != nil {
@@ -108,14 +226,14 @@ func (s *Service) Exchange(ctx context.Context, cmd *ExchangeCommand) error {
 			return fmt.Errorf("saving target account: %w", err)
 		}
 
-		err = s.accounts.Save(ctx, usdCashbookAccount)
+		err = s.accounts.Save(ctx, sourceCashbook)
 		if err != nil {
-			return fmt.Errorf("saving USD cashbook account: %w", err)
+			return fmt.Errorf("saving source cashbook account: %w", err)
 		}
 
-		err = s.accounts.Save(ctx, eurCashbookAccount)
+		err = s.accounts.Save(ctx, targetCashbook)
 		if err != nil {
-			return fmt.Errorf("saving EUR cashbook account: %w", err)
+			return fmt.Errorf("saving target cashbook account: %w", err)
 		}
 
 		err = s.CheckLedgerBalanceByCurrency(ctx)
@@ -133,41 +251,118 @@ func (s *Service) Exchange(ctx context.Context, cmd *ExchangeCommand) error {
 			return fmt.Errorf("checking target account ledger consistency: %w", err)
 		}
 
-		err = s.checkAccountLedgerConsistency(ctx, usdCashbookAccount)
+		err = s.checkAccountLedgerConsistency(ctx, sourceCashbook)
 		if err != nil {
-			return fmt.Errorf("checking USD cashbook ledger consistency: %w", err)
+			return fmt.Errorf("checking source cashbook ledger consistency: %w", err)
 		}
 
-		err = s.checkAccountLedgerConsistency(ctx, eurCashbookAccount)
+		err = s.checkAccountLedgerConsistency(ctx, targetCashbook)
 		if err != nil {
-			return fmt.Errorf("checking EUR cashbook ledger consistency: %w", err)
+			return fmt.Errorf("checking target cashbook ledger consistency: %w", err)
 		}
 
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("doing atomic operation: %w", err)
+		return nil, fmt.Errorf("doing atomic operation: %w", err)
 	}
 
-	return nil
+	s.logger.LogAttrs(ctx, slog.LevelInfo, "exchange completed",
+		append(
+			moneyAttrs(result.Details.SourceAmount()),
+			userIDAttr(userID),
+			transactionIDAttr(result.Details.TransactionID()),
+		)...,
+	)
+
+	return &result, nil
 }
 
-type ExchangeCalculation struct {
-	SourceAmount Money
-	TargetAmount Money
-	ExchangeRate domain.ExchangeRate
+// lockExchangeCashbooks locks the cashbooks of both exchanged currencies.
+// They are always locked in the order of domain.CurrencyValues, so concurrent
+// exchanges in opposite directions cannot deadlock on them.
+func (s *Service) lockExchangeCashbooks(
+	ctx context.Context,
+	sourceCurrency domain.Currency,
+	targetCurrency domain.Currency,
+) (*domain.Account, *domain.Account, error) {
+	locked := make(map[domain.Currency]*domain.Account, 2)
+	for _, currency := range domain.CurrencyValues() {
+		if currency != sourceCurrency && currency != targetCurrency {
+			continue
+		}
+
+		cashbook, err := s.accounts.GetForUpdate(ctx, domain.GetCashbookAccount(currency))
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting %s cashbook account: %w", currency, err)
+		}
+		locked[currency] = cashbook
+	}
+
+	sourceCashbook, ok := locked[sourceCurrency]
+	if !ok {
+		return nil, nil, domain.NewUnsupportedCurrencyError(sourceCurrency)
+	}
+
+	targetCashbook, ok := locked[targetCurrency]
+	if !ok {
+		return nil, nil, domain.NewUnsupportedCurrencyError(targetCurrency)
+	}
+
+	return sourceCashbook, targetCashbook, nil
+}
+
+// GetExchange returns the exchange with the given exchange id. The user must own
+// either of the exchanged accounts.
+func (s *Service) GetExchange(
+	ctx context.Context,
+	userID domain.UserID,
+	exchangeID domain.ExchangeDetailsID,
+) (*domain.TransactionWithDetails, error) {
+	exchange, err := s.exchanges.GetByID(ctx, exchangeID)
+	if err != nil {
+		return nil, fmt.Errorf("getting exchange: %w", err)
+	}
+
+	details := exchange.ExchangeDetails()
+	for _, accountID := range []domain.AccountID{details.SourceAccount(), details.TargetAccount()} {
+		account, err := s.accounts.Get(ctx, accountID)
+		if err != nil {
+			return nil, fmt.Errorf("getting exchange account: %w", err)
+		}
+		if account.UserID() == userID {
+			return exchange, nil
+		}
+	}
+
+	return nil, domain.NewExchangeAccessDeniedError(exchangeID)
 }
 
-type Money struct {
-	Amount   decimal.Decimal
-	Currency string
+// ExchangeCalculation is a preview of an exchange. TargetAmount is rounded to
+// the target currency like the exchange itself would book it.
+type ExchangeCalculation struct {
+	SourceAmount domain.Money
+	TargetAmount domain.Money
+	ExchangeRate domain.ExchangeRate
 }
 
+// CalculateExchangeAmount previews an exchange without executing it. ctx bounds
+// the rate lookup of providers that fetch rates remotely, and a cancelled ctx
+// already stops the calculation.
 func (s *Service) CalculateExchangeAmount(
+	ctx context.Context,
 	sourceAmount domain.Money,
 	targetCurrency domain.Currency,
 ) (*ExchangeCalculation, error) {
-	exchangeRate, err := s.exchangeRateProvider.GetRate(sourceAmount.Currency(), targetCurrency)
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("calculating exchange amount: %w", err)
+	}
+
+	if err := s.checkExchangeMinimum(sourceAmount, targetCurrency); err != nil {
+		return nil, err
+	}
+
+	exchangeRate, err := s.getExchangeRate(ctx, sourceAmount.Currency(), targetCurrency)
 	if err != nil {
 		return nil, fmt.Errorf("getting exchange rate: %w", err)
 	}
@@ -178,14 +373,121 @@ func (s *Service) CalculateExchangeAmount(
 	}
 
 	return &ExchangeCalculation{
-		SourceAmount: Money{
-			Amount:   sourceAmount.Amount(),
-			Currency: string(sourceAmount.Currency()),
-		},
-		TargetAmount: Money{
-			Amount:   targetAmount.Amount(),
-			Currency: string(targetAmount.Currency()),
-		},
+		SourceAmount: sourceAmount,
+		TargetAmount: targetAmount,
 		ExchangeRate: exchangeRate,
 	}, nil
 }
+
+// EffectiveExchangeRate compares the provider's mid-rate of a pair with the
+// rate a customer actually gets for an amount.
+type EffectiveExchangeRate struct {
+	MidRate domain.ExchangeRate
+	// Rate is the received target amount divided by the source amount, so it
+	// includes the spread and the rounding of the target amount.
+	Rate         decimal.Decimal
+	SourceAmount domain.Money
+	TargetAmount domain.Money
+}
+
+// GetEffectiveExchangeRate returns the all-in rate of exchanging amount of from
+// into to, as the exchange would book it right now.
+func (s *Service) GetEffectiveExchangeRate(
+	ctx context.Context,
+	from, to domain.Currency,
+	amount decimal.Decimal,
+) (*EffectiveExchangeRate, error) {
+	sourceAmount, err := domain.NewMoney(amount, from)
+	if err != nil {
+		return nil, fmt.Errorf("getting source amount: %w", err)
+	}
+
+	if !amount.IsPositive() {
+		return nil, domain.NewZeroAmountError(from)
+	}
+
+	if err := s.checkExchangeMinimum(sourceAmount, to); err != nil {
+		return nil, err
+	}
+
+	// The mid-rate is fetched once, so the booked rate derived from it can't
+	// come from a different provider quote.
+	midRate, err := s.getMidRate(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("getting mid-rate: %w", err)
+	}
+
+	targetAmount, err := domain.CalculateExchangeAmount(sourceAmount, s.bookedRate(midRate))
+	if err != nil {
+		return nil, fmt.Errorf("calculating exchange amount: %w", err)
+	}
+
+	return &EffectiveExchangeRate{
+		MidRate:      midRate,
+		Rate:         targetAmount.Amount().Div(amount),
+		SourceAmount: sourceAmount,
+		TargetAmount: targetAmount,
+	}, nil
+}
+
+// getExchangeRate returns the rate exchanges are booked at: the provider's
+// mid-rate less the configured spread.
+func (s *Service) getExchangeRate(ctx context.Context, from, to domain.Currency) (domain.ExchangeRate, error) {
+	midRate, err := s.getMidRate(ctx, from, to)
+	if err != nil {
+		return domain.ExchangeRate{}, err
+	}
+
+	return s.bookedRate(midRate), nil
+}
+
+// getMidRate returns the provider's rate of a direction exchanges are allowed in.
+func (s *Service) getMidRate(ctx context.Context, from, to domain.Currency) (domain.ExchangeRate, error) {
+	if from != to && !s.isExchangeDirectionAllowed(from, to) {
+		return domain.ExchangeRate{}, domain.NewExchangeDirectionNotAllowedError(from, to)
+	}
+
+	return s.exchangeRateProvider.GetRate(ctx, from, to)
+}
+
+// bookedRate applies the configured spread and rounding bias to a mid-rate.
+func (s *Service) bookedRate(midRate domain.ExchangeRate) domain.ExchangeRate {
+	return midRate.WithSpread(s.config.ExchangeSpread).WithRoundingBias(s.config.ExchangeRoundingBias)
+}
+
+func (s *Service) isExchangeDirectionAllowed(from, to domain.Currency) bool {
+	if len(s.config.AllowedExchangeDirections) == 0 {
+		return true
+	}
+
+	for _, direction := range s.config.AllowedExchangeDirections {
+		if direction.From == from && direction.To == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkExchangeMinimum rejects source amounts below the minimum configured for
+// the pair, or below Config.MinimumExchangeAmount when the pair has none.
+func (s *Service) checkExchangeMinimum(sourceAmount domain.Money, targetCurrency domain.Currency) error {
+	minimumAmount := s.config.MinimumExchangeAmount
+	for _, minimum := range s.config.ExchangeMinimums {
+		if minimum.Direction.From == sourceAmount.Currency() && minimum.Direction.To == targetCurrency {
+			minimumAmount = minimum.Amount
+			break
+		}
+	}
+
+	if sourceAmount.Amount().GreaterThanOrEqual(minimumAmount) {
+		return nil
+	}
+
+	minimum, err := domain.NewMoney(minimumAmount, sourceAmount.Currency())
+	if err != nil {
+		return fmt.Errorf("getting minimum exchange amount: %w", err)
+	}
+
+	return domain.NewExchangeBelowMinimumError(sourceAmount, minimum, targetCurrency)
+}
@@ -1,9 +1,13 @@
 package service_test
 
 import (
+	"context"
+	"sync/atomic"
 	"testing"
 
 	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/infrastructure"
+	"minibankingplatform/internal/service"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -12,20 +16,21 @@ import (
 
 func TestCalculateExchangeAmount_USDtoEUR(t *testing.T) {
 	t.Parallel()
+	ctx := context.Background()
 
 	// Arrange
 	svc := setupService(t, testPool)
 	sourceAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
 
 	// Act
-	result, err := svc.CalculateExchangeAmount(sourceAmount, domain.CurrencyEUR)
+	result, err := svc.CalculateExchangeAmount(ctx, sourceAmount, domain.CurrencyEUR)
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, "100", result.SourceAmount.Amount.String())
-	assert.Equal(t, "USD", result.SourceAmount.Currency)
-	assert.True(t, result.TargetAmount.Amount.Equal(decimal.NewFromInt(92)))
-	assert.Equal(t, "EUR", result.TargetAmount.Currency)
+	assert.Equal(t, "100", result.SourceAmount.Amount().String())
+	assert.Equal(t, domain.CurrencyUSD, result.SourceAmount.Currency())
+	assert.True(t, result.TargetAmount.Amount().Equal(decimal.NewFromInt(92)))
+	assert.Equal(t, domain.CurrencyEUR, result.TargetAmount.Currency())
 	assert.Equal(t, domain.CurrencyUSD, result.ExchangeRate.From())
 	assert.Equal(t, domain.CurrencyEUR, result.ExchangeRate.To())
 	assert.True(t, result.ExchangeRate.Rate().Equal(decimal.NewFromFloat(0.92)))
@@ -33,20 +38,21 @@ func TestCalculateExchangeAmount_USDtoEUR(t *testing.T) {
 
 func TestCalculateExchangeAmount_EURtoUSD(t *testing.T) {
 	t.Parallel()
+	ctx := context.Background()
 
 	// Arrange
 	svc := setupService(t, testPool)
 	sourceAmount, _ := domain.NewMoney(decimal.NewFromInt(92), domain.CurrencyEUR)
 
 	// Act
-	result, err := svc.CalculateExchangeAmount(sourceAmount, domain.CurrencyUSD)
+	result, err := svc.CalculateExchangeAmount(ctx, sourceAmount, domain.CurrencyUSD)
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, "92", result.SourceAmount.Amount.String())
-	assert.Equal(t, "EUR", result.SourceAmount.Currency)
-	assert.True(t, result.TargetAmount.Amount.Equal(decimal.NewFromInt(100)))
-	assert.Equal(t, "USD", result.TargetAmount.Currency)
+	assert.Equal(t, "92", result.SourceAmount.Amount().String())
+	assert.Equal(t, domain.CurrencyEUR, result.SourceAmount.Currency())
+	assert.True(t, result.TargetAmount.Amount().Equal(decimal.NewFromInt(100)))
+	assert.Equal(t, domain.CurrencyUSD, result.TargetAmount.Currency())
 	assert.Equal(t, domain.CurrencyEUR, result.ExchangeRate.From())
 	assert.Equal(t, domain.CurrencyUSD, result.ExchangeRate.To())
 	// EUR to USD uses inverse rate: 1/0.92 ≈ 1.086957
@@ -55,19 +61,190 @@ func TestCalculateExchangeAmount_EURtoUSD(t *testing.T) {
 
 func TestCalculateExchangeAmount_DecimalPrecision(t *testing.T) {
 	t.Parallel()
+	ctx := context.Background()
 
 	// Arrange
 	svc := setupService(t, testPool)
 	sourceAmount, _ := domain.NewMoney(decimal.NewFromFloat(123.45), domain.CurrencyUSD)
 
 	// Act
-	result, err := svc.CalculateExchangeAmount(sourceAmount, domain.CurrencyEUR)
+	result, err := svc.CalculateExchangeAmount(ctx, sourceAmount, domain.CurrencyEUR)
 
 	// Assert
 	require.NoError(t, err)
-	assert.True(t, result.SourceAmount.Amount.Equal(decimal.NewFromFloat(123.45)))
+	assert.True(t, result.SourceAmount.Amount().Equal(decimal.NewFromFloat(123.45)))
 
 	// 123.45 * 0.92 = 113.574, rounded to 2 decimal places = 113.57
 	expectedTarget := decimal.NewFromFloat(123.45).Mul(decimal.NewFromFloat(0.92)).Round(2)
-	assert.True(t, result.TargetAmount.Amount.Equal(expectedTarget))
+	assert.True(t, result.TargetAmount.Amount().Equal(expectedTarget))
+}
+
+func TestCalculateExchangeAmount_TargetAmountIsRoundedToCurrency(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange
+	oneThird := decimal.NewFromInt(1).Div(decimal.NewFromInt(3))
+	svc := setupService(t, testPool, withRateProvider(infrastructure.NewFixedExchangeRateProvider(oneThird)))
+	sourceAmount, _ := domain.NewMoney(decimal.NewFromInt(1), domain.CurrencyUSD)
+
+	// Act
+	result, err := svc.CalculateExchangeAmount(ctx, sourceAmount, domain.CurrencyEUR)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.CurrencyEUR, result.TargetAmount.Currency())
+	assert.Equal(t, "0.33", result.TargetAmount.Amount().String(), "1 USD at 1/3 must be rounded to EUR cents")
+}
+
+func TestCalculateExchangeAmount_RoundingBias(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// 123.45 USD * 0.92 = 113.574 EUR, which has to be rounded to minor units
+	tests := []struct {
+		name     string
+		bias     domain.RoundingBias
+		expected string
+	}{
+		{name: "no bias rounds half away from zero", bias: domain.RoundingBiasNone, expected: "113.57"},
+		{name: "user bias rounds target up", bias: domain.RoundingBiasUser, expected: "113.58"},
+		{name: "platform bias rounds target down", bias: domain.RoundingBiasPlatform, expected: "113.57"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			svc := setupService(t, testPool, withServiceOptions(service.WithExchangeRoundingBias(tt.bias)))
+			sourceAmount, _ := domain.NewMoney(decimal.RequireFromString("123.45"), domain.CurrencyUSD)
+
+			// Act
+			result, err := svc.CalculateExchangeAmount(ctx, sourceAmount, domain.CurrencyEUR)
+
+			// Assert
+			require.NoError(t, err)
+			assert.True(t, result.TargetAmount.Amount().Equal(decimal.RequireFromString(tt.expected)),
+				"expected %s, got %s", tt.expected, result.TargetAmount.Amount())
+		})
+	}
+}
+
+func TestCalculateExchangeAmount_UserBiasYieldsOneMinorUnitMore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange
+	neutral := setupService(t, testPool)
+	userFavoring := setupService(t, testPool, withServiceOptions(service.WithExchangeRoundingBias(domain.RoundingBiasUser)))
+	sourceAmount, _ := domain.NewMoney(decimal.RequireFromString("123.45"), domain.CurrencyUSD)
+
+	// Act
+	neutralResult, err := neutral.CalculateExchangeAmount(ctx, sourceAmount, domain.CurrencyEUR)
+	require.NoError(t, err)
+	userResult, err := userFavoring.CalculateExchangeAmount(ctx, sourceAmount, domain.CurrencyEUR)
+	require.NoError(t, err)
+
+	// Assert
+	diff := userResult.TargetAmount.Amount().Sub(neutralResult.TargetAmount.Amount())
+	assert.True(t, diff.Equal(decimal.RequireFromString("0.01")), "expected one minor unit difference, got %s", diff)
+}
+
+func TestCalculateExchangeAmount_CancelledContext(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Arrange
+	svc := setupService(t, testPool)
+	sourceAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
+
+	// Act
+	_, err := svc.CalculateExchangeAmount(ctx, sourceAmount, domain.CurrencyEUR)
+
+	// Assert
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGetEffectiveExchangeRate_IncludesSpread(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange
+	spread := decimal.RequireFromString("0.005")
+	svc := setupService(t, testPool, withServiceOptions(service.WithExchangeSpread(spread)))
+
+	// Act
+	result, err := svc.GetEffectiveExchangeRate(ctx, domain.CurrencyUSD, domain.CurrencyEUR, decimal.NewFromInt(1000))
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, result.MidRate.Rate().Equal(decimal.RequireFromString("0.92")))
+	expected := result.MidRate.Rate().Mul(decimal.NewFromInt(1).Sub(spread))
+	assert.True(t, result.Rate.Equal(expected), "expected %s, got %s", expected, result.Rate)
+	assert.True(t, result.Rate.LessThan(result.MidRate.Rate()))
+	assert.True(t, result.TargetAmount.Amount().Equal(decimal.RequireFromString("915.40")), "got %s", result.TargetAmount.Amount())
+
+	calculation, err := svc.CalculateExchangeAmount(ctx, result.SourceAmount, domain.CurrencyEUR)
+	require.NoError(t, err)
+	assert.True(t, calculation.TargetAmount.Amount().Equal(result.TargetAmount.Amount()), "the preview must match the effective rate")
+}
+
+func TestGetEffectiveExchangeRate_WithoutSpreadIsMidRate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange
+	svc := setupService(t, testPool)
+
+	// Act
+	result, err := svc.GetEffectiveExchangeRate(ctx, domain.CurrencyUSD, domain.CurrencyEUR, decimal.NewFromInt(100))
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, result.Rate.Equal(result.MidRate.Rate()), "got %s", result.Rate)
+}
+
+func TestGetEffectiveExchangeRate_ZeroAmount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange
+	svc := setupService(t, testPool)
+
+	// Act
+	_, err := svc.GetEffectiveExchangeRate(ctx, domain.CurrencyUSD, domain.CurrencyEUR, decimal.Zero)
+
+	// Assert
+	var zeroErr *domain.ZeroAmountError
+	assert.ErrorAs(t, err, &zeroErr)
+}
+
+// movingRateProvider quotes a higher USD→EUR rate on every call.
+type movingRateProvider struct {
+	calls atomic.Int32
+}
+
+func (p *movingRateProvider) GetRate(_ context.Context, from, to domain.Currency) (domain.ExchangeRate, error) {
+	calls := p.calls.Add(1)
+	return domain.NewExchangeRate(from, to, decimal.RequireFromString("0.90").Add(decimal.NewFromInt32(calls).Shift(-2)))
+}
+
+func TestGetEffectiveExchangeRate_FetchesTheRateOnce(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange
+	provider := &movingRateProvider{}
+	svc := setupService(t, testPool, withRateProvider(provider))
+
+	// Act
+	result, err := svc.GetEffectiveExchangeRate(ctx, domain.CurrencyUSD, domain.CurrencyEUR, decimal.NewFromInt(100))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), provider.calls.Load())
+	assert.True(t, result.MidRate.Rate().Equal(decimal.RequireFromString("0.91")), "got %s", result.MidRate.Rate())
+	assert.True(t, result.Rate.Equal(result.MidRate.Rate()), "the rate is derived from the same quote, got %s", result.Rate)
 }
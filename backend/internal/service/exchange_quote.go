@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/pkg/trm"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const exchangeQuotePurpose = "exchange-quote"
+
+var errExchangeQuotesDisabled = errors.New("exchange quotes are disabled: no executed quote registry is configured")
+
+// ExchangeQuote locks an exchange rate for a short time. Token carries the
+// signed terms, including the user the quote was issued to.
+type ExchangeQuote struct {
+	Token         string
+	Nonce         uuid.UUID
+	SourceAccount domain.AccountID
+	TargetAccount domain.AccountID
+	SourceAmount  domain.Money
+	TargetAmount  domain.Money
+	ExchangeRate  domain.ExchangeRate
+	ExpiresAt     time.Time
+}
+
+type exchangeQuoteClaims struct {
+	SourceAccount  uuid.UUID `json:"source_account"`
+	TargetAccount  uuid.UUID `json:"target_account"`
+	SourceAmount   string    `json:"source_amount"`
+	SourceCurrency string    `json:"source_currency"`
+	TargetCurrency string    `json:"target_currency"`
+	Rate           string    `json:"rate"`
+	RoundingBias   string    `json:"rounding_bias"`
+	jwt.RegisteredClaims
+}
+
+// QuoteExchange prices the exchange at the current rate and returns a signed
+// quote that ExecuteQuotedExchange honors for cmd.UserID until it expires, even
+// if the rate changes in the meantime.
+func (s *Service) QuoteExchange(ctx context.Context, cmd *ExchangeCommand) (*ExchangeQuote, error) {
+	if s.config.ExecutedExchangeQuotes == nil {
+		return nil, errExchangeQuotesDisabled
+	}
+
+	if err := cmd.Validate(); err != nil {
+		return nil, fmt.Errorf("validating exchange command: %w", err)
+	}
+
+	sourceAccount, err := s.accounts.Get(ctx, cmd.SourceAccount)
+	if err != nil {
+		return nil, fmt.Errorf("getting source account: %w", err)
+	}
+
+	targetAccount, err := s.accounts.Get(ctx, cmd.TargetAccount)
+	if err != nil {
+		return nil, fmt.Errorf("getting target account: %w", err)
+	}
+
+	err = checkExchangeOwnership(cmd.UserID, sourceAccount, targetAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkExchangeMinimum(cmd.SourceAmount, targetAccount.Balance().Currency())
+	if err != nil {
+		return nil, err
+	}
+
+	exchangeRate, err := s.getExchangeRate(ctx, cmd.SourceAmount.Currency(), targetAccount.Balance().Currency())
+	if err != nil {
+		return nil, fmt.Errorf("getting exchange rate: %w", err)
+	}
+
+	targetAmount, err := domain.CalculateExchangeAmount(cmd.SourceAmount, exchangeRate)
+	if err != nil {
+		return nil, fmt.Errorf("calculating exchange amount: %w", err)
+	}
+
+	nonce := uuid.New()
+	expiresAt := jwt.NewNumericDate(cmd.Time.Add(s.exchangeQuoteTTL()))
+
+	token, err := s.tokenManager.SignClaims(exchangeQuotePurpose, exchangeQuoteClaims{
+		SourceAccount:  uuid.UUID(cmd.SourceAccount),
+		TargetAccount:  uuid.UUID(cmd.TargetAccount),
+		SourceAmount:   cmd.SourceAmount.Amount().String(),
+		SourceCurrency: cmd.SourceAmount.Currency().String(),
+		TargetCurrency: exchangeRate.To().String(),
+		Rate:           exchangeRate.Rate().String(),
+		RoundingBias:   string(exchangeRate.RoundingBias()),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        nonce.String(),
+			Subject:   uuid.UUID(cmd.UserID).String(),
+			IssuedAt:  jwt.NewNumericDate(cmd.Time),
+			ExpiresAt: expiresAt,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signing exchange quote: %w", err)
+	}
+
+	return &ExchangeQuote{
+		Token:         token,
+		Nonce:         nonce,
+		SourceAccount: cmd.SourceAccount,
+		TargetAccount: cmd.TargetAccount,
+		SourceAmount:  cmd.SourceAmount,
+		TargetAmount:  targetAmount,
+		ExchangeRate:  exchangeRate,
+		ExpiresAt:     expiresAt.Time,
+	}, nil
+}
+
+// ExecuteQuotedExchange executes the exchange described by a quote token at
+// exactly the quoted rate. Expired quotes fail with
+// *domain.ExchangeQuoteExpiredError; forged, altered or already executed ones,
+// and quotes issued to another user, with *domain.InvalidExchangeQuoteError.
+func (s *Service) ExecuteQuotedExchange(ctx context.Context, userID domain.UserID, token string, executedAt time.Time) error {
+	registry := s.config.ExecutedExchangeQuotes
+	if registry == nil {
+		return errExchangeQuotesDisabled
+	}
+
+	var claims exchangeQuoteClaims
+	if err := s.tokenManager.ParseClaims(exchangeQuotePurpose, token, &claims); err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) && claims.ExpiresAt != nil {
+			return domain.NewExchangeQuoteExpiredError(claims.ExpiresAt.Time)
+		}
+		return domain.NewInvalidExchangeQuoteError(err.Error())
+	}
+
+	if claims.Subject != uuid.UUID(userID).String() {
+		return domain.NewInvalidExchangeQuoteError("quote was issued to another user")
+	}
+
+	cmd, exchangeRate, err := claims.exchange(executedAt)
+	if err != nil {
+		return domain.NewInvalidExchangeQuoteError(err.Error())
+	}
+	cmd.UserID = userID
+
+	if err := cmd.Validate(); err != nil {
+		return fmt.Errorf("validating quoted exchange: %w", err)
+	}
+
+	release, err := s.acquireMoneyOperation()
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if !registry.TryAcquire(claims.ID) {
+		return domain.NewInvalidExchangeQuoteError("quote was already executed")
+	}
+
+	if _, err := s.executeExchange(ctx, cmd, exchangeRate); err != nil {
+		registry.Release(claims.ID, false)
+		return err
+	}
+
+	// The quote is used up only once the exchange is durable. Succeeded keys
+	// stay taken for the registry's window, which outlives the quote.
+	trm.AfterCommit(ctx, func() { registry.Release(claims.ID, true) })
+
+	return nil
+}
+
+func (c exchangeQuoteClaims) exchange(executedAt time.Time) (*ExchangeCommand, domain.ExchangeRate, error) {
+	if c.ExpiresAt == nil || c.ID == "" {
+		return nil, domain.ExchangeRate{}, errors.New("quote has no expiry or nonce")
+	}
+
+	cmd, err := NewExchangeCommand(c.SourceAccount, c.TargetAccount, c.SourceAmount, c.SourceCurrency, executedAt)
+	if err != nil {
+		return nil, domain.ExchangeRate{}, err
+	}
+
+	targetCurrency, err := domain.ParseSupportedCurrency(c.TargetCurrency)
+	if err != nil {
+		return nil, domain.ExchangeRate{}, err
+	}
+
+	rate, err := decimal.NewFromString(c.Rate)
+	if err != nil {
+		return nil, domain.ExchangeRate{}, fmt.Errorf("invalid rate: %w", err)
+	}
+
+	bias, err := domain.ParseRoundingBias(c.RoundingBias)
+	if err != nil {
+		return nil, domain.ExchangeRate{}, err
+	}
+
+	exchangeRate, err := domain.NewExchangeRate(cmd.SourceAmount.Currency(), targetCurrency, rate)
+	if err != nil {
+		return nil, domain.ExchangeRate{}, err
+	}
+
+	return cmd, exchangeRate.WithRoundingBias(bias), nil
+}
+
+func (s *Service) exchangeQuoteTTL() time.Duration {
+	if s.config.ExchangeQuoteTTL > 0 {
+		return s.config.ExchangeQuoteTTL
+	}
+	return DefaultExchangeQuoteTTL
+}
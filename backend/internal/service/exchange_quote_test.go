@@ -0,0 +1,238 @@
+package service_test
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/infrastructure"
+	"minibankingplatform/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withQuotes enables exchange quotes, which need a registry of executed quotes.
+func withQuotes(config service.Config) testServiceOption {
+	config.ExecutedExchangeQuotes = infrastructure.NewInMemoryInFlightRegistry(service.DefaultExchangeQuoteTTL)
+	return withConfig(config)
+}
+
+func newQuoteCommand(user *TestUserAccounts, amount int64, quotedAt time.Time) *service.ExchangeCommand {
+	sourceAmount, _ := domain.NewMoney(decimal.NewFromInt(amount), domain.CurrencyUSD)
+	return &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
+		SourceAccount: domain.AccountID(user.USDAccountID),
+		TargetAccount: domain.AccountID(user.EURAccountID),
+		SourceAmount:  sourceAmount,
+		Time:          quotedAt,
+	}
+}
+
+func TestExecuteQuotedExchange_UsesQuotedRate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange - quote at 0.92, then the market moves to 0.5
+	quoting := setupService(t, testPool, withQuotes(service.Config{}))
+	moved := setupService(t, testPool, withRateProvider(infrastructure.NewFixedExchangeRateProvider(decimal.RequireFromString("0.5"))), withQuotes(service.Config{}))
+	user := registerTestUser(ctx, t, quoting, testPool)
+
+	quote, err := quoting.QuoteExchange(ctx, newQuoteCommand(user, 100, time.Now()))
+	require.NoError(t, err)
+	assert.True(t, quote.TargetAmount.Amount().Equal(decimal.NewFromInt(92)))
+	assert.True(t, quote.ExchangeRate.Rate().Equal(decimal.RequireFromString("0.92")))
+
+	// Act
+	err = moved.ExecuteQuotedExchange(ctx, domain.UserID(user.UserID), quote.Token, time.Now())
+
+	// Assert
+	require.NoError(t, err)
+	assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(900))
+	assertBalanceEquals(t, ctx, testPool, user.EURAccountID, decimal.NewFromInt(592))
+	assertLedgerBalanced(ctx, t, moved)
+}
+
+func TestExecuteQuotedExchange_ExpiredQuote(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool, withQuotes(service.Config{ExchangeQuoteTTL: time.Minute}))
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Arrange - quoted an hour ago with a one minute lifetime
+	quote, err := svc.QuoteExchange(ctx, newQuoteCommand(user, 100, time.Now().Add(-time.Hour)))
+	require.NoError(t, err)
+
+	// Act
+	err = svc.ExecuteQuotedExchange(ctx, domain.UserID(user.UserID), quote.Token, time.Now())
+
+	// Assert
+	var expiredErr *domain.ExchangeQuoteExpiredError
+	require.ErrorAs(t, err, &expiredErr)
+	assert.True(t, expiredErr.ExpiredAt.Equal(quote.ExpiresAt))
+	assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(1000))
+	assertBalanceEquals(t, ctx, testPool, user.EURAccountID, decimal.NewFromInt(500))
+}
+
+func TestExecuteQuotedExchange_TamperedQuote(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool, withQuotes(service.Config{}))
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	quote, err := svc.QuoteExchange(ctx, newQuoteCommand(user, 100, time.Now()))
+	require.NoError(t, err)
+
+	// Arrange - raise the quoted rate without re-signing the token
+	parts := strings.Split(quote.Token, ".")
+	require.Len(t, parts, 3)
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	tampered := strings.Replace(string(payload), `"rate":"0.92"`, `"rate":"9.2"`, 1)
+	require.NotEqual(t, string(payload), tampered)
+	parts[1] = base64.RawURLEncoding.EncodeToString([]byte(tampered))
+
+	// Act
+	err = svc.ExecuteQuotedExchange(ctx, domain.UserID(user.UserID), strings.Join(parts, "."), time.Now())
+
+	// Assert
+	var invalidErr *domain.InvalidExchangeQuoteError
+	require.ErrorAs(t, err, &invalidErr)
+	assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(1000))
+	assertBalanceEquals(t, ctx, testPool, user.EURAccountID, decimal.NewFromInt(500))
+}
+
+func TestExecuteQuotedExchange_AuthTokenIsNotAQuote(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool, withQuotes(service.Config{}))
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	login, err := svc.Login(ctx, &service.LoginCommand{Email: user.Email, Password: "testpassword123"})
+	require.NoError(t, err)
+
+	// Act
+	err = svc.ExecuteQuotedExchange(ctx, domain.UserID(user.UserID), login.Token, time.Now())
+
+	// Assert
+	var invalidErr *domain.InvalidExchangeQuoteError
+	require.ErrorAs(t, err, &invalidErr)
+}
+
+func TestExecuteQuotedExchange_QuoteExecutesOnce(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool, withQuotes(service.Config{}))
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	quote, err := svc.QuoteExchange(ctx, newQuoteCommand(user, 100, time.Now()))
+	require.NoError(t, err)
+	require.NoError(t, svc.ExecuteQuotedExchange(ctx, domain.UserID(user.UserID), quote.Token, time.Now()))
+
+	// Act
+	err = svc.ExecuteQuotedExchange(ctx, domain.UserID(user.UserID), quote.Token, time.Now())
+
+	// Assert
+	var invalidErr *domain.InvalidExchangeQuoteError
+	require.ErrorAs(t, err, &invalidErr)
+	assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(900))
+}
+
+func TestExecuteQuotedExchange_QuoteOfAnotherUser(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool, withQuotes(service.Config{}))
+	owner := registerTestUser(ctx, t, svc, testPool)
+	other := registerTestUser(ctx, t, svc, testPool)
+
+	quote, err := svc.QuoteExchange(ctx, newQuoteCommand(owner, 100, time.Now()))
+	require.NoError(t, err)
+
+	// Act
+	err = svc.ExecuteQuotedExchange(ctx, domain.UserID(other.UserID), quote.Token, time.Now())
+
+	// Assert
+	var invalidErr *domain.InvalidExchangeQuoteError
+	require.ErrorAs(t, err, &invalidErr)
+	assertBalanceEquals(t, ctx, testPool, owner.USDAccountID, decimal.NewFromInt(1000))
+}
+
+func TestQuoteExchange_AccountOfAnotherUser(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool, withQuotes(service.Config{}))
+	owner := registerTestUser(ctx, t, svc, testPool)
+	other := registerTestUser(ctx, t, svc, testPool)
+
+	// Arrange - quote the owner's accounts on behalf of another user
+	cmd := newQuoteCommand(owner, 100, time.Now())
+	cmd.UserID = domain.UserID(other.UserID)
+
+	// Act
+	_, err := svc.QuoteExchange(ctx, cmd)
+
+	// Assert
+	var accessDeniedErr *domain.AccountAccessDeniedError
+	require.ErrorAs(t, err, &accessDeniedErr)
+}
+
+func TestExecuteQuotedExchange_FailedExecutionKeepsTheQuote(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool, withQuotes(service.Config{}))
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	quote, err := svc.QuoteExchange(ctx, newQuoteCommand(user, 100, time.Now()))
+	require.NoError(t, err)
+
+	// Arrange - withdraw most of the balance so the first execution fails
+	_, err = svc.Withdraw(ctx, &service.CashCommand{
+		Account: domain.AccountID(user.USDAccountID),
+		Amount:  decimal.NewFromInt(950),
+		Time:    time.Now(),
+	})
+	require.NoError(t, err)
+
+	var insufficientErr *domain.InsufficientFundsError
+	require.ErrorAs(t, svc.ExecuteQuotedExchange(ctx, domain.UserID(user.UserID), quote.Token, time.Now()), &insufficientErr)
+
+	_, err = svc.Deposit(ctx, &service.CashCommand{
+		Account: domain.AccountID(user.USDAccountID),
+		Amount:  decimal.NewFromInt(950),
+		Time:    time.Now(),
+	})
+	require.NoError(t, err)
+
+	// Act
+	err = svc.ExecuteQuotedExchange(ctx, domain.UserID(user.UserID), quote.Token, time.Now())
+
+	// Assert
+	require.NoError(t, err)
+	assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(900))
+	assertBalanceEquals(t, ctx, testPool, user.EURAccountID, decimal.NewFromInt(592))
+}
+
+func TestExecuteQuotedExchange_RequiresARegistry(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Act
+	_, err := svc.QuoteExchange(ctx, newQuoteCommand(user, 100, time.Now()))
+
+	// Assert
+	require.Error(t, err)
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"minibankingplatform/internal/domain"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ExchangeRateEntry is a rate of a currency pair as reported by the exchange
+// rate provider from EffectiveAt on.
+type ExchangeRateEntry struct {
+	From        domain.Currency
+	To          domain.Currency
+	Rate        decimal.Decimal
+	EffectiveAt time.Time
+	Source      string
+}
+
+// GetExchangeRateHistory returns the recorded rates of the pair that took
+// effect at or after since, oldest first. The rate in effect at since itself
+// may have been recorded earlier and is then not included.
+func (s *Service) GetExchangeRateHistory(ctx context.Context, from, to domain.Currency, since time.Time) ([]ExchangeRateEntry, error) {
+	if from == to {
+		return nil, domain.NewSameCurrencyExchangeRateError(from)
+	}
+
+	entries, err := s.exchangeRateHistory.GetSince(ctx, from, to, since)
+	if err != nil {
+		return nil, fmt.Errorf("getting exchange rate history: %w", err)
+	}
+
+	result := make([]ExchangeRateEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = ExchangeRateEntry{
+			From:        entry.Rate.From(),
+			To:          entry.Rate.To(),
+			Rate:        entry.Rate.Rate(),
+			EffectiveAt: entry.EffectiveAt,
+			Source:      entry.Source,
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/infrastructure"
+	"minibankingplatform/pkg/trm"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetExchangeRateHistory_ReturnsRecordedRateChanges(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange
+	history := infrastructure.NewExchangeRateHistoryRepository(trm.NewInjector[infrastructure.DBTX](testPool))
+	since := time.Now().Add(-time.Second)
+	amount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
+
+	for _, rate := range []string{"0.5", "0.5", "0.6"} {
+		provider := infrastructure.NewRecordingExchangeRateProvider(
+			infrastructure.NewFixedExchangeRateProvider(decimal.RequireFromString(rate)),
+			history,
+			"fixed",
+			slog.New(slog.DiscardHandler),
+		)
+		_, err := setupService(t, testPool, withRateProvider(provider)).CalculateExchangeAmount(ctx, amount, domain.CurrencyEUR)
+		require.NoError(t, err)
+	}
+
+	svc := setupService(t, testPool)
+
+	// Act
+	entries, err := svc.GetExchangeRateHistory(ctx, domain.CurrencyUSD, domain.CurrencyEUR, since)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "an unchanged rate is not recorded again")
+	for _, entry := range entries {
+		assert.Equal(t, domain.CurrencyUSD, entry.From)
+		assert.Equal(t, domain.CurrencyEUR, entry.To)
+		assert.Equal(t, "fixed", entry.Source)
+		assert.False(t, entry.EffectiveAt.Before(since))
+	}
+	assert.False(t, entries[1].EffectiveAt.Before(entries[0].EffectiveAt))
+
+	assert.True(t, entries[0].Rate.Equal(decimal.RequireFromString("0.5")))
+	assert.True(t, entries[1].Rate.Equal(decimal.RequireFromString("0.6")))
+
+	later, err := svc.GetExchangeRateHistory(ctx, domain.CurrencyUSD, domain.CurrencyEUR, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, later)
+}
+
+func TestGetExchangeRateHistory_SameCurrencyError(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange
+	svc := setupService(t, testPool)
+
+	// Act
+	_, err := svc.GetExchangeRateHistory(ctx, domain.CurrencyUSD, domain.CurrencyUSD, time.Time{})
+
+	// Assert
+	var sameCurrencyErr *domain.SameCurrencyExchangeRateError
+	require.True(t, errors.As(err, &sameCurrencyErr))
+}
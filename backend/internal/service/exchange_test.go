@@ -3,13 +3,18 @@ package service_test
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/infrastructure"
 	"minibankingplatform/internal/service"
+	"minibankingplatform/pkg/trm"
+	"minibankingplatform/pkg/trm/pgxfactory"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,6 +32,7 @@ func TestExchange_HappyPath_USDtoEUR(t *testing.T) {
 	// Exchange 100 USD to EUR (should get 92 EUR)
 	exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
 	cmd := &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
 		SourceAccount: domain.AccountID(user.USDAccountID),
 		TargetAccount: domain.AccountID(user.EURAccountID),
 		SourceAmount:  exchangeAmount,
@@ -34,7 +40,7 @@ func TestExchange_HappyPath_USDtoEUR(t *testing.T) {
 	}
 
 	// Act
-	err := svc.Exchange(ctx, cmd)
+	_, err := svc.Exchange(ctx, cmd)
 
 	// Assert
 	require.NoError(t, err)
@@ -64,6 +70,7 @@ func TestExchange_HappyPath_EURtoUSD(t *testing.T) {
 	// 92 * 1.086957 = 100.00 (rounded to 2 decimal places)
 	exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(92), domain.CurrencyEUR)
 	cmd := &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
 		SourceAccount: domain.AccountID(user.EURAccountID),
 		TargetAccount: domain.AccountID(user.USDAccountID),
 		SourceAmount:  exchangeAmount,
@@ -71,7 +78,7 @@ func TestExchange_HappyPath_EURtoUSD(t *testing.T) {
 	}
 
 	// Act
-	err := svc.Exchange(ctx, cmd)
+	_, err := svc.Exchange(ctx, cmd)
 
 	// Assert
 	require.NoError(t, err)
@@ -88,6 +95,80 @@ func TestExchange_HappyPath_EURtoUSD(t *testing.T) {
 	assertLedgerBalanced(ctx, t, svc)
 }
 
+func TestExchange_ReturnsBookedAmounts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - user gets 1000 USD and 500 EUR on registration
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	exchangeAmount, _ := domain.NewMoney(decimal.RequireFromString("123.45"), domain.CurrencyUSD)
+	cmd := &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
+		SourceAccount: domain.AccountID(user.USDAccountID),
+		TargetAccount: domain.AccountID(user.EURAccountID),
+		SourceAmount:  exchangeAmount,
+		Time:          time.Now(),
+	}
+
+	// Act
+	result, err := svc.Exchange(ctx, cmd)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, result.Details)
+
+	stored, err := svc.GetExchange(ctx, domain.UserID(user.UserID), result.Details.ID())
+	require.NoError(t, err)
+	storedDetails := stored.ExchangeDetails()
+
+	assert.Equal(t, result.Details.TransactionID(), stored.Transaction().ID())
+	assert.True(t, exchangeAmount.Amount().Equal(result.Details.SourceAmount().Amount()))
+	assert.True(t, result.Details.ExchangeRate().Equal(storedDetails.ExchangeRate()))
+
+	targetAmount := result.Details.TargetAmount()
+	expected := exchangeAmount.Amount().
+		Mul(storedDetails.ExchangeRate()).
+		Round(targetAmount.Currency().MinorUnitDecimals())
+	assert.Equal(t, domain.CurrencyEUR, targetAmount.Currency())
+	assert.True(t, expected.Equal(targetAmount.Amount()), "expected %s, got %s", expected, targetAmount.Amount())
+
+	assertBalanceEquals(t, ctx, testPool, user.EURAccountID, decimal.NewFromInt(500).Add(targetAmount.Amount()))
+}
+
+func TestExchange_HappyPath_USDtoGBP(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - user gets 1000 USD and 300 GBP on registration
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Exchange 100 USD to GBP at the fixed 0.79 rate
+	exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
+	cmd := &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
+		SourceAccount: domain.AccountID(user.USDAccountID),
+		TargetAccount: domain.AccountID(user.GBPAccountID),
+		SourceAmount:  exchangeAmount,
+		Time:          time.Now(),
+	}
+
+	// Act
+	result, err := svc.Exchange(ctx, cmd)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.CurrencyGBP, result.Details.TargetAmount().Currency())
+
+	assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(900))
+	assertBalanceEquals(t, ctx, testPool, user.GBPAccountID, decimal.NewFromInt(379))
+	assertLedgerBalanced(ctx, t, svc)
+}
+
 func TestExchange_SameCurrencyError(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -100,6 +181,7 @@ func TestExchange_SameCurrencyError(t *testing.T) {
 
 	exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
 	cmd := &service.ExchangeCommand{
+		UserID:        domain.UserID(user1.UserID),
 		SourceAccount: domain.AccountID(user1.USDAccountID),
 		TargetAccount: domain.AccountID(user2.USDAccountID),
 		SourceAmount:  exchangeAmount,
@@ -107,7 +189,7 @@ func TestExchange_SameCurrencyError(t *testing.T) {
 	}
 
 	// Act
-	err := svc.Exchange(ctx, cmd)
+	_, err := svc.Exchange(ctx, cmd)
 
 	// Assert
 	require.Error(t, err)
@@ -124,6 +206,33 @@ func TestExchange_SameCurrencyError(t *testing.T) {
 	assertLedgerBalanced(ctx, t, svc)
 }
 
+func TestExchange_SameAccountError(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
+	cmd := &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
+		SourceAccount: domain.AccountID(user.USDAccountID),
+		TargetAccount: domain.AccountID(user.USDAccountID),
+		SourceAmount:  exchangeAmount,
+		Time:          time.Now(),
+	}
+
+	// Act
+	_, err := svc.Exchange(ctx, cmd)
+
+	// Assert
+	var sameAccountErr *domain.SameAccountExchangeError
+	require.ErrorAs(t, err, &sameAccountErr)
+	assert.Equal(t, domain.AccountID(user.USDAccountID), sameAccountErr.AccountID)
+
+	assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(1000))
+}
+
 func TestExchange_NegativeAmount(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -135,6 +244,7 @@ func TestExchange_NegativeAmount(t *testing.T) {
 
 	exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(-100), domain.CurrencyUSD)
 	cmd := &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
 		SourceAccount: domain.AccountID(user.USDAccountID),
 		TargetAccount: domain.AccountID(user.EURAccountID),
 		SourceAmount:  exchangeAmount,
@@ -142,7 +252,7 @@ func TestExchange_NegativeAmount(t *testing.T) {
 	}
 
 	// Act
-	err := svc.Exchange(ctx, cmd)
+	_, err := svc.Exchange(ctx, cmd)
 
 	// Assert
 	require.Error(t, err)
@@ -167,6 +277,7 @@ func TestExchange_ZeroAmount(t *testing.T) {
 
 	exchangeAmount, _ := domain.NewMoney(decimal.Zero, domain.CurrencyUSD)
 	cmd := &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
 		SourceAccount: domain.AccountID(user.USDAccountID),
 		TargetAccount: domain.AccountID(user.EURAccountID),
 		SourceAmount:  exchangeAmount,
@@ -174,11 +285,12 @@ func TestExchange_ZeroAmount(t *testing.T) {
 	}
 
 	// Act
-	err := svc.Exchange(ctx, cmd)
+	_, err := svc.Exchange(ctx, cmd)
 
 	// Assert
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "exchange amount cannot be zero")
+	var zeroAmountErr *domain.ZeroAmountError
+	assert.ErrorAs(t, err, &zeroAmountErr)
 
 	// Balances should remain unchanged
 	assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(1000))
@@ -199,6 +311,7 @@ func TestExchange_InsufficientFunds(t *testing.T) {
 	// Try to exchange 2000 USD (user only has 1000)
 	exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(2000), domain.CurrencyUSD)
 	cmd := &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
 		SourceAccount: domain.AccountID(user.USDAccountID),
 		TargetAccount: domain.AccountID(user.EURAccountID),
 		SourceAmount:  exchangeAmount,
@@ -206,7 +319,7 @@ func TestExchange_InsufficientFunds(t *testing.T) {
 	}
 
 	// Act
-	err := svc.Exchange(ctx, cmd)
+	_, err := svc.Exchange(ctx, cmd)
 
 	// Assert
 	require.Error(t, err)
@@ -231,6 +344,7 @@ func TestExchange_AccountNotFound(t *testing.T) {
 
 	exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
 	cmd := &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
 		SourceAccount: domain.AccountID(user.USDAccountID),
 		TargetAccount: domain.AccountID(uuid.New()), // Non-existent account
 		SourceAmount:  exchangeAmount,
@@ -238,7 +352,7 @@ func TestExchange_AccountNotFound(t *testing.T) {
 	}
 
 	// Act
-	err := svc.Exchange(ctx, cmd)
+	_, err := svc.Exchange(ctx, cmd)
 
 	// Assert
 	require.Error(t, err)
@@ -251,6 +365,64 @@ func TestExchange_AccountNotFound(t *testing.T) {
 	assertLedgerBalanced(ctx, t, svc)
 }
 
+func TestExchange_CashbookAccountIsRejected(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - user gets 1000 USD and 500 EUR
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	usdAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
+	eurAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyEUR)
+
+	tests := []struct {
+		name     string
+		cmd      *service.ExchangeCommand
+		cashbook domain.AccountID
+	}{
+		{
+			name: "cashbook as target",
+			cmd: &service.ExchangeCommand{
+				UserID:        domain.UserID(user.UserID),
+				SourceAccount: domain.AccountID(user.USDAccountID),
+				TargetAccount: domain.CashbookEUR,
+				SourceAmount:  usdAmount,
+				Time:          time.Now(),
+			},
+			cashbook: domain.CashbookEUR,
+		},
+		{
+			name: "cashbook as source",
+			cmd: &service.ExchangeCommand{
+				UserID:        domain.UserID(user.UserID),
+				SourceAccount: domain.CashbookEUR,
+				TargetAccount: domain.AccountID(user.USDAccountID),
+				SourceAmount:  eurAmount,
+				Time:          time.Now(),
+			},
+			cashbook: domain.CashbookEUR,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := svc.Exchange(ctx, tt.cmd)
+
+			// Assert
+			require.Error(t, err)
+			var cashbookErr *domain.CashbookTransferError
+			require.ErrorAs(t, err, &cashbookErr)
+			assert.Equal(t, tt.cashbook, cashbookErr.AccountID)
+		})
+	}
+
+	assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(1000))
+	assertLedgerBalanced(ctx, t, svc)
+}
+
 func TestExchange_DecimalPrecision(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -267,6 +439,7 @@ func TestExchange_DecimalPrecision(t *testing.T) {
 	exchangeDecimal := decimal.NewFromFloat(100.25)
 	exchangeAmount, _ := domain.NewMoney(exchangeDecimal, domain.CurrencyUSD)
 	cmd := &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
 		SourceAccount: domain.AccountID(user.USDAccountID),
 		TargetAccount: domain.AccountID(user.EURAccountID),
 		SourceAmount:  exchangeAmount,
@@ -274,7 +447,7 @@ func TestExchange_DecimalPrecision(t *testing.T) {
 	}
 
 	// Act
-	err := svc.Exchange(ctx, cmd)
+	_, err := svc.Exchange(ctx, cmd)
 
 	// Assert
 	require.NoError(t, err)
@@ -302,6 +475,7 @@ func TestExchange_Atomicity(t *testing.T) {
 
 	exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
 	cmd := &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
 		SourceAccount: domain.AccountID(user.USDAccountID),
 		TargetAccount: domain.AccountID(uuid.New()), // Non-existent target
 		SourceAmount:  exchangeAmount,
@@ -309,7 +483,7 @@ func TestExchange_Atomicity(t *testing.T) {
 	}
 
 	// Act
-	err := svc.Exchange(ctx, cmd)
+	_, err := svc.Exchange(ctx, cmd)
 
 	// Assert - verify atomicity: all data should remain unchanged
 	require.Error(t, err)
@@ -340,12 +514,13 @@ func TestExchange_ConcurrentExchanges(t *testing.T) {
 		go func() {
 			defer wg.Done()
 			cmd := &service.ExchangeCommand{
+				UserID:        domain.UserID(user.UserID),
 				SourceAccount: domain.AccountID(user.USDAccountID),
 				TargetAccount: domain.AccountID(user.EURAccountID),
 				SourceAmount:  exchangeAmount,
 				Time:          time.Now(),
 			}
-			if err := svc.Exchange(ctx, cmd); err != nil {
+			if _, err := svc.Exchange(ctx, cmd); err != nil {
 				errors <- err
 			}
 		}()
@@ -385,7 +560,8 @@ func TestExchange_MultipleSequentialExchanges(t *testing.T) {
 	// Act - Exchange USD -> EUR -> USD
 	// 1. Exchange 500 USD to EUR (500 * 0.92 = 460 EUR)
 	exchange1, _ := domain.NewMoney(decimal.NewFromInt(500), domain.CurrencyUSD)
-	err := svc.Exchange(ctx, &service.ExchangeCommand{
+	_, err := svc.Exchange(ctx, &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
 		SourceAccount: domain.AccountID(user.USDAccountID),
 		TargetAccount: domain.AccountID(user.EURAccountID),
 		SourceAmount:  exchange1,
@@ -397,7 +573,8 @@ func TestExchange_MultipleSequentialExchanges(t *testing.T) {
 	// EUR->USD rate is 1/0.92 ≈ 1.086957
 	// 200 * 1.086957 = 217.39 (rounded to 2 decimal places)
 	exchange2, _ := domain.NewMoney(decimal.NewFromInt(200), domain.CurrencyEUR)
-	err = svc.Exchange(ctx, &service.ExchangeCommand{
+	_, err = svc.Exchange(ctx, &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
 		SourceAccount: domain.AccountID(user.EURAccountID),
 		TargetAccount: domain.AccountID(user.USDAccountID),
 		SourceAmount:  exchange2,
@@ -484,12 +661,9 @@ func TestNewExchangeCommand(t *testing.T) {
 			sourceAccount: sourceAccount,
 			targetAccount: targetAccount,
 			amount:        "100",
-			currency:      "GBP",
+			currency:      "JPY",
 			time:          now,
 			expectError:   true,
-			validate: func(t *testing.T, cmd *service.ExchangeCommand) {
-				// Verify it's specifically the ErrInvalidCurrency error
-			},
 		},
 	}
 
@@ -504,8 +678,11 @@ func TestNewExchangeCommand(t *testing.T) {
 				if tt.expectedErrorMsg != "" {
 					assert.Contains(t, err.Error(), tt.expectedErrorMsg)
 				}
-				if tt.currency == "GBP" {
+				if tt.currency == "JPY" {
 					assert.ErrorIs(t, err, domain.ErrInvalidCurrency)
+
+					var unsupportedErr *domain.UnsupportedCurrencyError
+					assert.ErrorAs(t, err, &unsupportedErr)
 				}
 			} else {
 				require.NoError(t, err)
@@ -517,3 +694,390 @@ func TestNewExchangeCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestExchange_AllowedDirections(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Only USD -> EUR is allowed, even though the provider has a rate for EUR -> USD
+	svc := setupService(t, testPool, withConfig(service.Config{
+		AllowedExchangeDirections: []domain.ExchangeDirection{
+			{From: domain.CurrencyUSD, To: domain.CurrencyEUR},
+		},
+	}))
+
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	t.Run("allowed direction", func(t *testing.T) {
+		// Arrange
+		exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
+
+		// Act
+		_, err := svc.Exchange(ctx, &service.ExchangeCommand{
+			UserID:        domain.UserID(user.UserID),
+			SourceAccount: domain.AccountID(user.USDAccountID),
+			TargetAccount: domain.AccountID(user.EURAccountID),
+			SourceAmount:  exchangeAmount,
+			Time:          time.Now(),
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(900))
+		assertBalanceEquals(t, ctx, testPool, user.EURAccountID, decimal.NewFromInt(592))
+	})
+
+	t.Run("blocked direction", func(t *testing.T) {
+		// Arrange
+		exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(50), domain.CurrencyEUR)
+
+		// Act
+		_, err := svc.Exchange(ctx, &service.ExchangeCommand{
+			UserID:        domain.UserID(user.UserID),
+			SourceAccount: domain.AccountID(user.EURAccountID),
+			TargetAccount: domain.AccountID(user.USDAccountID),
+			SourceAmount:  exchangeAmount,
+			Time:          time.Now(),
+		})
+
+		// Assert
+		var directionErr *domain.ExchangeDirectionNotAllowedError
+		require.ErrorAs(t, err, &directionErr)
+		assert.Equal(t, domain.CurrencyEUR, directionErr.From)
+		assert.Equal(t, domain.CurrencyUSD, directionErr.To)
+		assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(900))
+		assertBalanceEquals(t, ctx, testPool, user.EURAccountID, decimal.NewFromInt(592))
+	})
+
+	t.Run("calculation is consistent with exchange", func(t *testing.T) {
+		// Arrange
+		allowedAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
+		blockedAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyEUR)
+
+		// Act
+		_, allowedErr := svc.CalculateExchangeAmount(ctx, allowedAmount, domain.CurrencyEUR)
+		_, blockedErr := svc.CalculateExchangeAmount(ctx, blockedAmount, domain.CurrencyUSD)
+
+		// Assert
+		require.NoError(t, allowedErr)
+		var directionErr *domain.ExchangeDirectionNotAllowedError
+		assert.ErrorAs(t, blockedErr, &directionErr)
+	})
+
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestExchange_PairMinimums(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// USD -> EUR needs at least 50 USD, every other pair only the global 1 unit
+	svc := setupService(t, testPool, withConfig(service.Config{
+		MinimumExchangeAmount: decimal.NewFromInt(1),
+		ExchangeMinimums: []domain.ExchangeMinimum{
+			{
+				Direction: domain.ExchangeDirection{From: domain.CurrencyUSD, To: domain.CurrencyEUR},
+				Amount:    decimal.NewFromInt(50),
+			},
+		},
+	}))
+
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	t.Run("pair minimum rejects a smaller amount", func(t *testing.T) {
+		// Arrange
+		exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(20), domain.CurrencyUSD)
+
+		// Act
+		_, err := svc.Exchange(ctx, &service.ExchangeCommand{
+			UserID:        domain.UserID(user.UserID),
+			SourceAccount: domain.AccountID(user.USDAccountID),
+			TargetAccount: domain.AccountID(user.EURAccountID),
+			SourceAmount:  exchangeAmount,
+			Time:          time.Now(),
+		})
+		_, calculateErr := svc.CalculateExchangeAmount(ctx, exchangeAmount, domain.CurrencyEUR)
+
+		// Assert
+		var belowMinimumErr *domain.ExchangeBelowMinimumError
+		require.ErrorAs(t, err, &belowMinimumErr)
+		assert.True(t, belowMinimumErr.Minimum.Amount().Equal(decimal.NewFromInt(50)))
+		assert.Equal(t, domain.CurrencyUSD, belowMinimumErr.Minimum.Currency())
+		assert.ErrorAs(t, calculateErr, &belowMinimumErr)
+		assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(1000))
+		assertBalanceEquals(t, ctx, testPool, user.EURAccountID, decimal.NewFromInt(500))
+	})
+
+	t.Run("other pair accepts the same amount", func(t *testing.T) {
+		// Arrange
+		exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(20), domain.CurrencyUSD)
+
+		// Act
+		_, err := svc.Exchange(ctx, &service.ExchangeCommand{
+			UserID:        domain.UserID(user.UserID),
+			SourceAccount: domain.AccountID(user.USDAccountID),
+			TargetAccount: domain.AccountID(user.GBPAccountID),
+			SourceAmount:  exchangeAmount,
+			Time:          time.Now(),
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(980))
+	})
+
+	t.Run("global minimum applies to pairs without their own", func(t *testing.T) {
+		// Arrange
+		exchangeAmount, _ := domain.NewMoney(decimal.RequireFromString("0.50"), domain.CurrencyEUR)
+
+		// Act
+		_, err := svc.CalculateExchangeAmount(ctx, exchangeAmount, domain.CurrencyUSD)
+
+		// Assert
+		var belowMinimumErr *domain.ExchangeBelowMinimumError
+		require.ErrorAs(t, err, &belowMinimumErr)
+		assert.True(t, belowMinimumErr.Minimum.Amount().Equal(decimal.NewFromInt(1)))
+	})
+
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestGetExchange_Owner(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - user exchanges 100 USD to EUR
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
+	_, err := svc.Exchange(ctx, &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
+		SourceAccount: domain.AccountID(user.USDAccountID),
+		TargetAccount: domain.AccountID(user.EURAccountID),
+		SourceAmount:  exchangeAmount,
+		Time:          time.Now(),
+	})
+	require.NoError(t, err)
+
+	var exchangeID, transactionID uuid.UUID
+	err = testPool.QueryRow(ctx,
+		`SELECT id, transaction_id FROM exchange_details WHERE source_account_id = $1`, user.USDAccountID,
+	).Scan(&exchangeID, &transactionID)
+	require.NoError(t, err)
+
+	// Act
+	exchange, err := svc.GetExchange(ctx, domain.UserID(user.UserID), domain.ExchangeDetailsID(exchangeID))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.TransactionID(transactionID), exchange.Transaction().ID())
+	assert.Equal(t, domain.TransactionTypeExchange, exchange.Transaction().Type())
+
+	details := exchange.ExchangeDetails()
+	require.NotNil(t, details)
+	assert.Equal(t, exchangeID, details.ID())
+	assert.Equal(t, domain.AccountID(user.USDAccountID), details.SourceAccount())
+	assert.Equal(t, domain.AccountID(user.EURAccountID), details.TargetAccount())
+	assert.True(t, details.SourceAmount().Amount().Equal(decimal.NewFromInt(100)))
+	assert.Equal(t, domain.CurrencyUSD, details.SourceAmount().Currency())
+	assert.True(t, details.TargetAmount().Amount().Equal(decimal.NewFromInt(92)))
+	assert.Equal(t, domain.CurrencyEUR, details.TargetAmount().Currency())
+	assert.True(t, details.ExchangeRate().Equal(decimal.NewFromFloat(0.92)))
+}
+
+func TestGetExchange_NonOwnerIsDenied(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - owner exchanges, outsider tries to read it
+	owner := registerTestUser(ctx, t, svc, testPool)
+	outsider := registerTestUser(ctx, t, svc, testPool)
+
+	exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
+	_, err := svc.Exchange(ctx, &service.ExchangeCommand{
+		UserID:        domain.UserID(owner.UserID),
+		SourceAccount: domain.AccountID(owner.USDAccountID),
+		TargetAccount: domain.AccountID(owner.EURAccountID),
+		SourceAmount:  exchangeAmount,
+		Time:          time.Now(),
+	})
+	require.NoError(t, err)
+
+	var exchangeID uuid.UUID
+	err = testPool.QueryRow(ctx,
+		`SELECT id FROM exchange_details WHERE source_account_id = $1`, owner.USDAccountID,
+	).Scan(&exchangeID)
+	require.NoError(t, err)
+
+	// Act
+	_, err = svc.GetExchange(ctx, domain.UserID(outsider.UserID), domain.ExchangeDetailsID(exchangeID))
+
+	// Assert
+	var accessDeniedErr *domain.ExchangeAccessDeniedError
+	assert.ErrorAs(t, err, &accessDeniedErr)
+}
+
+func TestGetExchange_NotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Act
+	_, err := svc.GetExchange(ctx, domain.UserID(user.UserID), domain.NewExchangeDetailsID())
+
+	// Assert
+	var notFoundErr *domain.ExchangeNotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+}
+
+func TestExchange_InvalidCommandIsRejectedBeforeLocking(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange - accounts are only locked inside a transaction, so counting
+	// the transactions begun tells whether any GetForUpdate could have run
+	factory, err := pgxfactory.New(ctx, testPool)
+	require.NoError(t, err)
+
+	var begun atomic.Int32
+	countingFactory := func(ctx context.Context, opts pgx.TxOptions) (trm.Transaction[pgx.Tx], error) {
+		begun.Add(1)
+		return factory(ctx, opts)
+	}
+
+	registrar := setupService(t, testPool)
+	user := registerTestUser(ctx, t, registrar, testPool)
+	svc := setupService(t, testPool, withFactory(countingFactory))
+
+	negative, _ := domain.NewMoney(decimal.NewFromInt(-10), domain.CurrencyUSD)
+	zero, _ := domain.NewMoney(decimal.Zero, domain.CurrencyUSD)
+
+	tests := []struct {
+		name   string
+		amount domain.Money
+		target any
+	}{
+		{name: "negative amount", amount: negative, target: new(*domain.NegativeExchangeError)},
+		{name: "zero amount", amount: zero, target: new(*domain.ZeroAmountError)},
+	}
+
+	for _, tt := range tests {
+		// Act
+		_, err := svc.Exchange(ctx, &service.ExchangeCommand{
+			UserID:        domain.UserID(user.UserID),
+			SourceAccount: domain.AccountID(user.USDAccountID),
+			TargetAccount: domain.AccountID(user.EURAccountID),
+			SourceAmount:  tt.amount,
+			Time:          time.Now(),
+		})
+
+		// Assert
+		assert.ErrorAs(t, err, tt.target, tt.name)
+	}
+	assert.Zero(t, begun.Load(), "no transaction should be started for an invalid command")
+}
+
+// transactionCountingRateProvider notes how many transactions had begun when
+// it was asked for a rate.
+type transactionCountingRateProvider struct {
+	domain.ExchangeRateProvider
+	begun       *atomic.Int32
+	begunAtRate atomic.Int32
+}
+
+func (p *transactionCountingRateProvider) GetRate(ctx context.Context, from, to domain.Currency) (domain.ExchangeRate, error) {
+	p.begunAtRate.Store(p.begun.Load())
+	return p.ExchangeRateProvider.GetRate(ctx, from, to)
+}
+
+func TestExchange_RateIsFetchedBeforeLocking(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange - a provider that could be slow must not be asked while the
+	// exchange holds account or cashbook locks, i.e. inside its transaction
+	factory, err := pgxfactory.New(ctx, testPool)
+	require.NoError(t, err)
+
+	var begun atomic.Int32
+	countingFactory := func(ctx context.Context, opts pgx.TxOptions) (trm.Transaction[pgx.Tx], error) {
+		begun.Add(1)
+		return factory(ctx, opts)
+	}
+
+	registrar := setupService(t, testPool)
+	user := registerTestUser(ctx, t, registrar, testPool)
+
+	provider := &transactionCountingRateProvider{
+		ExchangeRateProvider: infrastructure.NewFixedExchangeRateProvider(decimal.NewFromFloat(0.92)),
+		begun:                &begun,
+	}
+	provider.begunAtRate.Store(-1)
+	svc := setupService(t, testPool, withFactory(countingFactory), withRateProvider(provider))
+
+	amount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
+
+	// Act
+	_, err = svc.Exchange(ctx, &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
+		SourceAccount: domain.AccountID(user.USDAccountID),
+		TargetAccount: domain.AccountID(user.EURAccountID),
+		SourceAmount:  amount,
+		Time:          time.Now(),
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), provider.begunAtRate.Load(), "the rate should be fetched before the transaction begins")
+	assert.Equal(t, int32(1), begun.Load())
+}
+
+func TestExchange_AccountsOfAnotherUserAreDenied(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	owner := registerTestUser(ctx, t, svc, testPool)
+	other := registerTestUser(ctx, t, svc, testPool)
+
+	exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
+
+	tests := []struct {
+		name   string
+		source uuid.UUID
+		target uuid.UUID
+		denied uuid.UUID
+	}{
+		{name: "source of another user", source: other.USDAccountID, target: owner.EURAccountID, denied: other.USDAccountID},
+		{name: "target of another user", source: owner.USDAccountID, target: other.EURAccountID, denied: other.EURAccountID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := svc.Exchange(ctx, &service.ExchangeCommand{
+				UserID:        domain.UserID(owner.UserID),
+				SourceAccount: domain.AccountID(tt.source),
+				TargetAccount: domain.AccountID(tt.target),
+				SourceAmount:  exchangeAmount,
+				Time:          time.Now(),
+			})
+
+			// Assert
+			var accessDeniedErr *domain.AccountAccessDeniedError
+			require.ErrorAs(t, err, &accessDeniedErr)
+			assert.Equal(t, domain.AccountID(tt.denied), accessDeniedErr.AccountID)
+		})
+	}
+
+	assertBalanceEquals(t, ctx, testPool, owner.USDAccountID, decimal.NewFromInt(1000))
+	assertBalanceEquals(t, ctx, testPool, other.USDAccountID, decimal.NewFromInt(1000))
+	assertBalanceEquals(t, ctx, testPool, other.EURAccountID, decimal.NewFromInt(500))
+	assertLedgerBalanced(ctx, t, svc)
+}
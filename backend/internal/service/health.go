@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"fmt"
+)
+
+// HealthCheck verifies that a database transaction can be started, used and
+// committed, which a bare ping of the pool doesn't exercise.
+func (s *Service) HealthCheck(ctx context.Context) error {
+	err := s.trm.Do(ctx, func(ctx context.Context) error {
+		return s.health.SelectOne(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("running health check transaction: %w", err)
+	}
+
+	return nil
+}
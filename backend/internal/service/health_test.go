@@ -0,0 +1,51 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"minibankingplatform/pkg/trm"
+	"minibankingplatform/pkg/trm/pgxfactory"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheck_Succeeds(t *testing.T) {
+	t.Parallel()
+
+	svc := setupService(t, testPool)
+
+	err := svc.HealthCheck(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestHealthCheck_CommitFailure(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange - a factory whose transactions can be used but never committed
+	errCommit := errors.New("cannot commit: read-only replica")
+	factory, err := pgxfactory.New(ctx, testPool)
+	require.NoError(t, err)
+
+	failingFactory := func(ctx context.Context, opts pgx.TxOptions) (trm.Transaction[pgx.Tx], error) {
+		tx, err := factory(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return trm.WrapTransaction(tx.Raw(), func() error { return errCommit }, tx.Rollback), nil
+	}
+
+	svc := setupService(t, testPool, withFactory(failingFactory))
+
+	// Act
+	err = svc.HealthCheck(ctx)
+
+	// Assert
+	require.ErrorIs(t, err, errCommit)
+	assert.ErrorContains(t, err, "failed to commit transaction")
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"minibankingplatform/internal/domain"
 	"minibankingplatform/internal/infrastructure"
 	"minibankingplatform/internal/service"
 	jwtpkg "minibankingplatform/pkg/jwt"
@@ -12,38 +13,110 @@ import (
 	"minibankingplatform/pkg/trm/pgxfactory"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// setupService creates a new Service instance with real repositories.
-func setupService(t *testing.T, pool *pgxpool.Pool) *service.Service {
-	t.Helper()
+// testServiceDeps holds what setupService wires into a Service. Tests replace
+// the defaults with testServiceOptions.
+type testServiceDeps struct {
+	factory        trm.TransactionFactory[pgx.Tx, pgx.TxOptions]
+	trmOptions     []trm.Option
+	rateProvider   domain.ExchangeRateProvider
+	tokenManager   service.TokenManager
+	serviceOptions []service.ServiceOption
+}
 
-	ctx := context.Background()
+type testServiceOption func(*testServiceDeps)
 
-	factory, err := pgxfactory.New(ctx, pool)
-	require.NoError(t, err)
+// withServiceOptions passes options on to service.NewService.
+func withServiceOptions(opts ...service.ServiceOption) testServiceOption {
+	return func(d *testServiceDeps) {
+		d.serviceOptions = append(d.serviceOptions, opts...)
+	}
+}
 
-	transactionManager := trm.NewTransactionManager(factory)
-	injector := trm.NewInjector[infrastructure.DBTX](pool)
+// withConfig sets the business policies of the Service.
+func withConfig(config service.Config) testServiceOption {
+	return withServiceOptions(service.WithConfig(config))
+}
+
+// withFactory makes the Service's transactions come from factory.
+func withFactory(factory trm.TransactionFactory[pgx.Tx, pgx.TxOptions]) testServiceOption {
+	return func(d *testServiceDeps) {
+		d.factory = factory
+	}
+}
+
+// withRateProvider makes the Service get its exchange rates from provider.
+func withRateProvider(provider domain.ExchangeRateProvider) testServiceOption {
+	return func(d *testServiceDeps) {
+		d.rateProvider = provider
+	}
+}
+
+// withTokenManager makes the Service issue its tokens with tokenManager.
+func withTokenManager(tokenManager service.TokenManager) testServiceOption {
+	return func(d *testServiceDeps) {
+		d.tokenManager = tokenManager
+	}
+}
+
+// withTracerProvider records the spans of the Service's operations and
+// transactions with provider.
+func withTracerProvider(provider trace.TracerProvider) testServiceOption {
+	return func(d *testServiceDeps) {
+		d.trmOptions = append(d.trmOptions, trm.WithTracer(provider.Tracer("trm")))
+		d.serviceOptions = append(d.serviceOptions, service.WithTracerProvider(provider))
+	}
+}
+
+// setupService creates a new Service instance with real repositories. It
+// converts 1 USD to 0.92 EUR and retries serialization failures unless the
+// options say otherwise.
+func setupService(t *testing.T, pool *pgxpool.Pool, opts ...testServiceOption) *service.Service {
+	t.Helper()
 
-	usersRepo := infrastructure.NewUsersRepository(injector)
-	accountsRepo := infrastructure.NewAccountsRepository(injector)
-	transfersRepo := infrastructure.NewTransfersRepository(injector)
-	exchangesRepo := infrastructure.NewExchangesRepository(injector)
-	transactionsRepo := infrastructure.NewTransactionsRepository(injector)
-	ledgerRepo := infrastructure.NewLedgerRepository(injector)
+	deps := testServiceDeps{
+		trmOptions:   []trm.Option{trm.WithRetry(3, 10*time.Millisecond, pgxfactory.IsRetryable)},
+		rateProvider: infrastructure.NewFixedExchangeRateProvider(decimal.NewFromFloat(0.92)),
+		tokenManager: jwtpkg.NewTokenManager("test-secret-key", time.Hour),
+	}
+	for _, opt := range opts {
+		opt(&deps)
+	}
 
-	// Create fixed exchange rate provider: 1 USD = 0.92 EUR
-	exchangeRateProvider := infrastructure.NewFixedExchangeRateProvider(decimal.NewFromFloat(0.92))
+	if deps.factory == nil {
+		factory, err := pgxfactory.New(context.Background(), pool)
+		require.NoError(t, err)
+		deps.factory = factory
+	}
 
-	// Create token manager for JWT
-	tokenManager := jwtpkg.NewTokenManager("test-secret-key", time.Hour)
+	injector := trm.NewInjector[infrastructure.DBTX](pool)
 
-	return service.NewService(transactionManager, usersRepo, accountsRepo, transfersRepo, exchangesRepo, transactionsRepo, ledgerRepo, exchangeRateProvider, tokenManager)
+	repositories := service.Repositories{
+		Users:               infrastructure.NewUsersRepository(injector),
+		Accounts:            infrastructure.NewAccountsRepository(injector),
+		Transfers:           infrastructure.NewTransfersRepository(injector),
+		Exchanges:           infrastructure.NewExchangesRepository(injector),
+		Transactions:        infrastructure.NewTransactionsRepository(injector),
+		Ledger:              infrastructure.NewLedgerRepository(injector),
+		Health:              infrastructure.NewHealthRepository(injector),
+		IdempotencyKeys:     infrastructure.NewIdempotencyKeysRepository(injector),
+		ExchangeRateHistory: infrastructure.NewExchangeRateHistoryRepository(injector),
+	}
+
+	return service.NewService(
+		trm.NewTransactionManager(deps.factory, deps.trmOptions...),
+		repositories,
+		deps.rateProvider,
+		deps.tokenManager,
+		deps.serviceOptions...,
+	)
 }
 
 // TestUserAccounts holds user info and account IDs created during registration.
@@ -52,10 +125,11 @@ type TestUserAccounts struct {
 	Email        string
 	USDAccountID uuid.UUID
 	EURAccountID uuid.UUID
+	GBPAccountID uuid.UUID
 }
 
 // registerTestUser registers a new user via service and returns user info with account IDs.
-// The user gets 1000 USD and 500 EUR initial balance from cashbook.
+// The user gets 1000 USD, 500 EUR and 300 GBP initial balance from cashbook.
 func registerTestUser(ctx context.Context, t *testing.T, svc *service.Service, pool *pgxpool.Pool) *TestUserAccounts {
 	t.Helper()
 
@@ -67,17 +141,20 @@ func registerTestUser(ctx context.Context, t *testing.T, svc *service.Service, p
 	require.NoError(t, err)
 
 	// Get account IDs for the registered user
-	var usdAccountID, eurAccountID uuid.UUID
+	var usdAccountID, eurAccountID, gbpAccountID uuid.UUID
 	err = pool.QueryRow(ctx, `SELECT id FROM accounts WHERE user_id = $1 AND currency = 'USD'`, result.UserID).Scan(&usdAccountID)
 	require.NoError(t, err)
 	err = pool.QueryRow(ctx, `SELECT id FROM accounts WHERE user_id = $1 AND currency = 'EUR'`, result.UserID).Scan(&eurAccountID)
 	require.NoError(t, err)
+	err = pool.QueryRow(ctx, `SELECT id FROM accounts WHERE user_id = $1 AND currency = 'GBP'`, result.UserID).Scan(&gbpAccountID)
+	require.NoError(t, err)
 
 	return &TestUserAccounts{
 		UserID:       result.UserID,
 		Email:        email,
 		USDAccountID: usdAccountID,
 		EURAccountID: eurAccountID,
+		GBPAccountID: gbpAccountID,
 	}
 }
 
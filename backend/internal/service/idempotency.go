@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/infrastructure"
+)
+
+// IdempotentRequest is what a client asked for under an idempotency key. Body
+// is compared by its JSON encoding when the key is reused.
+type IdempotentRequest struct {
+	Endpoint string
+	Body     any
+}
+
+// WithIdempotencyKey runs op at most once per user and key. The response op
+// returns is stored in the transaction op runs in, so it is kept exactly when
+// op's changes are. When the key was used before for the same request, op is
+// skipped and the stored JSON response is returned instead; a nil response
+// means op was run. A key used before for another endpoint or request fails
+// with *domain.IdempotencyKeyMismatchError. Failed operations are not stored
+// and can be retried with the same key. Keys expire after
+// Config.IdempotencyKeyTTL.
+func (s *Service) WithIdempotencyKey(
+	ctx context.Context,
+	userID domain.UserID,
+	key string,
+	request IdempotentRequest,
+	op func(ctx context.Context) (response any, err error),
+) (json.RawMessage, error) {
+	hash, err := requestHash(request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	expiredBefore := s.now().Add(-s.idempotencyKeyTTL())
+
+	stored, err := s.storedResponse(ctx, userID, key, request.Endpoint, hash, expiredBefore)
+	if err != nil || stored != nil {
+		return stored, err
+	}
+
+	err = s.trm.Do(ctx, func(ctx context.Context) error {
+		response, err := op(ctx)
+		if err != nil {
+			return err
+		}
+
+		body, err := json.Marshal(response)
+		if err != nil {
+			return fmt.Errorf("encoding response: %w", err)
+		}
+
+		return s.idempotencyKeys.Insert(ctx, userID, key, infrastructure.IdempotencyRecord{
+			Endpoint:    request.Endpoint,
+			RequestHash: hash,
+			Response:    body,
+		}, expiredBefore)
+	})
+
+	var usedErr *domain.IdempotencyKeyUsedError
+	if errors.As(err, &usedErr) {
+		// A concurrent request with the same key committed first and this one
+		// was rolled back, so answer the way that request was answered.
+		stored, err := s.storedResponse(ctx, userID, key, request.Endpoint, hash, expiredBefore)
+		if err == nil && stored == nil {
+			err = fmt.Errorf("finding idempotency key: %w", usedErr)
+		}
+		return stored, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("doing idempotent operation: %w", err)
+	}
+
+	return nil, nil
+}
+
+// storedResponse returns the response stored under the user's key for the same
+// request, or nil when the key is unused or expired.
+func (s *Service) storedResponse(
+	ctx context.Context,
+	userID domain.UserID,
+	key string,
+	endpoint string,
+	hash string,
+	expiredBefore time.Time,
+) (json.RawMessage, error) {
+	record, found, err := s.idempotencyKeys.Find(ctx, userID, key, expiredBefore)
+	if err != nil {
+		return nil, fmt.Errorf("finding idempotency key: %w", err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	if record.Endpoint != endpoint || record.RequestHash != hash {
+		return nil, domain.NewIdempotencyKeyMismatchError(key)
+	}
+
+	return record.Response, nil
+}
+
+// PurgeExpiredIdempotencyKeys deletes the idempotency keys older than
+// Config.IdempotencyKeyTTL, which can no longer be replayed, and returns how
+// many there were.
+func (s *Service) PurgeExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	return s.idempotencyKeys.DeleteExpired(ctx, s.now().Add(-s.idempotencyKeyTTL()))
+}
+
+func (s *Service) idempotencyKeyTTL() time.Duration {
+	if s.config.IdempotencyKeyTTL > 0 {
+		return s.config.IdempotencyKeyTTL
+	}
+	return DefaultIdempotencyKeyTTL
+}
+
+// requestHash fingerprints a request by its JSON encoding.
+func requestHash(body any) (string, error) {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("encoding request: %w", err)
+	}
+
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,212 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type storedTransfer struct {
+	TransactionID uuid.UUID `json:"transactionId"`
+}
+
+// transferRequest stands for the request an idempotency key is used with.
+var transferRequest = service.IdempotentRequest{
+	Endpoint: "/transactions/transfer",
+	Body:     map[string]string{"amount": "100.00"},
+}
+
+func TestWithIdempotencyKey_ReplaysStoredResponse(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	runs := 0
+	transfer := func(ctx context.Context) (any, error) {
+		runs++
+		result, err := svc.Transfer(ctx, &service.TransferCommand{
+			UserID:   domain.UserID(fromUser.UserID),
+			From:     domain.AccountID(fromUser.USDAccountID),
+			To:       domain.AccountID(toUser.USDAccountID),
+			Amount:   decimal.NewFromInt(100),
+			Currency: domain.CurrencyUSD,
+			Time:     time.Now(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return storedTransfer{TransactionID: uuid.UUID(result.TransactionID)}, nil
+	}
+	key := uuid.NewString()
+
+	// Act
+	first, err := svc.WithIdempotencyKey(ctx, domain.UserID(fromUser.UserID), key, transferRequest, transfer)
+	require.NoError(t, err)
+	second, err := svc.WithIdempotencyKey(ctx, domain.UserID(fromUser.UserID), key, transferRequest, transfer)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Nil(t, first)
+	require.NotNil(t, second)
+	assert.Equal(t, 1, runs)
+
+	var replayed storedTransfer
+	require.NoError(t, json.Unmarshal(second, &replayed))
+	assert.NotEqual(t, uuid.Nil, replayed.TransactionID)
+
+	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.NewFromInt(900))
+	assertBalanceEquals(t, ctx, testPool, toUser.USDAccountID, decimal.NewFromInt(1100))
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestWithIdempotencyKey_KeysAreScopedToUser(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	firstUser := registerTestUser(ctx, t, svc, testPool)
+	secondUser := registerTestUser(ctx, t, svc, testPool)
+
+	runs := 0
+	op := func(context.Context) (any, error) {
+		runs++
+		return map[string]int{"run": runs}, nil
+	}
+	key := uuid.NewString()
+
+	// Act
+	_, err := svc.WithIdempotencyKey(ctx, domain.UserID(firstUser.UserID), key, transferRequest, op)
+	require.NoError(t, err)
+	stored, err := svc.WithIdempotencyKey(ctx, domain.UserID(secondUser.UserID), key, transferRequest, op)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+	assert.Equal(t, 2, runs)
+}
+
+func TestWithIdempotencyKey_FailuresAreNotStored(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	transfer := func(amount int64) func(context.Context) (any, error) {
+		return func(ctx context.Context) (any, error) {
+			result, err := svc.Transfer(ctx, &service.TransferCommand{
+				UserID:   domain.UserID(fromUser.UserID),
+				From:     domain.AccountID(fromUser.USDAccountID),
+				To:       domain.AccountID(toUser.USDAccountID),
+				Amount:   decimal.NewFromInt(amount),
+				Currency: domain.CurrencyUSD,
+				Time:     time.Now(),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return storedTransfer{TransactionID: uuid.UUID(result.TransactionID)}, nil
+		}
+	}
+	key := uuid.NewString()
+
+	// Act - the first attempt overdraws, the retry with the same key asks for less
+	_, err := svc.WithIdempotencyKey(ctx, domain.UserID(fromUser.UserID), key, transferRequest, transfer(5000))
+	var insufficientErr *domain.InsufficientFundsError
+	require.ErrorAs(t, err, &insufficientErr)
+
+	stored, err := svc.WithIdempotencyKey(ctx, domain.UserID(fromUser.UserID), key, transferRequest, transfer(100))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.NewFromInt(900))
+}
+
+func TestWithIdempotencyKey_RejectsReuseForAnotherRequest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	runs := 0
+	op := func(context.Context) (any, error) {
+		runs++
+		return map[string]int{"run": runs}, nil
+	}
+	key := uuid.NewString()
+
+	_, err := svc.WithIdempotencyKey(ctx, domain.UserID(user.UserID), key, transferRequest, op)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		request service.IdempotentRequest
+	}{
+		{
+			name:    "different body",
+			request: service.IdempotentRequest{Endpoint: transferRequest.Endpoint, Body: map[string]string{"amount": "200.00"}},
+		},
+		{
+			name:    "different endpoint",
+			request: service.IdempotentRequest{Endpoint: "/transactions/exchange", Body: transferRequest.Body},
+		},
+	}
+
+	for _, tt := range tests {
+		// Act
+		stored, err := svc.WithIdempotencyKey(ctx, domain.UserID(user.UserID), key, tt.request, op)
+
+		// Assert
+		var mismatchErr *domain.IdempotencyKeyMismatchError
+		require.ErrorAs(t, err, &mismatchErr, tt.name)
+		assert.Nil(t, stored, tt.name)
+	}
+	assert.Equal(t, 1, runs)
+}
+
+func TestWithIdempotencyKey_ExpiredKeysAreUsedAfresh(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange - a key used a day and a minute ago by the service's clock
+	now := time.Now()
+	svc := setupService(t, testPool, withServiceOptions(service.WithClock(func() time.Time { return now })))
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	runs := 0
+	op := func(context.Context) (any, error) {
+		runs++
+		return map[string]int{"run": runs}, nil
+	}
+	key := uuid.NewString()
+
+	_, err := svc.WithIdempotencyKey(ctx, domain.UserID(user.UserID), key, transferRequest, op)
+	require.NoError(t, err)
+
+	now = now.Add(service.DefaultIdempotencyKeyTTL + time.Minute)
+
+	// Act - even for another request, the expired key is no longer bound to it
+	stored, err := svc.WithIdempotencyKey(ctx, domain.UserID(user.UserID), key, service.IdempotentRequest{
+		Endpoint: "/transactions/exchange",
+	}, op)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+	assert.Equal(t, 2, runs)
+}
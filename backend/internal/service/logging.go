@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"minibankingplatform/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// Attribute keys of the operation logs, shared so that records of different
+// operations can be correlated by the same fields.
+const (
+	logKeyUserID        = "user_id"
+	logKeyAccountID     = "account_id"
+	logKeyTransactionID = "transaction_id"
+	logKeyAmount        = "amount"
+	logKeyCurrency      = "currency"
+	logKeyError         = "error"
+)
+
+func userIDAttr(id domain.UserID) slog.Attr {
+	return slog.String(logKeyUserID, uuid.UUID(id).String())
+}
+
+func accountIDAttr(id domain.AccountID) slog.Attr {
+	return slog.String(logKeyAccountID, uuid.UUID(id).String())
+}
+
+func transactionIDAttr(id domain.TransactionID) slog.Attr {
+	return slog.String(logKeyTransactionID, uuid.UUID(id).String())
+}
+
+func moneyAttrs(money domain.Money) []slog.Attr {
+	return []slog.Attr{
+		slog.String(logKeyAmount, money.Amount().String()),
+		slog.String(logKeyCurrency, string(money.Currency())),
+	}
+}
+
+// logFailure records a failed operation with its whole wrapped error chain.
+func (s *Service) logFailure(ctx context.Context, msg string, err error, attrs ...slog.Attr) {
+	attrs = append(attrs, slog.String(logKeyError, err.Error()))
+	s.logger.LogAttrs(ctx, slog.LevelError, msg, attrs...)
+}
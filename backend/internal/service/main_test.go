@@ -61,6 +61,18 @@ func applyMigrations(ctx context.Context, pool *pgxpool.Pool) error {
 	migrations := []string{
 		"000001_init_tables.up.sql",
 		"000002_cashbook.up.sql",
+		"000003_account_closed.up.sql",
+		"000004_exchange_rate_precision.up.sql",
+		"000005_gbp_currency.up.sql",
+		"000006_gbp_cashbook.up.sql",
+		"000007_account_status.up.sql",
+		"000008_users_email_lower_unique.up.sql",
+		"000009_idempotency_keys.up.sql",
+		"000010_account_daily_limit.up.sql",
+		"000011_transactions_timestamp_id_index.up.sql",
+		"000012_exchange_rate_history.up.sql",
+		"000013_account_label.up.sql",
+		"000014_idempotency_key_request.up.sql",
 	}
 
 	for _, migrationFile := range migrations {
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"minibankingplatform/internal/domain"
+)
+
+// SetMaintenanceMode turns maintenance mode on or off. It is safe to call
+// while requests are being served.
+func (s *Service) SetMaintenanceMode(enabled bool) {
+	s.maintenance.Store(enabled)
+}
+
+// InMaintenanceMode reports whether money-moving operations are currently blocked.
+func (s *Service) InMaintenanceMode() bool {
+	return s.maintenance.Load()
+}
+
+// checkMaintenance fails with *domain.MaintenanceModeError while maintenance
+// mode is on. Every operation that moves money calls it, most of them through
+// acquireMoneyOperation.
+func (s *Service) checkMaintenance() error {
+	if !s.InMaintenanceMode() {
+		return nil
+	}
+
+	retryAfter := s.config.MaintenanceRetryAfter
+	if retryAfter <= 0 {
+		retryAfter = DefaultMaintenanceRetryAfter
+	}
+
+	return domain.NewMaintenanceModeError(retryAfter)
+}
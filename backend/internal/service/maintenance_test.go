@@ -0,0 +1,91 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceMode_BlocksMoneyOperations(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool, withConfig(service.Config{MaintenanceRetryAfter: 90 * time.Second}))
+	user := registerTestUser(ctx, t, svc, testPool)
+	amount, _ := domain.NewMoney(decimal.NewFromInt(10), domain.CurrencyUSD)
+
+	// Arrange
+	svc.SetMaintenanceMode(true)
+
+	operations := map[string]func() error{
+		"register": func() error {
+			_, err := svc.Register(ctx, &service.RegisterCommand{Email: "maintenance@test.com", Password: "testpassword123"})
+			return err
+		},
+		"transfer": func() error {
+			_, err := svc.Transfer(ctx, &service.TransferCommand{
+				UserID:   domain.UserID(user.UserID),
+				From:     domain.AccountID(user.USDAccountID),
+				To:       domain.CashbookUSD,
+				Amount:   amount.Amount(),
+				Currency: amount.Currency(),
+				Time:     time.Now(),
+			})
+			return err
+		},
+		"exchange": func() error {
+			_, err := svc.Exchange(ctx, &service.ExchangeCommand{
+				UserID:        domain.UserID(user.UserID),
+				SourceAccount: domain.AccountID(user.USDAccountID),
+				TargetAccount: domain.AccountID(user.EURAccountID),
+				SourceAmount:  amount,
+				Time:          time.Now(),
+			})
+			return err
+		},
+		"withdraw": func() error {
+			_, err := svc.Withdraw(ctx, &service.CashCommand{
+				Account: domain.AccountID(user.USDAccountID),
+				Amount:  decimal.NewFromInt(10),
+				Time:    time.Now(),
+			})
+			return err
+		},
+		"sweep": func() error {
+			_, err := svc.SweepAccount(ctx, domain.AccountID(user.USDAccountID), domain.CashbookUSD)
+			return err
+		},
+	}
+
+	for name, operation := range operations {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			err := operation()
+
+			// Assert
+			var maintenanceErr *domain.MaintenanceModeError
+			require.ErrorAs(t, err, &maintenanceErr)
+			assert.Equal(t, 90*time.Second, maintenanceErr.RetryAfter)
+		})
+	}
+
+	// Reads keep working, and money moves again once maintenance is over
+	_, err := svc.GetUserAccounts(ctx, domain.UserID(user.UserID), false)
+	require.NoError(t, err)
+
+	svc.SetMaintenanceMode(false)
+	_, err = svc.Withdraw(ctx, &service.CashCommand{
+		Account: domain.AccountID(user.USDAccountID),
+		Amount:  decimal.NewFromInt(10),
+		Time:    time.Now(),
+	})
+	require.NoError(t, err)
+	assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(990))
+}
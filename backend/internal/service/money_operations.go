@@ -0,0 +1,26 @@
+package service
+
+import (
+	"minibankingplatform/internal/domain"
+)
+
+// acquireMoneyOperation takes a slot for a money operation, to be given back
+// with the returned release function once the operation's transaction is over.
+// It fails with *domain.MaintenanceModeError in maintenance mode, and with
+// *domain.TooManyRequestsError instead of waiting when every slot is taken.
+func (s *Service) acquireMoneyOperation() (release func(), err error) {
+	if err := s.checkMaintenance(); err != nil {
+		return nil, err
+	}
+
+	if s.moneyOperations == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case s.moneyOperations <- struct{}{}:
+		return func() { <-s.moneyOperations }, nil
+	default:
+		return nil, domain.NewTooManyRequestsError(MoneyOperationRetryAfter)
+	}
+}
@@ -0,0 +1,82 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransfer_RejectsOperationsBeyondConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool, withServiceOptions(service.WithMaxMoneyOperations(1)))
+
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+	otherUser := registerTestUser(ctx, t, svc, testPool)
+
+	// Arrange - hold the source account's row lock so the first transfer
+	// keeps its slot until the lock is released
+	lock, err := testPool.Begin(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = lock.Rollback(context.Background()) })
+
+	_, err = lock.Exec(ctx, `SELECT 1 FROM accounts WHERE id = $1 FOR UPDATE`, fromUser.USDAccountID)
+	require.NoError(t, err)
+
+	first := make(chan error, 1)
+	go func() {
+		_, err := svc.Transfer(ctx, &service.TransferCommand{
+			UserID:   domain.UserID(fromUser.UserID),
+			From:     domain.AccountID(fromUser.USDAccountID),
+			To:       domain.AccountID(toUser.USDAccountID),
+			Amount:   decimal.NewFromInt(100),
+			Currency: domain.CurrencyUSD,
+			Time:     time.Now(),
+		})
+		first <- err
+	}()
+
+	// Act - a second transfer, of more than the account holds so that it can't
+	// go through even if it ran before the first one took the slot
+	var tooManyRequestsErr *domain.TooManyRequestsError
+	require.Eventually(t, func() bool {
+		_, err := svc.Transfer(ctx, &service.TransferCommand{
+			UserID:   domain.UserID(otherUser.UserID),
+			From:     domain.AccountID(otherUser.USDAccountID),
+			To:       domain.AccountID(toUser.USDAccountID),
+			Amount:   decimal.NewFromInt(5000),
+			Currency: domain.CurrencyUSD,
+			Time:     time.Now(),
+		})
+		return errors.As(err, &tooManyRequestsErr)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Withdrawals take the same slots, so with the first transfer still
+	// holding the only one, an overdrawing withdrawal is turned away as well
+	_, withdrawErr := svc.Withdraw(ctx, &service.CashCommand{
+		Account: domain.AccountID(otherUser.USDAccountID),
+		Amount:  decimal.NewFromInt(5000),
+		Time:    time.Now(),
+	})
+
+	require.NoError(t, lock.Rollback(ctx))
+
+	// Assert - the second transfer was turned away, the first went through
+	assert.Equal(t, service.MoneyOperationRetryAfter, tooManyRequestsErr.RetryAfter)
+	assert.ErrorAs(t, withdrawErr, &tooManyRequestsErr)
+
+	require.NoError(t, <-first)
+	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.NewFromInt(900))
+	assertBalanceEquals(t, ctx, testPool, otherUser.USDAccountID, decimal.NewFromInt(1000))
+	assertLedgerBalanced(ctx, t, svc)
+}
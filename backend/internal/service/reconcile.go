@@ -2,19 +2,26 @@ package service
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
+	"io"
 	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/infrastructure"
+	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
 type ReconciliationReport struct {
-	Timestamp            time.Time
-	IsConsistent         bool
-	LedgerBalances       []LedgerCurrencyStatus
-	AccountMismatches    []AccountMismatch
-	TotalAccountsChecked int
+	Timestamp                time.Time
+	IsConsistent             bool
+	LedgerBalances           []LedgerCurrencyStatus
+	AccountMismatches        []AccountMismatch
+	LedgerCurrencyMismatches []LedgerCurrencyMismatch
+	DetailMismatches         []TransactionDetailMismatch
+	TotalAccountsChecked     int
 }
 
 type LedgerCurrencyStatus struct {
@@ -31,6 +38,23 @@ type AccountMismatch struct {
 	Difference     decimal.Decimal
 }
 
+type LedgerCurrencyMismatch struct {
+	RecordID        domain.LedgerRecordID
+	TransactionID   domain.TransactionID
+	AccountID       domain.AccountID
+	LedgerCurrency  domain.Currency
+	AccountCurrency domain.Currency
+}
+
+// TransactionDetailMismatch is a transaction missing its transfer or exchange
+// details, having more than one, or having details of the wrong kind.
+type TransactionDetailMismatch struct {
+	TransactionID   domain.TransactionID
+	Type            domain.TransactionType
+	TransferDetails int
+	ExchangeDetails int
+}
+
 func (s *Service) CheckLedgerBalanceByCurrency(ctx context.Context) error {
 	totals, err := s.ledger.GetTotalBalanceByCurrency(ctx)
 	if err != nil {
@@ -75,9 +99,26 @@ func (s *Service) CheckAllAccountBalances(ctx context.Context) error {
 	return nil
 }
 
+// CheckTransactionDetailIntegrity returns the transactions whose detail rows
+// don't match their type. Transaction lists show them marked as having their
+// details unavailable and log them; fetching one of them alone fails.
+func (s *Service) CheckTransactionDetailIntegrity(ctx context.Context) ([]TransactionDetailMismatch, error) {
+	mismatches, err := s.transactions.GetDetailMismatches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting transaction detail mismatches: %w", err)
+	}
+
+	result := make([]TransactionDetailMismatch, len(mismatches))
+	for i, m := range mismatches {
+		result[i] = TransactionDetailMismatch(m)
+	}
+
+	return result, nil
+}
+
 func (s *Service) Reconcile(ctx context.Context) (*ReconciliationReport, error) {
 	report := &ReconciliationReport{
-		Timestamp:    time.Now(),
+		Timestamp:    s.now().UTC(),
 		IsConsistent: true,
 	}
 
@@ -116,6 +157,24 @@ func (s *Service) Reconcile(ctx context.Context) (*ReconciliationReport, error)
 		report.IsConsistent = false
 	}
 
+	currencyMismatches, err := s.ledger.GetLedgerCurrencyMismatches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting ledger currency mismatches: %w", err)
+	}
+
+	for _, m := range currencyMismatches {
+		report.LedgerCurrencyMismatches = append(report.LedgerCurrencyMismatches, LedgerCurrencyMismatch(m))
+		report.IsConsistent = false
+	}
+
+	report.DetailMismatches, err = s.CheckTransactionDetailIntegrity(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("checking transaction detail integrity: %w", err)
+	}
+	if len(report.DetailMismatches) > 0 {
+		report.IsConsistent = false
+	}
+
 	accountsCount, err := s.accounts.Count(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("counting accounts: %w", err)
@@ -124,3 +183,63 @@ func (s *Service) Reconcile(ctx context.Context) (*ReconciliationReport, error)
 
 	return report, nil
 }
+
+// reconcileExportHeader is the first row of the ReconcileExport CSV.
+var reconcileExportHeader = []string{
+	"account_id",
+	"currency",
+	"account_balance",
+	"ledger_balance",
+	"difference",
+	"consistent",
+}
+
+// ReconcileExport returns a CSV comparing the balance of every account, not
+// just the mismatching ones, with the sum of its ledger records. The CSV is
+// produced while the reader is consumed; a failure midway, including a
+// cancelled ctx, surfaces as a read error. The reader must be drained, or ctx
+// cancelled, to release the database connection.
+func (s *Service) ReconcileExport(ctx context.Context) io.Reader {
+	pr, pw := io.Pipe()
+
+	go func() {
+		stop := context.AfterFunc(ctx, func() {
+			pw.CloseWithError(ctx.Err())
+		})
+		defer stop()
+
+		pw.CloseWithError(s.writeReconcileExport(ctx, pw))
+	}()
+
+	return pr
+}
+
+func (s *Service) writeReconcileExport(ctx context.Context, w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(reconcileExportHeader); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+
+	err := s.ledger.ForEachAccountLedgerBalance(ctx, func(b infrastructure.AccountLedgerBalance) error {
+		difference := b.AccountBalance.Sub(b.LedgerBalance)
+		return cw.Write([]string{
+			uuid.UUID(b.AccountID).String(),
+			string(b.Currency),
+			b.AccountBalance.String(),
+			b.LedgerBalance.String(),
+			difference.String(),
+			strconv.FormatBool(difference.IsZero()),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("writing account rows: %w", err)
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("flushing export: %w", err)
+	}
+
+	return nil
+}
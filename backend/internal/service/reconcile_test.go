@@ -2,12 +2,14 @@ package service_test
 
 import (
 	"context"
+	"encoding/csv"
 	"testing"
 	"time"
 
 	"minibankingplatform/internal/domain"
 	"minibankingplatform/internal/service"
 
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,17 +21,26 @@ func TestReconcile_ConsistentSystem(t *testing.T) {
 
 	svc := setupService(t, testPool)
 
-	// Arrange: register two users (each gets 1000 USD and 500 EUR)
+	// Arrange: register two users (each gets 1000 USD, 500 EUR and 300 GBP)
 	user1 := registerTestUser(ctx, t, svc, testPool)
 	user2 := registerTestUser(ctx, t, svc, testPool)
 
-	// Perform a transfer
-	transferAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
-	err := svc.Transfer(ctx, &service.TransferCommand{
-		From:  domain.AccountID(user1.USDAccountID),
-		To:    domain.AccountID(user2.USDAccountID),
-		Money: transferAmount,
-		Time:  time.Now(),
+	// Perform a USD and a GBP transfer
+	_, err := svc.Transfer(ctx, &service.TransferCommand{
+		From:     domain.AccountID(user1.USDAccountID),
+		To:       domain.AccountID(user2.USDAccountID),
+		Amount:   decimal.NewFromInt(100),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
+	})
+	require.NoError(t, err)
+
+	_, err = svc.Transfer(ctx, &service.TransferCommand{
+		From:     domain.AccountID(user2.GBPAccountID),
+		To:       domain.AccountID(user1.GBPAccountID),
+		Amount:   decimal.NewFromInt(50),
+		Currency: domain.CurrencyGBP,
+		Time:     time.Now(),
 	})
 	require.NoError(t, err)
 
@@ -43,11 +54,11 @@ func TestReconcile_ConsistentSystem(t *testing.T) {
 	assert.True(t, report.IsConsistent, "system should be consistent after valid transfers")
 	assert.NotZero(t, report.Timestamp)
 	assert.Empty(t, report.AccountMismatches, "should have no account mismatches")
-	assert.GreaterOrEqual(t, report.TotalAccountsChecked, 4, "should have checked at least the test accounts (2 users * 2 accounts)")
+	assert.GreaterOrEqual(t, report.TotalAccountsChecked, 6, "should have checked at least the test accounts (2 users * 3 accounts)")
 
 	// Verify ledger balances - should all be zero
+	assertCurrencyLedgersBalanced(t, report, domain.CurrencyUSD, domain.CurrencyEUR, domain.CurrencyGBP)
 	for _, balance := range report.LedgerBalances {
-		assert.True(t, balance.IsBalanced, "currency %s should be balanced", balance.Currency)
 		assert.True(t, balance.TotalSum.IsZero(), "currency %s total should be zero", balance.Currency)
 	}
 
@@ -69,6 +80,9 @@ func TestReconcile_EmptySystem(t *testing.T) {
 
 	assert.True(t, report.IsConsistent, "empty system should be consistent")
 	assert.Empty(t, report.AccountMismatches)
+	for _, balance := range report.LedgerBalances {
+		assert.True(t, balance.IsBalanced, "currency %s should be balanced", balance.Currency)
+	}
 }
 
 func TestReconcile_AfterExchange(t *testing.T) {
@@ -77,12 +91,13 @@ func TestReconcile_AfterExchange(t *testing.T) {
 
 	svc := setupService(t, testPool)
 
-	// Arrange: register user (gets 1000 USD and 500 EUR)
+	// Arrange: register user (gets 1000 USD, 500 EUR and 300 GBP)
 	user := registerTestUser(ctx, t, svc, testPool)
 
-	// Perform an exchange
+	// Perform a USD->EUR and an EUR->GBP exchange
 	exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
-	err := svc.Exchange(ctx, &service.ExchangeCommand{
+	_, err := svc.Exchange(ctx, &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
 		SourceAccount: domain.AccountID(user.USDAccountID),
 		TargetAccount: domain.AccountID(user.EURAccountID),
 		SourceAmount:  exchangeAmount,
@@ -90,6 +105,16 @@ func TestReconcile_AfterExchange(t *testing.T) {
 	})
 	require.NoError(t, err)
 
+	eurAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyEUR)
+	_, err = svc.Exchange(ctx, &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
+		SourceAccount: domain.AccountID(user.EURAccountID),
+		TargetAccount: domain.AccountID(user.GBPAccountID),
+		SourceAmount:  eurAmount,
+		Time:          time.Now(),
+	})
+	require.NoError(t, err)
+
 	// Act
 	report, err := svc.Reconcile(ctx)
 
@@ -100,10 +125,8 @@ func TestReconcile_AfterExchange(t *testing.T) {
 	assert.True(t, report.IsConsistent, "system should be consistent after valid exchange")
 	assert.Empty(t, report.AccountMismatches)
 
-	// Both USD and EUR ledgers should be balanced
-	for _, balance := range report.LedgerBalances {
-		assert.True(t, balance.IsBalanced, "currency %s should be balanced after exchange", balance.Currency)
-	}
+	// Every currency's ledger should be balanced
+	assertCurrencyLedgersBalanced(t, report, domain.CurrencyUSD, domain.CurrencyEUR, domain.CurrencyGBP)
 }
 
 func TestReconcile_MultipleTransfers(t *testing.T) {
@@ -112,7 +135,7 @@ func TestReconcile_MultipleTransfers(t *testing.T) {
 
 	svc := setupService(t, testPool)
 
-	// Arrange: register three users (each gets 1000 USD and 500 EUR)
+	// Arrange: register three users (each gets 1000 USD, 500 EUR and 300 GBP)
 	user1 := registerTestUser(ctx, t, svc, testPool)
 	user2 := registerTestUser(ctx, t, svc, testPool)
 	user3 := registerTestUser(ctx, t, svc, testPool)
@@ -130,12 +153,12 @@ func TestReconcile_MultipleTransfers(t *testing.T) {
 	}
 
 	for _, tr := range transfers {
-		money, _ := domain.NewMoney(decimal.NewFromInt(tr.amount), domain.CurrencyUSD)
-		err := svc.Transfer(ctx, &service.TransferCommand{
-			From:  tr.from,
-			To:    tr.to,
-			Money: money,
-			Time:  time.Now(),
+		_, err := svc.Transfer(ctx, &service.TransferCommand{
+			From:     tr.from,
+			To:       tr.to,
+			Amount:   decimal.NewFromInt(tr.amount),
+			Currency: domain.CurrencyUSD,
+			Time:     time.Now(),
 		})
 		require.NoError(t, err)
 	}
@@ -149,7 +172,8 @@ func TestReconcile_MultipleTransfers(t *testing.T) {
 
 	assert.True(t, report.IsConsistent, "system should remain consistent after multiple transfers")
 	assert.Empty(t, report.AccountMismatches)
-	assert.GreaterOrEqual(t, report.TotalAccountsChecked, 6, "should have checked at least 3 users * 2 accounts")
+	assert.GreaterOrEqual(t, report.TotalAccountsChecked, 9, "should have checked at least 3 users * 3 accounts")
+	assertCurrencyLedgersBalanced(t, report, domain.CurrencyUSD, domain.CurrencyEUR, domain.CurrencyGBP)
 }
 
 func TestReconcile_ReportContainsTimestamp(t *testing.T) {
@@ -174,3 +198,227 @@ func TestReconcile_ReportContainsTimestamp(t *testing.T) {
 	assert.True(t, report.Timestamp.Before(afterReconcile) || report.Timestamp.Equal(afterReconcile),
 		"timestamp should be before or equal to after time")
 }
+
+// TestReconcile_CrossCurrencyLedgerAnomaly deliberately corrupts the ledger, so it
+// does not run in parallel with tests that expect a consistent system.
+func TestReconcile_CrossCurrencyLedgerAnomaly(t *testing.T) {
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange: register user (gets 1000 USD, 500 EUR and 300 GBP)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Move 10 units of each account's ledger into the other currency. Per-currency
+	// totals stay at zero and a currency-agnostic sum per account still matches
+	// the balance, hiding the anomaly.
+	transactionID := uuid.New()
+	_, err := testPool.Exec(ctx,
+		`INSERT INTO transactions (id, type, account_id, timestamp) VALUES ($1, 'transfer', $2, NOW())`,
+		transactionID, user.USDAccountID)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := testPool.Exec(context.Background(), `DELETE FROM transactions WHERE id = $1`, transactionID)
+		require.NoError(t, err)
+	})
+
+	_, err = testPool.Exec(ctx, `
+		INSERT INTO ledger (id, transaction, account, amount, currency, timestamp) VALUES
+			(gen_random_uuid(), $1, $2, -10, 'USD', NOW()),
+			(gen_random_uuid(), $1, $2,  10, 'EUR', NOW()),
+			(gen_random_uuid(), $1, $3,  10, 'USD', NOW()),
+			(gen_random_uuid(), $1, $3, -10, 'EUR', NOW())`,
+		transactionID, user.USDAccountID, user.EURAccountID)
+	require.NoError(t, err)
+
+	// Act
+	report, err := svc.Reconcile(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, report.IsConsistent)
+
+	mismatches := make(map[domain.AccountID]service.AccountMismatch)
+	for _, m := range report.AccountMismatches {
+		mismatches[m.AccountID] = m
+	}
+
+	usdMismatch, ok := mismatches[domain.AccountID(user.USDAccountID)]
+	require.True(t, ok, "USD account mismatch should be detected")
+	assert.Equal(t, domain.CurrencyUSD, usdMismatch.Currency)
+	assert.True(t, usdMismatch.AccountBalance.Equal(decimal.NewFromInt(1000)))
+	assert.True(t, usdMismatch.LedgerBalance.Equal(decimal.NewFromInt(990)))
+
+	eurMismatch, ok := mismatches[domain.AccountID(user.EURAccountID)]
+	require.True(t, ok, "EUR account mismatch should be detected")
+	assert.Equal(t, domain.CurrencyEUR, eurMismatch.Currency)
+	assert.True(t, eurMismatch.AccountBalance.Equal(decimal.NewFromInt(500)))
+	assert.True(t, eurMismatch.LedgerBalance.Equal(decimal.NewFromInt(490)))
+
+	_, ok = mismatches[domain.AccountID(user.GBPAccountID)]
+	assert.False(t, ok, "untouched GBP account should not be flagged")
+	assertCurrencyLedgersBalanced(t, report, domain.CurrencyUSD, domain.CurrencyEUR, domain.CurrencyGBP)
+}
+
+// TestReconcile_LedgerCurrencyMismatch stores ledger rows in the wrong currency,
+// so it does not run in parallel with tests that expect a consistent system.
+func TestReconcile_LedgerCurrencyMismatch(t *testing.T) {
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Arrange: book a balanced EUR pair on the USD account, bypassing the
+	// repository guard. Totals and account balances are unaffected.
+	transactionID := uuid.New()
+	_, err := testPool.Exec(ctx,
+		`INSERT INTO transactions (id, type, account_id, timestamp) VALUES ($1, 'transfer', $2, NOW())`,
+		transactionID, user.USDAccountID)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := testPool.Exec(context.Background(), `DELETE FROM transactions WHERE id = $1`, transactionID)
+		require.NoError(t, err)
+	})
+
+	creditID, debitID := uuid.New(), uuid.New()
+	_, err = testPool.Exec(ctx, `
+		INSERT INTO ledger (id, transaction, account, amount, currency, timestamp) VALUES
+			($1, $3, $4,  10, 'EUR', NOW()),
+			($2, $3, $4, -10, 'EUR', NOW())`,
+		creditID, debitID, transactionID, user.USDAccountID)
+	require.NoError(t, err)
+
+	// Act
+	report, err := svc.Reconcile(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, report.IsConsistent)
+	assert.Empty(t, report.AccountMismatches)
+
+	flagged := make(map[domain.LedgerRecordID]service.LedgerCurrencyMismatch)
+	for _, m := range report.LedgerCurrencyMismatches {
+		flagged[m.RecordID] = m
+	}
+	require.Len(t, flagged, 2)
+
+	for _, recordID := range []uuid.UUID{creditID, debitID} {
+		m, ok := flagged[domain.LedgerRecordID(recordID)]
+		require.True(t, ok, "ledger record %s should be flagged", recordID)
+		assert.Equal(t, domain.TransactionID(transactionID), m.TransactionID)
+		assert.Equal(t, domain.AccountID(user.USDAccountID), m.AccountID)
+		assert.Equal(t, domain.CurrencyEUR, m.LedgerCurrency)
+		assert.Equal(t, domain.CurrencyUSD, m.AccountCurrency)
+	}
+	assertCurrencyLedgersBalanced(t, report, domain.CurrencyUSD, domain.CurrencyEUR, domain.CurrencyGBP)
+}
+
+// assertCurrencyLedgersBalanced checks that the report covers each currency and
+// that its ledger sums to zero.
+func assertCurrencyLedgersBalanced(t *testing.T, report *service.ReconciliationReport, currencies ...domain.Currency) {
+	t.Helper()
+
+	statuses := make(map[domain.Currency]service.LedgerCurrencyStatus, len(report.LedgerBalances))
+	for _, status := range report.LedgerBalances {
+		statuses[status.Currency] = status
+	}
+
+	for _, currency := range currencies {
+		status, ok := statuses[currency]
+		if assert.True(t, ok, "report should cover the %s ledger", currency) {
+			assert.True(t, status.IsBalanced, "currency %s should be balanced", currency)
+		}
+	}
+}
+
+// Not parallel: the export has to see the same set of accounts as the count.
+func TestReconcileExport_CoversEveryAccount(t *testing.T) {
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Act
+	records, err := csv.NewReader(svc.ReconcileExport(ctx)).ReadAll()
+
+	// Assert
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+	assert.Equal(t, []string{"account_id", "currency", "account_balance", "ledger_balance", "difference", "consistent"}, records[0])
+
+	var accountsCount int
+	err = testPool.QueryRow(ctx, `SELECT COUNT(*) FROM accounts`).Scan(&accountsCount)
+	require.NoError(t, err)
+	assert.Len(t, records[1:], accountsCount, "every account should have exactly one row")
+
+	rows := make(map[string][]string, len(records)-1)
+	for _, record := range records[1:] {
+		rows[record[0]] = record
+	}
+
+	usdRow, ok := rows[user.USDAccountID.String()]
+	require.True(t, ok, "matching accounts should be exported too")
+	assert.Equal(t, "USD", usdRow[1])
+	assert.True(t, decimal.RequireFromString(usdRow[2]).Equal(decimal.NewFromInt(1000)))
+	assert.True(t, decimal.RequireFromString(usdRow[3]).Equal(decimal.NewFromInt(1000)))
+	assert.True(t, decimal.RequireFromString(usdRow[4]).IsZero())
+	assert.Equal(t, "true", usdRow[5])
+}
+
+// TestCheckTransactionDetailIntegrity_FlagsMismatches stores transactions whose
+// details don't match their type, so it does not run in parallel with tests
+// that expect a consistent system.
+func TestCheckTransactionDetailIntegrity_FlagsMismatches(t *testing.T) {
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Arrange: a transfer without transfer details and an exchange carrying
+	// transfer details instead of exchange details
+	orphanID, mistypedID := uuid.New(), uuid.New()
+	_, err := testPool.Exec(ctx, `
+		INSERT INTO transactions (id, type, account_id, timestamp) VALUES
+			($1, 'transfer', $3, NOW()),
+			($2, 'exchange', $3, NOW())`,
+		orphanID, mistypedID, user.USDAccountID)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := testPool.Exec(context.Background(), `DELETE FROM transactions WHERE id IN ($1, $2)`, orphanID, mistypedID)
+		require.NoError(t, err)
+	})
+
+	_, err = testPool.Exec(ctx,
+		`INSERT INTO transfer_details (transaction_id, recipient_account_id, amount, currency) VALUES ($1, $2, 10, 'USD')`,
+		mistypedID, user.USDAccountID)
+	require.NoError(t, err)
+
+	// Act
+	mismatches, err := svc.CheckTransactionDetailIntegrity(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, mismatches, 2, "consistent transactions, e.g. registration transfers, are not flagged")
+
+	flagged := make(map[domain.TransactionID]service.TransactionDetailMismatch)
+	for _, m := range mismatches {
+		flagged[m.TransactionID] = m
+	}
+
+	orphan, ok := flagged[domain.TransactionID(orphanID)]
+	require.True(t, ok, "transfer without details should be flagged")
+	assert.Equal(t, domain.TransactionTypeTransfer, orphan.Type)
+	assert.Equal(t, 0, orphan.TransferDetails)
+	assert.Equal(t, 0, orphan.ExchangeDetails)
+
+	mistyped, ok := flagged[domain.TransactionID(mistypedID)]
+	require.True(t, ok, "exchange with transfer details should be flagged")
+	assert.Equal(t, domain.TransactionTypeExchange, mistyped.Type)
+	assert.Equal(t, 1, mistyped.TransferDetails)
+	assert.Equal(t, 0, mistyped.ExchangeDetails)
+
+	report, err := svc.Reconcile(ctx)
+	require.NoError(t, err)
+	assert.False(t, report.IsConsistent)
+	assert.Len(t, report.DetailMismatches, len(mismatches))
+}
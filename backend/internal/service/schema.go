@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"minibankingplatform/internal/domain"
+)
+
+// NumericScaleSource reports the declared scale of numeric database columns.
+type NumericScaleSource interface {
+	GetNumericScale(ctx context.Context, table, column string) (int32, error)
+}
+
+// MoneyColumn identifies a database column that stores money amounts.
+type MoneyColumn struct {
+	Table  string
+	Column string
+}
+
+// MoneyColumns lists every column holding balances or amounts.
+var MoneyColumns = []MoneyColumn{
+	{Table: "accounts", Column: "balance"},
+	{Table: "accounts", Column: "daily_limit"},
+	{Table: "transfer_details", Column: "amount"},
+	{Table: "exchange_details", Column: "source_amount"},
+	{Table: "exchange_details", Column: "target_amount"},
+	{Table: "ledger", Column: "amount"},
+}
+
+type ColumnScaleMismatchError struct {
+	Column   MoneyColumn
+	Scale    int32
+	Expected int32
+}
+
+func (err ColumnScaleMismatchError) Error() string {
+	return fmt.Sprintf(
+		"column %s.%s has numeric scale %d, but currencies need %d decimal places",
+		err.Column.Table, err.Column.Column, err.Scale, err.Expected,
+	)
+}
+
+// CheckMoneyColumnScales verifies that every money column can store amounts
+// with as many decimal places as the most precise supported currency. A
+// narrower column would make the database silently round amounts; a wider one
+// (like the ledger's) is accepted.
+func CheckMoneyColumnScales(ctx context.Context, source NumericScaleSource) error {
+	expected := maxCurrencyDecimals()
+
+	for _, column := range MoneyColumns {
+		scale, err := source.GetNumericScale(ctx, column.Table, column.Column)
+		if err != nil {
+			return fmt.Errorf("getting numeric scale: %w", err)
+		}
+
+		if scale < expected {
+			return &ColumnScaleMismatchError{Column: column, Scale: scale, Expected: expected}
+		}
+	}
+
+	return nil
+}
+
+func maxCurrencyDecimals() int32 {
+	var decimals int32
+	for _, currency := range domain.CurrencyValues() {
+		decimals = max(decimals, currency.MinorUnitDecimals())
+	}
+
+	return decimals
+}
@@ -0,0 +1,95 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"minibankingplatform/internal/infrastructure"
+	"minibankingplatform/internal/service"
+	"minibankingplatform/pkg/trm"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubScaleSource returns the configured scale for every column, with per-column overrides.
+type stubScaleSource struct {
+	scale     int32
+	overrides map[service.MoneyColumn]int32
+	err       error
+}
+
+func (s stubScaleSource) GetNumericScale(_ context.Context, table, column string) (int32, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	if scale, ok := s.overrides[service.MoneyColumn{Table: table, Column: column}]; ok {
+		return scale, nil
+	}
+	return s.scale, nil
+}
+
+func TestCheckMoneyColumnScales(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		source    stubScaleSource
+		wantErr   bool
+		wantScale int32
+	}{
+		{
+			name:   "matching scales",
+			source: stubScaleSource{scale: 2},
+		},
+		{
+			name:   "wider scale is accepted",
+			source: stubScaleSource{scale: 2, overrides: map[service.MoneyColumn]int32{{Table: "ledger", Column: "amount"}: 4}},
+		},
+		{
+			name:      "narrower scale is rejected",
+			source:    stubScaleSource{scale: 2, overrides: map[service.MoneyColumn]int32{{Table: "accounts", Column: "balance"}: 0}},
+			wantErr:   true,
+			wantScale: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			err := service.CheckMoneyColumnScales(context.Background(), tt.source)
+
+			// Assert
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			var mismatchErr *service.ColumnScaleMismatchError
+			require.ErrorAs(t, err, &mismatchErr)
+			assert.Equal(t, tt.wantScale, mismatchErr.Scale)
+			assert.Equal(t, int32(2), mismatchErr.Expected)
+		})
+	}
+}
+
+func TestCheckMoneyColumnScales_SourceError(t *testing.T) {
+	t.Parallel()
+
+	err := service.CheckMoneyColumnScales(context.Background(), stubScaleSource{err: errors.New("connection refused")})
+
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestCheckMoneyColumnScales_MigratedSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := infrastructure.NewSchemaRepository(trm.NewInjector[infrastructure.DBTX](testPool))
+
+	err := service.CheckMoneyColumnScales(context.Background(), schema)
+
+	assert.NoError(t, err)
+}
@@ -1,17 +1,28 @@
 package service
 
 import (
+	"log/slog"
+	"sync/atomic"
+	"time"
+
 	"minibankingplatform/internal/domain"
 	"minibankingplatform/internal/infrastructure"
 	jwtpkg "minibankingplatform/pkg/jwt"
 	"minibankingplatform/pkg/trm"
 
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 type Service struct {
-	transfer domain.TransferService
-	exchange domain.ExchangeService
+	transfer   domain.TransferService
+	exchange   domain.ExchangeService
+	deposit    domain.DepositService
+	withdrawal domain.WithdrawalService
 
 	trm *trm.TransactionManager[pgx.Tx, pgx.TxOptions]
 
@@ -21,32 +32,168 @@ type Service struct {
 	exchanges            *infrastructure.ExchangesRepository
 	transactions         *infrastructure.TransactionsRepository
 	ledger               *infrastructure.LedgerRepository
+	health               *infrastructure.HealthRepository
+	idempotencyKeys      *infrastructure.IdempotencyKeysRepository
+	exchangeRateHistory  *infrastructure.ExchangeRateHistoryRepository
 	exchangeRateProvider domain.ExchangeRateProvider
-	tokenManager         *jwtpkg.TokenManager
+	tokenManager         TokenManager
+	config               Config
+	now                  func() time.Time
+	logger               *slog.Logger
+	tracer               trace.Tracer
+
+	maintenance atomic.Bool
+
+	// moneyOperations holds a slot per running money operation, when
+	// Config.MaxMoneyOperations limits them.
+	moneyOperations chan struct{}
+}
+
+// Repositories groups the persistence dependencies of the Service.
+type Repositories struct {
+	Users               *infrastructure.UsersRepository
+	Accounts            *infrastructure.AccountsRepository
+	Transfers           *infrastructure.TransfersRepository
+	Exchanges           *infrastructure.ExchangesRepository
+	Transactions        *infrastructure.TransactionsRepository
+	Ledger              *infrastructure.LedgerRepository
+	Health              *infrastructure.HealthRepository
+	IdempotencyKeys     *infrastructure.IdempotencyKeysRepository
+	ExchangeRateHistory *infrastructure.ExchangeRateHistoryRepository
+}
+
+// TokenGenerator issues the tokens handed out on registration, login and
+// refresh, and signs purpose-bound tokens such as exchange quotes.
+type TokenGenerator interface {
+	GenerateToken(userID uuid.UUID, email string) (string, error)
+	// RefreshToken exchanges a token the generator still accepts for a fresh one.
+	RefreshToken(tokenString string) (string, error)
+	SignClaims(purpose string, claims jwt.Claims) (string, error)
+}
+
+// TokenValidator checks tokens issued by a TokenGenerator.
+type TokenValidator interface {
+	ValidateToken(tokenString string) (*jwtpkg.Claims, error)
+	// ParseClaims verifies a token signed for purpose and decodes it into claims.
+	ParseClaims(purpose string, tokenString string, claims jwt.Claims) error
+}
+
+// TokenManager issues and validates tokens. *jwt.TokenManager implements it.
+type TokenManager interface {
+	TokenGenerator
+	TokenValidator
+}
+
+// ServiceOption customizes a Service built by NewService.
+type ServiceOption func(*Service)
+
+// WithConfig sets the business policies of the Service. The zero Config is
+// used when omitted. Options apply in order and WithConfig replaces the whole
+// Config, so the options setting single policies must come after it.
+func WithConfig(config Config) ServiceOption {
+	return func(s *Service) {
+		s.config = config
+	}
+}
+
+// WithExchangeSpread sets Config.ExchangeSpread, the fee the platform keeps on
+// every exchange.
+func WithExchangeSpread(spread decimal.Decimal) ServiceOption {
+	return func(s *Service) {
+		s.config.ExchangeSpread = spread
+	}
+}
+
+// WithExchangeRoundingBias sets Config.ExchangeRoundingBias.
+func WithExchangeRoundingBias(bias domain.RoundingBias) ServiceOption {
+	return func(s *Service) {
+		s.config.ExchangeRoundingBias = bias
+	}
+}
+
+// WithSubUnitPolicy sets Config.SubUnitPolicy.
+func WithSubUnitPolicy(policy domain.SubUnitPolicy) ServiceOption {
+	return func(s *Service) {
+		s.config.SubUnitPolicy = policy
+	}
+}
+
+// WithMaxMoneyOperations sets Config.MaxMoneyOperations.
+func WithMaxMoneyOperations(limit int) ServiceOption {
+	return func(s *Service) {
+		s.config.MaxMoneyOperations = limit
+	}
+}
+
+// WithFundingAccounts sets Config.FundingAccounts, the accounts new users are
+// funded from.
+func WithFundingAccounts(accounts map[domain.Currency]domain.AccountID) ServiceOption {
+	return func(s *Service) {
+		s.config.FundingAccounts = accounts
+	}
+}
+
+// WithClock replaces time.Now as the source of the current time for operations
+// that stamp their own time, such as registration and reconciliation.
+func WithClock(now func() time.Time) ServiceOption {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+// WithLogger sets the logger of completed and failed operations. Logs are
+// discarded when omitted.
+func WithLogger(logger *slog.Logger) ServiceOption {
+	return func(s *Service) {
+		s.logger = logger
+	}
+}
+
+// WithTracerProvider sets the provider of the spans recorded for transfers,
+// exchanges, registrations and transaction lists. No spans are recorded when
+// omitted.
+func WithTracerProvider(provider trace.TracerProvider) ServiceOption {
+	return func(s *Service) {
+		s.tracer = provider.Tracer(tracerName)
+	}
 }
 
 func NewService(
 	trm *trm.TransactionManager[pgx.Tx, pgx.TxOptions],
-	users *infrastructure.UsersRepository,
-	accounts *infrastructure.AccountsRepository,
-	transfers *infrastructure.TransfersRepository,
-	exchanges *infrastructure.ExchangesRepository,
-	transactions *infrastructure.TransactionsRepository,
-	ledger *infrastructure.LedgerRepository,
+	repositories Repositories,
 	exchangeRateProvider domain.ExchangeRateProvider,
-	tokenManager *jwtpkg.TokenManager,
+	tokenManager TokenManager,
+	opts ...ServiceOption,
 ) *Service {
-	return &Service{
+	s := &Service{
 		transfer:             domain.TransferService{},
 		exchange:             domain.ExchangeService{},
+		deposit:              domain.DepositService{},
+		withdrawal:           domain.WithdrawalService{},
 		trm:                  trm,
-		users:                users,
-		accounts:             accounts,
-		transfers:            transfers,
-		exchanges:            exchanges,
-		transactions:         transactions,
-		ledger:               ledger,
+		users:                repositories.Users,
+		accounts:             repositories.Accounts,
+		transfers:            repositories.Transfers,
+		exchanges:            repositories.Exchanges,
+		transactions:         repositories.Transactions,
+		ledger:               repositories.Ledger,
+		health:               repositories.Health,
+		idempotencyKeys:      repositories.IdempotencyKeys,
+		exchangeRateHistory:  repositories.ExchangeRateHistory,
 		exchangeRateProvider: exchangeRateProvider,
 		tokenManager:         tokenManager,
+		now:                  time.Now,
+		logger:               slog.New(slog.DiscardHandler),
+		tracer:               noop.NewTracerProvider().Tracer(tracerName),
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if limit := s.config.MaxMoneyOperations; limit > 0 {
+		s.moneyOperations = make(chan struct{}, limit)
+	}
+
+	return s
 }
@@ -0,0 +1,44 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewService_Defaults(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	assert.Equal(t, service.FallbackPageSize, svc.DefaultPageSize())
+
+	before := time.Now()
+	report, err := svc.Reconcile(ctx)
+	require.NoError(t, err)
+	assert.WithinRange(t, report.Timestamp, before.Add(-time.Second), time.Now().Add(time.Second))
+}
+
+func TestNewService_Options(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	fixed := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	svc := setupService(t, testPool, withServiceOptions(
+		service.WithConfig(service.Config{DefaultPageSize: 7}),
+		service.WithClock(func() time.Time { return fixed }),
+	))
+
+	assert.Equal(t, 7, svc.DefaultPageSize())
+
+	report, err := svc.Reconcile(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, fixed.UTC(), report.Timestamp)
+	assert.Equal(t, time.UTC, report.Timestamp.Location())
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+
+	"minibankingplatform/internal/domain"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans of the service layer.
+const tracerName = "minibankingplatform/internal/service"
+
+// Span attribute keys shared by the traced operations.
+const (
+	spanKeyUserID      = "user.id"
+	spanKeyAccountFrom = "account.from"
+	spanKeyAccountTo   = "account.to"
+)
+
+// startSpan starts the span of a service operation as a child of the span in
+// ctx. Repository queries run with the returned context nest below it.
+func (s *Service) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan marks the span as failed when err is set and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func userIDSpanAttr(id domain.UserID) attribute.KeyValue {
+	return attribute.String(spanKeyUserID, uuid.UUID(id).String())
+}
+
+func accountSpanAttr(key string, id domain.AccountID) attribute.KeyValue {
+	return attribute.String(key, uuid.UUID(id).String())
+}
@@ -0,0 +1,62 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTransfer_RecordsSpans(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	svc := setupService(t, testPool, withTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))))
+
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	cmd := &service.TransferCommand{
+		UserID:   domain.UserID(fromUser.UserID),
+		From:     domain.AccountID(fromUser.USDAccountID),
+		To:       domain.AccountID(toUser.USDAccountID),
+		Amount:   decimal.NewFromInt(100),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
+	}
+
+	// Act
+	_, err := svc.Transfer(ctx, cmd)
+
+	// Assert
+	require.NoError(t, err)
+
+	var transferSpan sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == "service.Transfer" {
+			transferSpan = span
+		}
+	}
+	require.NotNil(t, transferSpan, "service.Transfer should record a span")
+	assert.Contains(t, transferSpan.Attributes(), attribute.String("account.from", fromUser.USDAccountID.String()))
+	assert.Contains(t, transferSpan.Attributes(), attribute.String("account.to", toUser.USDAccountID.String()))
+
+	var children []string
+	for _, span := range recorder.Ended() {
+		if span.Parent().SpanID() == transferSpan.SpanContext().SpanID() {
+			children = append(children, span.Name())
+		}
+	}
+	assert.Contains(t, children, "trm.Transaction", "the transaction should run in a child span of the transfer")
+}
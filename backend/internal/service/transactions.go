@@ -5,44 +5,175 @@ import (
 	"fmt"
 	"minibankingplatform/internal/domain"
 	"minibankingplatform/internal/infrastructure"
+	"time"
 )
 
 type GetTransactionsCommand struct {
 	UserID          domain.UserID
 	TransactionType *domain.TransactionType
-	Limit           int
-	Offset          int
+	// AccountID restricts the list to transactions touching the account. The
+	// caller is responsible for checking the user owns it.
+	AccountID *domain.AccountID
+	// From and To restrict the list to transactions booked within the range,
+	// both ends inclusive. Either may be left nil for an open-ended range.
+	From *time.Time
+	To   *time.Time
+	// Limit falls back to DefaultPageSize when zero.
+	Limit  int
+	Offset int
+	// Cursor continues the list after the last transaction of a previous
+	// page. Offset is ignored when it is set.
+	Cursor *domain.TransactionCursor
+
+	// IncludeTypeCounts requests per-type counts of the whole filtered set.
+	// It is ignored when paging with a cursor.
+	IncludeTypeCounts bool
 }
 
 type TransactionsResult struct {
 	Transactions []*domain.TransactionWithDetails
-	Total        int
-	Limit        int
-	Offset       int
+	// Total is only counted for pages requested without a cursor; clients
+	// following cursors already have it from the first page.
+	Total  int
+	Limit  int
+	Offset int
+	// NextCursor points at the last listed transaction when more follow it.
+	NextCursor *domain.TransactionCursor
+
+	// TypeCounts is only set when requested with IncludeTypeCounts.
+	TypeCounts map[domain.TransactionType]int
+}
+
+// DefaultPageSize returns the page size used by paginated lists when the
+// client doesn't ask for one.
+func (s *Service) DefaultPageSize() int {
+	if s.config.DefaultPageSize > 0 {
+		return s.config.DefaultPageSize
+	}
+	return FallbackPageSize
 }
 
-func (s *Service) GetTransactions(ctx context.Context, cmd *GetTransactionsCommand) (*TransactionsResult, error) {
+func (s *Service) GetTransactions(ctx context.Context, cmd *GetTransactionsCommand) (_ *TransactionsResult, err error) {
+	ctx, span := s.startSpan(ctx, "service.GetTransactions", userIDSpanAttr(cmd.UserID))
+	defer func() { endSpan(span, err) }()
+
+	limit := cmd.Limit
+	if limit == 0 {
+		limit = s.DefaultPageSize()
+	}
+
 	filter := infrastructure.TransactionsFilter{
 		UserID:          cmd.UserID,
 		TransactionType: cmd.TransactionType,
-		Limit:           cmd.Limit,
+		AccountID:       cmd.AccountID,
+		From:            cmd.From,
+		To:              cmd.To,
+		Limit:           limit,
 		Offset:          cmd.Offset,
+		After:           cmd.Cursor,
+	}
+	if cmd.Cursor != nil {
+		filter.Offset = 0
 	}
 
-	transactions, err := s.transactions.GetList(ctx, filter)
+	// One transaction more than asked for tells whether a next page exists.
+	listFilter := filter
+	listFilter.Limit++
+	transactions, err := s.transactions.GetList(ctx, listFilter)
 	if err != nil {
 		return nil, fmt.Errorf("getting transactions list: %w", err)
 	}
 
-	total, err := s.transactions.Count(ctx, filter)
+	for _, transaction := range transactions {
+		if inconsistency := transaction.Inconsistency(); inconsistency != nil {
+			s.logFailure(ctx, "listing transaction with inconsistent details", inconsistency,
+				userIDAttr(cmd.UserID), transactionIDAttr(inconsistency.TransactionID))
+		}
+	}
+
+	var nextCursor *domain.TransactionCursor
+	if len(transactions) > limit {
+		transactions = transactions[:limit]
+		cursor := domain.NewTransactionCursor(transactions[limit-1].Transaction())
+		nextCursor = &cursor
+	}
+
+	result := &TransactionsResult{
+		Transactions: transactions,
+		Limit:        limit,
+		Offset:       filter.Offset,
+		NextCursor:   nextCursor,
+	}
+
+	// Counting scans the whole filtered set, which every cursor page would
+	// repeat for nothing.
+	if cmd.Cursor != nil {
+		return result, nil
+	}
+
+	result.Total, err = s.transactions.Count(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("counting transactions: %w", err)
 	}
 
-	return &TransactionsResult{
-		Transactions: transactions,
-		Total:        total,
-		Limit:        cmd.Limit,
-		Offset:       cmd.Offset,
-	}, nil
+	if cmd.IncludeTypeCounts {
+		result.TypeCounts, err = s.transactions.CountByType(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("counting transactions by type: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Service) GetTransactionByID(
+	ctx context.Context,
+	transactionID domain.TransactionID,
+	userID domain.UserID,
+) (*domain.TransactionWithDetails, error) {
+	transaction, err := s.transactions.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("getting transaction: %w", err)
+	}
+
+	isParticipant, err := s.transactions.IsParticipant(ctx, transactionID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("checking transaction ownership: %w", err)
+	}
+	if !isParticipant {
+		return nil, domain.NewTransactionAccessDeniedError(transactionID)
+	}
+
+	return transaction, nil
+}
+
+// GetTransactionLedger returns the ledger records booked by the transaction.
+// They are visible to the participants of the transaction and to administrators.
+func (s *Service) GetTransactionLedger(
+	ctx context.Context,
+	transactionID domain.TransactionID,
+	userID domain.UserID,
+) ([]*domain.LedgerRecord, error) {
+	// Unknown transactions are reported as such before checking access.
+	_, err := s.transactions.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("getting transaction: %w", err)
+	}
+
+	if s.RequireAdmin(userID) != nil {
+		isParticipant, err := s.transactions.IsParticipant(ctx, transactionID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("checking transaction ownership: %w", err)
+		}
+		if !isParticipant {
+			return nil, domain.NewTransactionAccessDeniedError(transactionID)
+		}
+	}
+
+	records, err := s.ledger.GetByTransaction(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("getting transaction ledger: %w", err)
+	}
+
+	return records, nil
 }
@@ -0,0 +1,726 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTransactionByID_NotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Act
+	_, err := svc.GetTransactionByID(ctx, domain.NewTransactionID(), domain.UserID(user.UserID))
+
+	// Assert
+	var notFoundErr *domain.TransactionNotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+}
+
+func TestGetTransactionByID_OwnershipCheck(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - sender transfers to recipient, outsider is not involved
+	sender := registerTestUser(ctx, t, svc, testPool)
+	recipient := registerTestUser(ctx, t, svc, testPool)
+	outsider := registerTestUser(ctx, t, svc, testPool)
+
+	_, err := svc.Transfer(ctx, &service.TransferCommand{
+		From:     domain.AccountID(sender.USDAccountID),
+		To:       domain.AccountID(recipient.USDAccountID),
+		Amount:   decimal.NewFromInt(100),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
+	})
+	require.NoError(t, err)
+
+	var transactionID uuid.UUID
+	err = testPool.QueryRow(ctx, `SELECT id FROM transactions WHERE account_id = $1`, sender.USDAccountID).Scan(&transactionID)
+	require.NoError(t, err)
+
+	// Act & Assert - both participants can see the transaction
+	for _, participant := range []*TestUserAccounts{sender, recipient} {
+		transaction, err := svc.GetTransactionByID(ctx, domain.TransactionID(transactionID), domain.UserID(participant.UserID))
+		require.NoError(t, err)
+		assert.Equal(t, domain.TransactionID(transactionID), transaction.Transaction().ID())
+	}
+
+	// Act & Assert - another user is denied rather than told it does not exist
+	_, err = svc.GetTransactionByID(ctx, domain.TransactionID(transactionID), domain.UserID(outsider.UserID))
+	var accessDeniedErr *domain.TransactionAccessDeniedError
+	assert.ErrorAs(t, err, &accessDeniedErr)
+	var notFoundErr *domain.TransactionNotFoundError
+	assert.NotErrorAs(t, err, &notFoundErr)
+}
+
+func TestOperations_ShareOneTimestamp(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - registration funds every account, then a transfer and an exchange follow
+	user := registerTestUser(ctx, t, svc, testPool)
+	recipient := registerTestUser(ctx, t, svc, testPool)
+
+	_, err := svc.Transfer(ctx, &service.TransferCommand{
+		From:     domain.AccountID(user.USDAccountID),
+		To:       domain.AccountID(recipient.USDAccountID),
+		Amount:   decimal.NewFromInt(10),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
+	})
+	require.NoError(t, err)
+
+	exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(10), domain.CurrencyUSD)
+	_, err = svc.Exchange(ctx, &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
+		SourceAccount: domain.AccountID(user.USDAccountID),
+		TargetAccount: domain.AccountID(user.EURAccountID),
+		SourceAmount:  exchangeAmount,
+		Time:          time.Now(),
+	})
+	require.NoError(t, err)
+
+	// Act - find every record whose time differs from its transaction's
+	const query = `
+		SELECT COUNT(*)
+		FROM transactions t
+		LEFT JOIN ledger l ON l.transaction = t.id
+		LEFT JOIN transfer_details td ON td.transaction_id = t.id
+		LEFT JOIN exchange_details ed ON ed.transaction_id = t.id
+		WHERE t.id IN (SELECT transaction FROM ledger WHERE account = ANY($1))
+		  AND (l.timestamp <> t.timestamp OR td.created_at <> t.timestamp OR ed.created_at <> t.timestamp)`
+
+	accounts := []uuid.UUID{user.USDAccountID, user.EURAccountID, user.GBPAccountID}
+	var mismatches int
+	err = testPool.QueryRow(ctx, query, accounts).Scan(&mismatches)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Zero(t, mismatches, "transaction, details and ledger records should share one timestamp")
+
+	var fundingTimes int
+	err = testPool.QueryRow(ctx, `
+		SELECT COUNT(DISTINCT t.timestamp)
+		FROM transactions t
+		JOIN transfer_details td ON td.transaction_id = t.id
+		WHERE td.recipient_account_id = ANY($1)
+		  AND t.account_id IN ($2, $3, $4)`,
+		accounts,
+		uuid.UUID(domain.GetCashbookAccount(domain.CurrencyUSD)),
+		uuid.UUID(domain.GetCashbookAccount(domain.CurrencyEUR)),
+		uuid.UUID(domain.GetCashbookAccount(domain.CurrencyGBP)),
+	).Scan(&fundingTimes)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fundingTimes, "all registration funding transfers should share one timestamp")
+}
+
+func TestGetTransactionByID_TimestampIsUTC(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - the transfer is submitted by a server running at UTC+05:00
+	sender := registerTestUser(ctx, t, svc, testPool)
+	recipient := registerTestUser(ctx, t, svc, testPool)
+
+	submittedAt := time.Now().In(time.FixedZone("UTC+5", 5*60*60)).Truncate(time.Microsecond)
+	result, err := svc.Transfer(ctx, &service.TransferCommand{
+		From:     domain.AccountID(sender.USDAccountID),
+		To:       domain.AccountID(recipient.USDAccountID),
+		Amount:   decimal.NewFromInt(10),
+		Currency: domain.CurrencyUSD,
+		Time:     submittedAt,
+	})
+	require.NoError(t, err)
+
+	// Act
+	transaction, err := svc.GetTransactionByID(ctx, result.TransactionID, domain.UserID(sender.UserID))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, transaction.Transaction().Time().Location())
+	assert.True(t, submittedAt.Equal(transaction.Transaction().Time()))
+}
+
+func TestGetTransactions_TypeCountsCoverAllPages(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - 3 funding transfers from registration, 3 transfers and 2 exchanges
+	user := registerTestUser(ctx, t, svc, testPool)
+	recipient := registerTestUser(ctx, t, svc, testPool)
+
+	for range 3 {
+		amount, _ := domain.NewMoney(decimal.NewFromInt(10), domain.CurrencyUSD)
+		_, err := svc.Transfer(ctx, &service.TransferCommand{
+			From:     domain.AccountID(user.USDAccountID),
+			To:       domain.AccountID(recipient.USDAccountID),
+			Amount:   amount.Amount(),
+			Currency: amount.Currency(),
+			Time:     time.Now(),
+		})
+		require.NoError(t, err)
+	}
+	for range 2 {
+		amount, _ := domain.NewMoney(decimal.NewFromInt(10), domain.CurrencyUSD)
+		_, err := svc.Exchange(ctx, &service.ExchangeCommand{
+			UserID:        domain.UserID(user.UserID),
+			SourceAccount: domain.AccountID(user.USDAccountID),
+			TargetAccount: domain.AccountID(user.EURAccountID),
+			SourceAmount:  amount,
+			Time:          time.Now(),
+		})
+		require.NoError(t, err)
+	}
+
+	// Act - walk every page, counting types of the returned items
+	const limit = 2
+	seen := make(map[domain.TransactionType]int)
+	var first *service.TransactionsResult
+	for offset := 0; ; offset += limit {
+		result, err := svc.GetTransactions(ctx, &service.GetTransactionsCommand{
+			UserID:            domain.UserID(user.UserID),
+			Limit:             limit,
+			Offset:            offset,
+			IncludeTypeCounts: true,
+		})
+		require.NoError(t, err)
+		if first == nil {
+			first = result
+		}
+		if len(result.Transactions) == 0 {
+			break
+		}
+		for _, transaction := range result.Transactions {
+			seen[transaction.Transaction().Type()]++
+		}
+	}
+
+	// Assert
+	assert.Equal(t, 6, first.TypeCounts[domain.TransactionTypeTransfer])
+	assert.Equal(t, 2, first.TypeCounts[domain.TransactionTypeExchange])
+	assert.Equal(t, seen, first.TypeCounts)
+
+	var sum int
+	for _, count := range first.TypeCounts {
+		sum += count
+	}
+	assert.Equal(t, first.Total, sum)
+}
+
+func TestGetTransactions_TypeCountsOnlyWhenRequested(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	result, err := svc.GetTransactions(ctx, &service.GetTransactionsCommand{
+		UserID: domain.UserID(user.UserID),
+		Limit:  10,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, result.TypeCounts)
+}
+
+func TestGetTransactions_ExchangeShowsQuotedRate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		source       func(*TestUserAccounts) uuid.UUID
+		target       func(*TestUserAccounts) uuid.UUID
+		amount       string
+		currency     domain.Currency
+		expectedRate string
+	}{
+		{
+			// 113.57 / 123.45 would be 0.9199676...
+			name:         "USD to EUR",
+			source:       func(u *TestUserAccounts) uuid.UUID { return u.USDAccountID },
+			target:       func(u *TestUserAccounts) uuid.UUID { return u.EURAccountID },
+			amount:       "123.45",
+			currency:     domain.CurrencyUSD,
+			expectedRate: "0.92",
+		},
+		{
+			// the inverse rate needs more precision than cents
+			name:         "EUR to USD",
+			source:       func(u *TestUserAccounts) uuid.UUID { return u.EURAccountID },
+			target:       func(u *TestUserAccounts) uuid.UUID { return u.USDAccountID },
+			amount:       "10",
+			currency:     domain.CurrencyEUR,
+			expectedRate: "1.086957",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			// Arrange
+			svc := setupService(t, testPool)
+			user := registerTestUser(ctx, t, svc, testPool)
+
+			amount, err := domain.NewMoney(decimal.RequireFromString(tt.amount), tt.currency)
+			require.NoError(t, err)
+			_, err = svc.Exchange(ctx, &service.ExchangeCommand{
+				UserID:        domain.UserID(user.UserID),
+				SourceAccount: domain.AccountID(tt.source(user)),
+				TargetAccount: domain.AccountID(tt.target(user)),
+				SourceAmount:  amount,
+				Time:          time.Now(),
+			})
+			require.NoError(t, err)
+
+			exchangeType := domain.TransactionTypeExchange
+
+			// Act
+			result, err := svc.GetTransactions(ctx, &service.GetTransactionsCommand{
+				UserID:          domain.UserID(user.UserID),
+				TransactionType: &exchangeType,
+				Limit:           10,
+			})
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, result.Transactions, 1)
+			details := result.Transactions[0].ExchangeDetails()
+			require.NotNil(t, details)
+			assert.Equal(t, tt.expectedRate, details.ExchangeRate().String())
+		})
+	}
+}
+
+func TestGetTransactions_DefaultPageSize(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange - registration funds three accounts, an exchange makes it four transactions
+	svc := setupService(t, testPool, withConfig(service.Config{DefaultPageSize: 2}))
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(10), domain.CurrencyUSD)
+	_, err := svc.Exchange(ctx, &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
+		SourceAccount: domain.AccountID(user.USDAccountID),
+		TargetAccount: domain.AccountID(user.EURAccountID),
+		SourceAmount:  exchangeAmount,
+		Time:          time.Now(),
+	})
+	require.NoError(t, err)
+
+	// Act
+	defaulted, err := svc.GetTransactions(ctx, &service.GetTransactionsCommand{UserID: domain.UserID(user.UserID)})
+	require.NoError(t, err)
+	explicit, err := svc.GetTransactions(ctx, &service.GetTransactionsCommand{UserID: domain.UserID(user.UserID), Limit: 3})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 2, svc.DefaultPageSize())
+	assert.Equal(t, 2, defaulted.Limit)
+	assert.Len(t, defaulted.Transactions, 2)
+	assert.Equal(t, 4, defaulted.Total)
+
+	assert.Equal(t, 3, explicit.Limit)
+	assert.Len(t, explicit.Transactions, 3)
+}
+
+func TestGetTransactions_CursorPagesAreStableAcrossInserts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - three funding transactions sharing one timestamp, then three
+	// exchanges, six transactions in all
+	user := registerTestUser(ctx, t, svc, testPool)
+	exchange := func() {
+		t.Helper()
+		amount, _ := domain.NewMoney(decimal.NewFromInt(10), domain.CurrencyUSD)
+		_, err := svc.Exchange(ctx, &service.ExchangeCommand{
+			UserID:        domain.UserID(user.UserID),
+			SourceAccount: domain.AccountID(user.USDAccountID),
+			TargetAccount: domain.AccountID(user.EURAccountID),
+			SourceAmount:  amount,
+			Time:          time.Now(),
+		})
+		require.NoError(t, err)
+	}
+	for range 3 {
+		exchange()
+	}
+
+	all, err := svc.GetTransactions(ctx, &service.GetTransactionsCommand{UserID: domain.UserID(user.UserID), Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, all.Transactions, 6)
+	assert.Nil(t, all.NextCursor)
+
+	// Act - take the first page, book another transaction, then follow the cursors
+	first, err := svc.GetTransactions(ctx, &service.GetTransactionsCommand{UserID: domain.UserID(user.UserID), Limit: 2})
+	require.NoError(t, err)
+	exchange()
+
+	pages := []*service.TransactionsResult{first}
+	for next := first.NextCursor; next != nil; next = pages[len(pages)-1].NextCursor {
+		require.Less(t, len(pages), 4, "paging does not end")
+
+		// Cursors survive a round trip through their string form
+		cursor, err := domain.ParseTransactionCursor(next.String())
+		require.NoError(t, err)
+
+		page, err := svc.GetTransactions(ctx, &service.GetTransactionsCommand{
+			UserID: domain.UserID(user.UserID),
+			Limit:  2,
+			Offset: 4, // ignored when paging with a cursor
+			Cursor: &cursor,
+
+			IncludeTypeCounts: true,
+		})
+		require.NoError(t, err)
+		assert.Zero(t, page.Total, "cursor pages are not counted")
+		assert.Nil(t, page.TypeCounts)
+		pages = append(pages, page)
+	}
+
+	var listed []domain.TransactionID
+	for _, page := range pages {
+		for _, transaction := range page.Transactions {
+			listed = append(listed, transaction.Transaction().ID())
+		}
+	}
+
+	// Assert - the pages hold the transactions that existed before, in order
+	expected := make([]domain.TransactionID, len(all.Transactions))
+	for i, transaction := range all.Transactions {
+		expected[i] = transaction.Transaction().ID()
+	}
+	assert.Equal(t, expected, listed)
+}
+
+func TestGetTransactions_DateRange(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - transfers booked 30, 7 and 1 days ago, on top of the 3
+	// funding transfers registration books now
+	user := registerTestUser(ctx, t, svc, testPool)
+	recipient := registerTestUser(ctx, t, svc, testPool)
+
+	now := time.Now().UTC()
+	for _, daysAgo := range []int{30, 7, 1} {
+		at := now.AddDate(0, 0, -daysAgo)
+		_, err := svc.Transfer(ctx, &service.TransferCommand{
+			From:     domain.AccountID(user.USDAccountID),
+			To:       domain.AccountID(recipient.USDAccountID),
+			Amount:   decimal.NewFromInt(10),
+			Currency: domain.CurrencyUSD,
+			Time:     at,
+		})
+		require.NoError(t, err)
+	}
+
+	list := func(from, to *time.Time) *service.TransactionsResult {
+		result, err := svc.GetTransactions(ctx, &service.GetTransactionsCommand{
+			UserID: domain.UserID(user.UserID),
+			From:   from,
+			To:     to,
+			Limit:  100,
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("closed range", func(t *testing.T) {
+		from, to := now.AddDate(0, 0, -10), now.AddDate(0, 0, -2)
+
+		result := list(&from, &to)
+
+		require.Len(t, result.Transactions, 1)
+		assert.Equal(t, 1, result.Total)
+		assert.WithinDuration(t, now.AddDate(0, 0, -7), result.Transactions[0].Transaction().Time(), time.Millisecond)
+	})
+
+	t.Run("from only", func(t *testing.T) {
+		from := now.AddDate(0, 0, -10)
+
+		result := list(&from, nil)
+
+		assert.Len(t, result.Transactions, 5)
+		assert.Equal(t, 5, result.Total)
+	})
+
+	t.Run("to only", func(t *testing.T) {
+		to := now.AddDate(0, 0, -2)
+
+		result := list(nil, &to)
+
+		assert.Len(t, result.Transactions, 2)
+		assert.Equal(t, 2, result.Total)
+	})
+
+	t.Run("bounds are inclusive", func(t *testing.T) {
+		at := now.AddDate(0, 0, -30).Truncate(time.Microsecond)
+
+		result := list(&at, &at)
+
+		assert.Len(t, result.Transactions, 1)
+	})
+
+	t.Run("no bounds", func(t *testing.T) {
+		result := list(nil, nil)
+
+		assert.Equal(t, 6, result.Total)
+	})
+}
+
+func TestGetTransactions_AccountFilter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - user A sends to user B from USD and exchanges EUR to GBP;
+	// user B makes an exchange of their own
+	userA := registerTestUser(ctx, t, svc, testPool)
+	userB := registerTestUser(ctx, t, svc, testPool)
+
+	usd, _ := domain.NewMoney(decimal.NewFromInt(25), domain.CurrencyUSD)
+	transfer, err := svc.Transfer(ctx, &service.TransferCommand{
+		From:     domain.AccountID(userA.USDAccountID),
+		To:       domain.AccountID(userB.USDAccountID),
+		Amount:   usd.Amount(),
+		Currency: usd.Currency(),
+		Time:     time.Now(),
+	})
+	require.NoError(t, err)
+
+	eur, _ := domain.NewMoney(decimal.NewFromInt(10), domain.CurrencyEUR)
+	_, err = svc.Exchange(ctx, &service.ExchangeCommand{
+		UserID:        domain.UserID(userA.UserID),
+		SourceAccount: domain.AccountID(userA.EURAccountID),
+		TargetAccount: domain.AccountID(userA.GBPAccountID),
+		SourceAmount:  eur,
+		Time:          time.Now(),
+	})
+	require.NoError(t, err)
+
+	_, err = svc.Exchange(ctx, &service.ExchangeCommand{
+		UserID:        domain.UserID(userB.UserID),
+		SourceAccount: domain.AccountID(userB.USDAccountID),
+		TargetAccount: domain.AccountID(userB.EURAccountID),
+		SourceAmount:  usd,
+		Time:          time.Now(),
+	})
+	require.NoError(t, err)
+
+	// Act
+	accountID := domain.AccountID(userA.USDAccountID)
+	result, err := svc.GetTransactions(ctx, &service.GetTransactionsCommand{
+		UserID:    domain.UserID(userA.UserID),
+		AccountID: &accountID,
+		Limit:     100,
+	})
+
+	// Assert - only the USD funding transfer and the transfer to user B, none
+	// of the exchanges
+	require.NoError(t, err)
+	require.Len(t, result.Transactions, 2)
+	assert.Equal(t, 2, result.Total)
+	assert.Equal(t, transfer.TransactionID, result.Transactions[0].Transaction().ID())
+
+	for _, transaction := range result.Transactions {
+		details := transaction.TransferDetails()
+		require.NotNil(t, details)
+		involved := []domain.AccountID{transaction.Transaction().Account(), details.RecipientAccount()}
+		assert.Contains(t, involved, accountID)
+	}
+}
+
+func TestGetTransactions_CashMovementsCarryDetails(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - deposit into and withdraw from the user's EUR account
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	deposit, err := svc.Deposit(ctx, &service.CashCommand{
+		Account: domain.AccountID(user.EURAccountID),
+		Amount:  decimal.NewFromInt(40),
+		Time:    time.Now(),
+	})
+	require.NoError(t, err)
+
+	withdrawal, err := svc.Withdraw(ctx, &service.CashCommand{
+		Account: domain.AccountID(user.EURAccountID),
+		Amount:  decimal.NewFromInt(15),
+		Time:    time.Now(),
+	})
+	require.NoError(t, err)
+
+	depositType := domain.TransactionTypeDeposit
+	withdrawalType := domain.TransactionTypeWithdrawal
+
+	// Act
+	deposits, err := svc.GetTransactions(ctx, &service.GetTransactionsCommand{
+		UserID:          domain.UserID(user.UserID),
+		TransactionType: &depositType,
+		Limit:           10,
+	})
+	require.NoError(t, err)
+
+	withdrawals, err := svc.GetTransactions(ctx, &service.GetTransactionsCommand{
+		UserID:          domain.UserID(user.UserID),
+		TransactionType: &withdrawalType,
+		Limit:           10,
+	})
+	require.NoError(t, err)
+
+	// Assert - the deposit is booked on the cashbook but still listed for the
+	// owner of the credited account, and both carry their amount
+	require.Len(t, deposits.Transactions, 1)
+	assert.Equal(t, 1, deposits.Total)
+	assert.Equal(t, deposit.TransactionID, deposits.Transactions[0].Transaction().ID())
+	depositDetails := deposits.Transactions[0].TransferDetails()
+	require.NotNil(t, depositDetails)
+	assert.Equal(t, domain.AccountID(user.EURAccountID), depositDetails.RecipientAccount())
+	assert.True(t, depositDetails.Amount().Amount().Equal(decimal.NewFromInt(40)))
+
+	require.Len(t, withdrawals.Transactions, 1)
+	assert.Equal(t, withdrawal.TransactionID, withdrawals.Transactions[0].Transaction().ID())
+	withdrawalDetails := withdrawals.Transactions[0].TransferDetails()
+	require.NotNil(t, withdrawalDetails)
+	assert.True(t, withdrawalDetails.Amount().Amount().Equal(decimal.NewFromInt(15)))
+}
+
+func TestGetTransactionByID_MatchesTransfer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	sender := registerTestUser(ctx, t, svc, testPool)
+	recipient := registerTestUser(ctx, t, svc, testPool)
+
+	at := time.Now().UTC().Truncate(time.Microsecond)
+	amount, _ := domain.NewMoney(decimal.RequireFromString("42.50"), domain.CurrencyEUR)
+	result, err := svc.Transfer(ctx, &service.TransferCommand{
+		From:     domain.AccountID(sender.EURAccountID),
+		To:       domain.AccountID(recipient.EURAccountID),
+		Amount:   amount.Amount(),
+		Currency: amount.Currency(),
+		Time:     at,
+	})
+	require.NoError(t, err)
+
+	// Act
+	transaction, err := svc.GetTransactionByID(ctx, result.TransactionID, domain.UserID(sender.UserID))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, result.TransactionID, transaction.Transaction().ID())
+	assert.Equal(t, domain.TransactionTypeTransfer, transaction.Transaction().Type())
+	assert.Equal(t, domain.AccountID(sender.EURAccountID), transaction.Transaction().Account())
+	assert.True(t, at.Equal(transaction.Transaction().Time()))
+	assert.Nil(t, transaction.ExchangeDetails())
+
+	details := transaction.TransferDetails()
+	require.NotNil(t, details)
+	assert.Equal(t, domain.AccountID(recipient.EURAccountID), details.RecipientAccount())
+	assert.True(t, details.Amount().Amount().Equal(amount.Amount()))
+	assert.Equal(t, domain.CurrencyEUR, details.Amount().Currency())
+}
+
+func TestGetTransactionLedger_ExchangeHasFourBalancedRecords(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange
+	admin := registerTestUser(ctx, t, setupService(t, testPool), testPool)
+	svc := setupService(t, testPool, withConfig(service.Config{
+		AdminUserIDs: []domain.UserID{domain.UserID(admin.UserID)},
+	}))
+	user := registerTestUser(ctx, t, svc, testPool)
+	outsider := registerTestUser(ctx, t, svc, testPool)
+
+	exchangeAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
+	result, err := svc.Exchange(ctx, &service.ExchangeCommand{
+		UserID:        domain.UserID(user.UserID),
+		SourceAccount: domain.AccountID(user.USDAccountID),
+		TargetAccount: domain.AccountID(user.EURAccountID),
+		SourceAmount:  exchangeAmount,
+		Time:          time.Now(),
+	})
+	require.NoError(t, err)
+	transactionID := result.Details.TransactionID()
+
+	// Act
+	records, err := svc.GetTransactionLedger(ctx, transactionID, domain.UserID(user.UserID))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+
+	sums := make(map[domain.Currency]decimal.Decimal)
+	accounts := make(map[domain.AccountID]bool)
+	for _, record := range records {
+		assert.Equal(t, transactionID, record.Transaction())
+		currency := record.Money().Currency()
+		sums[currency] = sums[currency].Add(record.Money().Amount())
+		accounts[record.Account()] = true
+	}
+	assert.Len(t, sums, 2)
+	for currency, sum := range sums {
+		assert.True(t, sum.IsZero(), "%s records sum to %s", currency, sum)
+	}
+	assert.True(t, accounts[domain.AccountID(user.USDAccountID)])
+	assert.True(t, accounts[domain.AccountID(user.EURAccountID)])
+
+	// Administrators see the records too, other users don't
+	_, err = svc.GetTransactionLedger(ctx, transactionID, domain.UserID(admin.UserID))
+	assert.NoError(t, err)
+
+	_, err = svc.GetTransactionLedger(ctx, transactionID, domain.UserID(outsider.UserID))
+	var accessDeniedErr *domain.TransactionAccessDeniedError
+	assert.ErrorAs(t, err, &accessDeniedErr)
+}
+
+func TestGetTransactionLedger_UnknownTransaction(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Act
+	_, err := svc.GetTransactionLedger(ctx, domain.NewTransactionID(), domain.UserID(user.UserID))
+
+	// Assert
+	var notFoundErr *domain.TransactionNotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+}
@@ -1,9 +1,12 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"log/slog"
 	"minibankingplatform/internal/domain"
+	"minibankingplatform/pkg/trm"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,12 +14,18 @@ import (
 )
 
 type TransferCommand struct {
-	From  domain.AccountID
-	To    domain.AccountID
-	Money domain.Money
-	Time  time.Time
+	UserID domain.UserID
+	From   domain.AccountID
+	To     domain.AccountID
+	Amount decimal.Decimal
+	// Currency may be left empty, the source account's currency is used then.
+	Currency domain.Currency
+	Time     time.Time
 }
 
+// NewTransferCommand builds a transfer command. The currency may be empty, the
+// transfer is then made in the source account's currency. Amount and currency
+// are normalized, so " 10.00 " and " usd " are accepted.
 func NewTransferCommand(
 	from uuid.UUID,
 	to uuid.UUID,
@@ -24,42 +33,95 @@ func NewTransferCommand(
 	rawCurrency string,
 	time time.Time,
 ) (*TransferCommand, error) {
-	decimalAmount, err := decimal.NewFromString(amount)
+	decimalAmount, err := domain.ParseAmount(domain.NormalizeAmount(amount))
 	if err != nil {
 		return nil, fmt.Errorf("invalid amount: %w", err)
 	}
 
-	currency, err := domain.ParseCurrency(rawCurrency)
+	cmd := &TransferCommand{
+		From:   domain.AccountID(from),
+		To:     domain.AccountID(to),
+		Amount: decimalAmount,
+		Time:   time,
+	}
+
+	if domain.NormalizeCurrencyCode(rawCurrency) != "" {
+		cmd.Currency, err = domain.ParseSupportedCurrency(rawCurrency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid currency: %w", err)
+		}
+	}
+
+	return cmd, nil
+}
+
+// TransferResult identifies the transaction created by a transfer and the
+// money it moved, in the resolved currency and after the sub-unit policy.
+type TransferResult struct {
+	TransactionID domain.TransactionID
+	Money         domain.Money
+}
+
+func (s *Service) Transfer(ctx context.Context, cmd *TransferCommand) (_ *TransferResult, err error) {
+	ctx, span := s.startSpan(ctx, "service.Transfer",
+		userIDSpanAttr(cmd.UserID),
+		accountSpanAttr(spanKeyAccountFrom, cmd.From),
+		accountSpanAttr(spanKeyAccountTo, cmd.To),
+	)
+	defer func() {
+		if err != nil {
+			s.logFailure(ctx, "transfer failed", err, userIDAttr(cmd.UserID), accountIDAttr(cmd.From))
+		}
+		endSpan(span, err)
+	}()
+
+	money, err := s.transferMoney(ctx, cmd)
 	if err != nil {
-		return nil, fmt.Errorf("invalid currency: %w", err)
+		return nil, err
 	}
 
-	money, err := domain.NewMoney(decimalAmount, currency)
+	if registry := s.config.InFlightTransfers; registry != nil && !isDryRun(ctx) {
+		key := transferInFlightKey(cmd.UserID, cmd.From, cmd.To, money)
+		if !registry.TryAcquire(key) {
+			return nil, domain.NewDuplicateTransferInProgressError(cmd.From, cmd.To)
+		}
+		defer func() {
+			if err != nil {
+				registry.Release(key, false)
+				return
+			}
+			// The transfer only succeeded once it is durable. A transaction
+			// it joined may still roll back, and then it can be retried.
+			trm.AfterCommit(ctx, func() { registry.Release(key, true) })
+			trm.AfterRollback(ctx, func() { registry.Release(key, false) })
+		}()
+	}
+
+	release, err := s.acquireMoneyOperation()
 	if err != nil {
-		return nil, fmt.Errorf("cannot get money value: %w", err)
+		return nil, err
 	}
+	defer release()
 
-	return &TransferCommand{
-		From:  domain.AccountID(from),
-		To:    domain.AccountID(to),
-		Money: money,
-		Time:  time,
-	}, nil
+	return s.executeTransfer(ctx, cmd, money)
 }
 
-func (s *Service) Transfer(ctx context.Context, cmd *TransferCommand) error {
+// executeTransfer moves money, already resolved by transferMoney, between the
+// accounts of cmd.
+func (s *Service) executeTransfer(ctx context.Context, cmd *TransferCommand, money domain.Money) (*TransferResult, error) {
+	result := TransferResult{Money: money}
 	err := s.trm.Do(ctx, func(ctx context.Context) error {
-		from, err := s.accounts.GetForUpdate(ctx, cmd.From)
+		from, to, err := s.lockTransferAccounts(ctx, cmd.From, cmd.To)
 		if err != nil {
-			return fmt.Errorf("getting 'from' account: %w", err)
+			return err
 		}
 
-		to, err := s.accounts.GetForUpdate(ctx, cmd.To)
+		err = s.checkDailyLimit(ctx, from, money, cmd.Time)
 		if err != nil {
-			return fmt.Errorf("getting 'to' account: %w", err)
+			return fmt.Errorf("checking daily limit: %w", err)
 		}
 
-		details, err := s.transfer.Execute(from, to, cmd.Money, cmd.Time)
+		details, err := s.transfer.ExecuteUserTransfer(from, to, money, cmd.Time)
 		if err != nil {
 			return fmt.Errorf("executing transfer domain service: %w", err)
 		}
@@ -68,6 +130,7 @@ func (s *Service) Transfer(ctx context.Context, cmd *TransferCommand) error {
 		if err != nil {
 			return fmt.Errorf("inserting transfer domain service: %w", err)
 		}
+		result.TransactionID = details.TransactionID()
 
 		err = s.accounts.Save(ctx, from)
 		if err != nil {
@@ -97,8 +160,97 @@ func (s *Service) Transfer(ctx context.Context, cmd *TransferCommand) error {
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("doing atomic operation: %w", err)
+		return nil, fmt.Errorf("doing atomic operation: %w", err)
+	}
+
+	s.logger.LogAttrs(ctx, slog.LevelInfo, "transfer completed",
+		append(moneyAttrs(money), userIDAttr(cmd.UserID), transactionIDAttr(result.TransactionID))...,
+	)
+
+	return &result, nil
+}
+
+// transferMoney returns the money to transfer after the sub-unit policy,
+// taking the source account's currency when the command doesn't state one.
+// Account currencies never change, so the account is read without locking it.
+func (s *Service) transferMoney(ctx context.Context, cmd *TransferCommand) (domain.Money, error) {
+	currency := cmd.Currency
+	if currency == "" {
+		from, err := s.accounts.Get(ctx, cmd.From)
+		if err != nil {
+			return domain.Money{}, fmt.Errorf("resolving transfer currency: getting 'from' account: %w", err)
+		}
+		currency = from.Balance().Currency()
+	}
+
+	money, err := domain.NewMoney(cmd.Amount, currency)
+	if err != nil {
+		return domain.Money{}, fmt.Errorf("cannot get money value: %w", err)
+	}
+
+	money, err = s.config.SubUnitPolicy.Apply(money)
+	if err != nil {
+		return domain.Money{}, fmt.Errorf("applying sub-unit policy: %w", err)
+	}
+
+	return money, nil
+}
+
+// lockTransferAccounts locks both accounts of a transfer in the order of their
+// IDs rather than from before to, so concurrent transfers in opposite
+// directions, also as part of batches, cannot deadlock on them.
+func (s *Service) lockTransferAccounts(ctx context.Context, fromID, toID domain.AccountID) (from, to *domain.Account, err error) {
+	if bytes.Compare(toID[:], fromID[:]) < 0 {
+		to, err = s.accounts.GetForUpdate(ctx, toID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting 'to' account: %w", err)
+		}
+	}
+
+	from, err = s.accounts.GetForUpdate(ctx, fromID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting 'from' account: %w", err)
+	}
+
+	if to == nil {
+		to, err = s.accounts.GetForUpdate(ctx, toID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting 'to' account: %w", err)
+		}
+	}
+
+	return from, to, nil
+}
+
+// checkDailyLimit rejects a transfer, withdrawal or exchange when it would take
+// the day's outflow of the account above its daily limit. The account must be
+// locked, so concurrent operations from it cannot both fit in the remaining
+// limit.
+func (s *Service) checkDailyLimit(ctx context.Context, from *domain.Account, money domain.Money, at time.Time) error {
+	limit := from.DailyLimit()
+	if limit == nil {
+		return nil
+	}
+
+	outflow, err := s.transfers.GetDailyOutflowByAccount(ctx, from.ID(), at)
+	if err != nil {
+		return fmt.Errorf("getting daily outflow: %w", err)
+	}
+
+	if outflow.Add(money.Amount()).GreaterThan(*limit) {
+		return domain.NewDailyLimitExceededError(from.ID(), *limit, outflow, money)
 	}
 
 	return nil
 }
+
+func transferInFlightKey(userID domain.UserID, from, to domain.AccountID, money domain.Money) string {
+	return fmt.Sprintf(
+		"transfer:%v:%v:%v:%s:%s",
+		uuid.UUID(userID),
+		uuid.UUID(from),
+		uuid.UUID(to),
+		money.Amount().String(),
+		money.Currency(),
+	)
+}
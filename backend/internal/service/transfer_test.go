@@ -1,13 +1,20 @@
 package service_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
 	"sync"
 	"testing"
 	"time"
 
 	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/infrastructure"
 	"minibankingplatform/internal/service"
+	"minibankingplatform/pkg/trm"
+	"minibankingplatform/pkg/trm/pgxfactory"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -25,16 +32,16 @@ func TestTransfer_HappyPath(t *testing.T) {
 	fromUser := registerTestUser(ctx, t, svc, testPool)
 	toUser := registerTestUser(ctx, t, svc, testPool)
 
-	transferAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
 	cmd := &service.TransferCommand{
-		From:  domain.AccountID(fromUser.USDAccountID),
-		To:    domain.AccountID(toUser.USDAccountID),
-		Money: transferAmount,
-		Time:  time.Now(),
+		From:     domain.AccountID(fromUser.USDAccountID),
+		To:       domain.AccountID(toUser.USDAccountID),
+		Amount:   decimal.NewFromInt(100),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
 	}
 
 	// Act
-	err := svc.Transfer(ctx, cmd)
+	_, err := svc.Transfer(ctx, cmd)
 
 	// Assert
 	require.NoError(t, err)
@@ -49,6 +56,50 @@ func TestTransfer_HappyPath(t *testing.T) {
 	assertLedgerBalanced(ctx, t, svc)
 }
 
+func TestTransfer_ReturnsCreatedTransactionID(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	cmd := &service.TransferCommand{
+		From:     domain.AccountID(fromUser.USDAccountID),
+		To:       domain.AccountID(toUser.USDAccountID),
+		Amount:   decimal.NewFromInt(100),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
+	}
+
+	// Act
+	result, err := svc.Transfer(ctx, cmd)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	rows, err := testPool.Query(ctx,
+		`SELECT account, amount FROM ledger WHERE transaction = $1`, uuid.UUID(result.TransactionID))
+	require.NoError(t, err)
+	defer rows.Close()
+
+	amounts := make(map[uuid.UUID]decimal.Decimal)
+	for rows.Next() {
+		var (
+			account uuid.UUID
+			amount  decimal.Decimal
+		)
+		require.NoError(t, rows.Scan(&account, &amount))
+		amounts[account] = amount
+	}
+	require.NoError(t, rows.Err())
+
+	require.Len(t, amounts, 2, "the transfer should have posted two ledger records")
+	assert.True(t, amounts[fromUser.USDAccountID].Equal(decimal.NewFromInt(-100)))
+	assert.True(t, amounts[toUser.USDAccountID].Equal(decimal.NewFromInt(100)))
+}
+
 func TestTransfer_ValidationErrors(t *testing.T) {
 	t.Parallel()
 
@@ -141,16 +192,16 @@ func TestTransfer_ValidationErrors(t *testing.T) {
 			initialFromBalance := getAccountBalanceOrZero(ctx, t, testPool, fromAccountID)
 			initialToBalance := getAccountBalanceOrZero(ctx, t, testPool, toAccountID)
 
-			transferAmount, _ := domain.NewMoney(tt.transferAmount, tt.transferCurrency)
 			cmd := &service.TransferCommand{
-				From:  domain.AccountID(fromAccountID),
-				To:    domain.AccountID(toAccountID),
-				Money: transferAmount,
-				Time:  time.Now(),
+				From:     domain.AccountID(fromAccountID),
+				To:       domain.AccountID(toAccountID),
+				Amount:   tt.transferAmount,
+				Currency: tt.transferCurrency,
+				Time:     time.Now(),
 			}
 
 			// Act
-			err := svc.Transfer(ctx, cmd)
+			_, err := svc.Transfer(ctx, cmd)
 
 			// Assert
 			require.Error(t, err)
@@ -175,19 +226,21 @@ func TestTransfer_ZeroAmount(t *testing.T) {
 	fromUser := registerTestUser(ctx, t, svc, testPool)
 	toUser := registerTestUser(ctx, t, svc, testPool)
 
-	transferAmount, _ := domain.NewMoney(decimal.Zero, domain.CurrencyUSD)
 	cmd := &service.TransferCommand{
-		From:  domain.AccountID(fromUser.USDAccountID),
-		To:    domain.AccountID(toUser.USDAccountID),
-		Money: transferAmount,
-		Time:  time.Now(),
+		From:     domain.AccountID(fromUser.USDAccountID),
+		To:       domain.AccountID(toUser.USDAccountID),
+		Amount:   decimal.Zero,
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
 	}
 
 	// Act - zero amount transfer should fail due to DB constraint (amount > 0)
-	err := svc.Transfer(ctx, cmd)
+	_, err := svc.Transfer(ctx, cmd)
 
-	// Assert
-	require.Error(t, err)
+	// Assert - the violated constraint surfaces as a domain error
+	var zeroAmountErr *domain.ZeroAmountError
+	require.ErrorAs(t, err, &zeroAmountErr)
+	assert.Equal(t, domain.CurrencyUSD, zeroAmountErr.Currency)
 
 	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.NewFromInt(1000))
 	assertBalanceEquals(t, ctx, testPool, toUser.USDAccountID, decimal.NewFromInt(1000))
@@ -203,19 +256,19 @@ func TestTransfer_SelfTransfer(t *testing.T) {
 
 	user := registerTestUser(ctx, t, svc, testPool)
 
-	transferAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
 	cmd := &service.TransferCommand{
-		From:  domain.AccountID(user.USDAccountID),
-		To:    domain.AccountID(user.USDAccountID),
-		Money: transferAmount,
-		Time:  time.Now(),
+		From:     domain.AccountID(user.USDAccountID),
+		To:       domain.AccountID(user.USDAccountID),
+		Amount:   decimal.NewFromInt(100),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
 	}
 
 	// Act - self transfer fails due to invariant violation
 	// The implementation creates two separate Account objects in memory.
 	// The "from" gets debited (900), the "to" gets credited (1100).
 	// The invariant check detects this: ledger sum = 1000, but account balance would be wrong.
-	err := svc.Transfer(ctx, cmd)
+	_, err := svc.Transfer(ctx, cmd)
 
 	// Assert
 	require.Error(t, err)
@@ -237,16 +290,16 @@ func TestTransfer_InsufficientFunds(t *testing.T) {
 	toUser := registerTestUser(ctx, t, svc, testPool)
 
 	// Try to transfer more than the balance (1500 USD when balance is 1000 USD)
-	transferAmount, _ := domain.NewMoney(decimal.NewFromInt(1500), domain.CurrencyUSD)
 	cmd := &service.TransferCommand{
-		From:  domain.AccountID(fromUser.USDAccountID),
-		To:    domain.AccountID(toUser.USDAccountID),
-		Money: transferAmount,
-		Time:  time.Now(),
+		From:     domain.AccountID(fromUser.USDAccountID),
+		To:       domain.AccountID(toUser.USDAccountID),
+		Amount:   decimal.NewFromInt(1500),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
 	}
 
 	// Act - should fail due to insufficient funds
-	err := svc.Transfer(ctx, cmd)
+	_, err := svc.Transfer(ctx, cmd)
 
 	// Assert
 	require.Error(t, err)
@@ -260,40 +313,266 @@ func TestTransfer_InsufficientFunds(t *testing.T) {
 	assertLedgerBalanced(ctx, t, svc)
 }
 
-func TestTransfer_DecimalPrecision(t *testing.T) {
+func TestTransfer_DailyLimit(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	tests := []struct {
+		name        string
+		amounts     []int64
+		wantBalance int64
+		wantErr     bool
+	}{
+		{name: "within limit", amounts: []int64{200, 100}, wantBalance: 700},
+		{name: "over limit", amounts: []int64{200, 101}, wantBalance: 800, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange - a limit of 300 USD a day on the sender
+			fromUser := registerTestUser(ctx, t, svc, testPool)
+			toUser := registerTestUser(ctx, t, svc, testPool)
+			_, err := testPool.Exec(ctx, `UPDATE accounts SET daily_limit = 300 WHERE id = $1`, fromUser.USDAccountID)
+			require.NoError(t, err)
+
+			// Act
+			for _, amount := range tt.amounts {
+				_, err = svc.Transfer(ctx, &service.TransferCommand{
+					UserID:   domain.UserID(fromUser.UserID),
+					From:     domain.AccountID(fromUser.USDAccountID),
+					To:       domain.AccountID(toUser.USDAccountID),
+					Amount:   decimal.NewFromInt(amount),
+					Currency: domain.CurrencyUSD,
+					Time:     time.Now(),
+				})
+				if err != nil {
+					break
+				}
+			}
+
+			// Assert
+			if tt.wantErr {
+				var limitErr *domain.DailyLimitExceededError
+				require.ErrorAs(t, err, &limitErr)
+				assert.Equal(t, domain.AccountID(fromUser.USDAccountID), limitErr.AccountID)
+				assert.True(t, limitErr.Outflow.Equal(decimal.NewFromInt(200)))
+			} else {
+				require.NoError(t, err)
+			}
+
+			assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.NewFromInt(tt.wantBalance))
+			assertLedgerBalanced(ctx, t, svc)
+		})
+	}
+}
+
+func TestTransfer_DailyLimitCountsOnlyToday(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
 
 	svc := setupService(t, testPool)
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+	_, err := testPool.Exec(ctx, `UPDATE accounts SET daily_limit = 300 WHERE id = $1`, fromUser.USDAccountID)
+	require.NoError(t, err)
 
-	// Register users - each gets 1000 USD, 500 EUR
+	cmd := func(at time.Time) *service.TransferCommand {
+		return &service.TransferCommand{
+			From:     domain.AccountID(fromUser.USDAccountID),
+			To:       domain.AccountID(toUser.USDAccountID),
+			Amount:   decimal.NewFromInt(300),
+			Currency: domain.CurrencyUSD,
+			Time:     at,
+		}
+	}
+	_, err = svc.Transfer(ctx, cmd(time.Now().Add(-24*time.Hour)))
+	require.NoError(t, err)
+
+	// Act - yesterday's transfer used up yesterday's limit only
+	_, err = svc.Transfer(ctx, cmd(time.Now()))
+
+	// Assert
+	require.NoError(t, err)
+	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.NewFromInt(400))
+}
+
+func TestTransfer_DailyLimitCountsWithdrawalsAndExchanges(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
 	fromUser := registerTestUser(ctx, t, svc, testPool)
 	toUser := registerTestUser(ctx, t, svc, testPool)
+	_, err := testPool.Exec(ctx, `UPDATE accounts SET daily_limit = 300 WHERE id = $1`, fromUser.USDAccountID)
+	require.NoError(t, err)
+
+	// Arrange - 100 USD withdrawn and 100 USD exchanged today
+	_, err = svc.Withdraw(ctx, &service.CashCommand{
+		Account: domain.AccountID(fromUser.USDAccountID),
+		Amount:  decimal.NewFromInt(100),
+		Time:    time.Now(),
+	})
+	require.NoError(t, err)
+
+	exchanged, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
+	_, err = svc.Exchange(ctx, &service.ExchangeCommand{
+		UserID:        domain.UserID(fromUser.UserID),
+		SourceAccount: domain.AccountID(fromUser.USDAccountID),
+		TargetAccount: domain.AccountID(fromUser.EURAccountID),
+		SourceAmount:  exchanged,
+		Time:          time.Now(),
+	})
+	require.NoError(t, err)
+
+	// Act
+	money, _ := domain.NewMoney(decimal.NewFromInt(101), domain.CurrencyUSD)
+	_, err = svc.Transfer(ctx, &service.TransferCommand{
+		UserID:   domain.UserID(fromUser.UserID),
+		From:     domain.AccountID(fromUser.USDAccountID),
+		To:       domain.AccountID(toUser.USDAccountID),
+		Amount:   money.Amount(),
+		Currency: money.Currency(),
+		Time:     time.Now(),
+	})
+
+	// Assert
+	var limitErr *domain.DailyLimitExceededError
+	require.ErrorAs(t, err, &limitErr)
+	assert.True(t, limitErr.Outflow.Equal(decimal.NewFromInt(200)))
+
+	_, err = svc.Withdraw(ctx, &service.CashCommand{
+		Account: domain.AccountID(fromUser.USDAccountID),
+		Amount:  decimal.NewFromInt(101),
+		Time:    time.Now(),
+	})
+	require.ErrorAs(t, err, &limitErr)
+
+	_, err = svc.Exchange(ctx, &service.ExchangeCommand{
+		UserID:        domain.UserID(fromUser.UserID),
+		SourceAccount: domain.AccountID(fromUser.USDAccountID),
+		TargetAccount: domain.AccountID(fromUser.EURAccountID),
+		SourceAmount:  money,
+		Time:          time.Now(),
+	})
+	require.ErrorAs(t, err, &limitErr)
+	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.NewFromInt(800))
+}
 
-	initialFrom := decimal.NewFromInt(1000)
-	initialTo := decimal.NewFromInt(1000)
+func TestTransfer_ToCashbookIsRejected(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - user gets 1000 USD
+	user := registerTestUser(ctx, t, svc, testPool)
 
-	transferDecimal := decimal.NewFromFloat(0.0001)
-	transferAmount, _ := domain.NewMoney(transferDecimal, domain.CurrencyUSD)
 	cmd := &service.TransferCommand{
-		From:  domain.AccountID(fromUser.USDAccountID),
-		To:    domain.AccountID(toUser.USDAccountID),
-		Money: transferAmount,
-		Time:  time.Now(),
+		From:     domain.AccountID(user.USDAccountID),
+		To:       domain.CashbookUSD,
+		Amount:   decimal.NewFromInt(100),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
 	}
 
 	// Act
-	err := svc.Transfer(ctx, cmd)
+	_, err := svc.Transfer(ctx, cmd)
 
 	// Assert
-	require.NoError(t, err)
-
-	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, initialFrom.Sub(transferDecimal))
-	assertBalanceEquals(t, ctx, testPool, toUser.USDAccountID, initialTo.Add(transferDecimal))
+	require.Error(t, err)
+	var cashbookErr *domain.CashbookTransferError
+	require.ErrorAs(t, err, &cashbookErr)
+	assert.Equal(t, domain.CashbookUSD, cashbookErr.AccountID)
 
+	assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(1000))
 	assertLedgerBalanced(ctx, t, svc)
 }
 
+func TestTransfer_DecimalPrecision(t *testing.T) {
+	t.Parallel()
+
+	// AllowSubUnit is covered by the domain tests: account balances are stored
+	// with two decimal places, so executing it here would leave the shared
+	// database with a balance that no longer matches the ledger.
+	tests := []struct {
+		name         string
+		policy       domain.SubUnitPolicy
+		amount       string
+		expectErr    bool
+		expectedDiff decimal.Decimal
+	}{
+		{
+			name:      "reject policy rejects sub-unit amount",
+			policy:    domain.RejectSubUnit,
+			amount:    "0.0001",
+			expectErr: true,
+		},
+		{
+			name:      "round policy rejects amount rounding to zero",
+			policy:    domain.RoundSubUnit,
+			amount:    "0.0001",
+			expectErr: true,
+		},
+		{
+			name:         "round policy rounds to minor unit",
+			policy:       domain.RoundSubUnit,
+			amount:       "10.005",
+			expectedDiff: decimal.RequireFromString("10.01"),
+		},
+		{
+			name:         "reject policy accepts whole minor units",
+			policy:       domain.RejectSubUnit,
+			amount:       "0.01",
+			expectedDiff: decimal.RequireFromString("0.01"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc := setupService(t, testPool, withServiceOptions(service.WithSubUnitPolicy(tt.policy)))
+
+			// Register users - each gets 1000 USD, 500 EUR
+			fromUser := registerTestUser(ctx, t, svc, testPool)
+			toUser := registerTestUser(ctx, t, svc, testPool)
+
+			initialFrom := decimal.NewFromInt(1000)
+			initialTo := decimal.NewFromInt(1000)
+
+			cmd := &service.TransferCommand{
+				From:     domain.AccountID(fromUser.USDAccountID),
+				To:       domain.AccountID(toUser.USDAccountID),
+				Amount:   decimal.RequireFromString(tt.amount),
+				Currency: domain.CurrencyUSD,
+				Time:     time.Now(),
+			}
+
+			// Act
+			_, err := svc.Transfer(ctx, cmd)
+
+			// Assert
+			if tt.expectErr {
+				var subUnitErr *domain.SubUnitAmountError
+				require.ErrorAs(t, err, &subUnitErr)
+				assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, initialFrom)
+				assertBalanceEquals(t, ctx, testPool, toUser.USDAccountID, initialTo)
+			} else {
+				require.NoError(t, err)
+				assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, initialFrom.Sub(tt.expectedDiff))
+				assertBalanceEquals(t, ctx, testPool, toUser.USDAccountID, initialTo.Add(tt.expectedDiff))
+			}
+
+			assertLedgerBalanced(ctx, t, svc)
+		})
+	}
+}
+
 func TestTransfer_Atomicity(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -307,16 +586,16 @@ func TestTransfer_Atomicity(t *testing.T) {
 	initialFromBalance := getAccountBalance(ctx, t, testPool, user.USDAccountID)
 	initialToBalance := getAccountBalance(ctx, t, testPool, user.EURAccountID)
 
-	transferAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
 	cmd := &service.TransferCommand{
-		From:  domain.AccountID(user.USDAccountID),
-		To:    domain.AccountID(user.EURAccountID),
-		Money: transferAmount,
-		Time:  time.Now(),
+		From:     domain.AccountID(user.USDAccountID),
+		To:       domain.AccountID(user.EURAccountID),
+		Amount:   decimal.NewFromInt(100),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
 	}
 
 	// Act
-	err := svc.Transfer(ctx, cmd)
+	_, err := svc.Transfer(ctx, cmd)
 
 	// Assert - verify atomicity: all data should remain unchanged
 	require.Error(t, err)
@@ -337,8 +616,6 @@ func TestTransfer_ConcurrentTransfers(t *testing.T) {
 	fromUser := registerTestUser(ctx, t, svc, testPool)
 	toUser := registerTestUser(ctx, t, svc, testPool)
 
-	transferAmount, _ := domain.NewMoney(decimal.NewFromInt(100), domain.CurrencyUSD)
-
 	// Act - run 10 concurrent transfers of 100 USD each
 	const numTransfers = 10
 	var wg sync.WaitGroup
@@ -349,12 +626,13 @@ func TestTransfer_ConcurrentTransfers(t *testing.T) {
 		go func() {
 			defer wg.Done()
 			cmd := &service.TransferCommand{
-				From:  domain.AccountID(fromUser.USDAccountID),
-				To:    domain.AccountID(toUser.USDAccountID),
-				Money: transferAmount,
-				Time:  time.Now(),
+				From:     domain.AccountID(fromUser.USDAccountID),
+				To:       domain.AccountID(toUser.USDAccountID),
+				Amount:   decimal.NewFromInt(100),
+				Currency: domain.CurrencyUSD,
+				Time:     time.Now(),
 			}
-			if err := svc.Transfer(ctx, cmd); err != nil {
+			if _, err := svc.Transfer(ctx, cmd); err != nil {
 				errors <- err
 			}
 		}()
@@ -397,21 +675,21 @@ func TestTransfer_MultipleSequentialTransfers(t *testing.T) {
 	user3 := registerTestUser(ctx, t, svc, testPool)
 
 	// Act - chain of transfers: user1 -> user2 -> user3
-	transfer1, _ := domain.NewMoney(decimal.NewFromInt(500), domain.CurrencyUSD)
-	err := svc.Transfer(ctx, &service.TransferCommand{
-		From:  domain.AccountID(user1.USDAccountID),
-		To:    domain.AccountID(user2.USDAccountID),
-		Money: transfer1,
-		Time:  time.Now(),
+	_, err := svc.Transfer(ctx, &service.TransferCommand{
+		From:     domain.AccountID(user1.USDAccountID),
+		To:       domain.AccountID(user2.USDAccountID),
+		Amount:   decimal.NewFromInt(500),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
 	})
 	require.NoError(t, err)
 
-	transfer2, _ := domain.NewMoney(decimal.NewFromInt(700), domain.CurrencyUSD)
-	err = svc.Transfer(ctx, &service.TransferCommand{
-		From:  domain.AccountID(user2.USDAccountID),
-		To:    domain.AccountID(user3.USDAccountID),
-		Money: transfer2,
-		Time:  time.Now(),
+	_, err = svc.Transfer(ctx, &service.TransferCommand{
+		From:     domain.AccountID(user2.USDAccountID),
+		To:       domain.AccountID(user3.USDAccountID),
+		Amount:   decimal.NewFromInt(700),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
 	})
 	require.NoError(t, err)
 
@@ -455,11 +733,37 @@ func TestNewTransferCommand(t *testing.T) {
 			validate: func(t *testing.T, cmd *service.TransferCommand) {
 				assert.Equal(t, domain.AccountID(from), cmd.From)
 				assert.Equal(t, domain.AccountID(to), cmd.To)
-				assert.True(t, cmd.Money.Amount().Equal(decimal.NewFromFloat(100.50)))
-				assert.Equal(t, domain.CurrencyUSD, cmd.Money.Currency())
+				assert.True(t, cmd.Amount.Equal(decimal.NewFromFloat(100.50)))
+				assert.Equal(t, domain.CurrencyUSD, cmd.Currency)
 				assert.Equal(t, now, cmd.Time)
 			},
 		},
+		{
+			name:        "padded amount and lower case currency",
+			from:        from,
+			to:          to,
+			amount:      " 100.50 ",
+			currency:    " usd ",
+			time:        now,
+			expectError: false,
+			validate: func(t *testing.T, cmd *service.TransferCommand) {
+				assert.True(t, cmd.Amount.Equal(decimal.NewFromFloat(100.50)))
+				assert.Equal(t, domain.CurrencyUSD, cmd.Currency)
+			},
+		},
+		{
+			name:        "without currency",
+			from:        from,
+			to:          to,
+			amount:      "100.50",
+			currency:    "",
+			time:        now,
+			expectError: false,
+			validate: func(t *testing.T, cmd *service.TransferCommand) {
+				assert.True(t, cmd.Amount.Equal(decimal.NewFromFloat(100.50)))
+				assert.Empty(t, cmd.Currency, "resolved from the source account by the service")
+			},
+		},
 		{
 			name:             "invalid amount - not a number",
 			from:             from,
@@ -475,12 +779,9 @@ func TestNewTransferCommand(t *testing.T) {
 			from:        from,
 			to:          to,
 			amount:      "100",
-			currency:    "GBP",
+			currency:    "JPY",
 			time:        now,
 			expectError: true,
-			validate: func(t *testing.T, cmd *service.TransferCommand) {
-				// Verify it's specifically the ErrInvalidCurrency error
-			},
 		},
 	}
 
@@ -495,8 +796,11 @@ func TestNewTransferCommand(t *testing.T) {
 				if tt.expectedErrorMsg != "" {
 					assert.Contains(t, err.Error(), tt.expectedErrorMsg)
 				}
-				if tt.currency == "GBP" {
+				if tt.currency == "JPY" {
 					assert.ErrorIs(t, err, domain.ErrInvalidCurrency)
+
+					var unsupportedErr *domain.UnsupportedCurrencyError
+					assert.ErrorAs(t, err, &unsupportedErr)
 				}
 			} else {
 				require.NoError(t, err)
@@ -508,3 +812,291 @@ func TestNewTransferCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestTransfer_DeduplicatesConcurrentIdenticalTransfers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool, withConfig(service.Config{
+		InFlightTransfers: infrastructure.NewInMemoryInFlightRegistry(time.Minute),
+	}))
+
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	// Act - fire two identical transfers at the same moment
+	const numTransfers = 2
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	results := make(chan error, numTransfers)
+
+	for i := 0; i < numTransfers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := svc.Transfer(ctx, &service.TransferCommand{
+				UserID:   domain.UserID(fromUser.UserID),
+				From:     domain.AccountID(fromUser.USDAccountID),
+				To:       domain.AccountID(toUser.USDAccountID),
+				Amount:   decimal.NewFromInt(100),
+				Currency: domain.CurrencyUSD,
+				Time:     time.Now(),
+			})
+			results <- err
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+	close(results)
+
+	// Assert - exactly one transfer executed, the other was rejected as a duplicate
+	var succeeded, duplicates int
+	for err := range results {
+		var duplicateErr *domain.DuplicateTransferInProgressError
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.As(err, &duplicateErr):
+			duplicates++
+		default:
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, 1, duplicates)
+
+	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.NewFromInt(900))
+	assertBalanceEquals(t, ctx, testPool, toUser.USDAccountID, decimal.NewFromInt(1100))
+	assert.Equal(t, 2, countLedgerRecords(ctx, t, testPool, fromUser.USDAccountID))
+
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestTransfer_CurrencyOmitted_UsesSourceAccountCurrency(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - the client sends no currency for a transfer between EUR accounts
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	cmd, err := service.NewTransferCommand(fromUser.EURAccountID, toUser.EURAccountID, "50.25", "", time.Now())
+	require.NoError(t, err)
+
+	// Act
+	result, err := svc.Transfer(ctx, cmd)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.CurrencyEUR, result.Money.Currency())
+	assert.True(t, result.Money.Amount().Equal(decimal.RequireFromString("50.25")))
+
+	var currency string
+	err = testPool.QueryRow(ctx,
+		`SELECT currency FROM transfer_details WHERE recipient_account_id = $1 AND amount = 50.25`, toUser.EURAccountID,
+	).Scan(&currency)
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", currency)
+
+	assertBalanceEquals(t, ctx, testPool, fromUser.EURAccountID, decimal.RequireFromString("449.75"))
+	assertBalanceEquals(t, ctx, testPool, toUser.EURAccountID, decimal.RequireFromString("550.25"))
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestTransfer_CurrencyDisagreesWithSourceAccount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Arrange - EUR is requested from a USD account, more than its balance
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	cmd, err := service.NewTransferCommand(fromUser.USDAccountID, toUser.USDAccountID, "5000", "EUR", time.Now())
+	require.NoError(t, err)
+
+	// Act
+	_, err = svc.Transfer(ctx, cmd)
+
+	// Assert - the mismatch is reported rather than insufficient funds
+	var mismatchErr *domain.CurrencyMismatchError
+	assert.ErrorAs(t, err, &mismatchErr)
+
+	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.NewFromInt(1000))
+}
+
+func TestTransfersRepository_RejectsLedgerCurrencyMismatch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	sender := registerTestUser(ctx, t, svc, testPool)
+	recipient := registerTestUser(ctx, t, svc, testPool)
+
+	factory, err := pgxfactory.New(ctx, testPool)
+	require.NoError(t, err)
+	transactionManager := trm.NewTransactionManager(factory)
+	transfers := infrastructure.NewTransfersRepository(trm.NewInjector[infrastructure.DBTX](testPool))
+
+	// Arrange - a EUR transfer between USD accounts that skipped the domain checks
+	eur, _ := domain.NewMoney(decimal.NewFromInt(10), domain.CurrencyEUR)
+	details, err := domain.NewTransferDetails(
+		domain.NewTransferDetailsID(),
+		domain.AccountID(sender.USDAccountID),
+		domain.AccountID(recipient.USDAccountID),
+		eur,
+		time.Now(),
+	)
+	require.NoError(t, err)
+
+	// Act
+	err = transactionManager.Do(ctx, func(ctx context.Context) error {
+		return transfers.Insert(ctx, details)
+	})
+
+	// Assert
+	var mismatchErr *domain.LedgerCurrencyMismatchError
+	require.ErrorAs(t, err, &mismatchErr)
+	assert.Equal(t, domain.AccountID(sender.USDAccountID), mismatchErr.AccountID)
+	assert.Equal(t, domain.CurrencyEUR, mismatchErr.Currency)
+
+	var count int
+	require.NoError(t, testPool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM transactions WHERE id = $1`, uuid.UUID(details.TransactionID())).Scan(&count))
+	assert.Zero(t, count, "the whole transfer should be rolled back")
+}
+
+func TestTransfer_LogsCompletedTransfer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	svc := setupService(t, testPool, withServiceOptions(service.WithLogger(logger)))
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	cmd := &service.TransferCommand{
+		UserID:   domain.UserID(fromUser.UserID),
+		From:     domain.AccountID(fromUser.USDAccountID),
+		To:       domain.AccountID(toUser.USDAccountID),
+		Amount:   decimal.NewFromInt(100),
+		Currency: domain.CurrencyUSD,
+		Time:     time.Now(),
+	}
+
+	// Act
+	result, err := svc.Transfer(ctx, cmd)
+
+	// Assert
+	require.NoError(t, err)
+
+	var records []map[string]any
+	decoder := json.NewDecoder(&logs)
+	for decoder.More() {
+		var record map[string]any
+		require.NoError(t, decoder.Decode(&record))
+		if record[slog.MessageKey] == "transfer completed" {
+			records = append(records, record)
+		}
+	}
+
+	require.Len(t, records, 1)
+	assert.Equal(t, slog.LevelInfo.String(), records[0][slog.LevelKey])
+	assert.Equal(t, uuid.UUID(result.TransactionID).String(), records[0]["transaction_id"])
+	assert.Equal(t, fromUser.UserID.String(), records[0]["user_id"])
+	assert.Equal(t, "100", records[0]["amount"])
+	assert.Equal(t, "USD", records[0]["currency"])
+}
+
+func TestTransfer_FailedTransferCanBeRetriedAtOnce(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool, withConfig(service.Config{
+		InFlightTransfers: infrastructure.NewInMemoryInFlightRegistry(time.Minute),
+	}))
+
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	transfer := func() error {
+		_, err := svc.Transfer(ctx, &service.TransferCommand{
+			UserID:   domain.UserID(fromUser.UserID),
+			From:     domain.AccountID(fromUser.USDAccountID),
+			To:       domain.AccountID(toUser.USDAccountID),
+			Amount:   decimal.NewFromInt(1500),
+			Currency: domain.CurrencyUSD,
+			Time:     time.Now(),
+		})
+		return err
+	}
+
+	// Arrange - the first attempt fails for lack of funds
+	var insufficientFundsErr *domain.InsufficientFundsError
+	require.ErrorAs(t, transfer(), &insufficientFundsErr)
+
+	_, err := svc.Deposit(ctx, &service.CashCommand{
+		Account: domain.AccountID(fromUser.USDAccountID),
+		Amount:  decimal.NewFromInt(500),
+		Time:    time.Now(),
+	})
+	require.NoError(t, err)
+
+	// Act
+	err = transfer()
+
+	// Assert
+	require.NoError(t, err)
+	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.Zero)
+
+	var duplicateErr *domain.DuplicateTransferInProgressError
+	require.ErrorAs(t, transfer(), &duplicateErr, "a transfer that went through is still deduplicated")
+}
+
+func TestTransfer_RolledBackTransferCanBeRetriedAtOnce(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool, withConfig(service.Config{
+		InFlightTransfers: infrastructure.NewInMemoryInFlightRegistry(time.Minute),
+	}))
+
+	fromUser := registerTestUser(ctx, t, svc, testPool)
+	toUser := registerTestUser(ctx, t, svc, testPool)
+
+	transfer := func(ctx context.Context) (any, error) {
+		return svc.Transfer(ctx, &service.TransferCommand{
+			UserID:   domain.UserID(fromUser.UserID),
+			From:     domain.AccountID(fromUser.USDAccountID),
+			To:       domain.AccountID(toUser.USDAccountID),
+			Amount:   decimal.NewFromInt(100),
+			Currency: domain.CurrencyUSD,
+			Time:     time.Now(),
+		})
+	}
+
+	// Arrange - the transfer goes through, the transaction it joined rolls back
+	errAfterTransfer := errors.New("storing the response failed")
+	_, err := svc.WithIdempotencyKey(ctx, domain.UserID(fromUser.UserID), uuid.NewString(), transferRequest,
+		func(ctx context.Context) (any, error) {
+			if _, err := transfer(ctx); err != nil {
+				return nil, err
+			}
+			return nil, errAfterTransfer
+		})
+	require.ErrorIs(t, err, errAfterTransfer)
+	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.NewFromInt(1000))
+
+	// Act
+	_, err = transfer(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assertBalanceEquals(t, ctx, testPool, fromUser.USDAccountID, decimal.NewFromInt(900))
+}
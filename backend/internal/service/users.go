@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"minibankingplatform/internal/domain"
+	"minibankingplatform/pkg/trm"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,16 +19,49 @@ type RegisterCommand struct {
 	Password string
 }
 
+// NewRegisterCommand builds a registration command with a normalized email.
+// The password is kept exactly as entered.
+func NewRegisterCommand(email, password string) *RegisterCommand {
+	return &RegisterCommand{
+		Email:    domain.NormalizeEmail(email),
+		Password: password,
+	}
+}
+
 type AuthResult struct {
 	UserID uuid.UUID
 	Email  string
 	Token  string
 }
 
-func (s *Service) Register(ctx context.Context, cmd *RegisterCommand) (*AuthResult, error) {
+func (s *Service) Register(ctx context.Context, cmd *RegisterCommand) (_ *AuthResult, err error) {
+	ctx, span := s.startSpan(ctx, "service.Register")
+	defer func() { endSpan(span, err) }()
+
+	err = s.checkEmailDomain(cmd.Email)
+	if err != nil {
+		return nil, fmt.Errorf("registering user: %w", err)
+	}
+
+	err = domain.ValidatePassword(cmd.Password)
+	if err != nil {
+		return nil, fmt.Errorf("registering user: %w", err)
+	}
+
+	// Registration funds the new accounts, which is a money operation.
+	if err := s.checkMaintenance(); err != nil {
+		return nil, err
+	}
+
 	var result *AuthResult
 
-	err := s.trm.Do(ctx, func(ctx context.Context) error {
+	// All funding transfers belong to the same registration and share its time.
+	now := s.now().UTC()
+	cashbooks := s.newCashbookWatch()
+
+	err = s.trm.Do(ctx, func(ctx context.Context) error {
+		trm.AfterCommit(ctx, cashbooks.notify)
+
 		exists, err := s.users.ExistsByEmail(ctx, cmd.Email)
 		if err != nil {
 			return fmt.Errorf("checking user existence: %w", err)
@@ -45,129 +81,229 @@ func (s *Service) Register(ctx context.Context, cmd *RegisterCommand) (*AuthResu
 			return fmt.Errorf("saving user: %w", err)
 		}
 
-		zeroUSD, err := domain.NewMoney(decimal.Zero, domain.CurrencyUSD)
-		if err != nil {
-			return fmt.Errorf("creating zero USD balance: %w", err)
-		}
-		usdAccount := domain.NewAccount(domain.GenerateAccountID(), userID, zeroUSD)
-		err = s.accounts.Save(ctx, usdAccount)
-		if err != nil {
-			return fmt.Errorf("saving USD account: %w", err)
+		accounts := make([]*domain.Account, 0, len(initialFunding))
+		for _, funding := range initialFunding {
+			account, err := s.openFundedAccount(ctx, userID, funding.currency, funding.amount, now, cashbooks)
+			if err != nil {
+				return err
+			}
+			accounts = append(accounts, account)
 		}
 
-		zeroEUR, err := domain.NewMoney(decimal.Zero, domain.CurrencyEUR)
-		if err != nil {
-			return fmt.Errorf("creating zero EUR balance: %w", err)
-		}
-		eurAccount := domain.NewAccount(domain.GenerateAccountID(), userID, zeroEUR)
-		err = s.accounts.Save(ctx, eurAccount)
+		err = s.CheckLedgerBalanceByCurrency(ctx)
 		if err != nil {
-			return fmt.Errorf("saving EUR account: %w", err)
+			return fmt.Errorf("checking ledger balance: %w", err)
 		}
 
-		usdCashbookID := domain.GetCashbookAccount(domain.CurrencyUSD)
-		usdCashbook, err := s.accounts.GetForUpdate(ctx, usdCashbookID)
-		if err != nil {
-			return fmt.Errorf("getting USD cashbook: %w", err)
+		for _, account := range accounts {
+			err = s.checkAccountLedgerConsistency(ctx, account)
+			if err != nil {
+				return fmt.Errorf("checking %s account ledger consistency: %w", account.Balance().Currency(), err)
+			}
 		}
 
-		initialUSD, err := domain.NewMoney(decimal.NewFromInt(1000), domain.CurrencyUSD)
+		token, err := s.tokenManager.GenerateToken(uuid.UUID(userID), cmd.Email)
 		if err != nil {
-			return fmt.Errorf("creating initial USD amount: %w", err)
+			return fmt.Errorf("generating token: %w", err)
 		}
 
-		usdTransferDetails, err := s.transfer.Execute(usdCashbook, usdAccount, initialUSD, time.Now())
-		if err != nil {
-			return fmt.Errorf("transferring initial USD: %w", err)
+		result = &AuthResult{
+			UserID: uuid.UUID(userID),
+			Email:  cmd.Email,
+			Token:  token,
 		}
 
-		err = s.transfers.Insert(ctx, usdTransferDetails)
-		if err != nil {
-			return fmt.Errorf("inserting USD transfer: %w", err)
-		}
+		return nil
+	})
+	if err != nil {
+		err = fmt.Errorf("registering user: %w", err)
+		s.logFailure(ctx, "registration failed", err)
+		return nil, err
+	}
 
-		err = s.accounts.Save(ctx, usdCashbook)
-		if err != nil {
-			return fmt.Errorf("saving USD cashbook: %w", err)
-		}
+	span.SetAttributes(userIDSpanAttr(domain.UserID(result.UserID)))
+	s.logger.LogAttrs(ctx, slog.LevelInfo, "user registered", userIDAttr(domain.UserID(result.UserID)))
 
-		err = s.accounts.Save(ctx, usdAccount)
-		if err != nil {
-			return fmt.Errorf("saving funded USD account: %w", err)
-		}
+	return result, nil
+}
 
-		eurCashbookID := domain.GetCashbookAccount(domain.CurrencyEUR)
-		eurCashbook, err := s.accounts.GetForUpdate(ctx, eurCashbookID)
-		if err != nil {
-			return fmt.Errorf("getting EUR cashbook: %w", err)
+// checkEmailDomain rejects emails whose domain is on the configured blocklist.
+func (s *Service) checkEmailDomain(email string) error {
+	emailDomain := domain.EmailDomain(email)
+	for _, blocked := range s.config.BlockedEmailDomains {
+		if strings.EqualFold(emailDomain, strings.TrimSpace(blocked)) {
+			return domain.NewBlockedEmailDomainError(emailDomain)
 		}
+	}
+	return nil
+}
 
-		initialEUR, err := domain.NewMoney(decimal.NewFromInt(500), domain.CurrencyEUR)
-		if err != nil {
-			return fmt.Errorf("creating initial EUR amount: %w", err)
-		}
+// initialFunding is what every new user receives from the funding accounts,
+// one account per currency. Registration locks the funding accounts in this
+// order, which must stay the order of domain.CurrencyValues: exchanges lock
+// cashbooks in that order too, so a registration funded from the cashbooks
+// and a concurrent exchange can't wait on each other's cashbook.
+var initialFunding = []struct {
+	currency domain.Currency
+	amount   int64
+}{
+	{currency: domain.CurrencyUSD, amount: 1000},
+	{currency: domain.CurrencyEUR, amount: 500},
+	{currency: domain.CurrencyGBP, amount: 300},
+}
 
-		eurTransferDetails, err := s.transfer.Execute(eurCashbook, eurAccount, initialEUR, time.Now())
-		if err != nil {
-			return fmt.Errorf("transferring initial EUR: %w", err)
-		}
+// openFundedAccount opens the user's account in currency and funds it from
+// the currency's funding account.
+func (s *Service) openFundedAccount(
+	ctx context.Context,
+	userID domain.UserID,
+	currency domain.Currency,
+	amount int64,
+	now time.Time,
+	cashbooks *cashbookWatch,
+) (*domain.Account, error) {
+	zero, err := domain.ZeroMoney(currency)
+	if err != nil {
+		return nil, fmt.Errorf("creating zero %s balance: %w", currency, err)
+	}
+	account := domain.NewAccount(domain.GenerateAccountID(), userID, zero)
+	err = s.accounts.Save(ctx, account)
+	if err != nil {
+		return nil, fmt.Errorf("saving %s account: %w", currency, err)
+	}
 
-		err = s.transfers.Insert(ctx, eurTransferDetails)
-		if err != nil {
-			return fmt.Errorf("inserting EUR transfer: %w", err)
-		}
+	source, err := s.accounts.GetForUpdate(ctx, s.fundingAccount(currency))
+	if err != nil {
+		return nil, fmt.Errorf("getting %s funding account: %w", currency, err)
+	}
+	if source.IsCashbook() {
+		cashbooks.add(source)
+	}
 
-		err = s.accounts.Save(ctx, eurCashbook)
-		if err != nil {
-			return fmt.Errorf("saving EUR cashbook: %w", err)
+	initial, err := domain.NewMoney(decimal.NewFromInt(amount), currency)
+	if err != nil {
+		return nil, fmt.Errorf("creating initial %s amount: %w", currency, err)
+	}
+
+	details, err := s.transfer.Execute(source, account, initial, now)
+	if err != nil {
+		return nil, fmt.Errorf("transferring initial %s: %w", currency, err)
+	}
+
+	err = s.transfers.Insert(ctx, details)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %s transfer: %w", currency, err)
+	}
+
+	err = s.accounts.Save(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("saving %s funding account: %w", currency, err)
+	}
+
+	err = s.accounts.Save(ctx, account)
+	if err != nil {
+		return nil, fmt.Errorf("saving funded %s account: %w", currency, err)
+	}
+
+	return account, nil
+}
+
+// fundingAccount returns the account new users are funded from in currency.
+func (s *Service) fundingAccount(currency domain.Currency) domain.AccountID {
+	if id, ok := s.config.FundingAccounts[currency]; ok {
+		return id
+	}
+	return domain.GetCashbookAccount(currency)
+}
+
+// CheckFundingAccounts verifies that every account of Config.FundingAccounts
+// exists and holds the currency it funds, so a misconfigured deployment fails
+// at startup rather than on the first registration.
+func (s *Service) CheckFundingAccounts(ctx context.Context) error {
+	for _, currency := range domain.CurrencyValues() {
+		id, ok := s.config.FundingAccounts[currency]
+		if !ok {
+			continue
 		}
 
-		err = s.accounts.Save(ctx, eurAccount)
+		account, err := s.accounts.Get(ctx, id)
 		if err != nil {
-			return fmt.Errorf("saving funded EUR account: %w", err)
+			return fmt.Errorf("getting %s funding account: %w", currency, err)
 		}
 
-		err = s.CheckLedgerBalanceByCurrency(ctx)
-		if err != nil {
-			return fmt.Errorf("checking ledger balance: %w", err)
+		if held := account.Balance().Currency(); held != currency {
+			return fmt.Errorf("checking %s funding account: %w", currency, domain.NewCurrencyMismatchError(currency, held))
 		}
+	}
+
+	return nil
+}
+
+type LoginCommand struct {
+	Email    string
+	Password string
+}
+
+// NewLoginCommand builds a login command with a normalized email. The password
+// is kept exactly as entered.
+func NewLoginCommand(email, password string) *LoginCommand {
+	return &LoginCommand{
+		Email:    domain.NormalizeEmail(email),
+		Password: password,
+	}
+}
 
-		err = s.checkAccountLedgerConsistency(ctx, usdAccount)
+type ChangePasswordCommand struct {
+	UserID      domain.UserID
+	OldPassword string
+	NewPassword string
+}
+
+// ChangePassword replaces the user's password. It fails with
+// *domain.InvalidCredentialsError when OldPassword is wrong and with
+// *domain.InvalidPasswordError when NewPassword breaks the password policy.
+// Tokens issued before the change stay valid until they expire.
+func (s *Service) ChangePassword(ctx context.Context, cmd *ChangePasswordCommand) (err error) {
+	defer func() {
 		if err != nil {
-			return fmt.Errorf("checking USD account ledger consistency: %w", err)
+			s.logFailure(ctx, "password change failed", err, userIDAttr(cmd.UserID))
 		}
+	}()
 
-		err = s.checkAccountLedgerConsistency(ctx, eurAccount)
+	err = s.trm.Do(ctx, func(ctx context.Context) error {
+		user, err := s.users.GetByID(ctx, cmd.UserID)
 		if err != nil {
-			return fmt.Errorf("checking EUR account ledger consistency: %w", err)
+			return fmt.Errorf("getting user: %w", err)
 		}
 
-		token, err := s.tokenManager.GenerateToken(uuid.UUID(userID), cmd.Email)
+		err = user.ChangePassword(cmd.OldPassword, cmd.NewPassword, s.now())
 		if err != nil {
-			return fmt.Errorf("generating token: %w", err)
+			return fmt.Errorf("changing password: %w", err)
 		}
 
-		result = &AuthResult{
-			UserID: uuid.UUID(userID),
-			Email:  cmd.Email,
-			Token:  token,
+		err = s.users.Save(ctx, user)
+		if err != nil {
+			return fmt.Errorf("saving user: %w", err)
 		}
 
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("registering user: %w", err)
+		return fmt.Errorf("doing atomic operation: %w", err)
 	}
 
-	return result, nil
-}
+	s.logger.LogAttrs(ctx, slog.LevelInfo, "password changed", userIDAttr(cmd.UserID))
 
-type LoginCommand struct {
-	Email    string
-	Password string
+	return nil
 }
 
-func (s *Service) Login(ctx context.Context, cmd *LoginCommand) (*AuthResult, error) {
+func (s *Service) Login(ctx context.Context, cmd *LoginCommand) (_ *AuthResult, err error) {
+	defer func() {
+		if err != nil {
+			s.logFailure(ctx, "login failed", err)
+		}
+	}()
+
 	user, err := s.users.GetByEmail(ctx, cmd.Email)
 	if err != nil {
 		var notFoundErr *domain.UserNotFoundError
@@ -186,6 +322,63 @@ func (s *Service) Login(ctx context.Context, cmd *LoginCommand) (*AuthResult, er
 		return nil, fmt.Errorf("generating token: %w", err)
 	}
 
+	s.logger.LogAttrs(ctx, slog.LevelInfo, "user logged in", userIDAttr(user.ID()))
+
+	return &AuthResult{
+		UserID: uuid.UUID(user.ID()),
+		Email:  user.Email(),
+		Token:  token,
+	}, nil
+}
+
+// RotateToken issues a fresh token with a new expiry to an already authenticated
+// user, without asking for the password again.
+func (s *Service) RotateToken(ctx context.Context, userID domain.UserID) (*AuthResult, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("getting user: %w", err)
+	}
+
+	if registry := s.config.TokenRotations; registry != nil {
+		key := uuid.UUID(userID).String()
+		if !registry.TryAcquire(key) {
+			return nil, domain.NewTokenRotationLimitError(userID)
+		}
+		registry.Release(key, true)
+	}
+
+	token, err := s.tokenManager.GenerateToken(uuid.UUID(user.ID()), user.Email())
+	if err != nil {
+		return nil, fmt.Errorf("generating token: %w", err)
+	}
+
+	return &AuthResult{
+		UserID: uuid.UUID(user.ID()),
+		Email:  user.Email(),
+		Token:  token,
+	}, nil
+}
+
+// RefreshToken exchanges a token that is still valid, or expired within the
+// refresh grace window, for a fresh one. It fails with an error wrapping
+// jwt.ErrInvalidToken for other tokens and with *domain.UserNotFoundError when
+// the user no longer exists.
+func (s *Service) RefreshToken(ctx context.Context, tokenString string) (*AuthResult, error) {
+	token, err := s.tokenManager.RefreshToken(tokenString)
+	if err != nil {
+		return nil, fmt.Errorf("refreshing token: %w", err)
+	}
+
+	claims, err := s.tokenManager.ValidateToken(token)
+	if err != nil {
+		return nil, fmt.Errorf("validating refreshed token: %w", err)
+	}
+
+	user, err := s.users.GetByID(ctx, domain.UserID(claims.UserID))
+	if err != nil {
+		return nil, fmt.Errorf("getting user: %w", err)
+	}
+
 	return &AuthResult{
 		UserID: uuid.UUID(user.ID()),
 		Email:  user.Email(),
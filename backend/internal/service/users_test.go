@@ -0,0 +1,421 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"minibankingplatform/internal/domain"
+	"minibankingplatform/internal/infrastructure"
+	"minibankingplatform/internal/service"
+	jwtpkg "minibankingplatform/pkg/jwt"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTokenManager issues unsigned tokens naming the user, so token handling
+// can be checked without JWT signing.
+type fakeTokenManager struct {
+	mu     sync.Mutex
+	issued []string
+}
+
+func (f *fakeTokenManager) GenerateToken(userID uuid.UUID, email string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	token := "fake:" + userID.String() + ":" + email
+	f.issued = append(f.issued, token)
+	return token, nil
+}
+
+func (f *fakeTokenManager) RefreshToken(string) (string, error) {
+	return "", errors.New("not supported by fake")
+}
+
+func (f *fakeTokenManager) SignClaims(string, jwt.Claims) (string, error) {
+	return "", errors.New("not supported by fake")
+}
+
+func (f *fakeTokenManager) ValidateToken(string) (*jwtpkg.Claims, error) {
+	return nil, errors.New("not supported by fake")
+}
+
+func (f *fakeTokenManager) ParseClaims(string, string, jwt.Claims) error {
+	return errors.New("not supported by fake")
+}
+
+func TestRegisterAndLogin_UseTokenManager(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tokens := &fakeTokenManager{}
+	svc := setupService(t, testPool, withTokenManager(tokens))
+	email := uuid.NewString() + "@test.com"
+
+	// Act
+	registered, err := svc.Register(ctx, &service.RegisterCommand{Email: email, Password: "testpassword123"})
+	require.NoError(t, err)
+	login, err := svc.Login(ctx, &service.LoginCommand{Email: email, Password: "testpassword123"})
+	require.NoError(t, err)
+
+	// Assert
+	expected := "fake:" + registered.UserID.String() + ":" + email
+	assert.Equal(t, expected, registered.Token)
+	assert.Equal(t, expected, login.Token)
+	assert.Equal(t, []string{expected, expected}, tokens.issued)
+}
+
+func TestRotateToken_IssuesFreshValidToken(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+	login, err := svc.Login(ctx, &service.LoginCommand{Email: user.Email, Password: "testpassword123"})
+	require.NoError(t, err)
+
+	// Act
+	rotated, err := svc.RotateToken(ctx, domain.UserID(user.UserID))
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, login.Token, rotated.Token)
+	assert.Equal(t, user.UserID, rotated.UserID)
+
+	claims, err := jwtpkg.NewTokenManager("test-secret-key", time.Hour).ValidateToken(rotated.Token)
+	require.NoError(t, err)
+	assert.Equal(t, user.UserID, claims.UserID)
+	assert.Equal(t, user.Email, claims.Email)
+}
+
+func TestRotateToken_IsRateLimited(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool, withConfig(service.Config{
+		TokenRotations: infrastructure.NewInMemoryInFlightRegistry(time.Minute),
+	}))
+	user := registerTestUser(ctx, t, svc, testPool)
+	other := registerTestUser(ctx, t, svc, testPool)
+
+	_, err := svc.RotateToken(ctx, domain.UserID(user.UserID))
+	require.NoError(t, err)
+
+	// Act
+	_, err = svc.RotateToken(ctx, domain.UserID(user.UserID))
+
+	// Assert
+	var limitErr *domain.TokenRotationLimitError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, domain.UserID(user.UserID), limitErr.UserID)
+
+	_, err = svc.RotateToken(ctx, domain.UserID(other.UserID))
+	assert.NoError(t, err, "other users are not limited")
+}
+
+func TestRotateToken_UnknownUser(t *testing.T) {
+	t.Parallel()
+
+	svc := setupService(t, testPool)
+
+	// Act
+	_, err := svc.RotateToken(context.Background(), domain.UserID(uuid.New()))
+
+	// Assert
+	var notFoundErr *domain.UserNotFoundError
+	require.ErrorAs(t, err, &notFoundErr)
+}
+
+func TestRegister_FundsAccountPerCurrency(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	// Act
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Assert
+	accounts, err := svc.GetUserAccounts(ctx, domain.UserID(user.UserID), false)
+	require.NoError(t, err)
+	require.Len(t, accounts, 3)
+
+	assertBalanceEquals(t, ctx, testPool, user.USDAccountID, decimal.NewFromInt(1000))
+	assertBalanceEquals(t, ctx, testPool, user.EURAccountID, decimal.NewFromInt(500))
+	assertBalanceEquals(t, ctx, testPool, user.GBPAccountID, decimal.NewFromInt(300))
+	assert.Equal(t, 1, countLedgerRecords(ctx, t, testPool, user.GBPAccountID))
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestRegister_FundsFromFundingAccountUntilExhausted(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange - a promotions account holding exactly one USD funding
+	promotions := registerTestUser(ctx, t, setupService(t, testPool), testPool)
+	svc := setupService(t, testPool, withServiceOptions(service.WithFundingAccounts(map[domain.Currency]domain.AccountID{
+		domain.CurrencyUSD: domain.AccountID(promotions.USDAccountID),
+	})))
+
+	// Act
+	funded := registerTestUser(ctx, t, svc, testPool)
+	_, err := svc.Register(ctx, &service.RegisterCommand{
+		Email:    uuid.NewString() + "@test.com",
+		Password: "testpassword123",
+	})
+
+	// Assert
+	assertBalanceEquals(t, ctx, testPool, funded.USDAccountID, decimal.NewFromInt(1000))
+	assertBalanceEquals(t, ctx, testPool, promotions.USDAccountID, decimal.Zero)
+
+	var insufficientErr *domain.InsufficientFundsError
+	require.ErrorAs(t, err, &insufficientErr)
+	assert.Equal(t, domain.AccountID(promotions.USDAccountID), insufficientErr.AccountID)
+	assertBalanceEquals(t, ctx, testPool, promotions.USDAccountID, decimal.Zero)
+
+	// Other currencies still come from the cashbooks
+	assertBalanceEquals(t, ctx, testPool, funded.EURAccountID, decimal.NewFromInt(500))
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestCheckFundingAccounts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	promotions := registerTestUser(ctx, t, setupService(t, testPool), testPool)
+
+	tests := []struct {
+		name     string
+		accounts map[domain.Currency]domain.AccountID
+		check    func(t *testing.T, err error)
+	}{
+		{
+			name: "existing account of the funded currency",
+			accounts: map[domain.Currency]domain.AccountID{
+				domain.CurrencyUSD: domain.AccountID(promotions.USDAccountID),
+			},
+			check: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "account of another currency",
+			accounts: map[domain.Currency]domain.AccountID{
+				domain.CurrencyUSD: domain.AccountID(promotions.EURAccountID),
+			},
+			check: func(t *testing.T, err error) {
+				var mismatchErr *domain.CurrencyMismatchError
+				assert.ErrorAs(t, err, &mismatchErr)
+			},
+		},
+		{
+			name: "unknown account",
+			accounts: map[domain.Currency]domain.AccountID{
+				domain.CurrencyGBP: domain.AccountID(uuid.New()),
+			},
+			check: func(t *testing.T, err error) {
+				var notFoundErr *domain.AccountNotFoundError
+				assert.ErrorAs(t, err, &notFoundErr)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			svc := setupService(t, testPool, withServiceOptions(service.WithFundingAccounts(tt.accounts)))
+
+			// Act
+			err := svc.CheckFundingAccounts(ctx)
+
+			// Assert
+			tt.check(t, err)
+		})
+	}
+}
+
+func TestRegister_EmailUniqueIgnoringCase(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	local := uuid.NewString()
+	emails := []string{"A" + local + "@x.com", "a" + local + "@x.com"}
+
+	// Act - register both spellings at the same time, so both pass the
+	// existence check and only the database can tell them apart
+	start := make(chan struct{})
+	errs := make([]error, len(emails))
+	var wg sync.WaitGroup
+	for i, email := range emails {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, errs[i] = svc.Register(ctx, &service.RegisterCommand{Email: email, Password: "testpassword123"})
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	// Assert
+	var succeeded int
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		var existsErr *domain.UserAlreadyExistsError
+		assert.ErrorAs(t, err, &existsErr)
+	}
+	assert.Equal(t, 1, succeeded, "exactly one registration should succeed")
+
+	var count int
+	err := testPool.QueryRow(ctx, `SELECT COUNT(*) FROM users WHERE lower(email) = lower($1)`, emails[0]).Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// Either spelling logs into the one account
+	for _, email := range emails {
+		_, err := svc.Login(ctx, &service.LoginCommand{Email: email, Password: "testpassword123"})
+		assert.NoError(t, err, email)
+	}
+	assertLedgerBalanced(ctx, t, svc)
+}
+
+func TestRegister_TrimsPaddedEmail(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange
+	svc := setupService(t, testPool)
+	email := uuid.NewString() + "@x.com"
+
+	// Act
+	result, err := svc.Register(ctx, service.NewRegisterCommand("  "+email+"\t", "testpassword123"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, email, result.Email)
+
+	_, err = svc.Login(ctx, service.NewLoginCommand(" "+email+" ", "testpassword123"))
+	assert.NoError(t, err, "a padded email should log in")
+
+	_, err = svc.Register(ctx, service.NewRegisterCommand(email, "testpassword123"))
+	var existsErr *domain.UserAlreadyExistsError
+	assert.ErrorAs(t, err, &existsErr, "the trimmed email is taken")
+}
+
+func TestRegister_BlockedEmailDomain(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Arrange
+	svc := setupService(t, testPool, withConfig(service.Config{
+		BlockedEmailDomains: []string{"mailinator.com", "TempMail.dev"},
+	}))
+
+	tests := []struct {
+		name    string
+		email   string
+		blocked string
+	}{
+		{name: "blocked domain", email: uuid.NewString() + "@mailinator.com", blocked: "mailinator.com"},
+		{name: "blocklist is case-insensitive", email: " " + uuid.NewString() + "@TEMPMAIL.dev ", blocked: "tempmail.dev"},
+		{name: "subdomain of a blocked domain passes", email: uuid.NewString() + "@eu.mailinator.com"},
+		{name: "other domain passes", email: uuid.NewString() + "@x.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := svc.Register(ctx, service.NewRegisterCommand(tt.email, "testpassword123"))
+
+			// Assert
+			if tt.blocked == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var blockedErr *domain.BlockedEmailDomainError
+			require.ErrorAs(t, err, &blockedErr)
+			assert.Equal(t, tt.blocked, blockedErr.Domain)
+
+			_, err = svc.Login(ctx, service.NewLoginCommand(tt.email, "testpassword123"))
+			assert.Error(t, err, "a rejected registration must not create the user")
+		})
+	}
+}
+
+func TestChangePassword_OldPasswordStopsWorking(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+	user := registerTestUser(ctx, t, svc, testPool)
+
+	// Act
+	err := svc.ChangePassword(ctx, &service.ChangePasswordCommand{
+		UserID:      domain.UserID(user.UserID),
+		OldPassword: "testpassword123",
+		NewPassword: "newpassword456",
+	})
+
+	// Assert
+	require.NoError(t, err)
+
+	_, err = svc.Login(ctx, &service.LoginCommand{Email: user.Email, Password: "testpassword123"})
+	var credentialsErr *domain.InvalidCredentialsError
+	require.ErrorAs(t, err, &credentialsErr)
+
+	_, err = svc.Login(ctx, &service.LoginCommand{Email: user.Email, Password: "newpassword456"})
+	assert.NoError(t, err)
+}
+
+func TestChangePassword_Rejected(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	svc := setupService(t, testPool)
+
+	tests := []struct {
+		name        string
+		oldPassword string
+		newPassword string
+		wantErr     any
+	}{
+		{name: "wrong old password", oldPassword: "wrongpassword", newPassword: "newpassword456", wantErr: new(*domain.InvalidCredentialsError)},
+		{name: "new password too short", oldPassword: "testpassword123", newPassword: "short", wantErr: new(*domain.InvalidPasswordError)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			user := registerTestUser(ctx, t, svc, testPool)
+
+			// Act
+			err := svc.ChangePassword(ctx, &service.ChangePasswordCommand{
+				UserID:      domain.UserID(user.UserID),
+				OldPassword: tt.oldPassword,
+				NewPassword: tt.newPassword,
+			})
+
+			// Assert
+			require.ErrorAs(t, err, tt.wantErr)
+
+			_, err = svc.Login(ctx, &service.LoginCommand{Email: user.Email, Password: "testpassword123"})
+			assert.NoError(t, err, "the password is unchanged")
+		})
+	}
+}
@@ -0,0 +1,2 @@
+-- Closed accounts are kept for history but hidden from regular listings.
+ALTER TABLE accounts ADD COLUMN is_closed BOOLEAN NOT NULL DEFAULT FALSE;
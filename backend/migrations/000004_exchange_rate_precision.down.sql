@@ -0,0 +1 @@
+ALTER TABLE exchange_details ALTER COLUMN exchange_rate TYPE DECIMAL(19, 2);
@@ -0,0 +1,2 @@
+-- Keep the quoted rate as used for the conversion, e.g. the 6-decimal EUR->USD inverse.
+ALTER TABLE exchange_details ALTER COLUMN exchange_rate TYPE DECIMAL(19, 10);
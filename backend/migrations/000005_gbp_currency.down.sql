@@ -0,0 +1,12 @@
+-- PostgreSQL cannot drop an enum value, so the type is rebuilt without GBP.
+-- This fails while any GBP account, transfer, exchange or ledger row remains.
+ALTER TYPE currency RENAME TO currency_with_gbp;
+CREATE TYPE currency AS ENUM ('USD', 'EUR');
+
+ALTER TABLE accounts ALTER COLUMN currency TYPE currency USING currency::text::currency;
+ALTER TABLE transfer_details ALTER COLUMN currency TYPE currency USING currency::text::currency;
+ALTER TABLE exchange_details ALTER COLUMN source_currency TYPE currency USING source_currency::text::currency;
+ALTER TABLE exchange_details ALTER COLUMN target_currency TYPE currency USING target_currency::text::currency;
+ALTER TABLE ledger ALTER COLUMN currency TYPE currency USING currency::text::currency;
+
+DROP TYPE currency_with_gbp;
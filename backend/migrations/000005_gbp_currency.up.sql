@@ -0,0 +1,3 @@
+-- GBP gets its own migration: a new enum value cannot be used in the
+-- transaction that adds it.
+ALTER TYPE currency ADD VALUE 'GBP';
@@ -0,0 +1,8 @@
+-- Cashbook account for GBP, owned by the cashbook system user
+INSERT INTO accounts (id, user_id, balance, currency)
+VALUES (
+    '00000000-0000-0000-0000-000000000012',
+    '00000000-0000-0000-0000-000000000001',
+    0,
+    'GBP'
+);
@@ -0,0 +1,6 @@
+-- Frozen accounts become active again, the closed flag has no frozen state.
+ALTER TABLE accounts ADD COLUMN is_closed BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE accounts SET is_closed = TRUE WHERE status = 'closed';
+ALTER TABLE accounts DROP COLUMN status;
+
+DROP TYPE account_status;
@@ -0,0 +1,6 @@
+-- Accounts can now be frozen as well as closed, so the closed flag becomes a status.
+CREATE TYPE account_status AS ENUM ('active', 'frozen', 'closed');
+
+ALTER TABLE accounts ADD COLUMN status account_status NOT NULL DEFAULT 'active';
+UPDATE accounts SET status = 'closed' WHERE is_closed;
+ALTER TABLE accounts DROP COLUMN is_closed;
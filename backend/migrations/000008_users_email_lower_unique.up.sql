@@ -0,0 +1,2 @@
+-- Emails are unique regardless of case, also for registrations racing each other.
+CREATE UNIQUE INDEX idx_users_email_lower ON users (lower(email));
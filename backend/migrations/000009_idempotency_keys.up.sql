@@ -0,0 +1,9 @@
+-- Responses of money-moving requests by their client supplied Idempotency-Key.
+-- Keys are scoped to the user, so one user's key never replays another's response.
+CREATE TABLE idempotency_keys (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key TEXT NOT NULL,
+    response_body JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, key)
+);
@@ -0,0 +1,3 @@
+-- Optional cap on what an account can transfer out per UTC day; NULL means unlimited.
+ALTER TABLE accounts ADD COLUMN daily_limit DECIMAL(19, 2)
+    CONSTRAINT account_daily_limit_non_negative CHECK (daily_limit >= 0);
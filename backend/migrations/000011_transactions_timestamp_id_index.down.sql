@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_transactions_timestamp_id;
+CREATE INDEX idx_transactions_timestamp ON transactions(timestamp);
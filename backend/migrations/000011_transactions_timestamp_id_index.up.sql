@@ -0,0 +1,3 @@
+-- Transaction lists are ordered and paged by (timestamp, id).
+DROP INDEX IF EXISTS idx_transactions_timestamp;
+CREATE INDEX idx_transactions_timestamp_id ON transactions(timestamp, id);
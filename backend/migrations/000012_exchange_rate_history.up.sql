@@ -0,0 +1,12 @@
+-- Rates as reported by the exchange rate provider, one row per observed change.
+CREATE TABLE exchange_rate_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    from_currency currency NOT NULL,
+    to_currency currency NOT NULL,
+    rate DECIMAL(19, 10) NOT NULL,
+    effective_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    source TEXT NOT NULL,
+    CONSTRAINT exchange_rate_history_positive_rate CHECK (rate > 0)
+);
+
+CREATE INDEX idx_exchange_rate_history_pair ON exchange_rate_history(from_currency, to_currency, effective_at);
@@ -0,0 +1,2 @@
+-- Display name users give their accounts; NULL means the account has none.
+ALTER TABLE accounts ADD COLUMN label VARCHAR(100);
@@ -0,0 +1,12 @@
+-- A key is bound to the endpoint and request it was first used with, so reusing
+-- it for another request is rejected instead of replaying the wrong response.
+-- Keys stored before have neither and can't be reused until they expire.
+ALTER TABLE idempotency_keys
+    ADD COLUMN endpoint TEXT NOT NULL DEFAULT '',
+    ADD COLUMN request_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE idempotency_keys
+    ALTER COLUMN endpoint DROP DEFAULT,
+    ALTER COLUMN request_hash DROP DEFAULT;
+
+-- Expired keys are purged by their age.
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys(created_at);
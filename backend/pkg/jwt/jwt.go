@@ -1,6 +1,9 @@
 package jwt
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"time"
 
@@ -15,18 +18,40 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
+// ErrInvalidToken is wrapped by errors of tokens that are malformed, wrongly
+// signed or expired.
+var ErrInvalidToken = errors.New("invalid token")
+
 // TokenManager handles JWT token generation and validation.
 type TokenManager struct {
 	secretKey     []byte
 	tokenDuration time.Duration
+	refreshGrace  time.Duration
+}
+
+// Option customizes a TokenManager built by NewTokenManager.
+type Option func(*TokenManager)
+
+// WithRefreshGrace lets RefreshToken accept tokens that expired at most grace
+// ago. Only still valid tokens can be refreshed when omitted.
+func WithRefreshGrace(grace time.Duration) Option {
+	return func(tm *TokenManager) {
+		tm.refreshGrace = grace
+	}
 }
 
 // NewTokenManager creates a new TokenManager with the given secret key and token duration.
-func NewTokenManager(secretKey string, tokenDuration time.Duration) *TokenManager {
-	return &TokenManager{
+func NewTokenManager(secretKey string, tokenDuration time.Duration, opts ...Option) *TokenManager {
+	tm := &TokenManager{
 		secretKey:     []byte(secretKey),
 		tokenDuration: tokenDuration,
 	}
+
+	for _, opt := range opts {
+		opt(tm)
+	}
+
+	return tm
 }
 
 // GenerateToken creates a new JWT token for the given user.
@@ -36,6 +61,7 @@ func (tm *TokenManager) GenerateToken(userID uuid.UUID, email string) (string, e
 		UserID: userID,
 		Email:  email,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			ExpiresAt: jwt.NewNumericDate(now.Add(tm.tokenDuration)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
@@ -53,21 +79,77 @@ func (tm *TokenManager) GenerateToken(userID uuid.UUID, email string) (string, e
 
 // ValidateToken validates the JWT token and returns the claims if valid.
 func (tm *TokenManager) ValidateToken(tokenString string) (*Claims, error) {
+	return tm.parseToken(tokenString)
+}
+
+// RefreshToken issues a new token with a fresh expiry for the user of a token
+// that is still valid or expired within the refresh grace window. Other tokens
+// are rejected with an error wrapping ErrInvalidToken.
+func (tm *TokenManager) RefreshToken(tokenString string) (string, error) {
+	claims, err := tm.parseToken(tokenString, jwt.WithLeeway(tm.refreshGrace))
+	if err != nil {
+		return "", err
+	}
+
+	return tm.GenerateToken(claims.UserID, claims.Email)
+}
+
+func (tm *TokenManager) parseToken(tokenString string, opts ...jwt.ParserOption) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return tm.secretKey, nil
-	})
+	}, opts...)
 
 	if err != nil {
-		return nil, fmt.Errorf("parsing token: %w", err)
+		return nil, fmt.Errorf("%w: parsing token: %w", ErrInvalidToken, err)
 	}
 
 	claims, ok := token.Claims.(*Claims)
 	if !ok || !token.Valid {
-		return nil, fmt.Errorf("invalid token claims")
+		return nil, fmt.Errorf("%w: invalid token claims", ErrInvalidToken)
 	}
 
 	return claims, nil
 }
+
+// SignClaims signs arbitrary claims with a key derived from the secret key for
+// the given purpose. Tokens signed for one purpose don't validate for another
+// purpose nor as authentication tokens.
+func (tm *TokenManager) SignClaims(purpose string, claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString(tm.purposeKey(purpose))
+	if err != nil {
+		return "", fmt.Errorf("signing %s token: %w", purpose, err)
+	}
+
+	return tokenString, nil
+}
+
+// ParseClaims validates a token created by SignClaims for the same purpose and
+// decodes its claims into claims.
+func (tm *TokenManager) ParseClaims(purpose string, tokenString string, claims jwt.Claims) error {
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return tm.purposeKey(purpose), nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("parsing %s token: %w", purpose, err)
+	}
+
+	if !token.Valid {
+		return fmt.Errorf("invalid %s token claims", purpose)
+	}
+
+	return nil
+}
+
+func (tm *TokenManager) purposeKey(purpose string) []byte {
+	mac := hmac.New(sha256.New, tm.secretKey)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
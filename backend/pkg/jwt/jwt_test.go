@@ -0,0 +1,100 @@
+package jwt_test
+
+import (
+	"testing"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"minibankingplatform/pkg/jwt"
+)
+
+const testSecret = "test-secret-key"
+
+// tokenExpiringAt signs an authentication token for userID that expires at expiresAt.
+func tokenExpiringAt(t *testing.T, userID uuid.UUID, expiresAt time.Time) string {
+	t.Helper()
+
+	issuedAt := expiresAt.Add(-time.Hour)
+	token := gojwt.NewWithClaims(gojwt.SigningMethodHS256, jwt.Claims{
+		UserID: userID,
+		Email:  "user@example.com",
+		RegisteredClaims: gojwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: gojwt.NewNumericDate(expiresAt),
+			IssuedAt:  gojwt.NewNumericDate(issuedAt),
+			NotBefore: gojwt.NewNumericDate(issuedAt),
+		},
+	})
+
+	tokenString, err := token.SignedString([]byte(testSecret))
+	require.NoError(t, err)
+
+	return tokenString
+}
+
+func TestTokenManager_RefreshToken(t *testing.T) {
+	t.Parallel()
+
+	sut := jwt.NewTokenManager(testSecret, time.Hour, jwt.WithRefreshGrace(10*time.Minute))
+	userID := uuid.New()
+
+	t.Run("valid token", func(t *testing.T) {
+		t.Parallel()
+
+		refreshed, err := sut.RefreshToken(tokenExpiringAt(t, userID, time.Now().Add(5*time.Minute)))
+		require.NoError(t, err)
+
+		claims, err := sut.ValidateToken(refreshed)
+		require.NoError(t, err)
+		assert.Equal(t, userID, claims.UserID)
+		assert.Equal(t, "user@example.com", claims.Email)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt.Time, 5*time.Second)
+	})
+
+	t.Run("expired within grace", func(t *testing.T) {
+		t.Parallel()
+
+		expired := tokenExpiringAt(t, userID, time.Now().Add(-5*time.Minute))
+		_, err := sut.ValidateToken(expired)
+		require.ErrorIs(t, err, jwt.ErrInvalidToken, "expired token must not authenticate")
+
+		refreshed, err := sut.RefreshToken(expired)
+		require.NoError(t, err)
+
+		claims, err := sut.ValidateToken(refreshed)
+		require.NoError(t, err)
+		assert.Equal(t, userID, claims.UserID)
+		assert.True(t, claims.ExpiresAt.After(time.Now()))
+	})
+
+	t.Run("expired beyond grace", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sut.RefreshToken(tokenExpiringAt(t, userID, time.Now().Add(-15*time.Minute)))
+		assert.ErrorIs(t, err, jwt.ErrInvalidToken)
+	})
+
+	t.Run("no grace by default", func(t *testing.T) {
+		t.Parallel()
+
+		strict := jwt.NewTokenManager(testSecret, time.Hour)
+
+		_, err := strict.RefreshToken(tokenExpiringAt(t, userID, time.Now().Add(-time.Minute)))
+		assert.ErrorIs(t, err, jwt.ErrInvalidToken)
+	})
+
+	t.Run("foreign signature", func(t *testing.T) {
+		t.Parallel()
+
+		other := jwt.NewTokenManager("another-secret", time.Hour, jwt.WithRefreshGrace(10*time.Minute))
+		token, err := other.GenerateToken(userID, "user@example.com")
+		require.NoError(t, err)
+
+		_, err = sut.RefreshToken(token)
+		assert.ErrorIs(t, err, jwt.ErrInvalidToken)
+	})
+}
@@ -0,0 +1,105 @@
+package trm
+
+import (
+	"context"
+	"sync"
+)
+
+// AfterCommit registers fn to run once the transaction in ctx has committed,
+// e.g. to send a notification about changes that must not be announced before
+// they are durable. Callbacks run in registration order after the outermost
+// transaction commits, as a nested transaction's changes are only durable then.
+// They are discarded when the transaction, or the nested transaction they were
+// registered in, rolls back, including attempts that are retried. Without a
+// transaction in ctx fn runs immediately.
+func AfterCommit(ctx context.Context, fn func()) {
+	callbacks, ok := ctx.Value(callbacksKey{}).(*txCallbacks)
+	if !ok {
+		fn()
+		return
+	}
+
+	callbacks.addCommitted(fn)
+}
+
+// AfterRollback registers fn to run once the transaction in ctx has rolled
+// back, e.g. to free a resource claimed for changes that were undone. This
+// includes a nested transaction that committed but whose outer transaction
+// then rolled back, and attempts that are retried. Callbacks run in
+// registration order and are discarded when the outermost transaction
+// commits. Without a transaction in ctx fn never runs, as there is nothing left
+// to roll back.
+func AfterRollback(ctx context.Context, fn func()) {
+	callbacks, ok := ctx.Value(callbacksKey{}).(*txCallbacks)
+	if !ok {
+		return
+	}
+
+	callbacks.addRolledBack(fn)
+}
+
+type callbacksKey struct{}
+
+// txCallbacks collects the callbacks registered in one transaction. Functions
+// run in the transaction may register from several goroutines.
+type txCallbacks struct {
+	mu         sync.Mutex
+	committed  []func()
+	rolledBack []func()
+}
+
+func (c *txCallbacks) addCommitted(fns ...func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.committed = append(c.committed, fns...)
+}
+
+func (c *txCallbacks) addRolledBack(fns ...func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rolledBack = append(c.rolledBack, fns...)
+}
+
+func (c *txCallbacks) take() (committed, rolledBack []func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	committed, rolledBack = c.committed, c.rolledBack
+	c.committed, c.rolledBack = nil, nil
+	return committed, rolledBack
+}
+
+// withCallbacks gives the transaction about to run in ctx its own callback
+// lists, and returns the lists of the transaction it is nested in, if any.
+func withCallbacks(ctx context.Context) (context.Context, *txCallbacks, *txCallbacks) {
+	parent, _ := ctx.Value(callbacksKey{}).(*txCallbacks)
+	callbacks := &txCallbacks{}
+
+	return context.WithValue(ctx, callbacksKey{}, callbacks), callbacks, parent
+}
+
+// commit hands the callbacks of a committed transaction to the transaction it
+// is nested in, or runs the commit callbacks when there is none.
+func (c *txCallbacks) commit(parent *txCallbacks) {
+	committed, rolledBack := c.take()
+	if parent != nil {
+		parent.addCommitted(committed...)
+		parent.addRolledBack(rolledBack...)
+		return
+	}
+
+	for _, fn := range committed {
+		fn()
+	}
+}
+
+// rollback runs the rollback callbacks of a rolled back transaction, including
+// those of the nested transactions it committed, and discards the rest.
+func (c *txCallbacks) rollback() {
+	_, rolledBack := c.take()
+	for _, fn := range rolledBack {
+		fn()
+	}
+}
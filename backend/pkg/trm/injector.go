@@ -22,12 +22,27 @@ func (i *Injector[T]) DB(ctx context.Context) T {
 
 // HasContextTransaction returns true if there is a transaction in the context
 func (i *Injector[T]) HasContextTransaction(ctx context.Context) bool {
-	_, ok := ctx.Value(ctxKey{}).(T)
+	_, ok := ContextTransaction[T](ctx)
 	return ok
 }
 
+// ContextTransaction returns the transaction started by a TransactionManager
+// that the context carries, if any.
+func ContextTransaction[T any](ctx context.Context) (T, bool) {
+	tx, ok := ctx.Value(ctxKey{}).(T)
+	return tx, ok
+}
+
 type ctxKey struct{}
 
+// WithoutTransaction returns ctx without the transaction it carries, for work
+// that must not join it, such as bookkeeping registered with AfterCommit. The
+// values and cancellation of ctx are kept.
+func WithoutTransaction(ctx context.Context) context.Context {
+	ctx = context.WithValue(ctx, ctxKey{}, nil)
+	return context.WithValue(ctx, callbacksKey{}, nil)
+}
+
 func withTx[T any](ctx context.Context, tx T) context.Context {
 	return context.WithValue(ctx, ctxKey{}, tx)
 }
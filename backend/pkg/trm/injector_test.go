@@ -0,0 +1,93 @@
+package trm_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"minibankingplatform/pkg/trm"
+)
+
+func TestInjector_DB(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	factory := func(_ context.Context, _ any) (trm.Transaction[string], error) {
+		return namedTX("tx"), nil
+	}
+	manager := trm.NewTransactionManager(factory)
+	sut := trm.NewInjector("pool")
+
+	t.Run("should return default db outside transaction", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "pool", sut.DB(ctx))
+		assert.False(t, sut.HasContextTransaction(ctx))
+	})
+
+	t.Run("should return context transaction inside Do", func(t *testing.T) {
+		t.Parallel()
+
+		err := manager.Do(ctx, func(ctx context.Context) error {
+			assert.Equal(t, "tx", sut.DB(ctx))
+			assert.True(t, sut.HasContextTransaction(ctx))
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("should not leak transaction to the caller context", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, manager.Do(ctx, func(context.Context) error { return nil }))
+
+		assert.Equal(t, "pool", sut.DB(ctx))
+		assert.False(t, sut.HasContextTransaction(ctx))
+	})
+
+	t.Run("should return default db for a context without transaction", func(t *testing.T) {
+		t.Parallel()
+
+		err := manager.Do(ctx, func(ctx context.Context) error {
+			detached := trm.WithoutTransaction(ctx)
+
+			assert.Equal(t, "pool", sut.DB(detached))
+			assert.False(t, sut.HasContextTransaction(detached))
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("should ignore transactions of another type", func(t *testing.T) {
+		t.Parallel()
+
+		other := trm.NewInjector(42)
+
+		err := manager.Do(ctx, func(ctx context.Context) error {
+			assert.Equal(t, 42, other.DB(ctx))
+			assert.False(t, other.HasContextTransaction(ctx))
+			return nil
+		})
+		require.NoError(t, err)
+	})
+}
+
+var _ trm.Transaction[string] = namedTX("")
+
+// namedTX is a transaction whose raw value is its name.
+type namedTX string
+
+func (tx namedTX) Raw() string {
+	return string(tx)
+}
+
+func (namedTX) Commit() error {
+	return nil
+}
+
+func (namedTX) Rollback() error {
+	return nil
+}
@@ -2,10 +2,17 @@ package pgxfactory
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"minibankingplatform/pkg/trm"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
 )
 
 type DB interface {
@@ -13,28 +20,69 @@ type DB interface {
 	BeginTx(ctx context.Context, options pgx.TxOptions) (pgx.Tx, error)
 }
 
+// New returns a factory beginning transactions on db. When the context already
+// carries a transaction, the factory nests into it with a savepoint instead:
+// committing releases the savepoint and rolling back undoes only the work done
+// since it. Options don't apply to savepoints and are ignored.
 func New(ctx context.Context, db DB) (trm.TransactionFactory[pgx.Tx, pgx.TxOptions], error) {
 	if err := db.Ping(ctx); err != nil {
 		return nil, fmt.Errorf("failed to connect to db: %w", err)
 	}
 
 	return func(ctx context.Context, opts pgx.TxOptions) (trm.Transaction[pgx.Tx], error) {
-		// TODO: add nested transactions support using savepoints
+		if outer, ok := trm.ContextTransaction[pgx.Tx](ctx); ok {
+			tx, err := outer.Begin(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create savepoint: %w", err)
+			}
+
+			return wrap(ctx, tx), nil
+		}
+
 		tx, err := db.BeginTx(ctx, opts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to begin new transaction: %w", err)
 		}
 
-		return trm.WrapTransaction[pgx.Tx](
-			tx,
-			injectContext(ctx, tx.Commit),
-			injectContext(ctx, tx.Rollback),
-		), nil
+		return wrap(ctx, tx), nil
 	}, nil
 }
 
+func wrap(ctx context.Context, tx pgx.Tx) trm.Transaction[pgx.Tx] {
+	return trm.WrapTransaction[pgx.Tx](
+		tx,
+		injectContext(ctx, tx.Commit),
+		injectContext(ctx, rollback(tx)),
+	)
+}
+
+// rollback translates pgx.ErrTxClosed, which pgx returns once a failed commit
+// has ended the transaction, into trm.ErrTxClosed.
+func rollback(tx pgx.Tx) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		err := tx.Rollback(ctx)
+		if errors.Is(err, pgx.ErrTxClosed) {
+			return fmt.Errorf("%w: %w", trm.ErrTxClosed, err)
+		}
+
+		return err
+	}
+}
+
 func injectContext(ctx context.Context, fn func(ctx context.Context) error) func() error {
 	return func() error {
 		return fn(ctx)
 	}
 }
+
+// IsRetryable reports whether err is a serialization failure or a detected
+// deadlock: the transaction was aborted only because of concurrent ones and
+// running it again may succeed. Use it with trm.WithRetry.
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected
+}
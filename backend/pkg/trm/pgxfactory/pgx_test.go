@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -74,3 +75,133 @@ func TestPGXTRM(t *testing.T) {
 		assert.NoError(t, err, "schema should be committed after successful transaction")
 	})
 }
+
+func TestInjector_ReadYourWrites(t *testing.T) {
+	type Querier interface {
+		Exec(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error)
+		QueryRow(ctx context.Context, query string, args ...any) pgx.Row
+	}
+
+	ctx := context.Background()
+
+	pool, err := pgxpool.New(ctx, postgresURL)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	transactionFactory, err := pgxfactory.New(ctx, pool)
+	require.NoError(t, err)
+
+	transactionManager := trm.NewTransactionManager(transactionFactory)
+	txInjector := trm.NewInjector[Querier](pool)
+
+	_, err = pool.Exec(ctx, `CREATE TABLE read_your_writes (id INT PRIMARY KEY)`)
+	require.NoError(t, err)
+
+	countRows := func(ctx context.Context, db Querier) int {
+		var count int
+		require.NoError(t, db.QueryRow(ctx, `SELECT COUNT(*) FROM read_your_writes`).Scan(&count))
+		return count
+	}
+
+	err = transactionManager.Do(ctx, func(ctx context.Context) error {
+		require.True(t, txInjector.HasContextTransaction(ctx))
+
+		_, err := txInjector.DB(ctx).Exec(ctx, `INSERT INTO read_your_writes (id) VALUES (1)`)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, countRows(ctx, txInjector.DB(ctx)), "write must be visible inside the transaction")
+		assert.Equal(t, 0, countRows(ctx, pool), "write must not be visible to the pool before commit")
+
+		// a context without the transaction falls back to the pool
+		assert.False(t, txInjector.HasContextTransaction(context.Background()))
+		assert.Equal(t, 0, countRows(ctx, txInjector.DB(context.Background())))
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.False(t, txInjector.HasContextTransaction(ctx))
+	assert.Equal(t, 1, countRows(ctx, txInjector.DB(ctx)), "write must be visible after commit")
+}
+
+func TestPGXTRM_NestedSavepoints(t *testing.T) {
+	ctx := context.Background()
+
+	pool, err := pgxpool.New(ctx, postgresURL)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	transactionFactory, err := pgxfactory.New(ctx, pool)
+	require.NoError(t, err)
+
+	transactionManager := trm.NewTransactionManager(transactionFactory)
+	txInjector := trm.NewInjector[pgx.Tx](nil)
+
+	_, err = pool.Exec(ctx, `CREATE TABLE nested_savepoints (id INT PRIMARY KEY)`)
+	require.NoError(t, err)
+
+	insert := func(ctx context.Context, id int) {
+		_, err := txInjector.DB(ctx).Exec(ctx, `INSERT INTO nested_savepoints (id) VALUES ($1)`, id)
+		require.NoError(t, err)
+	}
+
+	errInner := errors.New("inner block failed")
+	err = transactionManager.Do(ctx, func(ctx context.Context) error {
+		outer := txInjector.DB(ctx)
+		insert(ctx, 1)
+
+		err := transactionManager.Do(ctx, func(ctx context.Context) error {
+			assert.NotSame(t, outer, txInjector.DB(ctx), "inner block should run in a savepoint")
+			insert(ctx, 2)
+			return errInner
+		})
+		require.ErrorIs(t, err, errInner)
+
+		err = transactionManager.Do(ctx, func(ctx context.Context) error {
+			insert(ctx, 3)
+			return nil
+		})
+		require.NoError(t, err)
+
+		insert(ctx, 4)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	rows, err := pool.Query(ctx, `SELECT id FROM nested_savepoints ORDER BY id`)
+	require.NoError(t, err)
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 3, 4}, ids, "only the failed inner block should be rolled back")
+}
+
+func TestPGXTRM_CommitFailure(t *testing.T) {
+	ctx := context.Background()
+
+	pool, err := pgxpool.New(ctx, postgresURL)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	transactionFactory, err := pgxfactory.New(ctx, pool)
+	require.NoError(t, err)
+
+	transactionManager := trm.NewTransactionManager(transactionFactory)
+	txInjector := trm.NewInjector[pgx.Tx](nil)
+
+	// A deferred constraint is only checked on commit, which then fails.
+	_, err = pool.Exec(ctx, `CREATE TABLE commit_failure (id INT UNIQUE DEFERRABLE INITIALLY DEFERRED)`)
+	require.NoError(t, err)
+
+	err = transactionManager.Do(ctx, func(ctx context.Context) error {
+		_, err := txInjector.DB(ctx).Exec(ctx, `INSERT INTO commit_failure (id) VALUES (1), (1)`)
+		return err
+	})
+
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	assert.Equal(t, "23505", pgErr.Code)
+	assert.NotErrorIs(t, err, trm.ErrTxClosed, "the rollback after the failed commit must not mask it")
+	assert.NotErrorIs(t, err, pgx.ErrTxClosed)
+}
@@ -1,5 +1,12 @@
 package trm
 
+import "errors"
+
+// ErrTxClosed is matched by the error of a Rollback on a transaction that has
+// already ended. Many drivers, pgx among them, end the transaction when Commit
+// fails, so the rollback that follows has nothing left to undo.
+var ErrTxClosed = errors.New("transaction already closed")
+
 type Transaction[T any] interface {
 	// Raw returns the real transaction sql.Tx, sqlx.Tx or another.
 	Raw() T
@@ -8,5 +15,7 @@ type Transaction[T any] interface {
 	Commit() error
 	// Rollback the trm.Transaction.
 	// Rollback should be used only inside Manager.
+	// Rollback returns an error matching ErrTxClosed if the transaction has
+	// already ended.
 	Rollback() error
 }
@@ -2,7 +2,15 @@ package trm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 type TransactionFactory[Tx any, Opts any] func(ctx context.Context, opts Opts) (Transaction[Tx], error)
@@ -10,14 +18,66 @@ type TransactionFactory[Tx any, Opts any] func(ctx context.Context, opts Opts) (
 type TransactionManager[Tx any, Opts any] struct {
 	factory  TransactionFactory[Tx, Opts]
 	injector Injector[Tx]
+	settings
+}
+
+// settings are the parts of a TransactionManager that options customize.
+type settings struct {
+	retry  retryPolicy
+	tracer trace.Tracer
+}
+
+// retryPolicy decides which failed transactions are run again. The zero value
+// never retries.
+type retryPolicy struct {
+	maxAttempts int
+	backoff     time.Duration
+	retryable   func(error) bool
+}
+
+// Option customizes a TransactionManager built by NewTransactionManager.
+type Option func(*settings)
+
+// WithRetry runs a transaction again, up to maxAttempts times in total, when it
+// fails with an error for which retryable returns true, e.g. a serialization
+// failure. Before attempt n the manager sleeps n-1 times backoff plus a random
+// jitter of up to backoff. The function passed to Do must then be safe to run
+// more than once. Nested transactions are never retried on their own: the
+// outermost one is.
+func WithRetry(maxAttempts int, backoff time.Duration, retryable func(error) bool) Option {
+	return func(s *settings) {
+		s.retry.maxAttempts = maxAttempts
+		s.retry.backoff = backoff
+		s.retry.retryable = retryable
+	}
+}
+
+// WithTracer records a span for every transaction attempt, a child of the span
+// in the context passed to Do. The context passed on to the function carries
+// the transaction's span, so queries traced from there nest below it. No spans
+// are recorded when omitted.
+func WithTracer(tracer trace.Tracer) Option {
+	return func(s *settings) {
+		s.tracer = tracer
+	}
 }
 
 func NewTransactionManager[Tx any, Opts any](
 	factory TransactionFactory[Tx, Opts],
+	opts ...Option,
 ) *TransactionManager[Tx, Opts] {
-	return &TransactionManager[Tx, Opts]{
+	trm := &TransactionManager[Tx, Opts]{
 		factory: factory,
+		settings: settings{
+			tracer: noop.NewTracerProvider().Tracer(""),
+		},
 	}
+
+	for _, opt := range opts {
+		opt(&trm.settings)
+	}
+
+	return trm
 }
 
 // Do invoke function in transaction with zero-value options, that means to use default settings.
@@ -29,29 +89,101 @@ func (trm *TransactionManager[Tx, Opts]) Do(ctx context.Context, fn func(context
 
 // DoTx invoke function in transaction.
 // It accepts options, so you can use it to pass you transaction options.
+// Called inside another Do, the factory receives the outer transaction in ctx
+// and decides how to nest, e.g. with a savepoint.
 func (trm *TransactionManager[Tx, Opts]) DoTx(ctx context.Context, opts Opts, fn func(context.Context) error) error {
+	_, nested := ContextTransaction[Tx](ctx)
+
+	for attempt := 1; ; attempt++ {
+		err := trm.tracedTx(ctx, opts, fn, attempt, nested)
+		if err == nil || nested || !trm.shouldRetry(err, attempt) {
+			return err
+		}
+
+		if !trm.wait(ctx, attempt) {
+			return err
+		}
+	}
+}
+
+func (trm *TransactionManager[Tx, Opts]) shouldRetry(err error, attempt int) bool {
+	return trm.retry.retryable != nil && attempt < trm.retry.maxAttempts && trm.retry.retryable(err)
+}
+
+// wait sleeps before the attempt following the given one and reports whether
+// the context is still alive.
+func (trm *TransactionManager[Tx, Opts]) wait(ctx context.Context, attempt int) bool {
+	delay := time.Duration(attempt) * trm.retry.backoff
+	if trm.retry.backoff > 0 {
+		delay += rand.N(trm.retry.backoff)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (trm *TransactionManager[Tx, Opts]) tracedTx(
+	ctx context.Context,
+	opts Opts,
+	fn func(context.Context) error,
+	attempt int,
+	nested bool,
+) error {
+	ctx, span := trm.tracer.Start(ctx, "trm.Transaction", trace.WithAttributes(
+		attribute.Int("trm.attempt", attempt),
+		attribute.Bool("trm.nested", nested),
+	))
+	defer span.End()
+
+	err := trm.doTx(ctx, opts, fn)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
+
+func (trm *TransactionManager[Tx, Opts]) doTx(ctx context.Context, opts Opts, fn func(context.Context) error) error {
 	tx, err := trm.factory(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
 	ctx = withTx(ctx, tx.Raw())
+	ctx, callbacks, parent := withCallbacks(ctx)
 
 	if err = fn(ctx); err != nil {
-		if rerr := tx.Rollback(); rerr != nil {
-			return fmt.Errorf("failed to rollback transaction: %w", rerr)
-		}
-
-		return err
+		defer callbacks.rollback()
+		return rollback(tx, err)
 	}
 
 	if err = tx.Commit(); err != nil {
-		if rerr := tx.Rollback(); rerr != nil {
-			return fmt.Errorf("failed to rollback transaction: %w", rerr)
-		}
-
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		defer callbacks.rollback()
+		return rollback(tx, fmt.Errorf("failed to commit transaction: %w", err))
 	}
 
+	callbacks.commit(parent)
+
 	return nil
 }
+
+// rollback rolls the transaction back after it failed with cause. A failing
+// rollback is reported alongside cause rather than in place of it, as cause is
+// what explains the failure; errors.Is matches either. A transaction that
+// already ended, like one whose commit failed, is rolled back by definition, so
+// ErrTxClosed is not reported at all.
+func rollback[Tx any](tx Transaction[Tx], cause error) error {
+	if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, ErrTxClosed) {
+		return fmt.Errorf("%w (failed to rollback transaction: %w)", cause, rerr)
+	}
+
+	return cause
+}
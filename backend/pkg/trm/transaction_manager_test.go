@@ -2,12 +2,22 @@ package trm_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 
 	"minibankingplatform/pkg/trm"
+	"minibankingplatform/pkg/trm/pgxfactory"
 )
 
 func TestTransactionManager_Do(t *testing.T) {
@@ -51,3 +61,481 @@ func (MockTX) Commit() error {
 func (MockTX) Rollback() error {
 	return nil
 }
+
+func TestTransactionManager_WithRetry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	serializationFailure := &pgconn.PgError{Code: "40001"}
+
+	// failingFactory returns transactions whose commit fails with err the first
+	// failures times.
+	failingFactory := func(failures int, err error) (trm.TransactionFactory[any, any], *int) {
+		attempts := 0
+		return func(_ context.Context, _ any) (trm.Transaction[any], error) {
+			attempts++
+			tx := &recordingTX{}
+			if attempts <= failures {
+				tx.commitErr = err
+			}
+			return tx, nil
+		}, &attempts
+	}
+
+	t.Run("should retry serialization failures until success", func(t *testing.T) {
+		t.Parallel()
+
+		factory, attempts := failingFactory(2, serializationFailure)
+		sut := trm.NewTransactionManager(factory, trm.WithRetry(3, time.Millisecond, pgxfactory.IsRetryable))
+
+		runs := 0
+		err := sut.Do(ctx, func(context.Context) error {
+			runs++
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, *attempts)
+		assert.Equal(t, 3, runs)
+	})
+
+	t.Run("should give up after max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		factory, attempts := failingFactory(5, serializationFailure)
+		sut := trm.NewTransactionManager(factory, trm.WithRetry(3, time.Millisecond, pgxfactory.IsRetryable))
+
+		err := sut.Do(ctx, func(context.Context) error { return nil })
+
+		require.ErrorIs(t, err, serializationFailure)
+		assert.Equal(t, 3, *attempts)
+	})
+
+	t.Run("should not retry other errors", func(t *testing.T) {
+		t.Parallel()
+
+		factory, attempts := failingFactory(0, nil)
+		sut := trm.NewTransactionManager(factory, trm.WithRetry(3, time.Millisecond, pgxfactory.IsRetryable))
+
+		errBusiness := errors.New("insufficient funds")
+		err := sut.Do(ctx, func(context.Context) error { return errBusiness })
+
+		require.ErrorIs(t, err, errBusiness)
+		assert.Equal(t, 1, *attempts)
+	})
+
+	t.Run("should retry errors returned by the function", func(t *testing.T) {
+		t.Parallel()
+
+		factory, attempts := failingFactory(0, nil)
+		sut := trm.NewTransactionManager(factory, trm.WithRetry(3, time.Millisecond, pgxfactory.IsRetryable))
+
+		runs := 0
+		err := sut.Do(ctx, func(context.Context) error {
+			runs++
+			if runs == 1 {
+				return fmt.Errorf("locking account: %w", &pgconn.PgError{Code: "40P01"})
+			}
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, *attempts)
+	})
+
+	t.Run("should not retry without the option", func(t *testing.T) {
+		t.Parallel()
+
+		factory, attempts := failingFactory(1, serializationFailure)
+		sut := trm.NewTransactionManager(factory)
+
+		err := sut.Do(ctx, func(context.Context) error { return nil })
+
+		require.ErrorIs(t, err, serializationFailure)
+		assert.Equal(t, 1, *attempts)
+	})
+}
+
+func TestTransactionManager_RollbackFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	errRollback := errors.New("connection reset")
+	errFn := errors.New("insufficient funds")
+	errCommit := errors.New("commit failed")
+
+	tests := []struct {
+		name      string
+		commitErr error
+		fnErr     error
+		cause     error
+	}{
+		{name: "should keep the function error", fnErr: errFn, cause: errFn},
+		{name: "should keep the commit error", commitErr: errCommit, cause: errCommit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sut := trm.NewTransactionManager(func(context.Context, any) (trm.Transaction[any], error) {
+				return &recordingTX{commitErr: tt.commitErr, rollbackErr: errRollback}, nil
+			})
+
+			err := sut.Do(ctx, func(context.Context) error { return tt.fnErr })
+
+			require.ErrorIs(t, err, tt.cause)
+			require.ErrorIs(t, err, errRollback)
+			assert.Contains(t, err.Error(), tt.cause.Error())
+			assert.Contains(t, err.Error(), errRollback.Error())
+		})
+	}
+}
+
+func TestTransactionManager_CommitFailureClosesTransaction(t *testing.T) {
+	t.Parallel()
+
+	errCommit := errors.New("commit failed")
+	sut := trm.NewTransactionManager(func(context.Context, any) (trm.Transaction[any], error) {
+		return &recordingTX{
+			commitErr:   errCommit,
+			rollbackErr: fmt.Errorf("%w: tx is closed", trm.ErrTxClosed),
+		}, nil
+	})
+
+	err := sut.Do(context.Background(), func(context.Context) error { return nil })
+
+	require.ErrorIs(t, err, errCommit)
+	assert.NotErrorIs(t, err, trm.ErrTxClosed)
+	assert.EqualError(t, err, "failed to commit transaction: commit failed")
+}
+
+func TestTransactionManager_WithTracer(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	errCommit := errors.New("commit failed")
+	sut := trm.NewTransactionManager(func(context.Context, any) (trm.Transaction[any], error) {
+		return &recordingTX{commitErr: errCommit}, nil
+	}, trm.WithTracer(tracer))
+
+	ctx, parent := tracer.Start(context.Background(), "service.Operation")
+
+	// Act
+	var spanInFn trace.SpanContext
+	err := sut.Do(ctx, func(ctx context.Context) error {
+		spanInFn = trace.SpanContextFromContext(ctx)
+		return nil
+	})
+	parent.End()
+
+	// Assert
+	require.ErrorIs(t, err, errCommit)
+
+	var txSpan sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == "trm.Transaction" {
+			txSpan = span
+		}
+	}
+	require.NotNil(t, txSpan)
+	assert.Equal(t, parent.SpanContext().SpanID(), txSpan.Parent().SpanID())
+	assert.Equal(t, txSpan.SpanContext().SpanID(), spanInFn.SpanID(), "the function should run in the transaction's span")
+	assert.Equal(t, codes.Error, txSpan.Status().Code)
+	assert.Contains(t, txSpan.Attributes(), attribute.Int("trm.attempt", 1))
+}
+
+func TestAfterCommit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	errFn := errors.New("insufficient funds")
+
+	t.Run("should run callbacks in order after commit", func(t *testing.T) {
+		t.Parallel()
+
+		committed := false
+		sut := trm.NewTransactionManager(func(context.Context, any) (trm.Transaction[any], error) {
+			return trm.WrapTransaction[any](nil, func() error {
+				committed = true
+				return nil
+			}, func() error { return nil }), nil
+		})
+
+		var calls []string
+		err := sut.Do(ctx, func(ctx context.Context) error {
+			trm.AfterCommit(ctx, func() {
+				assert.True(t, committed, "callbacks must not run before the commit")
+				calls = append(calls, "first")
+			})
+			trm.AfterCommit(ctx, func() { calls = append(calls, "second") })
+			assert.Empty(t, calls)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first", "second"}, calls)
+	})
+
+	t.Run("should discard callbacks on rollback", func(t *testing.T) {
+		t.Parallel()
+
+		sut := trm.NewTransactionManager(func(context.Context, any) (trm.Transaction[any], error) {
+			return MockTX{}, nil
+		})
+
+		called := false
+		err := sut.Do(ctx, func(ctx context.Context) error {
+			trm.AfterCommit(ctx, func() { called = true })
+			return errFn
+		})
+
+		require.ErrorIs(t, err, errFn)
+		assert.False(t, called)
+	})
+
+	t.Run("should discard callbacks when the commit fails", func(t *testing.T) {
+		t.Parallel()
+
+		errCommit := errors.New("commit failed")
+		sut := trm.NewTransactionManager(func(context.Context, any) (trm.Transaction[any], error) {
+			return &recordingTX{commitErr: errCommit}, nil
+		})
+
+		called := false
+		err := sut.Do(ctx, func(ctx context.Context) error {
+			trm.AfterCommit(ctx, func() { called = true })
+			return nil
+		})
+
+		require.ErrorIs(t, err, errCommit)
+		assert.False(t, called)
+	})
+
+	t.Run("should only run callbacks of the committed attempt", func(t *testing.T) {
+		t.Parallel()
+
+		attempts := 0
+		sut := trm.NewTransactionManager(func(context.Context, any) (trm.Transaction[any], error) {
+			attempts++
+			if attempts == 1 {
+				return &recordingTX{commitErr: &pgconn.PgError{Code: "40001"}}, nil
+			}
+			return &recordingTX{}, nil
+		}, trm.WithRetry(2, time.Millisecond, pgxfactory.IsRetryable))
+
+		var calls []int
+		err := sut.Do(ctx, func(ctx context.Context) error {
+			attempt := attempts
+			trm.AfterCommit(ctx, func() { calls = append(calls, attempt) })
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{2}, calls)
+	})
+
+	t.Run("should run callbacks of nested transactions after the outermost commit", func(t *testing.T) {
+		t.Parallel()
+
+		sut := trm.NewTransactionManager(func(context.Context, any) (trm.Transaction[any], error) {
+			return MockTX{}, nil
+		})
+
+		var calls []string
+		err := sut.Do(ctx, func(ctx context.Context) error {
+			err := sut.Do(ctx, func(ctx context.Context) error {
+				trm.AfterCommit(ctx, func() { calls = append(calls, "nested") })
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Empty(t, calls, "the outer transaction has not committed yet")
+
+			err = sut.Do(ctx, func(ctx context.Context) error {
+				trm.AfterCommit(ctx, func() { calls = append(calls, "rolled back") })
+				return errFn
+			})
+			require.ErrorIs(t, err, errFn)
+
+			trm.AfterCommit(ctx, func() { calls = append(calls, "outer") })
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"nested", "outer"}, calls)
+	})
+
+	t.Run("should discard callbacks of nested transactions when the outer one rolls back", func(t *testing.T) {
+		t.Parallel()
+
+		sut := trm.NewTransactionManager(func(context.Context, any) (trm.Transaction[any], error) {
+			return MockTX{}, nil
+		})
+
+		called := false
+		err := sut.Do(ctx, func(ctx context.Context) error {
+			err := sut.Do(ctx, func(ctx context.Context) error {
+				trm.AfterCommit(ctx, func() { called = true })
+				return nil
+			})
+			require.NoError(t, err)
+			return errFn
+		})
+
+		require.ErrorIs(t, err, errFn)
+		assert.False(t, called)
+	})
+
+	t.Run("should run callbacks immediately outside a transaction", func(t *testing.T) {
+		t.Parallel()
+
+		called := false
+		trm.AfterCommit(ctx, func() { called = true })
+
+		assert.True(t, called)
+	})
+}
+
+func TestAfterRollback(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	errFn := errors.New("insufficient funds")
+
+	t.Run("should run callbacks in order after rollback", func(t *testing.T) {
+		t.Parallel()
+
+		rolledBack := false
+		sut := trm.NewTransactionManager(func(context.Context, any) (trm.Transaction[any], error) {
+			return trm.WrapTransaction[any](nil, func() error { return nil }, func() error {
+				rolledBack = true
+				return nil
+			}), nil
+		})
+
+		var calls []string
+		err := sut.Do(ctx, func(ctx context.Context) error {
+			trm.AfterRollback(ctx, func() {
+				assert.True(t, rolledBack, "callbacks must not run before the rollback")
+				calls = append(calls, "first")
+			})
+			trm.AfterRollback(ctx, func() { calls = append(calls, "second") })
+			return errFn
+		})
+
+		require.ErrorIs(t, err, errFn)
+		assert.Equal(t, []string{"first", "second"}, calls)
+	})
+
+	t.Run("should discard callbacks on commit", func(t *testing.T) {
+		t.Parallel()
+
+		sut := trm.NewTransactionManager(func(context.Context, any) (trm.Transaction[any], error) {
+			return MockTX{}, nil
+		})
+
+		called := false
+		err := sut.Do(ctx, func(ctx context.Context) error {
+			trm.AfterRollback(ctx, func() { called = true })
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.False(t, called)
+	})
+
+	t.Run("should run callbacks when the commit fails", func(t *testing.T) {
+		t.Parallel()
+
+		errCommit := errors.New("commit failed")
+		sut := trm.NewTransactionManager(func(context.Context, any) (trm.Transaction[any], error) {
+			return &recordingTX{commitErr: errCommit}, nil
+		})
+
+		called := false
+		err := sut.Do(ctx, func(ctx context.Context) error {
+			trm.AfterRollback(ctx, func() { called = true })
+			return nil
+		})
+
+		require.ErrorIs(t, err, errCommit)
+		assert.True(t, called)
+	})
+
+	t.Run("should run callbacks of every retried attempt", func(t *testing.T) {
+		t.Parallel()
+
+		attempts := 0
+		sut := trm.NewTransactionManager(func(context.Context, any) (trm.Transaction[any], error) {
+			attempts++
+			if attempts == 1 {
+				return &recordingTX{commitErr: &pgconn.PgError{Code: "40001"}}, nil
+			}
+			return &recordingTX{}, nil
+		}, trm.WithRetry(2, time.Millisecond, pgxfactory.IsRetryable))
+
+		var calls []int
+		err := sut.Do(ctx, func(ctx context.Context) error {
+			attempt := attempts
+			trm.AfterRollback(ctx, func() { calls = append(calls, attempt) })
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, calls)
+	})
+
+	t.Run("should run callbacks of committed nested transactions when the outer one rolls back", func(t *testing.T) {
+		t.Parallel()
+
+		sut := trm.NewTransactionManager(func(context.Context, any) (trm.Transaction[any], error) {
+			return MockTX{}, nil
+		})
+
+		var calls []string
+		err := sut.Do(ctx, func(ctx context.Context) error {
+			err := sut.Do(ctx, func(ctx context.Context) error {
+				trm.AfterRollback(ctx, func() { calls = append(calls, "nested") })
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Empty(t, calls, "the outer transaction has not rolled back yet")
+
+			trm.AfterRollback(ctx, func() { calls = append(calls, "outer") })
+			return errFn
+		})
+
+		require.ErrorIs(t, err, errFn)
+		assert.Equal(t, []string{"nested", "outer"}, calls)
+	})
+
+	t.Run("should not run callbacks outside a transaction", func(t *testing.T) {
+		t.Parallel()
+
+		called := false
+		trm.AfterRollback(ctx, func() { called = true })
+
+		assert.False(t, called)
+	})
+}
+
+// recordingTX is a transaction whose commit fails with commitErr and whose
+// rollback fails with rollbackErr, if set.
+type recordingTX struct {
+	commitErr   error
+	rollbackErr error
+}
+
+func (*recordingTX) Raw() any {
+	return nil
+}
+
+func (tx *recordingTX) Commit() error {
+	return tx.commitErr
+}
+
+func (tx *recordingTX) Rollback() error {
+	return tx.rollbackErr
+}
@@ -9,7 +9,7 @@ export const transferSchema = z.object({
     .refine((val) => !isNaN(parseFloat(val)) && parseFloat(val) > 0, {
       message: 'Amount must be greater than 0',
     }),
-  currency: z.enum(['USD', 'EUR'], { message: 'Select a currency' }),
+  currency: z.enum(['USD', 'EUR', 'GBP'], { message: 'Select a currency' }),
 });
 
 export type TransferFormData = z.infer<typeof transferSchema>;
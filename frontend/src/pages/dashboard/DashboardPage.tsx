@@ -12,6 +12,7 @@ export function DashboardPage() {
         <Stack direction="row" gap="md" wrap>
           <WalletCard currency="USD" />
           <WalletCard currency="EUR" />
+          <WalletCard currency="GBP" />
         </Stack>
 
         {/* Transfer and Exchange Forms */}
@@ -1,7 +1,13 @@
-import type { Money } from '@shared/types';
+import type { Currency, Money } from '@shared/types';
+
+export const currencySymbols: Record<Currency, string> = {
+  USD: '$',
+  EUR: '€',
+  GBP: '£',
+};
 
 export function formatMoney(money: Money): string {
-  const symbol = money.currency === 'USD' ? '$' : '€';
+  const symbol = currencySymbols[money.currency];
   const amount = parseFloat(money.amount).toFixed(2);
   return `${symbol}${amount}`;
 }
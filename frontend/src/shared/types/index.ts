@@ -1,4 +1,4 @@
-export type Currency = 'USD' | 'EUR';
+export type Currency = 'USD' | 'EUR' | 'GBP';
 export type TransactionType = 'transfer' | 'exchange' | 'deposit' | 'withdrawal';
 
 export interface Money {
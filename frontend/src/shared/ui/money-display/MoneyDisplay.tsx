@@ -1,4 +1,4 @@
-import { cn } from '@shared/lib';
+import { cn, currencySymbols } from '@shared/lib';
 import type { Currency } from '@shared/types';
 
 interface MoneyDisplayProps {
@@ -16,7 +16,7 @@ export function MoneyDisplay({
   showSign = false,
   className,
 }: MoneyDisplayProps) {
-  const symbol = currency === 'USD' ? '$' : '€';
+  const symbol = currencySymbols[currency];
   const numericAmount = parseFloat(amount);
   const formattedAmount = Math.abs(numericAmount).toFixed(2);
   const isNegative = numericAmount < 0;
@@ -65,6 +65,7 @@ export function TransferForm() {
                 options={[
                   { value: 'USD', label: 'USD' },
                   { value: 'EUR', label: 'EUR' },
+                  { value: 'GBP', label: 'GBP' },
                 ]}
                 error={!!form.formState.errors.currency}
                 {...form.register('currency', {